@@ -0,0 +1,154 @@
+package authorization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs"
+)
+
+// AllRoutes allows a client to call every route.
+const AllRoutes = "*"
+
+// A Client is an API caller, identified by the common name of the TLS
+// certificate it presents or by a bearer token.
+//
+// Routes lists the bbs route names the client may call. Domains, when set,
+// limits the client to requests that modify those domains, and CellID, when
+// set, limits it to requests on behalf of that cell.
+type Client struct {
+	Name       string   `json:"name"`
+	CommonName string   `json:"common_name,omitempty"`
+	Token      string   `json:"token,omitempty"`
+	Routes     []string `json:"routes"`
+	Domains    []string `json:"domains,omitempty"`
+	CellID     string   `json:"cell_id,omitempty"`
+}
+
+func (c *Client) AllowsRoute(route string) bool {
+	for _, allowed := range c.Routes {
+		if allowed == AllRoutes || allowed == route {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) RestrictsDomains() bool {
+	return len(c.Domains) > 0
+}
+
+func (c *Client) AllowsDomain(domain string) bool {
+	if !c.RestrictsDomains() {
+		return true
+	}
+
+	for _, allowed := range c.Domains {
+		if allowed == domain {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) AllowsCell(cellID string) bool {
+	return c.CellID == "" || c.CellID == cellID
+}
+
+type config struct {
+	Clients []Client `json:"clients"`
+}
+
+// A Policy maps the identity of an incoming request to a Client.
+type Policy struct {
+	clientsByCommonName map[string]*Client
+	clientsByToken      map[string]*Client
+}
+
+func NewPolicy(clients []Client) (*Policy, error) {
+	policy := &Policy{
+		clientsByCommonName: map[string]*Client{},
+		clientsByToken:      map[string]*Client{},
+	}
+
+	knownRoutes := map[string]bool{AllRoutes: true}
+	for _, route := range bbs.Routes {
+		knownRoutes[route.Name] = true
+	}
+
+	for i := range clients {
+		client := clients[i]
+
+		if client.Name == "" {
+			return nil, errors.New("client name is required")
+		}
+		if client.CommonName == "" && client.Token == "" {
+			return nil, fmt.Errorf("client %s needs a common_name or a token", client.Name)
+		}
+
+		for _, route := range client.Routes {
+			if !knownRoutes[route] {
+				return nil, fmt.Errorf("client %s has unknown route %s", client.Name, route)
+			}
+		}
+
+		if client.CommonName != "" {
+			if _, found := policy.clientsByCommonName[client.CommonName]; found {
+				return nil, fmt.Errorf("common name %s is used by more than one client", client.CommonName)
+			}
+			policy.clientsByCommonName[client.CommonName] = &client
+		}
+
+		if client.Token != "" {
+			if _, found := policy.clientsByToken[client.Token]; found {
+				return nil, fmt.Errorf("client %s reuses the token of another client", client.Name)
+			}
+			policy.clientsByToken[client.Token] = &client
+		}
+	}
+
+	return policy, nil
+}
+
+// LoadPolicy reads a policy from a JSON file of the form
+// {"clients": [{"name": ..., "common_name": ..., "routes": [...]}]}.
+func LoadPolicy(path string) (*Policy, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg config
+	err = json.Unmarshal(payload, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPolicy(cfg.Clients)
+}
+
+// Authenticate returns the client making the request. A bearer token in the
+// Authorization header takes precedence over the TLS client certificate.
+func (p *Policy) Authenticate(req *http.Request) (*Client, bool) {
+	authorization := req.Header.Get("Authorization")
+	if authorization != "" {
+		fields := strings.Fields(authorization)
+		if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+			return nil, false
+		}
+
+		client, found := p.clientsByToken[fields[1]]
+		return client, found
+	}
+
+	if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		client, found := p.clientsByCommonName[req.TLS.PeerCertificates[0].Subject.CommonName]
+		return client, found
+	}
+
+	return nil, false
+}
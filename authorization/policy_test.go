@@ -0,0 +1,195 @@
+package authorization_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy", func() {
+	var (
+		clients []authorization.Client
+		policy  *authorization.Policy
+		err     error
+	)
+
+	BeforeEach(func() {
+		clients = []authorization.Client{
+			{
+				Name:       "cell",
+				CommonName: "cell-z1-0",
+				CellID:     "cell-z1-0",
+				Routes:     []string{bbs.ClaimActualLRPRoute, bbs.StartActualLRPRoute},
+			},
+			{
+				Name:    "scheduler",
+				Token:   "scheduler-token",
+				Domains: []string{"cf-apps"},
+				Routes:  []string{authorization.AllRoutes},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		policy, err = authorization.NewPolicy(clients)
+	})
+
+	Describe("NewPolicy", func() {
+		It("succeeds", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when a client has no name", func() {
+			BeforeEach(func() {
+				clients[0].Name = ""
+			})
+
+			It("errors", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when a client has neither a common name nor a token", func() {
+			BeforeEach(func() {
+				clients[0].CommonName = ""
+			})
+
+			It("errors", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when a client lists an unknown route", func() {
+			BeforeEach(func() {
+				clients[0].Routes = append(clients[0].Routes, "BogusRoute")
+			})
+
+			It("errors", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when two clients share a token", func() {
+			BeforeEach(func() {
+				clients[0].Token = "scheduler-token"
+			})
+
+			It("errors", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("LoadPolicy", func() {
+		var path string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "policy")
+			Expect(err).NotTo(HaveOccurred())
+			defer file.Close()
+
+			_, err = file.WriteString(`{"clients": [{"name": "nsync", "token": "nsync-token", "routes": ["DesireDesiredLRP"], "domains": ["cf-apps"]}]}`)
+			Expect(err).NotTo(HaveOccurred())
+			path = file.Name()
+		})
+
+		AfterEach(func() {
+			os.Remove(path)
+		})
+
+		It("loads the clients from the file", func() {
+			policy, err := authorization.LoadPolicy(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("GET", "/", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Authorization", "Bearer nsync-token")
+
+			client, found := policy.Authenticate(req)
+			Expect(found).To(BeTrue())
+			Expect(client.Name).To(Equal("nsync"))
+			Expect(client.AllowsRoute(bbs.DesireDesiredLRPRoute)).To(BeTrue())
+			Expect(client.AllowsDomain("cf-apps")).To(BeTrue())
+		})
+
+		Context("when the file is not valid JSON", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(path, []byte("{"), 0600)).To(Succeed())
+			})
+
+			It("errors", func() {
+				_, err := authorization.LoadPolicy(path)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Authenticate", func() {
+		var req *http.Request
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest("GET", "/", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("identifies clients by bearer token", func() {
+			req.Header.Set("Authorization", "Bearer scheduler-token")
+
+			client, found := policy.Authenticate(req)
+			Expect(found).To(BeTrue())
+			Expect(client.Name).To(Equal("scheduler"))
+		})
+
+		It("identifies clients by certificate common name", func() {
+			req.TLS = &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "cell-z1-0"}}},
+			}
+
+			client, found := policy.Authenticate(req)
+			Expect(found).To(BeTrue())
+			Expect(client.Name).To(Equal("cell"))
+		})
+
+		It("does not identify unknown tokens", func() {
+			req.Header.Set("Authorization", "Bearer bogus-token")
+
+			_, found := policy.Authenticate(req)
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not identify anonymous requests", func() {
+			_, found := policy.Authenticate(req)
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("Client", func() {
+		It("allows only the listed routes", func() {
+			Expect(clients[0].AllowsRoute(bbs.ClaimActualLRPRoute)).To(BeTrue())
+			Expect(clients[0].AllowsRoute(bbs.RetireActualLRPRoute)).To(BeFalse())
+			Expect(clients[1].AllowsRoute(bbs.RetireActualLRPRoute)).To(BeTrue())
+		})
+
+		It("allows only the listed domains", func() {
+			Expect(clients[0].AllowsDomain("any-domain")).To(BeTrue())
+			Expect(clients[1].AllowsDomain("cf-apps")).To(BeTrue())
+			Expect(clients[1].AllowsDomain("other-domain")).To(BeFalse())
+		})
+
+		It("allows only its own cell", func() {
+			Expect(clients[0].AllowsCell("cell-z1-0")).To(BeTrue())
+			Expect(clients[0].AllowsCell("cell-z1-1")).To(BeFalse())
+			Expect(clients[1].AllowsCell("cell-z1-1")).To(BeTrue())
+		})
+	})
+})
@@ -2,6 +2,7 @@ package bbs
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
@@ -22,6 +23,7 @@ const (
 	ContentTypeHeader    = "Content-Type"
 	XCfRouterErrorHeader = "X-Cf-Routererror"
 	ProtoContentType     = "application/x-protobuf"
+	JSONContentType      = "application/json"
 )
 
 //go:generate counterfeiter -o fake_bbs/fake_client.go . Client
@@ -31,26 +33,58 @@ type Client interface {
 	UpsertDomain(domain string, ttl time.Duration) error
 
 	ActualLRPGroups(models.ActualLRPFilter) ([]*models.ActualLRPGroup, error)
+	IterateActualLRPGroups(filter models.ActualLRPFilter, pageSize int) ActualLRPGroupIterator
 	ActualLRPGroupsByProcessGuid(processGuid string) ([]*models.ActualLRPGroup, error)
 	ActualLRPGroupByProcessGuidAndIndex(processGuid string, index int) (*models.ActualLRPGroup, error)
 
 	// ActualLRP Lifecycle
 	ClaimActualLRP(processGuid string, index int, instanceKey *models.ActualLRPInstanceKey) (*models.ActualLRP, error)
 	StartActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (*models.ActualLRP, error)
+	// the IfUnmodified variants fail with ResourceConflict unless the instance
+	// still has the given tag; see ActualLRPGroup.InstanceModificationTag
+	ClaimActualLRPIfUnmodified(processGuid string, index int, instanceKey *models.ActualLRPInstanceKey, expectedTag *models.ModificationTag) (*models.ActualLRP, error)
+	StartActualLRPIfUnmodified(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, expectedTag *models.ModificationTag) (*models.ActualLRP, error)
 	CrashActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) error
 	FailActualLRP(key *models.ActualLRPKey, errorMessage string) error
 	RemoveActualLRP(processGuid string, index int) error
 	RetireActualLRP(key *models.ActualLRPKey) error
 
+	// Evacuation; the returned bool tells the cell whether to keep the container
+	EvacuateClaimedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error)
+	EvacuateRunningActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, ttl uint64) (bool, error)
+	EvacuateStoppedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error)
+	EvacuateCrashedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) (bool, error)
+	RemoveEvacuatingActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) error
+
 	DesiredLRPs(models.DesiredLRPFilter) ([]*models.DesiredLRP, error)
+	IterateDesiredLRPs(filter models.DesiredLRPFilter, pageSize int) DesiredLRPIterator
 	DesiredLRPByProcessGuid(processGuid string) (*models.DesiredLRP, error)
 
+	// DesiredLRP Lifecycle
+	DesireLRP(*models.DesiredLRP) error
+	UpdateDesiredLRP(processGuid string, update *models.DesiredLRPUpdate) error
+	UpdateDesiredLRPIfUnmodified(processGuid string, update *models.DesiredLRPUpdate, expectedTag *models.ModificationTag) error
+	RemoveDesiredLRP(processGuid string) error
+
 	Tasks() ([]*models.Task, error)
 	TasksByDomain(domain string) ([]*models.Task, error)
 	TasksByCellID(cellId string) ([]*models.Task, error)
+	IterateTasks(filter models.TaskFilter, pageSize int) TaskIterator
 	TaskByGuid(guid string) (*models.Task, error)
 
+	// Task Lifecycle
+	DesireTask(*models.Task) error
+	StartTask(taskGuid string, cellId string) (bool, error)
+	CancelTask(taskGuid string) error
+	FailTask(taskGuid, failureReason string) error
+	CompleteTask(taskGuid, cellId string, failed bool, failureReason, result string) error
+	ResolvingTask(taskGuid string) error
+	ResolveTask(taskGuid string) error
+
+	Cells() ([]*models.CellPresence, error)
+
 	SubscribeToEvents() (events.EventSource, error)
+	SubscribeToEventsWithFilter(models.EventFilter) (events.EventSource, error)
 }
 
 func NewClient(url string) Client {
@@ -62,6 +96,33 @@ func NewClient(url string) Client {
 	}
 }
 
+// NewSecureClient returns a client that talks to a BBS requiring mutual TLS,
+// presenting the given certificate and trusting servers signed by the CA.
+func NewSecureClient(url, caFile, certFile, keyFile string) (Client, error) {
+	tlsConfig, err := cf_http.NewTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := cf_http.NewClient()
+	streamingHTTPClient := cf_http.NewStreamingClient()
+
+	for _, c := range []*http.Client{httpClient, streamingHTTPClient} {
+		transport, ok := c.Transport.(*http.Transport)
+		if !ok {
+			return nil, errors.New("unexpected http transport")
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &client{
+		httpClient:          httpClient,
+		streamingHTTPClient: streamingHTTPClient,
+
+		reqGen: rata.NewRequestGenerator(url, Routes),
+	}, nil
+}
+
 type client struct {
 	httpClient          *http.Client
 	streamingHTTPClient *http.Client
@@ -90,6 +151,11 @@ func (c *client) UpsertDomain(domain string, ttl time.Duration) error {
 
 func (c *client) ActualLRPGroups(filter models.ActualLRPFilter) ([]*models.ActualLRPGroup, error) {
 	var actualLRPGroups models.ActualLRPGroups
+	err := c.doRequest(ActualLRPGroupsRoute, nil, actualLRPFilterQuery(filter), nil, &actualLRPGroups)
+	return actualLRPGroups.GetActualLrpGroups(), err
+}
+
+func actualLRPFilterQuery(filter models.ActualLRPFilter) url.Values {
 	query := url.Values{}
 	if filter.Domain != "" {
 		query.Set("domain", filter.Domain)
@@ -97,8 +163,7 @@ func (c *client) ActualLRPGroups(filter models.ActualLRPFilter) ([]*models.Actua
 	if filter.CellID != "" {
 		query.Set("cell_id", filter.CellID)
 	}
-	err := c.doRequest(ActualLRPGroupsRoute, nil, query, nil, &actualLRPGroups)
-	return actualLRPGroups.GetActualLrpGroups(), err
+	return query
 }
 
 func (c *client) ActualLRPGroupsByProcessGuid(processGuid string) ([]*models.ActualLRPGroup, error) {
@@ -116,22 +181,32 @@ func (c *client) ActualLRPGroupByProcessGuidAndIndex(processGuid string, index i
 }
 
 func (c *client) ClaimActualLRP(processGuid string, index int, instanceKey *models.ActualLRPInstanceKey) (*models.ActualLRP, error) {
+	return c.ClaimActualLRPIfUnmodified(processGuid, index, instanceKey, nil)
+}
+
+func (c *client) ClaimActualLRPIfUnmodified(processGuid string, index int, instanceKey *models.ActualLRPInstanceKey, expectedTag *models.ModificationTag) (*models.ActualLRP, error) {
 	var actualLRP models.ActualLRP
 	request := models.ClaimActualLRPRequest{
-		ProcessGuid:          processGuid,
-		Index:                int32(index),
-		ActualLrpInstanceKey: instanceKey,
+		ProcessGuid:             processGuid,
+		Index:                   int32(index),
+		ActualLrpInstanceKey:    instanceKey,
+		ExpectedModificationTag: expectedTag,
 	}
 	err := c.doRequest(ClaimActualLRPRoute, nil, nil, &request, &actualLRP)
 	return &actualLRP, err
 }
 
 func (c *client) StartActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (*models.ActualLRP, error) {
+	return c.StartActualLRPIfUnmodified(key, instanceKey, netInfo, nil)
+}
+
+func (c *client) StartActualLRPIfUnmodified(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, expectedTag *models.ModificationTag) (*models.ActualLRP, error) {
 	var actualLRP models.ActualLRP
 	request := models.StartActualLRPRequest{
-		ActualLrpKey:         key,
-		ActualLrpInstanceKey: instanceKey,
-		ActualLrpNetInfo:     netInfo,
+		ActualLrpKey:            key,
+		ActualLrpInstanceKey:    instanceKey,
+		ActualLrpNetInfo:        netInfo,
+		ExpectedModificationTag: expectedTag,
 	}
 	err := c.doRequest(StartActualLRPRoute, nil, nil, &request, &actualLRP)
 	return &actualLRP, err
@@ -168,14 +243,61 @@ func (c *client) RetireActualLRP(key *models.ActualLRPKey) error {
 	return c.doRequest(RetireActualLRPRoute, nil, nil, &request, nil)
 }
 
+func (c *client) EvacuateClaimedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error) {
+	request := models.EvacuateClaimedActualLRPRequest{
+		ActualLrpKey:         key,
+		ActualLrpInstanceKey: instanceKey,
+	}
+	return c.doEvacuationRequest(EvacuateClaimedActualLRPRoute, &request)
+}
+
+func (c *client) EvacuateRunningActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, ttl uint64) (bool, error) {
+	request := models.EvacuateRunningActualLRPRequest{
+		ActualLrpKey:         key,
+		ActualLrpInstanceKey: instanceKey,
+		ActualLrpNetInfo:     netInfo,
+		Ttl:                  ttl,
+	}
+	return c.doEvacuationRequest(EvacuateRunningActualLRPRoute, &request)
+}
+
+func (c *client) EvacuateStoppedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error) {
+	request := models.EvacuateStoppedActualLRPRequest{
+		ActualLrpKey:         key,
+		ActualLrpInstanceKey: instanceKey,
+	}
+	return c.doEvacuationRequest(EvacuateStoppedActualLRPRoute, &request)
+}
+
+func (c *client) EvacuateCrashedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) (bool, error) {
+	request := models.EvacuateCrashedActualLRPRequest{
+		ActualLrpKey:         key,
+		ActualLrpInstanceKey: instanceKey,
+		ErrorMessage:         errorMessage,
+	}
+	return c.doEvacuationRequest(EvacuateCrashedActualLRPRoute, &request)
+}
+
+func (c *client) RemoveEvacuatingActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) error {
+	request := models.RemoveEvacuatingActualLRPRequest{
+		ActualLrpKey:         key,
+		ActualLrpInstanceKey: instanceKey,
+	}
+	return c.doRequest(RemoveEvacuatingActualLRPRoute, nil, nil, &request, nil)
+}
+
 func (c *client) DesiredLRPs(filter models.DesiredLRPFilter) ([]*models.DesiredLRP, error) {
 	var desiredLRPs models.DesiredLRPs
+	err := c.doRequest(DesiredLRPsRoute, nil, desiredLRPFilterQuery(filter), nil, &desiredLRPs)
+	return desiredLRPs.GetDesiredLrps(), err
+}
+
+func desiredLRPFilterQuery(filter models.DesiredLRPFilter) url.Values {
 	query := url.Values{}
 	if filter.Domain != "" {
 		query.Set("domain", filter.Domain)
 	}
-	err := c.doRequest(DesiredLRPsRoute, nil, query, nil, &desiredLRPs)
-	return desiredLRPs.GetDesiredLrps(), err
+	return query
 }
 
 func (c *client) DesiredLRPByProcessGuid(processGuid string) (*models.DesiredLRP, error) {
@@ -186,6 +308,27 @@ func (c *client) DesiredLRPByProcessGuid(processGuid string) (*models.DesiredLRP
 	return &desiredLRP, err
 }
 
+func (c *client) DesireLRP(desiredLRP *models.DesiredLRP) error {
+	return c.doRequest(DesireDesiredLRPRoute, nil, nil, desiredLRP, nil)
+}
+
+func (c *client) UpdateDesiredLRP(processGuid string, update *models.DesiredLRPUpdate) error {
+	return c.UpdateDesiredLRPIfUnmodified(processGuid, update, nil)
+}
+
+func (c *client) UpdateDesiredLRPIfUnmodified(processGuid string, update *models.DesiredLRPUpdate, expectedTag *models.ModificationTag) error {
+	request := models.UpdateDesiredLRPRequest{
+		ProcessGuid:             processGuid,
+		Update:                  update,
+		ExpectedModificationTag: expectedTag,
+	}
+	return c.doRequest(UpdateDesiredLRPRoute, nil, nil, &request, nil)
+}
+
+func (c *client) RemoveDesiredLRP(processGuid string) error {
+	return c.doRequest(RemoveDesiredLRPRoute, rata.Params{"process_guid": processGuid}, nil, nil, nil)
+}
+
 func (c *client) Tasks() ([]*models.Task, error) {
 	var tasks models.Tasks
 	err := c.doRequest(TasksRoute, nil, nil, nil, &tasks)
@@ -216,21 +359,104 @@ func (c *client) TaskByGuid(taskGuid string) (*models.Task, error) {
 	return &task, err
 }
 
-func (c *client) SubscribeToEvents() (events.EventSource, error) {
-	eventSource, err := sse.Connect(c.streamingHTTPClient, time.Second, func() *http.Request {
-		request, err := c.reqGen.CreateRequest(EventStreamRoute, nil, nil)
-		if err != nil {
-			panic(err) // totally shouldn't happen
-		}
+func (c *client) DesireTask(task *models.Task) error {
+	return c.doRequest(DesireTaskRoute, nil, nil, task, nil)
+}
 
-		return request
-	})
+func (c *client) StartTask(taskGuid string, cellId string) (bool, error) {
+	var response models.StartTaskResponse
+	request := models.StartTaskRequest{
+		TaskGuid: taskGuid,
+		CellId:   cellId,
+	}
+	err := c.doRequest(StartTaskRoute, nil, nil, &request, &response)
+	return response.ShouldStart, err
+}
 
-	if err != nil {
-		return nil, err
+func (c *client) CancelTask(taskGuid string) error {
+	request := models.TaskGuidRequest{
+		TaskGuid: taskGuid,
+	}
+	return c.doRequest(CancelTaskRoute, nil, nil, &request, nil)
+}
+
+func (c *client) FailTask(taskGuid, failureReason string) error {
+	request := models.FailTaskRequest{
+		TaskGuid:      taskGuid,
+		FailureReason: failureReason,
+	}
+	return c.doRequest(FailTaskRoute, nil, nil, &request, nil)
+}
+
+func (c *client) CompleteTask(taskGuid, cellId string, failed bool, failureReason, result string) error {
+	request := models.CompleteTaskRequest{
+		TaskGuid:      taskGuid,
+		CellId:        cellId,
+		Failed:        failed,
+		FailureReason: failureReason,
+		Result:        result,
+	}
+	return c.doRequest(CompleteTaskRoute, nil, nil, &request, nil)
+}
+
+func (c *client) ResolvingTask(taskGuid string) error {
+	request := models.TaskGuidRequest{
+		TaskGuid: taskGuid,
 	}
+	return c.doRequest(ResolvingTaskRoute, nil, nil, &request, nil)
+}
+
+func (c *client) ResolveTask(taskGuid string) error {
+	request := models.TaskGuidRequest{
+		TaskGuid: taskGuid,
+	}
+	return c.doRequest(ResolveTaskRoute, nil, nil, &request, nil)
+}
 
-	return events.NewEventSource(eventSource), nil
+func (c *client) Cells() ([]*models.CellPresence, error) {
+	var response models.CellsResponse
+	err := c.doRequest(CellsRoute, nil, nil, nil, &response)
+	return response.GetCells(), err
+}
+
+func (c *client) SubscribeToEvents() (events.EventSource, error) {
+	return c.SubscribeToEventsWithFilter(models.EventFilter{})
+}
+
+func (c *client) SubscribeToEventsWithFilter(filter models.EventFilter) (events.EventSource, error) {
+	query := url.Values{}
+	if filter.Domain != "" {
+		query.Set("domain", filter.Domain)
+	}
+	if filter.CellID != "" {
+		query.Set("cell_id", filter.CellID)
+	}
+	if filter.ProcessGuid != "" {
+		query.Set("process_guid", filter.ProcessGuid)
+	}
+	for _, eventType := range filter.EventTypes {
+		query.Add("event_type", eventType)
+	}
+
+	return events.NewHeartbeatEventSource(func(lastEventID string) (events.RawEventSource, error) {
+		connectQuery := url.Values{}
+		for key, values := range query {
+			connectQuery[key] = values
+		}
+		if lastEventID != "" {
+			connectQuery.Set("last_event_id", lastEventID)
+		}
+
+		return sse.Connect(c.streamingHTTPClient, time.Second, func() *http.Request {
+			request, err := c.reqGen.CreateRequest(EventStreamRoute, nil, nil)
+			if err != nil {
+				panic(err) // totally shouldn't happen
+			}
+			request.URL.RawQuery = connectQuery.Encode()
+
+			return request
+		})
+	})
 }
 
 func (c *client) createRequest(requestName string, params rata.Params, queryParams url.Values, message proto.Message) (*http.Request, error) {
@@ -262,6 +488,18 @@ func (c *client) doRequest(requestName string, params rata.Params, queryParams u
 	return c.do(req, message)
 }
 
+func (c *client) doEvacuationRequest(requestName string, request proto.Message) (bool, error) {
+	var response models.EvacuationResponse
+	err := c.doRequest(requestName, nil, nil, request, &response)
+	if err != nil {
+		return true, err
+	}
+	if response.Error != nil {
+		return response.KeepContainer, response.Error
+	}
+	return response.KeepContainer, nil
+}
+
 func (c *client) do(req *http.Request, responseObject interface{}) error {
 	res, err := c.httpClient.Do(req)
 	if err != nil {
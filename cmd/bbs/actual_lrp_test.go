@@ -238,6 +238,21 @@ var _ = Describe("ActualLRP API", func() {
 
 			Expect(*fetchedActualLRP).To(Equal(expectedActualLRP))
 		})
+
+		Context("when claiming again is conditional on the instance's modification tag", func() {
+			It("fails once the actual_lrp has changed since the tag was read", func() {
+				Expect(claimErr).NotTo(HaveOccurred())
+
+				staleTag := unclaimedLRP.ModificationTag
+				_, err := client.ClaimActualLRPIfUnmodified(unclaimedProcessGuid, unclaimedIndex, &instanceKey, &staleTag)
+				Expect(err).To(Equal(models.ErrResourceConflict))
+
+				group, err := client.ActualLRPGroupByProcessGuidAndIndex(unclaimedProcessGuid, unclaimedIndex)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.ClaimActualLRPIfUnmodified(unclaimedProcessGuid, unclaimedIndex, &instanceKey, group.InstanceModificationTag())
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("POST /v1/actual_lrps/start", func() {
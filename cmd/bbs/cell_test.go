@@ -0,0 +1,27 @@
+package main_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cell API", func() {
+	var cell1, cell2 models.CellPresence
+
+	BeforeEach(func() {
+		cell1 = models.NewCellPresence("cell-1", "1.2.3.4", "z1", models.NewCellCapacity(128, 1024, 6), []string{"docker"}, []string{"cflinuxfs2"})
+		cell2 = models.NewCellPresence("cell-2", "5.6.7.8", "z2", models.NewCellCapacity(256, 2048, 12), []string{}, []string{})
+		consulHelper.RegisterCell(cell1)
+		consulHelper.RegisterCell(cell2)
+	})
+
+	Describe("GET /v1/cells", func() {
+		It("returns every registered cell", func() {
+			cells, err := client.Cells()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cells).To(ConsistOf(&cell1, &cell2))
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package main_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Content negotiation", func() {
+	doRequest := func(method, path string, headers map[string]string, body []byte) *http.Response {
+		request, err := http.NewRequest(method, "http://"+bbsAddress+path, bytes.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		for name, value := range headers {
+			request.Header.Set(name, value)
+		}
+
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		return response
+	}
+
+	readBody := func(response *http.Response) []byte {
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return body
+	}
+
+	jsonHeaders := map[string]string{
+		"Accept":              bbs.JSONContentType,
+		bbs.ContentTypeHeader: bbs.JSONContentType,
+	}
+
+	It("accepts JSON requests and renders JSON responses", func() {
+		task := model_helpers.NewValidTask("task-guid")
+		payload, err := json.Marshal(task)
+		Expect(err).NotTo(HaveOccurred())
+
+		response := doRequest("POST", "/v1/tasks/desire", jsonHeaders, payload)
+		readBody(response)
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+		response = doRequest("GET", "/v1/tasks/task-guid", jsonHeaders, nil)
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get(bbs.ContentTypeHeader)).To(Equal(bbs.JSONContentType))
+
+		var fetchedTask models.Task
+		err = json.Unmarshal(readBody(response), &fetchedTask)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetchedTask.TaskGuid).To(Equal("task-guid"))
+		Expect(fetchedTask.Domain).To(Equal(task.Domain))
+	})
+
+	It("renders errors as JSON", func() {
+		response := doRequest("GET", "/v1/tasks/missing-guid", jsonHeaders, nil)
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(response.Header.Get(bbs.ContentTypeHeader)).To(Equal(bbs.JSONContentType))
+
+		var bbsErr models.Error
+		err := json.Unmarshal(readBody(response), &bbsErr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bbsErr.Type).To(Equal(models.ResourceNotFound))
+	})
+
+	It("defaults to protobuf", func() {
+		response := doRequest("GET", "/v1/tasks/missing-guid", nil, nil)
+		readBody(response)
+		Expect(response.Header.Get(bbs.ContentTypeHeader)).To(Equal(bbs.ProtoContentType))
+	})
+})
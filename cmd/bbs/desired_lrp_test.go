@@ -2,6 +2,7 @@ package main_test
 
 import (
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -91,4 +92,108 @@ var _ = Describe("DesiredLRP API", func() {
 			Expect(desiredLRP).To(Equal(expectedDesiredLRP))
 		})
 	})
+
+	Describe("POST /v1/desired_lrp/desire", func() {
+		var (
+			desiredLRP *models.DesiredLRP
+			desireErr  error
+		)
+
+		BeforeEach(func() {
+			desiredLRP = model_helpers.NewValidDesiredLRP("super-lrp")
+			desiredLRP.Instances = 2
+		})
+
+		JustBeforeEach(func() {
+			desireErr = client.DesireLRP(desiredLRP)
+		})
+
+		It("creates the desired LRP and its unclaimed actual LRPs", func() {
+			Expect(desireErr).NotTo(HaveOccurred())
+
+			persistedDesiredLRP, err := client.DesiredLRPByProcessGuid("super-lrp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(persistedDesiredLRP.Instances).To(BeEquivalentTo(2))
+
+			groups, err := client.ActualLRPGroupsByProcessGuid("super-lrp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(HaveLen(2))
+		})
+
+		Context("when the desired LRP already exists", func() {
+			BeforeEach(func() {
+				etcdHelper.SetRawDesiredLRP(model_helpers.NewValidDesiredLRP("super-lrp"))
+			})
+
+			It("responds with a ResourceConflict error", func() {
+				Expect(desireErr).To(Equal(models.ErrResourceExists))
+			})
+		})
+	})
+
+	Describe("POST /v1/desired_lrp/update", func() {
+		var updateErr error
+
+		BeforeEach(func() {
+			desiredLRP := model_helpers.NewValidDesiredLRP("super-lrp")
+			desiredLRP.Instances = 1
+			err := client.DesireLRP(desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			instances := int32(3)
+			updateErr = client.UpdateDesiredLRP("super-lrp", &models.DesiredLRPUpdate{Instances: &instances})
+		})
+
+		It("updates the desired LRP and creates the new actual LRPs", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+
+			persistedDesiredLRP, err := client.DesiredLRPByProcessGuid("super-lrp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(persistedDesiredLRP.Instances).To(BeEquivalentTo(3))
+
+			groups, err := client.ActualLRPGroupsByProcessGuid("super-lrp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(HaveLen(3))
+		})
+
+		Context("when the update is conditional on a stale modification tag", func() {
+			It("fails with a ResourceConflict error and leaves the desired LRP alone", func() {
+				persistedDesiredLRP, err := client.DesiredLRPByProcessGuid("super-lrp")
+				Expect(err).NotTo(HaveOccurred())
+
+				staleTag := *persistedDesiredLRP.ModificationTag
+				staleTag.Index--
+
+				instances := int32(5)
+				err = client.UpdateDesiredLRPIfUnmodified("super-lrp", &models.DesiredLRPUpdate{Instances: &instances}, &staleTag)
+				Expect(err).To(Equal(models.ErrResourceConflict))
+
+				persistedDesiredLRP, err = client.DesiredLRPByProcessGuid("super-lrp")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persistedDesiredLRP.Instances).To(BeEquivalentTo(3))
+			})
+		})
+	})
+
+	Describe("DELETE /v1/desired_lrps/:process_guid", func() {
+		BeforeEach(func() {
+			desiredLRP := model_helpers.NewValidDesiredLRP("super-lrp")
+			err := client.DesireLRP(desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the desired LRP and its unclaimed actual LRPs", func() {
+			err := client.RemoveDesiredLRP("super-lrp")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.DesiredLRPByProcessGuid("super-lrp")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			groups, err := client.ActualLRPGroupsByProcessGuid("super-lrp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,153 @@
+package main_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evacuation API", func() {
+	const (
+		processGuid     = "process-guid"
+		index           = 0
+		cellID          = "cell-id"
+		noExpirationTTL = 0
+	)
+
+	var (
+		actualLRP   *models.ActualLRP
+		key         models.ActualLRPKey
+		instanceKey models.ActualLRPInstanceKey
+		netInfo     models.ActualLRPNetInfo
+	)
+
+	BeforeEach(func() {
+		desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+		desiredLRP.Instances = 1
+		etcdHelper.SetRawDesiredLRP(desiredLRP)
+
+		key = models.NewActualLRPKey(processGuid, index, desiredLRP.Domain)
+		instanceKey = models.NewActualLRPInstanceKey("instance-guid", cellID)
+		netInfo = models.NewActualLRPNetInfo("127.0.0.1", models.NewPortMapping(8080, 80))
+
+		actualLRP = &models.ActualLRP{
+			ActualLRPKey:         key,
+			ActualLRPInstanceKey: instanceKey,
+			ActualLRPNetInfo:     netInfo,
+			State:                models.ActualLRPStateRunning,
+		}
+		etcdHelper.SetRawActualLRP(actualLRP)
+	})
+
+	Describe("POST /v1/actual_lrps/evacuate_running", func() {
+		var (
+			keepContainer bool
+			evacuateErr   error
+		)
+
+		JustBeforeEach(func() {
+			keepContainer, evacuateErr = client.EvacuateRunningActualLRP(&key, &instanceKey, &netInfo, 60)
+		})
+
+		It("keeps the container and moves the instance to the evacuating slot", func() {
+			Expect(evacuateErr).NotTo(HaveOccurred())
+			Expect(keepContainer).To(BeTrue())
+
+			group, err := client.ActualLRPGroupByProcessGuidAndIndex(processGuid, index)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(group.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			Expect(group.Evacuating.State).To(Equal(models.ActualLRPStateRunning))
+			Expect(group.Evacuating.ActualLRPInstanceKey).To(Equal(instanceKey))
+
+			resolved, evacuating := group.Resolve()
+			Expect(evacuating).To(BeTrue())
+			Expect(resolved.ActualLRPNetInfo).To(Equal(netInfo))
+		})
+	})
+
+	Describe("POST /v1/actual_lrps/evacuate_claimed", func() {
+		var (
+			keepContainer bool
+			evacuateErr   error
+		)
+
+		JustBeforeEach(func() {
+			keepContainer, evacuateErr = client.EvacuateClaimedActualLRP(&key, &instanceKey)
+		})
+
+		It("unclaims the instance and tells the cell to drop the container", func() {
+			Expect(evacuateErr).NotTo(HaveOccurred())
+			Expect(keepContainer).To(BeFalse())
+
+			group, err := client.ActualLRPGroupByProcessGuidAndIndex(processGuid, index)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			Expect(group.Evacuating).To(BeNil())
+		})
+	})
+
+	Describe("POST /v1/actual_lrps/evacuate_stopped", func() {
+		var (
+			keepContainer bool
+			evacuateErr   error
+		)
+
+		JustBeforeEach(func() {
+			keepContainer, evacuateErr = client.EvacuateStoppedActualLRP(&key, &instanceKey)
+		})
+
+		It("removes the instance", func() {
+			Expect(evacuateErr).NotTo(HaveOccurred())
+			Expect(keepContainer).To(BeFalse())
+
+			_, err := client.ActualLRPGroupByProcessGuidAndIndex(processGuid, index)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+	})
+
+	Describe("POST /v1/actual_lrps/evacuate_crashed", func() {
+		var (
+			keepContainer bool
+			evacuateErr   error
+		)
+
+		JustBeforeEach(func() {
+			keepContainer, evacuateErr = client.EvacuateCrashedActualLRP(&key, &instanceKey, "oh no")
+		})
+
+		It("crashes the instance", func() {
+			Expect(evacuateErr).NotTo(HaveOccurred())
+			Expect(keepContainer).To(BeFalse())
+
+			group, err := client.ActualLRPGroupByProcessGuidAndIndex(processGuid, index)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Instance.CrashCount).To(Equal(int32(1)))
+			Expect(group.Instance.CrashReason).To(Equal("oh no"))
+		})
+	})
+
+	Describe("POST /v1/actual_lrps/remove_evacuating", func() {
+		var removeErr error
+
+		BeforeEach(func() {
+			evacuatingLRP := *actualLRP
+			etcdHelper.SetRawEvacuatingActualLRP(&evacuatingLRP, noExpirationTTL)
+		})
+
+		JustBeforeEach(func() {
+			removeErr = client.RemoveEvacuatingActualLRP(&key, &instanceKey)
+		})
+
+		It("removes the evacuating actual lrp", func() {
+			Expect(removeErr).NotTo(HaveOccurred())
+
+			group, err := client.ActualLRPGroupByProcessGuidAndIndex(processGuid, index)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Evacuating).To(BeNil())
+			Expect(group.Instance).NotTo(BeNil())
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -91,6 +92,42 @@ var _ = Describe("Events API", func() {
 			}
 		})
 
+		It("receives crashed events", func() {
+			etcdHelper.SetRawActualLRP(baseLRP)
+
+			err := client.CrashActualLRP(&key, &instanceKey, "out of memory")
+			Expect(err).NotTo(HaveOccurred())
+
+			var event models.Event
+			Eventually(func() models.Event {
+				Eventually(eventChannel).Should(Receive(&event))
+				return event
+			}).Should(BeAssignableToTypeOf(&models.ActualLRPCrashedEvent{}))
+
+			actualLRPCrashedEvent := event.(*models.ActualLRPCrashedEvent)
+			Expect(*actualLRPCrashedEvent.ActualLrpKey).To(Equal(key))
+			Expect(*actualLRPCrashedEvent.ActualLrpInstanceKey).To(Equal(instanceKey))
+			Expect(actualLRPCrashedEvent.CrashCount).To(Equal(int32(1)))
+			Expect(actualLRPCrashedEvent.CrashReason).To(Equal("out of memory"))
+		})
+
+		It("receives crashed events for instances that crash while evacuating", func() {
+			etcdHelper.SetRawActualLRP(baseLRP)
+
+			_, err := client.EvacuateCrashedActualLRP(&key, &instanceKey, "out of memory")
+			Expect(err).NotTo(HaveOccurred())
+
+			var event models.Event
+			Eventually(func() models.Event {
+				Eventually(eventChannel).Should(Receive(&event))
+				return event
+			}).Should(BeAssignableToTypeOf(&models.ActualLRPCrashedEvent{}))
+
+			actualLRPCrashedEvent := event.(*models.ActualLRPCrashedEvent)
+			Expect(*actualLRPCrashedEvent.ActualLrpInstanceKey).To(Equal(instanceKey))
+			Expect(actualLRPCrashedEvent.CrashReason).To(Equal("out of memory"))
+		})
+
 		It("receives events", func() {
 			By("creating a ActualLRP")
 			etcdHelper.SetRawActualLRP(baseLRP)
@@ -229,4 +266,98 @@ var _ = Describe("Events API", func() {
 			// Expect(response).To(Equal(serialization.ActualLRPProtoToResponse(evacuatingLRP, true)))
 		})
 	})
+
+	Describe("Tasks", func() {
+		var (
+			done         chan struct{}
+			eventChannel chan models.Event
+
+			eventSource events.EventSource
+		)
+
+		JustBeforeEach(func() {
+			var err error
+			eventSource, err = client.SubscribeToEvents()
+			Expect(err).NotTo(HaveOccurred())
+
+			eventChannel = make(chan models.Event)
+			done = make(chan struct{})
+
+			go func() {
+				defer close(done)
+				for {
+					event, err := eventSource.Next()
+					if err != nil {
+						close(eventChannel)
+						return
+					}
+					eventChannel <- event
+				}
+			}()
+
+			primerTask := model_helpers.NewValidTask("primer-guid")
+
+		PRIMING:
+			for {
+				select {
+				case <-eventChannel:
+					break PRIMING
+				case <-time.After(50 * time.Millisecond):
+					etcdHelper.SetRawTask(primerTask)
+				}
+			}
+		})
+
+		It("receives events", func() {
+			By("desiring a Task")
+			err := client.DesireTask(model_helpers.NewValidTask("task-guid"))
+			Expect(err).NotTo(HaveOccurred())
+
+			task, err := client.TaskByGuid("task-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			var event models.Event
+			Eventually(func() models.Event {
+				Eventually(eventChannel).Should(Receive(&event))
+				return event
+			}).Should(BeAssignableToTypeOf(&models.TaskCreatedEvent{}))
+
+			taskCreatedEvent := event.(*models.TaskCreatedEvent)
+			Expect(taskCreatedEvent.Task).To(Equal(task))
+
+			By("starting the Task")
+			_, err = client.StartTask("task-guid", "cell-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			before := task
+			task, err = client.TaskByGuid("task-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() models.Event {
+				Eventually(eventChannel).Should(Receive(&event))
+				return event
+			}).Should(BeAssignableToTypeOf(&models.TaskChangedEvent{}))
+
+			taskChangedEvent := event.(*models.TaskChangedEvent)
+			Expect(taskChangedEvent.Before).To(Equal(before))
+			Expect(taskChangedEvent.After).To(Equal(task))
+
+			By("cancelling and resolving the Task")
+			err = client.CancelTask("task-guid")
+			Expect(err).NotTo(HaveOccurred())
+			err = client.ResolvingTask("task-guid")
+			Expect(err).NotTo(HaveOccurred())
+			err = client.ResolveTask("task-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() models.Event {
+				Eventually(eventChannel).Should(Receive(&event))
+				return event
+			}).Should(BeAssignableToTypeOf(&models.TaskRemovedEvent{}))
+
+			taskRemovedEvent := event.(*models.TaskRemovedEvent)
+			Expect(taskRemovedEvent.Task.TaskGuid).To(Equal("task-guid"))
+			Expect(taskRemovedEvent.Task.State).To(Equal(models.Task_Resolving))
+		})
+	})
 })
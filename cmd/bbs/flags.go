@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/encryption"
 )
 
 type ETCDFlags struct {
@@ -93,3 +95,85 @@ func (flags *ETCDFlags) Validate() (*ETCDOptions, error) {
 		IsSSL:       isSSL,
 	}, nil
 }
+
+type EncryptionFlags struct {
+	keyFile        string
+	activeKeyLabel string
+	encryptionKeys encryptionKeys
+}
+
+// encryptionKeys collects repeated -encryptionKey flags.
+type encryptionKeys []string
+
+func (keys *encryptionKeys) String() string {
+	return fmt.Sprintf("%d keys", len(*keys))
+}
+
+func (keys *encryptionKeys) Set(value string) error {
+	*keys = append(*keys, value)
+	return nil
+}
+
+func AddEncryptionFlags(flagSet *flag.FlagSet) *EncryptionFlags {
+	flags := &EncryptionFlags{}
+
+	flagSet.StringVar(
+		&flags.keyFile,
+		"encryptionKeyFile",
+		"",
+		"Location of a JSON file with encryption keys ({\"active_key_label\": ..., \"keys\": {label: passphrase}})",
+	)
+	flagSet.StringVar(
+		&flags.activeKeyLabel,
+		"activeKeyLabel",
+		"",
+		"Label of the encryption key that records are written with",
+	)
+	flagSet.Var(
+		&flags.encryptionKeys,
+		"encryptionKey",
+		"Encryption key as label:passphrase (may be given more than once)",
+	)
+	return flags
+}
+
+// Validate returns the keyring to encrypt records with, or nil when no keys
+// are configured and records are stored unencrypted.
+func (flags *EncryptionFlags) Validate() (encryption.KeyManager, error) {
+	cfg := encryption.KeyConfig{Keys: map[string]string{}}
+	if flags.keyFile != "" {
+		var err error
+		cfg, err = encryption.LoadKeyConfig(flags.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid encryption key file: %s", err.Error())
+		}
+		if cfg.Keys == nil {
+			cfg.Keys = map[string]string{}
+		}
+	}
+
+	for _, value := range flags.encryptionKeys {
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("Encryption keys must be given as label:passphrase")
+		}
+		label, passphrase := parts[0], parts[1]
+		if _, ok := cfg.Keys[label]; ok {
+			return nil, fmt.Errorf("Multiple encryption keys with label '%s'", label)
+		}
+		cfg.Keys[label] = passphrase
+	}
+
+	if flags.activeKeyLabel != "" {
+		cfg.ActiveKeyLabel = flags.activeKeyLabel
+	}
+
+	if len(cfg.Keys) == 0 {
+		if cfg.ActiveKeyLabel != "" {
+			return nil, errors.New("An active key label requires encryption keys")
+		}
+		return nil, nil
+	}
+
+	return cfg.KeyManager()
+}
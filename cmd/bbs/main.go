@@ -1,17 +1,31 @@
 package main
 
 import (
+	"crypto/rand"
+	"crypto/tls"
+	"database/sql"
 	"errors"
 	"flag"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/auctionhandlers"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
 	"github.com/cloudfoundry-incubator/bbs/cellhandlers"
+	"github.com/cloudfoundry-incubator/bbs/converger"
+	"github.com/cloudfoundry-incubator/bbs/db"
 	consuldb "github.com/cloudfoundry-incubator/bbs/db/consul"
 	etcddb "github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/db/memdb"
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/encryptor"
 	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/format"
 	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
 	"github.com/cloudfoundry-incubator/bbs/watcher"
 	cf_debug_server "github.com/cloudfoundry-incubator/cf-debug-server"
 	cf_lager "github.com/cloudfoundry-incubator/cf-lager"
@@ -19,6 +33,8 @@ import (
 	"github.com/cloudfoundry-incubator/consuladapter"
 	"github.com/cloudfoundry/dropsonde"
 	etcdclient "github.com/coreos/go-etcd/etcd"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/ifrit"
@@ -33,6 +49,36 @@ var serverAddress = flag.String(
 	"The host:port that the server is bound to.",
 )
 
+var requireSSL = flag.Bool(
+	"requireSSL",
+	false,
+	"Serve the API over HTTPS and require clients to present a certificate signed by caFile.",
+)
+
+var certFile = flag.String(
+	"certFile",
+	"",
+	"Location of the server certificate, used when requireSSL is set.",
+)
+
+var keyFile = flag.String(
+	"keyFile",
+	"",
+	"Location of the server private key, used when requireSSL is set.",
+)
+
+var caFile = flag.String(
+	"caFile",
+	"",
+	"Location of the CA certificate that client certificates must be signed by, used when requireSSL is set.",
+)
+
+var authorizationConfig = flag.String(
+	"authorizationConfig",
+	"",
+	"Path to a JSON file mapping client certificate common names and bearer tokens to the routes, domains and cell each client may use. If unset, every caller is authorized.",
+)
+
 var communicationTimeout = flag.Duration(
 	"communicationTimeout",
 	10*time.Second,
@@ -63,6 +109,96 @@ var lockTTL = flag.Duration(
 	"TTL for service lock",
 )
 
+var maxTaskCallbackWorkers = flag.Int(
+	"maxTaskCallbackWorkers",
+	1000,
+	"The maximum number of task completion callbacks in flight at once.",
+)
+
+var taskCallbackRetries = flag.Int(
+	"taskCallbackRetries",
+	3,
+	"The number of times a task completion callback is retried after a server error.",
+)
+
+var taskCallbackRetryInterval = flag.Duration(
+	"taskCallbackRetryInterval",
+	time.Second,
+	"Initial wait before retrying a task completion callback, doubled after each retry.",
+)
+
+var convergeRepeatInterval = flag.Duration(
+	"convergeRepeatInterval",
+	30*time.Second,
+	"How often to converge desired and actual state.",
+)
+
+var kickTaskDuration = flag.Duration(
+	"kickTaskDuration",
+	30*time.Second,
+	"How long a Pending task, or a Completed task whose callback stalled, waits before convergence kicks it again.",
+)
+
+var expirePendingTaskDuration = flag.Duration(
+	"expirePendingTaskDuration",
+	30*time.Minute,
+	"How long a task may stay Pending before convergence fails it.",
+)
+
+var expireCompletedTaskDuration = flag.Duration(
+	"expireCompletedTaskDuration",
+	2*time.Minute,
+	"How long a Completed or Resolving task is retained before convergence deletes it.",
+)
+
+var eventSubscriberBufferSize = flag.Int(
+	"eventSubscriberBufferSize",
+	events.MAX_PENDING_SUBSCRIBER_EVENTS,
+	"The number of events queued for an event stream subscriber before it is considered slow.",
+)
+
+var slowEventSubscriberPolicy = flag.String(
+	"slowEventSubscriberPolicy",
+	string(events.DisconnectSlowConsumers),
+	"What to do with slow event stream subscribers: disconnect, block (for up to slowEventSubscriberTimeout) or coalesce (keep only the latest actual LRP change per instance).",
+)
+
+var slowEventSubscriberTimeout = flag.Duration(
+	"slowEventSubscriberTimeout",
+	events.DEFAULT_SLOW_CONSUMER_TIMEOUT,
+	"How long the block policy waits on a slow event stream subscriber before disconnecting it.",
+)
+
+var databaseDriver = flag.String(
+	"databaseDriver",
+	"",
+	"SQL driver to store records with (postgres or mysql). Records are stored in etcd when unset.",
+)
+
+var databaseConnectionString = flag.String(
+	"databaseConnectionString",
+	"",
+	"Connection string for the SQL database.",
+)
+
+var storageFormat = flag.String(
+	"storageFormat",
+	"legacy",
+	"Encoding of records written to etcd (legacy, json or proto). Records in any encoding can be read, but BBSes that predate envelopes only read legacy ones, so switch once every BBS in the deployment has been upgraded.",
+)
+
+var inMemory = flag.Bool(
+	"inMemory",
+	false,
+	"Store records and cell presences in memory instead of etcd and consul (for tests and single-node development).",
+)
+
+var eventHeartbeatInterval = flag.Duration(
+	"eventHeartbeatInterval",
+	30*time.Second,
+	"How often idle event streams are sent a heartbeat. Zero disables heartbeats.",
+)
+
 const (
 	dropsondeDestination = "localhost:3457"
 	dropsondeOrigin      = "bbs"
@@ -74,6 +210,7 @@ func main() {
 	cf_debug_server.AddFlags(flag.CommandLine)
 	cf_lager.AddFlags(flag.CommandLine)
 	etcdFlags := AddETCDFlags(flag.CommandLine)
+	encryptionFlags := AddEncryptionFlags(flag.CommandLine)
 	flag.Parse()
 
 	cf_http.Initialize(*communicationTimeout)
@@ -83,48 +220,107 @@ func main() {
 
 	initializeDropsonde(logger)
 
-	etcdOptions, err := etcdFlags.Validate()
+	err := validateAuctioneerFlag()
 	if err != nil {
-		logger.Fatal("etcd-validation-failed", err)
+		logger.Fatal("auctioneer-address-validation-failed", err)
+	}
+
+	keyManager, err := encryptionFlags.Validate()
+	if err != nil {
+		logger.Fatal("encryption-validation-failed", err)
+	}
+	if keyManager != nil && (*inMemory || *databaseDriver != "") {
+		logger.Fatal("encryption-validation-failed", errors.New("encryption is only supported for records stored in etcd"))
 	}
 
+	auctioneerClient := auctionhandlers.NewClient(*auctioneerAddress)
+	cellClient := cellhandlers.NewClient()
+	taskCompletionWorkPool := taskworkpool.New(
+		logger,
+		*maxTaskCallbackWorkers,
+		taskworkpool.NewCompletedTaskHandler(cf_http.NewClient(), clock.NewClock(), *taskCallbackRetries, *taskCallbackRetryInterval),
+	)
+
+	var bbsDB db.DB
+	var cellDB db.CellDB
+	var encryptionDB db.EncryptionDB
+	var versionDB db.VersionDB
 	var etcdClient *etcdclient.Client
-	if etcdOptions.IsSSL {
-		etcdClient, err = etcdclient.NewTLSClient(etcdOptions.ClusterUrls, etcdOptions.CertFile, etcdOptions.KeyFile, etcdOptions.CAFile)
-		if err != nil {
-			logger.Fatal("failed-to-construct-etcd-tls-client", err)
-		}
+	var serializer format.Serializer
+	if *inMemory {
+		memDB := memdb.NewMemDB(auctioneerClient, cellClient, clock.NewClock(), taskCompletionWorkPool)
+		bbsDB, cellDB = memDB, memDB
 	} else {
-		etcdClient = etcdclient.NewClient(etcdOptions.ClusterUrls)
+		cellDB = consuldb.NewConsul(initializeConsul(logger))
+		if *databaseDriver != "" {
+			bbsDB = initializeSQLDB(logger, auctioneerClient, cellClient, cellDB, taskCompletionWorkPool)
+		} else {
+			etcdClient, serializer = initializeETCDStore(logger, etcdFlags, keyManager)
+			etcdDB := etcddb.NewETCD(serializer, etcdClient, auctioneerClient, cellClient, cellDB, clock.NewClock(), taskCompletionWorkPool)
+			bbsDB, encryptionDB, versionDB = etcdDB, etcdDB, etcdDB
+		}
 	}
-	etcdClient.SetConsistency(etcdclient.STRONG_CONSISTENCY)
 
-	err = validateAuctioneerFlag()
+	hubConfig, err := hubConfigFromFlags()
 	if err != nil {
-		logger.Fatal("auctioneer-address-validation-failed", err)
+		logger.Fatal("event-hub-validation-failed", err)
 	}
-	auctioneerClient := auctionhandlers.NewClient(*auctioneerAddress)
-	consulSession := initializeConsul(logger)
-	consulDB := consuldb.NewConsul(consulSession)
-	cellClient := cellhandlers.NewClient()
-	db := etcddb.NewETCD(etcdClient, auctioneerClient, cellClient, consulDB, clock.NewClock())
-	hub := events.NewHub()
+	hub := events.NewHubWithConfig(hubConfig)
 	watcher := watcher.NewWatcher(
 		logger,
-		db,
+		bbsDB,
 		hub,
 		clock.NewClock(),
 		bbsWatchRetryWaitDuration,
 	)
 
-	handler := handlers.New(logger, db, hub)
+	var policy *authorization.Policy
+	if *authorizationConfig != "" {
+		policy, err = authorization.LoadPolicy(*authorizationConfig)
+		if err != nil {
+			logger.Fatal("failed-to-load-authorization-config", err)
+		}
+	}
+
+	handler := handlers.New(logger, bbsDB, cellDB, hub, *eventHeartbeatInterval, policy)
+
+	server, err := initializeServer(handler)
+	if err != nil {
+		logger.Fatal("server-initialization-failed", err)
+	}
 
 	members := grouper.Members{
+		{"task-completion-workpool", taskCompletionWorkPool},
 		{"watcher", watcher},
-		{"server", http_server.New(*serverAddress, handler)},
+		{"converger", converger.New(
+			logger,
+			bbsDB,
+			bbsDB,
+			clock.NewClock(),
+			*convergeRepeatInterval,
+			*kickTaskDuration,
+			*expirePendingTaskDuration,
+			*expireCompletedTaskDuration,
+		)},
+		{"server", server},
 		{"hub-closer", closeHub(logger.Session("hub-closer"), hub)},
 	}
 
+	if keyManager != nil {
+		members = append(members, grouper.Member{
+			Name:   "encryptor",
+			Runner: encryptor.New(logger, encryptionDB, keyManager, clock.NewClock()),
+		})
+	}
+
+	if versionDB != nil {
+		members = append(grouper.Members{
+			{"migration-manager", migration.NewManager(logger, versionDB, etcdClient, serializer, migration.Migrations, clock.NewClock())},
+		}, members...)
+	} else {
+		logger.Info("skipping-migrations", lager.Data{"reason": "migrations only apply to data stored in etcd"})
+	}
+
 	if dbgAddr := cf_debug_server.DebugAddress(flag.CommandLine); dbgAddr != "" {
 		members = append(grouper.Members{
 			{"debug-server", cf_debug_server.Runner(dbgAddr, reconfigurableSink)},
@@ -153,6 +349,48 @@ func validateAuctioneerFlag() error {
 	return nil
 }
 
+func initializeServer(handler http.Handler) (ifrit.Runner, error) {
+	if !*requireSSL {
+		return http_server.New(*serverAddress, handler), nil
+	}
+
+	if *certFile == "" {
+		return nil, errors.New("certFile is required when requireSSL is set")
+	}
+	if *keyFile == "" {
+		return nil, errors.New("keyFile is required when requireSSL is set")
+	}
+	if *caFile == "" {
+		return nil, errors.New("caFile is required when requireSSL is set")
+	}
+
+	tlsConfig, err := cf_http.NewTLSConfig(*certFile, *keyFile, *caFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	tlsConfig.ClientCAs = tlsConfig.RootCAs
+
+	return http_server.NewTLSServer(*serverAddress, handler, tlsConfig), nil
+}
+
+func hubConfigFromFlags() (events.HubConfig, error) {
+	if *eventSubscriberBufferSize <= 0 {
+		return events.HubConfig{}, errors.New("eventSubscriberBufferSize must be positive")
+	}
+
+	policy, err := events.ParseSlowConsumerPolicy(*slowEventSubscriberPolicy)
+	if err != nil {
+		return events.HubConfig{}, err
+	}
+
+	return events.HubConfig{
+		MaxPendingEvents:    *eventSubscriberBufferSize,
+		SlowConsumerPolicy:  policy,
+		SlowConsumerTimeout: *slowEventSubscriberTimeout,
+	}, nil
+}
+
 func initializeDropsonde(logger lager.Logger) {
 	err := dropsonde.Initialize(dropsondeDestination, dropsondeOrigin)
 	if err != nil {
@@ -176,6 +414,68 @@ func closeHub(logger lager.Logger, hub events.Hub) ifrit.Runner {
 	})
 }
 
+func initializeETCDStore(
+	logger lager.Logger,
+	etcdFlags *ETCDFlags,
+	keyManager encryption.KeyManager,
+) (*etcdclient.Client, format.Serializer) {
+	etcdOptions, err := etcdFlags.Validate()
+	if err != nil {
+		logger.Fatal("etcd-validation-failed", err)
+	}
+
+	var etcdClient *etcdclient.Client
+	if etcdOptions.IsSSL {
+		etcdClient, err = etcdclient.NewTLSClient(etcdOptions.ClusterUrls, etcdOptions.CertFile, etcdOptions.KeyFile, etcdOptions.CAFile)
+		if err != nil {
+			logger.Fatal("failed-to-construct-etcd-tls-client", err)
+		}
+	} else {
+		etcdClient = etcdclient.NewClient(etcdOptions.ClusterUrls)
+	}
+	etcdClient.SetConsistency(etcdclient.STRONG_CONSISTENCY)
+
+	envelopeFormat, err := format.EnvelopeFormatFromString(*storageFormat)
+	if err != nil {
+		logger.Fatal("storage-format-validation-failed", err)
+	}
+
+	var cryptor encryption.Cryptor
+	if keyManager != nil {
+		cryptor = encryption.NewCryptor(keyManager, rand.Reader)
+	}
+
+	return etcdClient, format.NewSerializer(envelopeFormat, cryptor)
+}
+
+func initializeSQLDB(
+	logger lager.Logger,
+	auctioneerClient auctionhandlers.Client,
+	cellClient cellhandlers.Client,
+	cellDB db.CellDB,
+	taskCompletionClient taskworkpool.TaskCompletionClient,
+) *sqldb.SQLDB {
+	logger = logger.Session("sql", lager.Data{"driver": *databaseDriver})
+
+	sqlConn, err := sql.Open(*databaseDriver, *databaseConnectionString)
+	if err != nil {
+		logger.Fatal("failed-to-open-database", err)
+	}
+
+	err = sqlConn.Ping()
+	if err != nil {
+		logger.Fatal("failed-to-connect-to-database", err)
+	}
+
+	sqlDB := sqldb.NewSQL(sqlConn, *databaseDriver, auctioneerClient, cellClient, cellDB, clock.NewClock(), taskCompletionClient)
+	err = sqlDB.CreateSchema(logger)
+	if err != nil {
+		logger.Fatal("failed-to-create-schema", err)
+	}
+
+	return sqlDB
+}
+
 func initializeConsul(logger lager.Logger) *consuladapter.Session {
 	client, err := consuladapter.NewClient(*consulCluster)
 	if err != nil {
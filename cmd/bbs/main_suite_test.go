@@ -7,7 +7,9 @@ import (
 
 	"github.com/cloudfoundry-incubator/bbs"
 	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
+	"github.com/cloudfoundry-incubator/bbs/db/consul/internal/consul_helpers"
 	"github.com/cloudfoundry-incubator/bbs/db/etcd/internal/etcd_helpers"
+	"github.com/cloudfoundry-incubator/bbs/format"
 	"github.com/cloudfoundry-incubator/consuladapter"
 	"github.com/cloudfoundry-incubator/consuladapter/consulrunner"
 	"github.com/cloudfoundry/storeadapter/storerunner/etcdstorerunner"
@@ -40,6 +42,7 @@ var bbsArgs testrunner.Args
 var bbsRunner *ginkgomon.Runner
 var bbsProcess ifrit.Process
 var consulSession *consuladapter.Session
+var consulHelper *consul_helpers.ConsulHelper
 var consulRunner *consulrunner.ClusterRunner
 var etcdHelper *etcd_helpers.ETCDHelper
 var auctioneerServer *ghttp.Server
@@ -70,7 +73,8 @@ var _ = SynchronizedBeforeSuite(
 		)
 
 		auctioneerServer = ghttp.NewServer()
-		auctioneerServer.AppendHandlers(ghttp.RespondWith(http.StatusAccepted, nil))
+		auctioneerServer.UnhandledRequestStatusCode = http.StatusAccepted
+		auctioneerServer.AllowUnhandledRequests = true
 
 		etcdRunner.Start()
 		consulRunner.Start()
@@ -93,6 +97,7 @@ var _ = BeforeEach(func() {
 	etcdClient.SetConsistency(etcdclient.STRONG_CONSISTENCY)
 
 	consulRunner.Reset()
+	consulSession = consulRunner.NewSession("a-session")
 
 	bbsAddress = fmt.Sprintf("127.0.0.1:%d", 6700+GinkgoParallelNode())
 
@@ -112,7 +117,8 @@ var _ = BeforeEach(func() {
 	bbsRunner = testrunner.New(bbsBinPath, bbsArgs)
 
 	bbsProcess = ginkgomon.Invoke(bbsRunner)
-	etcdHelper = etcd_helpers.NewETCDHelper(etcdClient)
+	etcdHelper = etcd_helpers.NewETCDHelper(format.NewSerializer(format.PROTO, nil), etcdClient)
+	consulHelper = consul_helpers.NewConsulHelper(consulSession)
 })
 
 var _ = AfterEach(func() {
@@ -0,0 +1,51 @@
+package main_test
+
+import (
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
+	etcddb "github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Migrations", func() {
+	var latestVersion int64
+
+	BeforeEach(func() {
+		latestVersion = 0
+		for _, m := range migration.Migrations {
+			if m.Version() > latestVersion {
+				latestVersion = m.Version()
+			}
+		}
+	})
+
+	It("records the version of the stored data on start", func() {
+		response, err := etcdClient.Get(etcddb.VersionKey, false, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Node.Value).To(Equal(strconv.FormatInt(latestVersion, 10)))
+	})
+
+	Context("when the stored data is newer than this BBS understands", func() {
+		BeforeEach(func() {
+			ginkgomon.Kill(bbsProcess)
+
+			_, err := etcdClient.Set(etcddb.VersionKey, strconv.FormatInt(latestVersion+1, 10), 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			bbsRunner = testrunner.New(bbsBinPath, bbsArgs)
+			bbsProcess = ifrit.Background(bbsRunner)
+		})
+
+		It("refuses to start", func() {
+			Eventually(bbsProcess.Wait()).Should(Receive(HaveOccurred()))
+			Expect(bbsRunner.Buffer()).To(gbytes.Say("data-newer-than-bbs"))
+		})
+	})
+})
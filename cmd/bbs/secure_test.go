@@ -0,0 +1,100 @@
+package main_test
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/cmd/bbs/testrunner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secure", func() {
+	var (
+		basePath  string
+		secureURL string
+	)
+
+	BeforeEach(func() {
+		ginkgomon.Kill(bbsProcess)
+
+		basePath = "fixtures"
+		secureURL = fmt.Sprintf("https://%s", bbsAddress)
+
+		bbsArgs.RequireSSL = true
+		bbsArgs.CAFile = path.Join(basePath, "ca.crt")
+		bbsArgs.CertFile = path.Join(basePath, "server.crt")
+		bbsArgs.KeyFile = path.Join(basePath, "server.key")
+
+		bbsRunner = testrunner.New(bbsBinPath, bbsArgs)
+		bbsProcess = ginkgomon.Invoke(bbsRunner)
+	})
+
+	Context("when the client presents a certificate signed by the CA", func() {
+		BeforeEach(func() {
+			var err error
+			client, err = bbs.NewSecureClient(
+				secureURL,
+				path.Join(basePath, "ca.crt"),
+				path.Join(basePath, "client.crt"),
+				path.Join(basePath, "client.key"),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("serves requests", func() {
+			_, err := client.Domains()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("streams events", func() {
+			eventSource, err := client.SubscribeToEvents()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(eventSource.Close()).To(Succeed())
+		})
+	})
+
+	Context("when the client presents an untrusted certificate", func() {
+		BeforeEach(func() {
+			var err error
+			client, err = bbs.NewSecureClient(
+				secureURL,
+				path.Join(basePath, "ca.crt"),
+				path.Join(basePath, "untrusted.crt"),
+				path.Join(basePath, "untrusted.key"),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the request", func() {
+			_, err := client.Domains()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when the client does not present a certificate", func() {
+		BeforeEach(func() {
+			client = bbs.NewClient(secureURL)
+		})
+
+		It("rejects the request", func() {
+			_, err := client.Domains()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when the key pair cannot be loaded", func() {
+		It("fails to construct the client", func() {
+			_, err := bbs.NewSecureClient(
+				secureURL,
+				path.Join(basePath, "ca.crt"),
+				path.Join(basePath, "missing.crt"),
+				path.Join(basePath, "missing.key"),
+			)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
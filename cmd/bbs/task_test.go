@@ -1,7 +1,10 @@
 package main_test
 
 import (
-	"github.com/cloudfoundry-incubator/bbs/db"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 
@@ -14,13 +17,10 @@ var _ = Describe("Task API", func() {
 		actualTasks   []*models.Task
 		expectedTasks []*models.Task
 
-		filter db.TaskFilter
-
 		getErr error
 	)
 
 	BeforeEach(func() {
-		filter = nil
 		actualTasks = nil
 		expectedTasks = []*models.Task{model_helpers.NewValidTask("a-guid"), model_helpers.NewValidTask("b-guid")}
 		expectedTasks[1].Domain = "b-domain"
@@ -66,6 +66,34 @@ var _ = Describe("Task API", func() {
 				Expect(actualTasks).To(ConsistOf(expectedTasks[1]))
 			})
 		})
+
+		Context("when iterating a page at a time", func() {
+			It("returns every task in task guid order", func() {
+				iterator := client.IterateTasks(models.TaskFilter{}, 1)
+
+				task, err := iterator.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task).To(Equal(expectedTasks[0]))
+
+				task, err = iterator.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task).To(Equal(expectedTasks[1]))
+
+				_, err = iterator.Next()
+				Expect(err).To(Equal(io.EOF))
+			})
+
+			It("applies the filter to every page", func() {
+				iterator := client.IterateTasks(models.TaskFilter{CellID: "b-cell"}, 1)
+
+				task, err := iterator.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task).To(Equal(expectedTasks[1]))
+
+				_, err = iterator.Next()
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
 	})
 
 	Describe("GET /v1/tasks/:task_guid", func() {
@@ -75,4 +103,67 @@ var _ = Describe("Task API", func() {
 			Expect(task).To(Equal(expectedTasks[0]))
 		})
 	})
+
+	Describe("Task lifecycle", func() {
+		It("drives a task from desired to resolved", func() {
+			task := model_helpers.NewValidTask("lifecycle-guid")
+			err := client.DesireTask(task)
+			Expect(err).NotTo(HaveOccurred())
+
+			shouldStart, err := client.StartTask("lifecycle-guid", "the-cell")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shouldStart).To(BeTrue())
+
+			err = client.CompleteTask("lifecycle-guid", "the-cell", false, "", "the-result")
+			Expect(err).NotTo(HaveOccurred())
+
+			completedTask, err := client.TaskByGuid("lifecycle-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completedTask.State).To(Equal(models.Task_Completed))
+			Expect(completedTask.Result).To(Equal("the-result"))
+
+			err = client.ResolvingTask("lifecycle-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.ResolveTask("lifecycle-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.TaskByGuid("lifecycle-guid")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("resolves a completed task through its completion callback", func() {
+			callbacks := make(chan *http.Request, 1)
+			callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				callbacks <- req
+			}))
+			defer callbackServer.Close()
+
+			task := model_helpers.NewValidTask("callback-guid")
+			task.CompletionCallbackUrl = callbackServer.URL + "/the-callback"
+			err := client.DesireTask(task)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.StartTask("callback-guid", "the-cell")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.CompleteTask("callback-guid", "the-cell", false, "", "the-result")
+			Expect(err).NotTo(HaveOccurred())
+
+			var callback *http.Request
+			Eventually(callbacks).Should(Receive(&callback))
+			Expect(callback.Method).To(Equal("POST"))
+			Expect(callback.URL.Path).To(Equal("/the-callback"))
+
+			Eventually(func() error {
+				_, err := client.TaskByGuid("callback-guid")
+				return err
+			}).Should(Equal(models.ErrResourceNotFound))
+		})
+
+		It("returns a typed error for an invalid transition", func() {
+			err := client.ResolveTask(expectedTasks[0].TaskGuid)
+			Expect(err).To(Equal(models.ErrTaskCannotBeResolved))
+		})
+	})
 })
@@ -7,17 +7,24 @@ import (
 )
 
 type Args struct {
-	Address           string
-	AuctioneerAddress string
-	ConsulCluster     string
-	EtcdCluster       string
-	EtcdClientCert    string
-	EtcdClientKey     string
-	EtcdCACert        string
+	Address                  string
+	AuctioneerAddress        string
+	ConsulCluster            string
+	EtcdCluster              string
+	EtcdClientCert           string
+	EtcdClientKey            string
+	EtcdCACert               string
+	DatabaseDriver           string
+	DatabaseConnectionString string
+	InMemory                 bool
+	RequireSSL               bool
+	CertFile                 string
+	KeyFile                  string
+	CAFile                   string
 }
 
 func (args Args) ArgSlice() []string {
-	return []string{
+	arguments := []string{
 		"-address", args.Address,
 		"-auctioneerAddress", args.AuctioneerAddress,
 		"-consulCluster", args.ConsulCluster,
@@ -27,6 +34,28 @@ func (args Args) ArgSlice() []string {
 		"-etcdCaFile", args.EtcdCACert,
 		"-logLevel", "debug",
 	}
+
+	if args.DatabaseDriver != "" {
+		arguments = append(arguments,
+			"-databaseDriver", args.DatabaseDriver,
+			"-databaseConnectionString", args.DatabaseConnectionString,
+		)
+	}
+
+	if args.InMemory {
+		arguments = append(arguments, "-inMemory")
+	}
+
+	if args.RequireSSL {
+		arguments = append(arguments,
+			"-requireSSL",
+			"-certFile", args.CertFile,
+			"-keyFile", args.KeyFile,
+			"-caFile", args.CAFile,
+		)
+	}
+
+	return arguments
 }
 
 func New(binPath string, args Args) *ginkgomon.Runner {
@@ -0,0 +1,138 @@
+package converger
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/ifrit"
+)
+
+const (
+	convergeLRPRunsCounter = metric.Counter("ConvergenceLRPRuns")
+	convergeLRPDuration    = metric.Duration("ConvergenceLRPDuration")
+
+	missingLRPsMetric          = metric.Metric("LRPsMissing")
+	extraLRPsMetric            = metric.Metric("LRPsExtra")
+	restartedCrashedLRPsMetric = metric.Metric("CrashedActualLRPsRestarted")
+	lrpsOnMissingCellsMetric   = metric.Metric("LRPsOnMissingCells")
+	orphanedLRPsMetric         = metric.Metric("LRPsOrphaned")
+
+	convergeTaskRunsCounter = metric.Counter("ConvergenceTaskRuns")
+	convergeTaskDuration    = metric.Duration("ConvergenceTaskDuration")
+
+	pendingTasksKickedMetric   = metric.Metric("TasksPendingKicked")
+	pendingTasksExpiredMetric  = metric.Metric("TasksPendingExpired")
+	runningTasksFailedMetric   = metric.Metric("TasksRunningOnMissingCells")
+	completedTasksKickedMetric = metric.Metric("TasksCompletedKicked")
+	tasksPrunedMetric          = metric.Metric("TasksPruned")
+)
+
+type converger struct {
+	logger                      lager.Logger
+	lrpDB                       db.LRPConvergenceDB
+	taskDB                      db.TaskConvergenceDB
+	clock                       clock.Clock
+	convergeRepeatInterval      time.Duration
+	kickTaskDuration            time.Duration
+	expirePendingTaskDuration   time.Duration
+	expireCompletedTaskDuration time.Duration
+}
+
+func New(
+	logger lager.Logger,
+	lrpDB db.LRPConvergenceDB,
+	taskDB db.TaskConvergenceDB,
+	clock clock.Clock,
+	convergeRepeatInterval time.Duration,
+	kickTaskDuration time.Duration,
+	expirePendingTaskDuration time.Duration,
+	expireCompletedTaskDuration time.Duration,
+) ifrit.Runner {
+	return &converger{
+		logger:                      logger,
+		lrpDB:                       lrpDB,
+		taskDB:                      taskDB,
+		clock:                       clock,
+		convergeRepeatInterval:      convergeRepeatInterval,
+		kickTaskDuration:            kickTaskDuration,
+		expirePendingTaskDuration:   expirePendingTaskDuration,
+		expireCompletedTaskDuration: expireCompletedTaskDuration,
+	}
+}
+
+func (c *converger) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := c.logger.Session("converger")
+	logger.Info("starting", lager.Data{"converge-repeat-interval": c.convergeRepeatInterval.String()})
+
+	ticker := c.clock.NewTicker(c.convergeRepeatInterval)
+	defer ticker.Stop()
+
+	close(ready)
+	logger.Info("started")
+	defer logger.Info("finished")
+
+	for {
+		select {
+		case <-signals:
+			return nil
+
+		case <-ticker.C():
+			c.convergeLRPs(logger)
+			c.convergeTasks(logger)
+		}
+	}
+}
+
+func (c *converger) convergeLRPs(logger lager.Logger) {
+	convergeLRPRunsCounter.Increment()
+	startedAt := c.clock.Now()
+
+	summary := c.lrpDB.ConvergeLRPs(logger)
+
+	duration := c.clock.Now().Sub(startedAt)
+	convergeLRPDuration.Send(duration)
+
+	missingLRPsMetric.Send(summary.MissingLRPs)
+	extraLRPsMetric.Send(summary.ExtraLRPs)
+	restartedCrashedLRPsMetric.Send(summary.RestartedCrashedLRPs)
+	lrpsOnMissingCellsMetric.Send(summary.LRPsOnMissingCells)
+	orphanedLRPsMetric.Send(summary.OrphanedLRPs)
+
+	logger.Info("converged-lrps", lager.Data{
+		"duration":               duration.String(),
+		"missing-lrps":           summary.MissingLRPs,
+		"extra-lrps":             summary.ExtraLRPs,
+		"restarted-crashed-lrps": summary.RestartedCrashedLRPs,
+		"lrps-on-missing-cells":  summary.LRPsOnMissingCells,
+		"orphaned-lrps":          summary.OrphanedLRPs,
+	})
+}
+
+func (c *converger) convergeTasks(logger lager.Logger) {
+	convergeTaskRunsCounter.Increment()
+	startedAt := c.clock.Now()
+
+	summary := c.taskDB.ConvergeTasks(logger, c.kickTaskDuration, c.expirePendingTaskDuration, c.expireCompletedTaskDuration)
+
+	duration := c.clock.Now().Sub(startedAt)
+	convergeTaskDuration.Send(duration)
+
+	pendingTasksKickedMetric.Send(summary.PendingTasksKicked)
+	pendingTasksExpiredMetric.Send(summary.PendingTasksExpired)
+	runningTasksFailedMetric.Send(summary.RunningTasksFailed)
+	completedTasksKickedMetric.Send(summary.CompletedTasksKicked)
+	tasksPrunedMetric.Send(summary.TasksPruned)
+
+	logger.Info("converged-tasks", lager.Data{
+		"duration":               duration.String(),
+		"pending-tasks-kicked":   summary.PendingTasksKicked,
+		"pending-tasks-expired":  summary.PendingTasksExpired,
+		"running-tasks-failed":   summary.RunningTasksFailed,
+		"completed-tasks-kicked": summary.CompletedTasksKicked,
+		"tasks-pruned":           summary.TasksPruned,
+	})
+}
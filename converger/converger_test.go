@@ -0,0 +1,121 @@
+package converger_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/converger"
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Converger", func() {
+	const (
+		convergeRepeatInterval      = 30 * time.Second
+		kickTaskDuration            = 10 * time.Second
+		expirePendingTaskDuration   = 30 * time.Minute
+		expireCompletedTaskDuration = 2 * time.Minute
+	)
+
+	var (
+		lrpDB   *fakes.FakeLRPConvergenceDB
+		taskDB  *fakes.FakeTaskConvergenceDB
+		clock   *fakeclock.FakeClock
+		logger  *lagertest.TestLogger
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		lrpDB = new(fakes.FakeLRPConvergenceDB)
+		taskDB = new(fakes.FakeTaskConvergenceDB)
+		clock = fakeclock.NewFakeClock(time.Now())
+		logger = lagertest.NewTestLogger("test")
+
+		lrpDB.ConvergeLRPsReturns(models.LRPConvergenceSummary{
+			MissingLRPs:          1,
+			ExtraLRPs:            2,
+			RestartedCrashedLRPs: 3,
+			LRPsOnMissingCells:   4,
+			OrphanedLRPs:         5,
+		})
+
+		taskDB.ConvergeTasksReturns(models.TaskConvergenceSummary{
+			PendingTasksKicked:   6,
+			PendingTasksExpired:  7,
+			RunningTasksFailed:   8,
+			CompletedTasksKicked: 9,
+			TasksPruned:          10,
+		})
+
+		process = ifrit.Invoke(converger.New(
+			logger,
+			lrpDB,
+			taskDB,
+			clock,
+			convergeRepeatInterval,
+			kickTaskDuration,
+			expirePendingTaskDuration,
+			expireCompletedTaskDuration,
+		))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("does not converge before the interval elapses", func() {
+		clock.Increment(convergeRepeatInterval - time.Second)
+		Consistently(lrpDB.ConvergeLRPsCallCount).Should(Equal(0))
+		Consistently(taskDB.ConvergeTasksCallCount).Should(Equal(0))
+	})
+
+	It("converges LRPs on every interval", func() {
+		clock.Increment(convergeRepeatInterval + time.Second)
+		Eventually(lrpDB.ConvergeLRPsCallCount).Should(Equal(1))
+
+		clock.Increment(convergeRepeatInterval + time.Second)
+		Eventually(lrpDB.ConvergeLRPsCallCount).Should(Equal(2))
+	})
+
+	It("converges tasks on every interval with the configured thresholds", func() {
+		clock.Increment(convergeRepeatInterval + time.Second)
+		Eventually(taskDB.ConvergeTasksCallCount).Should(Equal(1))
+
+		_, kick, expirePending, expireCompleted := taskDB.ConvergeTasksArgsForCall(0)
+		Expect(kick).To(Equal(kickTaskDuration))
+		Expect(expirePending).To(Equal(expirePendingTaskDuration))
+		Expect(expireCompleted).To(Equal(expireCompletedTaskDuration))
+
+		clock.Increment(convergeRepeatInterval + time.Second)
+		Eventually(taskDB.ConvergeTasksCallCount).Should(Equal(2))
+	})
+
+	It("logs a summary of each LRP pass", func() {
+		clock.Increment(convergeRepeatInterval + time.Second)
+
+		Eventually(logger).Should(Say("converged-lrps"))
+		Expect(logger).To(Say(`"extra-lrps":2`))
+		Expect(logger).To(Say(`"lrps-on-missing-cells":4`))
+		Expect(logger).To(Say(`"missing-lrps":1`))
+		Expect(logger).To(Say(`"orphaned-lrps":5`))
+		Expect(logger).To(Say(`"restarted-crashed-lrps":3`))
+	})
+
+	It("logs a summary of each task pass", func() {
+		clock.Increment(convergeRepeatInterval + time.Second)
+
+		Eventually(logger).Should(Say("converged-tasks"))
+		Expect(logger).To(Say(`"completed-tasks-kicked":9`))
+		Expect(logger).To(Say(`"pending-tasks-expired":7`))
+		Expect(logger).To(Say(`"pending-tasks-kicked":6`))
+		Expect(logger).To(Say(`"running-tasks-failed":8`))
+		Expect(logger).To(Say(`"tasks-pruned":10`))
+	})
+})
@@ -7,5 +7,6 @@ import (
 
 //go:generate counterfeiter . CellDB
 type CellDB interface {
+	Cells(logger lager.Logger) ([]*models.CellPresence, *models.Error)
 	CellById(logger lager.Logger, cellId string) (*models.CellPresence, *models.Error)
 }
@@ -28,6 +28,31 @@ func (db *ConsulDB) CellById(logger lager.Logger, cellId string) (*models.CellPr
 	return &cellPresence, nil
 }
 
+func (db *ConsulDB) Cells(logger lager.Logger) ([]*models.CellPresence, *models.Error) {
+	values, err := db.session.ListAcquiredValues(CellSchemaRoot)
+	if err != nil {
+		bbsErr := convertConsulError(err)
+		if bbsErr.Equal(models.ErrResourceNotFound) {
+			return []*models.CellPresence{}, nil
+		}
+		logger.Error("failed-to-list-cells", err)
+		return nil, bbsErr
+	}
+
+	cells := make([]*models.CellPresence, 0, len(values))
+	for key, value := range values {
+		cellPresence := &models.CellPresence{}
+		err := models.FromJSON(value, cellPresence)
+		if err != nil {
+			logger.Error("failed-to-unmarshal-cell-presence", err, lager.Data{"key": key})
+			continue
+		}
+		cells = append(cells, cellPresence)
+	}
+
+	return cells, nil
+}
+
 func convertConsulError(err error) *models.Error {
 	switch err.(type) {
 	case consuladapter.KeyNotFoundError:
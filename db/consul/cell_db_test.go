@@ -45,4 +45,31 @@ var _ = Describe("Cell DB", func() {
 			})
 		})
 	})
+
+	Describe("Cells", func() {
+		Context("when there are cells", func() {
+			var cell1, cell2 models.CellPresence
+
+			BeforeEach(func() {
+				cell1 = models.NewCellPresence("cell-1", "1.2.3.4", "z1", models.NewCellCapacity(128, 1024, 6), []string{"docker"}, []string{"cflinuxfs2"})
+				cell2 = models.NewCellPresence("cell-2", "5.6.7.8", "z2", models.NewCellCapacity(256, 2048, 12), []string{}, []string{})
+				consulHelper.RegisterCell(cell1)
+				consulHelper.RegisterCell(cell2)
+			})
+
+			It("returns every registered cell", func() {
+				cells, err := consulDB.Cells(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cells).To(ConsistOf(&cell1, &cell2))
+			})
+		})
+
+		Context("when there are no cells", func() {
+			It("returns an empty list", func() {
+				cells, err := consulDB.Cells(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cells).To(BeEmpty())
+			})
+		})
+	})
 })
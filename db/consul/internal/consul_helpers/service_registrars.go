@@ -11,6 +11,6 @@ func (t *ConsulHelper) RegisterCell(cell models.CellPresence) {
 	jsonBytes, err := models.ToJSON(cell)
 	Expect(err).NotTo(HaveOccurred())
 
-	err = t.consulSession.AcquireLock(consul.CellSchemaPath(cell.CellID), jsonBytes)
+	err = t.consulSession.AcquireLock(consul.CellSchemaPath(cell.CellId), jsonBytes)
 	Expect(err).NotTo(HaveOccurred())
 }
@@ -3,7 +3,10 @@ package db
 type DB interface {
 	DomainDB
 	ActualLRPDB
+	EvacuationDB
 	DesiredLRPDB
 	TaskDB
 	EventDB
+	LRPConvergenceDB
+	TaskConvergenceDB
 }
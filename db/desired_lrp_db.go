@@ -9,4 +9,8 @@ import (
 type DesiredLRPDB interface {
 	DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) (*models.DesiredLRPs, *models.Error)
 	DesiredLRPByProcessGuid(logger lager.Logger, processGuid string) (*models.DesiredLRP, *models.Error)
+
+	DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) *models.Error
+	UpdateDesiredLRP(logger lager.Logger, request *models.UpdateDesiredLRPRequest) *models.Error
+	RemoveDesiredLRP(logger lager.Logger, processGuid string) *models.Error
 }
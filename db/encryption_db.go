@@ -0,0 +1,13 @@
+package db
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . EncryptionDB
+type EncryptionDB interface {
+	EncryptionKeyLabel(logger lager.Logger) (string, *models.Error)
+	SetEncryptionKeyLabel(logger lager.Logger, label string) *models.Error
+	PerformEncryption(logger lager.Logger) *models.Error
+}
@@ -1,18 +1,15 @@
 package etcd
 
 import (
-	"encoding/json"
-	"fmt"
 	"path"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry/gunk/workpool"
 	"github.com/coreos/go-etcd/etcd"
-	"github.com/nu7hatch/gouuid"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -37,19 +34,43 @@ func EvacuatingActualLRPSchemaPath(processGuid string, index int32) string {
 	return path.Join(ActualLRPIndexDir(processGuid, index), ActualLRPEvacuatingKey)
 }
 
-func (db *ETCDDB) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFilter) (*models.ActualLRPGroups, *models.Error) {
-	node, bbsErr := db.fetchRecursiveRaw(logger, ActualLRPSchemaRoot)
+func actualLRPSchemaPath(processGuid string, index int32, evacuating bool) string {
+	if evacuating {
+		return EvacuatingActualLRPSchemaPath(processGuid, index)
+	}
+	return ActualLRPSchemaPath(processGuid, index)
+}
+
+// ActualLRPGroups reads a single process directory when processGuid is set,
+// and otherwise walks every process directory in parallel.
+func (store etcdStore) ActualLRPGroups(logger lager.Logger, processGuid string, filter models.ActualLRPFilter) ([]*models.ActualLRPGroup, *models.Error) {
+	if processGuid != "" {
+		node, bbsErr := store.fetchRecursiveRaw(logger, ActualLRPProcessDir(processGuid))
+		if bbsErr.Equal(models.ErrResourceNotFound) {
+			return []*models.ActualLRPGroup{}, nil
+		}
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+		return store.parseActualLRPGroups(logger, node, filter)
+	}
+
+	if filter.Limit > 0 || filter.AfterProcessGuid != "" {
+		return store.actualLRPGroupPage(logger, filter)
+	}
+
+	node, bbsErr := store.fetchRecursiveRaw(logger, ActualLRPSchemaRoot)
 	if bbsErr.Equal(models.ErrResourceNotFound) {
-		return &models.ActualLRPGroups{}, nil
+		return []*models.ActualLRPGroup{}, nil
 	}
 	if bbsErr != nil {
 		return nil, bbsErr
 	}
 	if node.Nodes.Len() == 0 {
-		return &models.ActualLRPGroups{}, nil
+		return []*models.ActualLRPGroup{}, nil
 	}
 
-	groups := &models.ActualLRPGroups{}
+	groups := []*models.ActualLRPGroup{}
 
 	groupsLock := sync.Mutex{}
 	var workErr atomic.Value
@@ -59,13 +80,13 @@ func (db *ETCDDB) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFi
 		node := node
 
 		works = append(works, func() {
-			g, err := parseActualLRPGroups(logger, node, filter)
+			g, err := store.parseActualLRPGroups(logger, node, filter)
 			if err != nil {
 				workErr.Store(err)
 				return
 			}
 			groupsLock.Lock()
-			groups.ActualLrpGroups = append(groups.ActualLrpGroups, g.ActualLrpGroups...)
+			groups = append(groups, g...)
 			groupsLock.Unlock()
 		})
 	}
@@ -73,53 +94,51 @@ func (db *ETCDDB) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFi
 	throttler, err := workpool.NewThrottler(maxActualGroupGetterWorkPoolSize, works)
 	if err != nil {
 		logger.Error("failed-constructing-throttler", err, lager.Data{"max-workers": maxActualGroupGetterWorkPoolSize, "num-works": len(works)})
-		return &models.ActualLRPGroups{}, models.ErrUnknownError
+		return nil, models.ErrUnknownError
 	}
 
 	logger.Debug("performing-deserialization-work")
 	throttler.Work()
 	if err, ok := workErr.Load().(error); ok {
 		logger.Error("failed-performing-deserialization-work", err)
-		return &models.ActualLRPGroups{}, models.ErrUnknownError
+		return nil, models.ErrUnknownError
 	}
-	logger.Debug("succeeded-performing-deserialization-work", lager.Data{"num-actual-lrp-groups": len(groups.ActualLrpGroups)})
+	logger.Debug("succeeded-performing-deserialization-work", lager.Data{"num-actual-lrp-groups": len(groups)})
 
 	return groups, nil
 }
 
-func (db *ETCDDB) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) (*models.ActualLRPGroups, *models.Error) {
-	node, bbsErr := db.fetchRecursiveRaw(logger, ActualLRPProcessDir(processGuid))
-	if bbsErr.Equal(models.ErrResourceNotFound) {
-		return &models.ActualLRPGroups{}, nil
-	}
+func (store etcdStore) ActualLRP(logger lager.Logger, processGuid string, index int32, evacuating bool) (*models.ActualLRP, uint64, *models.Error) {
+	node, bbsErr := store.fetchRaw(logger, actualLRPSchemaPath(processGuid, index, evacuating))
 	if bbsErr != nil {
-		return nil, bbsErr
-	}
-	if node.Nodes.Len() == 0 {
-		return &models.ActualLRPGroups{}, nil
+		return nil, 0, bbsErr
 	}
 
-	return parseActualLRPGroups(logger, node, models.ActualLRPFilter{})
-}
+	var lrp models.ActualLRP
+	deserializeErr := store.deserializeModel(node, &lrp)
+	if deserializeErr != nil {
+		logger.Error("failed-parsing-actual-lrp", deserializeErr, lager.Data{"key": node.Key})
+		return nil, 0, models.ErrDeserializeJSON
+	}
 
-func (db *ETCDDB) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, *models.Error) {
-	group, _, err := db.rawActualLRPGroupByProcessGuidAndIndex(logger, processGuid, index)
-	return group, err
+	return &lrp, node.ModifiedIndex, nil
 }
 
-func (db *ETCDDB) rawActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, uint64, *models.Error) {
-	node, bbsErr := db.fetchRecursiveRaw(logger, ActualLRPIndexDir(processGuid, index))
+// ActualLRPGroup reads the index directory in one request, so the instance
+// and evacuating records come from the same etcd index.
+func (store etcdStore) ActualLRPGroup(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, *models.Error) {
+	node, bbsErr := store.fetchRecursiveRaw(logger, ActualLRPIndexDir(processGuid, index))
 	if bbsErr != nil {
-		return nil, 0, bbsErr
+		return nil, bbsErr
 	}
 
-	group := models.ActualLRPGroup{}
+	group := &models.ActualLRPGroup{}
 	for _, instanceNode := range node.Nodes {
 		var lrp models.ActualLRP
-		deserializeErr := models.FromJSON([]byte(instanceNode.Value), &lrp)
+		deserializeErr := store.deserializeModel(instanceNode, &lrp)
 		if deserializeErr != nil {
 			logger.Error("failed-parsing-actual-lrp", deserializeErr, lager.Data{"key": instanceNode.Key})
-			return nil, 0, models.ErrDeserializeJSON
+			return nil, models.ErrDeserializeJSON
 		}
 
 		if isInstanceActualLRPNode(instanceNode) {
@@ -131,354 +150,143 @@ func (db *ETCDDB) rawActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, pr
 		}
 	}
 
-	if group.Evacuating == nil && group.Instance == nil {
-		return nil, 0, models.ErrResourceNotFound
+	if group.Instance == nil && group.Evacuating == nil {
+		return nil, models.ErrResourceNotFound
 	}
 
-	return &group, node.ModifiedIndex, nil
+	return group, nil
 }
 
-func (db *ETCDDB) rawActuaLLRPByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRP, uint64, *models.Error) {
-	node, bbsErr := db.fetchRaw(logger, ActualLRPSchemaPath(processGuid, index))
-	if bbsErr != nil {
-		return nil, 0, bbsErr
-	}
-
-	var lrp models.ActualLRP
-	deserializeErr := json.Unmarshal([]byte(node.Value), &lrp)
-	if deserializeErr != nil {
-		return nil, 0, models.ErrDeserializeJSON
-	}
-
-	return &lrp, node.ModifiedIndex, nil
-}
-
-func (db *ETCDDB) ClaimActualLRP(logger lager.Logger, request *models.ClaimActualLRPRequest) (*models.ActualLRP, *models.Error) {
-	lrp, prevIndex, bbsErr := db.rawActuaLLRPByProcessGuidAndIndex(logger, request.ProcessGuid, request.Index)
-	if bbsErr != nil {
-		return nil, bbsErr
-	}
-
-	if !lrp.AllowsTransitionTo(&lrp.ActualLRPKey, request.ActualLrpInstanceKey, models.ActualLRPStateClaimed) {
-		return nil, models.ErrActualLRPCannotBeClaimed
-	}
-
-	lrp.PlacementError = ""
-	lrp.State = models.ActualLRPStateClaimed
-	lrp.ActualLRPInstanceKey = *request.ActualLrpInstanceKey
-	lrp.ActualLRPNetInfo = models.ActualLRPNetInfo{}
-	lrp.ModificationTag.Increment()
-
-	err := lrp.Validate()
+func (store etcdStore) CreateActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, ttl uint64) *models.Error {
+	key := actualLRPSchemaPath(lrp.ProcessGuid, lrp.Index, evacuating)
+	lrpData, err := store.serializer.Marshal(key, lrp)
 	if err != nil {
-		return nil, &models.Error{Type: models.InvalidRecord, Message: err.Error()}
-	}
-
-	lrpRawJSON, err := json.Marshal(lrp)
-	if err != nil {
-		return nil, models.ErrSerializeJSON
-	}
-
-	logger.Info("starting")
-	_, err = db.client.CompareAndSwap(ActualLRPSchemaPath(request.ProcessGuid, request.Index), string(lrpRawJSON), 0, "", prevIndex)
-	if err != nil {
-		logger.Error("failed", err)
-		return nil, models.ErrActualLRPCannotBeClaimed
-	}
-	logger.Info("succeeded")
-
-	return lrp, nil
-}
-
-func (db *ETCDDB) createRunningActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (*models.ActualLRP, *models.Error) {
-	guid, err := uuid.NewV4()
-	if err != nil {
-		return nil, models.ErrActualLRPCannotBeStarted
-	}
-	lrp := &models.ActualLRP{
-		ActualLRPKey:         *key,
-		ActualLRPInstanceKey: *instanceKey,
-		ActualLRPNetInfo:     *netInfo,
-		Since:                db.clock.Now().UnixNano(),
-		State:                models.ActualLRPStateRunning,
-		ModificationTag: models.ModificationTag{
-			Epoch: guid.String(),
-			Index: 0,
-		},
-	}
-
-	lrpRawJSON, err := json.Marshal(lrp)
-	if err != nil {
-		return nil, models.ErrSerializeJSON
-	}
-
-	_, err = db.client.Set(ActualLRPSchemaPath(key.ProcessGuid, key.Index), string(lrpRawJSON), 0)
-	if err != nil {
-		logger.Error("failed", err)
-		return nil, models.ErrActualLRPCannotBeStarted
-	}
-	return lrp, nil
-}
-
-func (db *ETCDDB) StartActualLRP(logger lager.Logger, request *models.StartActualLRPRequest) (*models.ActualLRP, *models.Error) {
-	key := request.ActualLrpKey
-	instanceKey := request.ActualLrpInstanceKey
-	netInfo := request.ActualLrpNetInfo
-
-	logger.Info("starting")
-	lrp, prevIndex, bbsErr := db.rawActuaLLRPByProcessGuidAndIndex(logger, key.ProcessGuid, key.Index)
-	if bbsErr == models.ErrResourceNotFound {
-		return db.createRunningActualLRP(logger, key, instanceKey, netInfo)
-	} else if bbsErr != nil {
-		logger.Error("failed-to-get-actual-lrp", bbsErr)
-		return nil, bbsErr
-	}
-
-	if lrp.ActualLRPKey.Equal(key) &&
-		lrp.ActualLRPInstanceKey.Equal(instanceKey) &&
-		lrp.ActualLRPNetInfo.Equal(netInfo) &&
-		lrp.State == models.ActualLRPStateRunning {
-		logger.Info("succeeded")
-		return lrp, nil
-	}
-
-	if !lrp.AllowsTransitionTo(key, instanceKey, models.ActualLRPStateRunning) {
-		logger.Error("failed-to-transition-actual-lrp-to-started", nil)
-		return nil, models.ErrActualLRPCannotBeStarted
-	}
-
-	lrp.ModificationTag.Increment()
-	lrp.State = models.ActualLRPStateRunning
-	lrp.Since = db.clock.Now().UnixNano()
-	lrp.ActualLRPInstanceKey = *instanceKey
-	lrp.ActualLRPNetInfo = *netInfo
-	lrp.PlacementError = ""
-
-	lrpRawJSON, err := json.Marshal(lrp)
-	if err != nil {
-		return nil, models.ErrSerializeJSON
+		return models.ErrSerializeJSON
 	}
 
-	_, err = db.client.CompareAndSwap(ActualLRPSchemaPath(key.ProcessGuid, key.Index), string(lrpRawJSON), 0, "", prevIndex)
+	_, err = store.client.Create(key, string(lrpData), ttl)
 	if err != nil {
-		logger.Error("failed", err)
-		return nil, models.ErrActualLRPCannotBeStarted
+		return storeError(logger, err)
 	}
-
-	logger.Info("succeeded")
-	return lrp, nil
+	return nil
 }
 
-func (db *ETCDDB) CrashActualLRP(logger lager.Logger, request *models.CrashActualLRPRequest) *models.Error {
-	key := request.ActualLrpKey
-	instanceKey := request.ActualLrpInstanceKey
-	errorMessage := request.ErrorMessage
-	logger.Info("starting")
-
-	lrp, prevIndex, bbsErr := db.rawActuaLLRPByProcessGuidAndIndex(logger, key.ProcessGuid, key.Index)
-	if bbsErr != nil {
-		logger.Error("failed-to-get-actual-lrp", bbsErr)
-		return bbsErr
-	}
-
-	latestChangeTime := time.Duration(db.clock.Now().UnixNano() - lrp.Since)
-
-	var newCrashCount int32
-	if latestChangeTime > models.CrashResetTimeout && lrp.State == models.ActualLRPStateRunning {
-		newCrashCount = 1
-	} else {
-		newCrashCount = lrp.CrashCount + 1
-	}
-
-	logger.Debug("retrieved-lrp")
-	if !lrp.AllowsTransitionTo(key, instanceKey, models.ActualLRPStateCrashed) {
-		err := fmt.Errorf("cannot transition crashed lrp from state %s to state %s", lrp.State, models.ActualLRPStateCrashed)
-		logger.Error("failed-to-transition-actual", err)
-		return models.ErrActualLRPCannotBeCrashed
-	}
-
-	if lrp.State == models.ActualLRPStateUnclaimed || lrp.State == models.ActualLRPStateCrashed ||
-		((lrp.State == models.ActualLRPStateClaimed || lrp.State == models.ActualLRPStateRunning) &&
-			!lrp.ActualLRPInstanceKey.Equal(instanceKey)) {
-		return models.ErrActualLRPCannotBeCrashed
-	}
-
-	lrp.State = models.ActualLRPStateCrashed
-	lrp.Since = db.clock.Now().UnixNano()
-	lrp.CrashCount = newCrashCount
-	lrp.ActualLRPInstanceKey = models.ActualLRPInstanceKey{}
-	lrp.ActualLRPNetInfo = models.EmptyActualLRPNetInfo()
-	lrp.ModificationTag.Increment()
-	lrp.CrashReason = errorMessage
-
-	var immediateRestart bool
-	if lrp.ShouldRestartImmediately(models.NewDefaultRestartCalculator()) {
-		lrp.State = models.ActualLRPStateUnclaimed
-		immediateRestart = true
-	}
-
-	lrpRawJSON, err := json.Marshal(lrp)
+func (store etcdStore) CompareAndSwapActualLRP(logger lager.Logger, before, after *models.ActualLRP, evacuating bool, prevIndex, ttl uint64) *models.Error {
+	key := actualLRPSchemaPath(after.ProcessGuid, after.Index, evacuating)
+	lrpData, err := store.serializer.Marshal(key, after)
 	if err != nil {
 		return models.ErrSerializeJSON
 	}
 
-	_, err = db.client.CompareAndSwap(ActualLRPSchemaPath(key.ProcessGuid, key.Index), string(lrpRawJSON), 0, "", prevIndex)
+	_, err = store.client.CompareAndSwap(key, string(lrpData), ttl, "", prevIndex)
 	if err != nil {
-		logger.Error("failed", err)
-		return models.ErrActualLRPCannotBeCrashed
-	}
-
-	if immediateRestart {
-		auctionErr := db.requestLRPAuctionForLRPKey(logger, key)
-		if err != nil {
-			return auctionErr
-		}
+		return storeError(logger, err)
 	}
-
-	logger.Info("succeeded")
 	return nil
 }
 
-func (db *ETCDDB) requestLRPAuctionForLRPKey(logger lager.Logger, key *models.ActualLRPKey) *models.Error {
-	desiredLRP, bbsErr := db.DesiredLRPByProcessGuid(logger, key.ProcessGuid)
-	if bbsErr == models.ErrResourceNotFound {
-		_, err := db.client.Delete(ActualLRPSchemaPath(key.ProcessGuid, key.Index), false)
-		if err != nil {
-			logger.Error("failed-to-delete-actual", err)
-			return models.ErrUnknownError
-		}
-	} else if bbsErr != nil {
-		return bbsErr
-	}
-
-	lrpStart := models.NewLRPStartRequest(desiredLRP, uint(key.Index))
-	err := db.auctioneerClient.RequestLRPAuctions([]*models.LRPStartRequest{&lrpStart})
+func (store etcdStore) CompareAndDeleteActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, prevIndex uint64) *models.Error {
+	_, err := store.client.CompareAndDelete(actualLRPSchemaPath(lrp.ProcessGuid, lrp.Index, evacuating), "", prevIndex)
 	if err != nil {
-		logger.Error("failed-to-request-auction", err)
-		return models.ErrUnknownError
+		return storeError(logger, err)
 	}
 	return nil
 }
 
-func (db *ETCDDB) FailActualLRP(logger lager.Logger, request *models.FailActualLRPRequest) *models.Error {
-	key := request.ActualLrpKey
-	errorMessage := request.ErrorMessage
-
-	logger.Info("failing")
-	lrp, prevIndex, bbsErr := db.rawActuaLLRPByProcessGuidAndIndex(logger, key.ProcessGuid, key.Index)
-	if bbsErr != nil {
-		logger.Error("failed-to-get-actual-lrp", bbsErr)
-		return bbsErr
-	}
-
-	if lrp.State != models.ActualLRPStateUnclaimed {
-		return models.ErrActualLRPCannotBeFailed
-	}
-
-	lrp.ModificationTag.Increment()
-	lrp.PlacementError = errorMessage
-	lrp.Since = db.clock.Now().UnixNano()
-
-	lrpRawJSON, err := json.Marshal(lrp)
-	if err != nil {
-		return models.ErrSerializeJSON
-	}
-
-	_, err = db.client.CompareAndSwap(ActualLRPSchemaPath(key.ProcessGuid, key.Index), string(lrpRawJSON), 0, "", prevIndex)
-	if err != nil {
-		logger.Error("failed", err)
-		return models.ErrActualLRPCannotBeFailed
+// actualLRPGroupPage lists the process directories in key order and reads
+// them one at a time from the one holding the group after AfterProcessGuid
+// and AfterIndex, stopping once the page is full.
+func (store etcdStore) actualLRPGroupPage(logger lager.Logger, filter models.ActualLRPFilter) ([]*models.ActualLRPGroup, *models.Error) {
+	root, bbsErr := store.fetchRaw(logger, ActualLRPSchemaRoot)
+	if bbsErr.Equal(models.ErrResourceNotFound) {
+		return []*models.ActualLRPGroup{}, nil
 	}
-
-	logger.Info("succeeded")
-	return nil
-}
-
-func (db *ETCDDB) RemoveActualLRP(logger lager.Logger, processGuid string, index int32) *models.Error {
-	lrp, prevIndex, bbsErr := db.rawActuaLLRPByProcessGuidAndIndex(logger, processGuid, index)
 	if bbsErr != nil {
-		return bbsErr
+		return nil, bbsErr
 	}
 
-	return db.removeActualLRP(logger, lrp, prevIndex)
-}
-
-func (db *ETCDDB) RetireActualLRP(logger lager.Logger, request *models.RetireActualLRPRequest) *models.Error {
-	var err *models.Error
-	var prevIndex uint64
-	var lrp *models.ActualLRP
-	processGuid := request.ActualLrpKey.ProcessGuid
-	index := request.ActualLrpKey.Index
+	sort.Sort(root.Nodes)
+	processNodes := root.Nodes
+	if filter.AfterProcessGuid != "" {
+		processNodes = processNodes[sort.Search(len(processNodes), func(i int) bool {
+			return path.Base(processNodes[i].Key) >= filter.AfterProcessGuid
+		}):]
+	}
 
-	for i := 0; i < models.RetireActualLRPRetryAttempts; i++ {
-		lrp, prevIndex, err = db.rawActuaLLRPByProcessGuidAndIndex(logger, processGuid, index)
-		if err != nil {
-			break
+	groups := []*models.ActualLRPGroup{}
+	for _, processNode := range processNodes {
+		node, bbsErr := store.fetchRecursiveRaw(logger, processNode.Key)
+		if bbsErr.Equal(models.ErrResourceNotFound) {
+			continue
+		}
+		if bbsErr != nil {
+			return nil, bbsErr
 		}
 
-		switch lrp.State {
-		case models.ActualLRPStateUnclaimed, models.ActualLRPStateCrashed:
-			err = db.removeActualLRP(logger, lrp, prevIndex)
-		default:
-			var cell *models.CellPresence
-			key := lrp.ActualLRPKey
-			instanceKey := lrp.ActualLRPInstanceKey
-			cell, err = db.cellDB.CellById(logger, instanceKey.CellId)
-			if err != nil {
-				if err == models.ErrResourceNotFound {
-					err = db.removeActualLRP(logger, lrp, prevIndex)
-				}
-				err = err
-				break
-			}
+		indexNodes := sortIndexNodes(node.Nodes)
+		if path.Base(node.Key) == filter.AfterProcessGuid {
+			indexNodes = indexNodes[sort.Search(len(indexNodes), func(i int) bool {
+				return indexNodes[i].index > filter.AfterIndex
+			}):]
+		}
 
-			logger.Info("stopping-lrp-instance", lager.Data{
-				"actual-lrp-key": key,
-			})
-			cellErr := db.cellClient.StopLRPInstance(cell.RepAddress, key, instanceKey)
-			if cellErr != nil {
-				err = models.ErrActualLRPCannotBeStopped
-			}
+		page := &etcd.Node{Key: node.Key}
+		for _, indexNode := range indexNodes {
+			page.Nodes = append(page.Nodes, indexNode.node)
 		}
 
-		if err == nil {
-			break
+		g, bbsErr := store.parseActualLRPGroups(logger, page, filter)
+		if bbsErr != nil {
+			return nil, bbsErr
 		}
+		groups = append(groups, g...)
 
-		if i+1 < models.RetireActualLRPRetryAttempts {
-			logger.Error("retrying-failed-retire-of-actual-lrp", err, lager.Data{
-				"actual-lrp-key": request.ActualLrpKey,
-				"attempt":        i + 1,
-			})
+		if filter.Limit > 0 && len(groups) >= filter.Limit {
+			return groups[:filter.Limit], nil
 		}
 	}
 
-	return err
+	return groups, nil
+}
+
+type indexNode struct {
+	index int32
+	node  *etcd.Node
 }
 
-func (db *ETCDDB) removeActualLRP(logger lager.Logger, lrp *models.ActualLRP, prevIndex uint64) *models.Error {
-	logger.Info("starting")
-	_, err := db.client.CompareAndDelete(ActualLRPSchemaPath(lrp.ProcessGuid, lrp.Index), "", prevIndex)
-	if err != nil {
-		logger.Error("failed", err)
-		return models.ErrActualLRPCannotBeRemoved
+// sortIndexNodes orders the index directories of a process numerically,
+// skipping any whose name is not an index.
+func sortIndexNodes(nodes etcd.Nodes) []indexNode {
+	indexNodes := make([]indexNode, 0, len(nodes))
+	for _, node := range nodes {
+		index, err := strconv.ParseInt(path.Base(node.Key), 10, 32)
+		if err != nil {
+			continue
+		}
+		indexNodes = append(indexNodes, indexNode{index: int32(index), node: node})
 	}
-	logger.Info("succeeded")
-	return nil
+	sort.Sort(byIndex(indexNodes))
+	return indexNodes
 }
 
-func parseActualLRPGroups(logger lager.Logger, node *etcd.Node, filter models.ActualLRPFilter) (*models.ActualLRPGroups, *models.Error) {
-	var groups = &models.ActualLRPGroups{}
+type byIndex []indexNode
+
+func (nodes byIndex) Len() int           { return len(nodes) }
+func (nodes byIndex) Swap(i, j int)      { nodes[i], nodes[j] = nodes[j], nodes[i] }
+func (nodes byIndex) Less(i, j int) bool { return nodes[i].index < nodes[j].index }
+
+func (store etcdStore) parseActualLRPGroups(logger lager.Logger, node *etcd.Node, filter models.ActualLRPFilter) ([]*models.ActualLRPGroup, *models.Error) {
+	groups := []*models.ActualLRPGroup{}
 
 	logger.Debug("performing-parsing-actual-lrp-groups")
 	for _, indexNode := range node.Nodes {
 		group := &models.ActualLRPGroup{}
 		for _, instanceNode := range indexNode.Nodes {
 			var lrp models.ActualLRP
-			deserializeErr := models.FromJSON([]byte(instanceNode.Value), &lrp)
+			deserializeErr := store.deserializeModel(instanceNode, &lrp)
 			if deserializeErr != nil {
 				logger.Error("failed-parsing-actual-lrp-groups", deserializeErr, lager.Data{"key": instanceNode.Key})
-				return &models.ActualLRPGroups{}, models.ErrDeserializeJSON
+				return nil, models.ErrDeserializeJSON
 			}
 			if filter.Domain != "" && lrp.Domain != filter.Domain {
 				continue
@@ -497,10 +305,10 @@ func parseActualLRPGroups(logger lager.Logger, node *etcd.Node, filter models.Ac
 		}
 
 		if group.Instance != nil || group.Evacuating != nil {
-			groups.ActualLrpGroups = append(groups.ActualLrpGroups, group)
+			groups = append(groups, group)
 		}
 	}
-	logger.Debug("succeeded-performing-parsing-actual-lrp-groups", lager.Data{"num-actual-lrp-groups": len(groups.ActualLrpGroups)})
+	logger.Debug("succeeded-performing-parsing-actual-lrp-groups", lager.Data{"num-actual-lrp-groups": len(groups)})
 
 	return groups, nil
 }
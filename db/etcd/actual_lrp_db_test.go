@@ -333,13 +333,20 @@ var _ = Describe("ActualLRPDB", func() {
 			processGuid string
 			index       int32
 			domain      string
+
+			expectedTag *models.ModificationTag
 		)
 
+		BeforeEach(func() {
+			expectedTag = nil
+		})
+
 		JustBeforeEach(func() {
 			request := &models.ClaimActualLRPRequest{
-				ProcessGuid:          processGuid,
-				Index:                index,
-				ActualLrpInstanceKey: &instanceKey,
+				ProcessGuid:             processGuid,
+				Index:                   index,
+				ActualLrpInstanceKey:    &instanceKey,
+				ExpectedModificationTag: expectedTag,
 			}
 			claimedActualLRP, claimErr = etcdDB.ClaimActualLRP(logger, request)
 		})
@@ -402,6 +409,38 @@ var _ = Describe("ActualLRPDB", func() {
 
 					Expect(lrpGroupInBBS.Instance.ModificationTag.Index).To(Equal(actualLRP.ModificationTag.Index + 1))
 				})
+
+				Context("when the expected modification tag matches", func() {
+					BeforeEach(func() {
+						tag := actualLRP.ModificationTag
+						expectedTag = &tag
+					})
+
+					It("claims the actual LRP", func() {
+						Expect(claimErr).NotTo(HaveOccurred())
+						Expect(claimedActualLRP.State).To(Equal(models.ActualLRPStateClaimed))
+					})
+				})
+
+				Context("when the expected modification tag does not match", func() {
+					BeforeEach(func() {
+						expectedTag = &models.ModificationTag{
+							Epoch: actualLRP.ModificationTag.Epoch,
+							Index: actualLRP.ModificationTag.Index + 1,
+						}
+					})
+
+					It("returns a ResourceConflict error", func() {
+						Expect(claimErr).To(Equal(models.ErrResourceConflict))
+					})
+
+					It("does not modify the persisted actual LRP", func() {
+						lrpGroupInBBS, err := etcdDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, index)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(lrpGroupInBBS.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+					})
+				})
 			})
 
 			Context("when the existing ActualLRP is Claimed", func() {
@@ -603,6 +642,10 @@ var _ = Describe("ActualLRPDB", func() {
 			netInfo     models.ActualLRPNetInfo
 		)
 
+		BeforeEach(func() {
+			request = models.StartActualLRPRequest{}
+		})
+
 		JustBeforeEach(func() {
 			request.ActualLrpKey = &lrpKey
 			request.ActualLrpInstanceKey = &instanceKey
@@ -648,6 +691,26 @@ var _ = Describe("ActualLRPDB", func() {
 					Expect(lrpGroupInBBS.Instance.State).To(Equal(models.ActualLRPStateRunning))
 				})
 
+				Context("when the expected modification tag does not match", func() {
+					BeforeEach(func() {
+						request.ExpectedModificationTag = &models.ModificationTag{
+							Epoch: actualLRP.ModificationTag.Epoch,
+							Index: actualLRP.ModificationTag.Index + 1,
+						}
+					})
+
+					It("returns a ResourceConflict error", func() {
+						Expect(startErr).To(Equal(models.ErrResourceConflict))
+					})
+
+					It("does not modify the persisted actual LRP", func() {
+						lrpGroupInBBS, err := etcdDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, index)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(lrpGroupInBBS.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+					})
+				})
+
 				Context("when there is a placement error", func() {
 					BeforeEach(func() {
 						actualLRP.PlacementError = "insufficient resources"
@@ -882,6 +945,21 @@ var _ = Describe("ActualLRPDB", func() {
 				Expect(lrpGroup.Instance.ModificationTag.Epoch).NotTo(BeEmpty())
 				Expect(lrpGroup.Instance.ModificationTag.Index).To(BeEquivalentTo(0))
 			})
+
+			Context("when an expected modification tag is given", func() {
+				BeforeEach(func() {
+					request.ExpectedModificationTag = &models.ModificationTag{Epoch: "some-epoch"}
+				})
+
+				It("returns a ResourceConflict error", func() {
+					Expect(startErr).To(Equal(models.ErrResourceConflict))
+				})
+
+				It("does not create an actual LRP", func() {
+					_, err := etcdDB.ActualLRPGroupByProcessGuidAndIndex(logger, "process-guid", 1)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
 		})
 	})
 
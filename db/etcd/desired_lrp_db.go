@@ -3,11 +3,13 @@ package etcd
 import (
 	"fmt"
 	"path"
+	"sort"
 	"sync"
 	"sync/atomic"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry/gunk/workpool"
+	"github.com/coreos/go-etcd/etcd"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -22,19 +24,22 @@ func DesiredLRPSchemaPathByProcessGuid(processGuid string) string {
 	return path.Join(DesiredLRPSchemaRoot, processGuid)
 }
 
-func (db *ETCDDB) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) (*models.DesiredLRPs, *models.Error) {
-	root, bbsErr := db.fetchRecursiveRaw(logger, DesiredLRPSchemaRoot)
+func (store etcdStore) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) ([]*models.DesiredLRP, *models.Error) {
+	root, bbsErr := store.fetchRecursiveRaw(logger, DesiredLRPSchemaRoot)
 	if bbsErr.Equal(models.ErrResourceNotFound) {
-		return &models.DesiredLRPs{}, nil
+		return []*models.DesiredLRP{}, nil
 	}
 	if bbsErr != nil {
 		return nil, bbsErr
 	}
 	if root.Nodes.Len() == 0 {
-		return &models.DesiredLRPs{}, nil
+		return []*models.DesiredLRP{}, nil
+	}
+	if filter.Limit > 0 || filter.AfterProcessGuid != "" {
+		return store.desiredLRPPage(logger, root.Nodes, filter)
 	}
 
-	desiredLRPs := models.DesiredLRPs{}
+	desiredLRPs := []*models.DesiredLRP{}
 
 	lrpsLock := sync.Mutex{}
 	var workErr atomic.Value
@@ -45,7 +50,7 @@ func (db *ETCDDB) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilte
 
 		works = append(works, func() {
 			var lrp models.DesiredLRP
-			deserializeErr := models.FromJSON([]byte(node.Value), &lrp)
+			deserializeErr := store.deserializeModel(node, &lrp)
 			if deserializeErr != nil {
 				logger.Error("failed-parsing-desired-lrp", deserializeErr)
 				workErr.Store(fmt.Errorf("cannot parse lrp JSON for key %s: %s", node.Key, deserializeErr.Error()))
@@ -54,7 +59,7 @@ func (db *ETCDDB) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilte
 
 			if filter.Domain == "" || lrp.GetDomain() == filter.Domain {
 				lrpsLock.Lock()
-				desiredLRPs.DesiredLrps = append(desiredLRPs.DesiredLrps, &lrp)
+				desiredLRPs = append(desiredLRPs, &lrp)
 				lrpsLock.Unlock()
 			}
 		})
@@ -63,32 +68,94 @@ func (db *ETCDDB) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilte
 	throttler, err := workpool.NewThrottler(maxDesiredLRPGetterWorkPoolSize, works)
 	if err != nil {
 		logger.Error("failed-constructing-throttler", err, lager.Data{"max-workers": maxDesiredLRPGetterWorkPoolSize, "num-works": len(works)})
-		return &models.DesiredLRPs{}, models.ErrUnknownError
+		return nil, models.ErrUnknownError
 	}
 
 	logger.Debug("performing-deserialization-work")
 	throttler.Work()
 	if err, ok := workErr.Load().(error); ok {
 		logger.Error("failed-performing-deserialization-work", err)
-		return &models.DesiredLRPs{}, models.ErrUnknownError
+		return nil, models.ErrUnknownError
+	}
+	logger.Debug("succeeded-performing-deserialization-work", lager.Data{"num-desired-lrps": len(desiredLRPs)})
+
+	return desiredLRPs, nil
+}
+
+// desiredLRPPage decodes the nodes in key order from the one after
+// AfterProcessGuid, stopping once the page is full.
+func (store etcdStore) desiredLRPPage(logger lager.Logger, nodes etcd.Nodes, filter models.DesiredLRPFilter) ([]*models.DesiredLRP, *models.Error) {
+	sort.Sort(nodes)
+
+	desiredLRPs := []*models.DesiredLRP{}
+	for _, node := range nodesAfter(nodes, filter.AfterProcessGuid) {
+		if filter.Limit > 0 && len(desiredLRPs) == filter.Limit {
+			break
+		}
+
+		var lrp models.DesiredLRP
+		deserializeErr := store.deserializeModel(node, &lrp)
+		if deserializeErr != nil {
+			logger.Error("failed-parsing-desired-lrp", deserializeErr, lager.Data{"key": node.Key})
+			return nil, models.ErrUnknownError
+		}
+
+		if filter.Domain == "" || lrp.GetDomain() == filter.Domain {
+			desiredLRPs = append(desiredLRPs, &lrp)
+		}
 	}
-	logger.Debug("succeeded-performing-deserialization-work", lager.Data{"num-desired-lrps": len(desiredLRPs.GetDesiredLrps())})
 
-	return &desiredLRPs, nil
+	return desiredLRPs, nil
 }
 
-func (db *ETCDDB) DesiredLRPByProcessGuid(logger lager.Logger, processGuid string) (*models.DesiredLRP, *models.Error) {
-	node, bbsErr := db.fetchRaw(logger, DesiredLRPSchemaPathByProcessGuid(processGuid))
+func (store etcdStore) DesiredLRP(logger lager.Logger, processGuid string) (*models.DesiredLRP, uint64, *models.Error) {
+	node, bbsErr := store.fetchRaw(logger, DesiredLRPSchemaPathByProcessGuid(processGuid))
 	if bbsErr != nil {
-		return nil, bbsErr
+		return nil, 0, bbsErr
 	}
 
 	var lrp models.DesiredLRP
-	deserializeErr := models.FromJSON([]byte(node.Value), &lrp)
+	deserializeErr := store.deserializeModel(node, &lrp)
 	if deserializeErr != nil {
 		logger.Error("failed-parsing-desired-lrp", deserializeErr)
-		return nil, models.ErrDeserializeJSON
+		return nil, 0, models.ErrDeserializeJSON
+	}
+
+	return &lrp, node.ModifiedIndex, nil
+}
+
+func (store etcdStore) CreateDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP) *models.Error {
+	key := DesiredLRPSchemaPath(lrp)
+	lrpData, err := store.serializer.Marshal(key, lrp)
+	if err != nil {
+		return models.ErrSerializeJSON
+	}
+
+	_, err = store.client.Create(key, string(lrpData), 0)
+	if err != nil {
+		return storeError(logger, err)
+	}
+	return nil
+}
+
+func (store etcdStore) CompareAndSwapDesiredLRP(logger lager.Logger, before, after *models.DesiredLRP, prevIndex uint64) *models.Error {
+	key := DesiredLRPSchemaPath(after)
+	lrpData, err := store.serializer.Marshal(key, after)
+	if err != nil {
+		return models.ErrSerializeJSON
 	}
 
-	return &lrp, nil
+	_, err = store.client.CompareAndSwap(key, string(lrpData), 0, "", prevIndex)
+	if err != nil {
+		return storeError(logger, err)
+	}
+	return nil
+}
+
+func (store etcdStore) CompareAndDeleteDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP, prevIndex uint64) *models.Error {
+	_, err := store.client.CompareAndDelete(DesiredLRPSchemaPath(lrp), "", prevIndex)
+	if err != nil {
+		return storeError(logger, err)
+	}
+	return nil
 }
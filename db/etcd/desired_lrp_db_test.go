@@ -1,6 +1,10 @@
 package etcd_test
 
 import (
+	"encoding/json"
+
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/format"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 
@@ -102,6 +106,25 @@ var _ = Describe("DesiredLRPDB", func() {
 			})
 		})
 
+		Context("when the desired lrp was stored as JSON before records were enveloped", func() {
+			var desiredLRP *models.DesiredLRP
+
+			BeforeEach(func() {
+				desiredLRP = model_helpers.NewValidDesiredLRP("process-guid")
+				value, err := json.Marshal(desiredLRP)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = etcdClient.Set(etcd.DesiredLRPSchemaPath(desiredLRP), string(value), 0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the desired lrp", func() {
+				lrp, err := etcdDB.DesiredLRPByProcessGuid(logger, "process-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lrp).To(Equal(desiredLRP))
+			})
+		})
+
 		Context("when there is no LRP", func() {
 			It("returns a ResourceNotFound", func() {
 				_, err := etcdDB.DesiredLRPByProcessGuid(logger, "nota-guid")
@@ -136,4 +159,299 @@ var _ = Describe("DesiredLRPDB", func() {
 		})
 	})
 
+	Describe("DesireLRP", func() {
+		var lrp *models.DesiredLRP
+
+		BeforeEach(func() {
+			lrp = model_helpers.NewValidDesiredLRP("some-process-guid")
+			lrp.Instances = 5
+		})
+
+		Context("when the desired LRP does not yet exist", func() {
+			It("persists the desired LRP with a fresh modification tag", func() {
+				err := etcdDB.DesireLRP(logger, lrp)
+				Expect(err).NotTo(HaveOccurred())
+
+				persisted, err := etcdDB.DesiredLRPByProcessGuid(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persisted.ModificationTag.Epoch).NotTo(BeEmpty())
+				Expect(persisted.ModificationTag.Index).To(BeEquivalentTo(0))
+				Expect(persisted).To(Equal(lrp))
+			})
+
+			It("writes the record in the serializer's format", func() {
+				err := etcdDB.DesireLRP(logger, lrp)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, getErr := etcdClient.Get(etcd.DesiredLRPSchemaPath(lrp), false, false)
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(response.Node.Value[0]).To(Equal(byte(format.PROTO)))
+			})
+
+			It("creates an unclaimed actual LRP for each instance", func() {
+				err := etcdDB.DesireLRP(logger, lrp)
+				Expect(err).NotTo(HaveOccurred())
+
+				groups, err := etcdDB.ActualLRPGroupsByProcessGuid(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups.ActualLrpGroups).To(HaveLen(5))
+
+				indices := []int32{}
+				for _, group := range groups.ActualLrpGroups {
+					Expect(group.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+					Expect(group.Instance.Domain).To(Equal(lrp.Domain))
+					Expect(group.Instance.Since).To(Equal(clock.Now().UnixNano()))
+					indices = append(indices, group.Instance.Index)
+				}
+				Expect(indices).To(ConsistOf(int32(0), int32(1), int32(2), int32(3), int32(4)))
+			})
+
+			It("requests a single auction for all of the instances", func() {
+				err := etcdDB.DesireLRP(logger, lrp)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(auctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+				requestedAuctions := auctioneerClient.RequestLRPAuctionsArgsForCall(0)
+				Expect(requestedAuctions).To(HaveLen(1))
+				Expect(requestedAuctions[0].DesiredLRP.ProcessGuid).To(Equal("some-process-guid"))
+				Expect(requestedAuctions[0].Indices).To(ConsistOf(uint(0), uint(1), uint(2), uint(3), uint(4)))
+			})
+		})
+
+		Context("when the desired LRP already exists", func() {
+			BeforeEach(func() {
+				etcdHelper.SetRawDesiredLRP(model_helpers.NewValidDesiredLRP("some-process-guid"))
+			})
+
+			It("returns a ResourceConflict error", func() {
+				err := etcdDB.DesireLRP(logger, lrp)
+				Expect(err).To(Equal(models.ErrResourceExists))
+			})
+
+			It("does not create any actual LRPs or request auctions", func() {
+				etcdDB.DesireLRP(logger, lrp)
+
+				groups, err := etcdDB.ActualLRPGroupsByProcessGuid(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups.ActualLrpGroups).To(BeEmpty())
+				Expect(auctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("UpdateDesiredLRP", func() {
+		var (
+			desiredLRP *models.DesiredLRP
+			update     *models.DesiredLRPUpdate
+		)
+
+		BeforeEach(func() {
+			desiredLRP = model_helpers.NewValidDesiredLRP("some-process-guid")
+			desiredLRP.Instances = 2
+			err := etcdDB.DesireLRP(logger, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+			auctioneerClient.RequestLRPAuctionsReturns(nil)
+
+			update = &models.DesiredLRPUpdate{}
+		})
+
+		Context("when updating the annotation", func() {
+			BeforeEach(func() {
+				annotation := "new-annotation"
+				update.Annotation = &annotation
+			})
+
+			It("persists the update and increments the modification tag", func() {
+				err := etcdDB.UpdateDesiredLRP(logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+				Expect(err).NotTo(HaveOccurred())
+
+				persisted, err := etcdDB.DesiredLRPByProcessGuid(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persisted.Annotation).To(Equal("new-annotation"))
+				Expect(persisted.ModificationTag.Epoch).To(Equal(desiredLRP.ModificationTag.Epoch))
+				Expect(persisted.ModificationTag.Index).To(Equal(desiredLRP.ModificationTag.Index + 1))
+			})
+		})
+
+		Context("when scaling up", func() {
+			BeforeEach(func() {
+				instances := int32(4)
+				update.Instances = &instances
+			})
+
+			It("creates and auctions the new indices", func() {
+				err := etcdDB.UpdateDesiredLRP(logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+				Expect(err).NotTo(HaveOccurred())
+
+				groups, err := etcdDB.ActualLRPGroupsByProcessGuid(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups.ActualLrpGroups).To(HaveLen(4))
+
+				Expect(auctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(2))
+				requestedAuctions := auctioneerClient.RequestLRPAuctionsArgsForCall(1)
+				Expect(requestedAuctions).To(HaveLen(1))
+				Expect(requestedAuctions[0].Indices).To(ConsistOf(uint(2), uint(3)))
+			})
+		})
+
+		Context("when scaling down", func() {
+			BeforeEach(func() {
+				instances := int32(1)
+				update.Instances = &instances
+			})
+
+			It("retires the indices above the new instance count", func() {
+				err := etcdDB.UpdateDesiredLRP(logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+				Expect(err).NotTo(HaveOccurred())
+
+				groups, err := etcdDB.ActualLRPGroupsByProcessGuid(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups.ActualLrpGroups).To(HaveLen(1))
+				Expect(groups.ActualLrpGroups[0].Instance.Index).To(BeEquivalentTo(0))
+			})
+		})
+
+		Context("when an expected modification tag is given", func() {
+			var expectedTag *models.ModificationTag
+
+			BeforeEach(func() {
+				annotation := "new-annotation"
+				update.Annotation = &annotation
+			})
+
+			Context("and it matches the persisted tag", func() {
+				BeforeEach(func() {
+					tag := *desiredLRP.ModificationTag
+					expectedTag = &tag
+				})
+
+				It("persists the update", func() {
+					err := etcdDB.UpdateDesiredLRP(logger, &models.UpdateDesiredLRPRequest{
+						ProcessGuid:             "some-process-guid",
+						Update:                  update,
+						ExpectedModificationTag: expectedTag,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted, err := etcdDB.DesiredLRPByProcessGuid(logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(persisted.Annotation).To(Equal("new-annotation"))
+				})
+			})
+
+			Context("and it does not match the persisted tag", func() {
+				BeforeEach(func() {
+					expectedTag = &models.ModificationTag{
+						Epoch: desiredLRP.ModificationTag.Epoch,
+						Index: desiredLRP.ModificationTag.Index + 1,
+					}
+				})
+
+				It("returns a ResourceConflict error and does not persist the update", func() {
+					err := etcdDB.UpdateDesiredLRP(logger, &models.UpdateDesiredLRPRequest{
+						ProcessGuid:             "some-process-guid",
+						Update:                  update,
+						ExpectedModificationTag: expectedTag,
+					})
+					Expect(err).To(Equal(models.ErrResourceConflict))
+
+					persisted, getErr := etcdDB.DesiredLRPByProcessGuid(logger, "some-process-guid")
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(persisted.Annotation).To(Equal(desiredLRP.Annotation))
+					Expect(persisted.ModificationTag).To(Equal(desiredLRP.ModificationTag))
+				})
+			})
+		})
+
+		Context("when the desired LRP does not exist", func() {
+			It("returns a ResourceNotFound error", func() {
+				err := etcdDB.UpdateDesiredLRP(logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "bogus-guid", Update: update})
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Context("when the update is invalid", func() {
+			BeforeEach(func() {
+				instances := int32(-1)
+				update.Instances = &instances
+			})
+
+			It("returns an InvalidRecord error and does not persist the update", func() {
+				err := etcdDB.UpdateDesiredLRP(logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Type).To(Equal(models.InvalidRecord))
+
+				persisted, getErr := etcdDB.DesiredLRPByProcessGuid(logger, "some-process-guid")
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(persisted.Instances).To(BeEquivalentTo(2))
+			})
+		})
+	})
+
+	Describe("RemoveDesiredLRP", func() {
+		Context("when the desired LRP exists", func() {
+			var (
+				unclaimedKey models.ActualLRPKey
+				claimedKey   models.ActualLRPKey
+				instanceKey  models.ActualLRPInstanceKey
+				cellPresence models.CellPresence
+			)
+
+			BeforeEach(func() {
+				desiredLRP := model_helpers.NewValidDesiredLRP("some-process-guid")
+				etcdHelper.SetRawDesiredLRP(desiredLRP)
+
+				unclaimedKey = models.NewActualLRPKey("some-process-guid", 0, desiredLRP.Domain)
+				etcdHelper.SetRawActualLRP(models.NewUnclaimedActualLRP(unclaimedKey, 1))
+
+				claimedKey = models.NewActualLRPKey("some-process-guid", 1, desiredLRP.Domain)
+				instanceKey = models.NewActualLRPInstanceKey("some-instance-guid", "cell-id")
+				etcdHelper.SetRawActualLRP(models.NewClaimedActualLRP(claimedKey, instanceKey, 1))
+
+				cellPresence = models.NewCellPresence(
+					"cell-id",
+					"cell.example.com",
+					"the-zone",
+					models.NewCellCapacity(128, 1024, 6),
+					[]string{},
+					[]string{},
+				)
+				consulHelper.RegisterCell(cellPresence)
+			})
+
+			It("removes the desired LRP", func() {
+				err := etcdDB.RemoveDesiredLRP(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = etcdDB.DesiredLRPByProcessGuid(logger, "some-process-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+
+			It("removes the unclaimed actual LRPs", func() {
+				err := etcdDB.RemoveDesiredLRP(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = etcdDB.ActualLRPGroupByProcessGuidAndIndex(logger, "some-process-guid", 0)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+
+			It("stops the claimed actual LRPs on their cells", func() {
+				err := etcdDB.RemoveDesiredLRP(logger, "some-process-guid")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cellClient.StopLRPInstanceCallCount()).To(Equal(1))
+				addr, stoppedKey, stoppedInstanceKey := cellClient.StopLRPInstanceArgsForCall(0)
+				Expect(addr).To(Equal(cellPresence.RepAddress))
+				Expect(stoppedKey).To(Equal(claimedKey))
+				Expect(stoppedInstanceKey).To(Equal(instanceKey))
+			})
+		})
+
+		Context("when the desired LRP does not exist", func() {
+			It("returns a ResourceNotFound error", func() {
+				err := etcdDB.RemoveDesiredLRP(logger, "bogus-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
 })
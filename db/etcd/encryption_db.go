@@ -0,0 +1,115 @@
+package etcd
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/coreos/go-etcd/etcd"
+	"github.com/pivotal-golang/lager"
+)
+
+// EncryptionKeyLabelKey records the label of the key every stored record was
+// last rewritten with.
+const EncryptionKeyLabelKey = DataSchemaRoot + "encryption_key_label"
+
+func (db *ETCDDB) EncryptionKeyLabel(logger lager.Logger) (string, *models.Error) {
+	node, bbsErr := db.fetchRaw(logger, EncryptionKeyLabelKey)
+	if bbsErr != nil {
+		return "", bbsErr
+	}
+
+	return node.Value, nil
+}
+
+func (db *ETCDDB) SetEncryptionKeyLabel(logger lager.Logger, label string) *models.Error {
+	logger = logger.Session("set-encryption-key-label", lager.Data{"label": label})
+
+	_, err := db.client.Set(EncryptionKeyLabelKey, label, 0)
+	if err != nil {
+		logger.Error("failed", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+// PerformEncryption rewrites every desired LRP, actual LRP and task, so that
+// each is encrypted with the serializer's active key. Records that change
+// while being rewritten are left alone, as the change wrote them with the
+// active key already. It fails if any other record could not be rewritten.
+func (db *ETCDDB) PerformEncryption(logger lager.Logger) *models.Error {
+	logger = logger.Session("perform-encryption")
+	logger.Info("starting")
+
+	roots := []struct {
+		key      string
+		newModel func() format.Model
+	}{
+		{DesiredLRPSchemaRoot, func() format.Model { return &models.DesiredLRP{} }},
+		{ActualLRPSchemaRoot, func() format.Model { return &models.ActualLRP{} }},
+		{TaskSchemaRoot, func() format.Model { return &models.Task{} }},
+	}
+
+	failures := 0
+	for _, root := range roots {
+		node, bbsErr := db.fetchRecursiveRaw(logger, root.key)
+		if bbsErr.Equal(models.ErrResourceNotFound) {
+			continue
+		}
+		if bbsErr != nil {
+			return bbsErr
+		}
+
+		failures += db.reEncryptNode(logger, node, root.newModel)
+	}
+
+	if failures > 0 {
+		logger.Error("failed-to-rewrite-records", nil, lager.Data{"failures": failures})
+		return models.ErrUnknownError
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+// reEncryptNode returns the number of records under node it failed to rewrite.
+func (db *ETCDDB) reEncryptNode(logger lager.Logger, node *etcd.Node, newModel func() format.Model) int {
+	if node.Dir {
+		failures := 0
+		for _, child := range node.Nodes {
+			failures += db.reEncryptNode(logger, child, newModel)
+		}
+		return failures
+	}
+
+	model := newModel()
+	err := db.serializer.Unmarshal(node.Key, []byte(node.Value), model)
+	if err != nil {
+		logger.Error("failed-to-read-record", err, lager.Data{"key": node.Key})
+		return 1
+	}
+
+	value, err := db.serializer.Marshal(node.Key, model)
+	if err != nil {
+		logger.Error("failed-to-serialize-record", err, lager.Data{"key": node.Key})
+		return 1
+	}
+
+	var ttl uint64
+	if node.TTL > 0 {
+		ttl = uint64(node.TTL)
+	}
+
+	_, err = db.client.CompareAndSwap(node.Key, string(value), ttl, "", node.ModifiedIndex)
+	if err == nil {
+		return 0
+	}
+
+	switch etcdErrCode(err) {
+	case ETCDErrCompareFailed, ETCDErrKeyNotFound:
+		logger.Info("record-changed-before-rewrite", lager.Data{"key": node.Key, "error": err.Error()})
+		return 0
+	default:
+		logger.Error("failed-to-rewrite-record", err, lager.Data{"key": node.Key})
+		return 1
+	}
+}
@@ -0,0 +1,119 @@
+package etcd_test
+
+import (
+	"crypto/rand"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncryptionDB", func() {
+	Describe("EncryptionKeyLabel", func() {
+		It("returns ResourceNotFound when records have never been encrypted", func() {
+			_, err := etcdDB.(db.EncryptionDB).EncryptionKeyLabel(logger)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("returns the label last set", func() {
+			encryptionDB := etcdDB.(db.EncryptionDB)
+			Expect(encryptionDB.SetEncryptionKeyLabel(logger, "some-label")).NotTo(HaveOccurred())
+
+			label, err := encryptionDB.EncryptionKeyLabel(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(label).To(Equal("some-label"))
+		})
+	})
+
+	Describe("PerformEncryption", func() {
+		var (
+			encryptingDB *etcd.ETCDDB
+			desiredLRP   *models.DesiredLRP
+			actualLRP    *models.ActualLRP
+			evacuating   *models.ActualLRP
+			task         *models.Task
+		)
+
+		BeforeEach(func() {
+			keyManager, err := encryption.KeyConfig{
+				ActiveKeyLabel: "active",
+				Keys:           map[string]string{"active": "a"},
+			}.KeyManager()
+			Expect(err).NotTo(HaveOccurred())
+
+			encryptingSerializer := format.NewSerializer(format.PROTO, encryption.NewCryptor(keyManager, rand.Reader))
+			encryptingDB = etcd.NewETCD(encryptingSerializer, etcdClient, auctioneerClient, cellClient, cellDB, clock, fakeTaskCompletionClient)
+
+			desiredLRP = model_helpers.NewValidDesiredLRP("some-guid")
+			actualLRP = model_helpers.NewValidActualLRP("some-guid", 0)
+			evacuating = model_helpers.NewValidActualLRP("some-guid", 1)
+			task = model_helpers.NewValidTask("some-task-guid")
+
+			etcdHelper.SetRawDesiredLRP(desiredLRP)
+			etcdHelper.SetRawActualLRP(actualLRP)
+			etcdHelper.SetRawEvacuatingActualLRP(evacuating, 100)
+			etcdHelper.SetRawTask(task)
+		})
+
+		It("rewrites every record with the active key", func() {
+			Expect(encryptingDB.PerformEncryption(logger)).NotTo(HaveOccurred())
+
+			for _, key := range []string{
+				etcd.DesiredLRPSchemaPath(desiredLRP),
+				etcd.ActualLRPSchemaPath("some-guid", 0),
+				etcd.EvacuatingActualLRPSchemaPath("some-guid", 1),
+				etcd.TaskSchemaPath(task),
+			} {
+				response, err := etcdClient.Get(key, false, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Node.Value[1]).To(Equal(byte(format.V1)), key)
+			}
+
+			lrp, err := encryptingDB.DesiredLRPByProcessGuid(logger, "some-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lrp).To(Equal(desiredLRP))
+
+			group, err := encryptingDB.ActualLRPGroupByProcessGuidAndIndex(logger, "some-guid", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Instance).To(Equal(actualLRP))
+
+			fetchedTask, err := encryptingDB.TaskByGuid(logger, "some-task-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetchedTask).To(Equal(task))
+		})
+
+		It("keeps the ttl of evacuating actual LRPs", func() {
+			Expect(encryptingDB.PerformEncryption(logger)).NotTo(HaveOccurred())
+
+			response, err := etcdClient.Get(etcd.EvacuatingActualLRPSchemaPath("some-guid", 1), false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Node.TTL).To(BeNumerically(">", 0))
+		})
+
+		It("rewrites the records it can read and fails for the rest", func() {
+			etcdHelper.CreateMalformedTask("bad-task-guid")
+
+			Expect(encryptingDB.PerformEncryption(logger)).To(Equal(models.ErrUnknownError))
+
+			response, err := etcdClient.Get(etcd.TaskSchemaPath(task), false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Node.Value[1]).To(Equal(byte(format.V1)))
+		})
+
+		Context("when there are no records", func() {
+			BeforeEach(func() {
+				etcdRunner.Reset()
+			})
+
+			It("succeeds", func() {
+				Expect(encryptingDB.PerformEncryption(logger)).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
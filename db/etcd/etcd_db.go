@@ -1,12 +1,17 @@
 package etcd
 
 import (
+	"path"
+	"sort"
 	"sync"
 
 	"github.com/cloudfoundry-incubator/bbs/auctionhandlers"
 	"github.com/cloudfoundry-incubator/bbs/cellhandlers"
 	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/storedb"
+	"github.com/cloudfoundry-incubator/bbs/format"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
 	"github.com/coreos/go-etcd/etcd"
 	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
@@ -15,30 +20,45 @@ import (
 const DataSchemaRoot = "/v1/"
 
 const (
-	ETCDErrKeyNotFound  = 100
-	ETCDErrIndexCleared = 401
+	ETCDErrKeyNotFound   = 100
+	ETCDErrCompareFailed = 101
+	ETCDErrKeyExists     = 105
+	ETCDErrIndexCleared  = 401
 )
 
 type ETCDDB struct {
+	*storedb.DB
+
+	serializer        format.Serializer
 	client            *etcd.Client
-	clock             clock.Clock
 	inflightWatches   map[chan bool]bool
 	inflightWatchLock *sync.Mutex
-	auctioneerClient  auctionhandlers.Client
-	cellClient        cellhandlers.Client
+}
+
+func NewETCD(serializer format.Serializer, etcdClient *etcd.Client, auctioneerClient auctionhandlers.Client, cellClient cellhandlers.Client, cellDB db.CellDB, clock clock.Clock, taskCompletionClient taskworkpool.TaskCompletionClient) *ETCDDB {
+	db := &ETCDDB{
+		serializer:        serializer,
+		client:            etcdClient,
+		inflightWatches:   map[chan bool]bool{},
+		inflightWatchLock: &sync.Mutex{},
+	}
+	db.DB = storedb.New(etcdStore{db}, auctioneerClient, cellClient, cellDB, clock, taskCompletionClient)
+	return db
+}
 
-	cellDB db.CellDB
+// etcdStore gives storedb access to the nodes of an ETCDDB.
+type etcdStore struct {
+	*ETCDDB
 }
 
-func NewETCD(etcdClient *etcd.Client, auctioneerClient auctionhandlers.Client, cellClient cellhandlers.Client, cellDB db.CellDB, clock clock.Clock) *ETCDDB {
-	return &ETCDDB{etcdClient,
-		clock,
-		map[chan bool]bool{},
-		&sync.Mutex{},
-		auctioneerClient,
-		cellClient,
-		cellDB,
+// deserializeModel decodes a stored record and validates it, as reading the
+// record with models.FromJSON did.
+func (db *ETCDDB) deserializeModel(node *etcd.Node, model format.Model) error {
+	err := db.serializer.Unmarshal(node.Key, []byte(node.Value), model)
+	if err != nil {
+		return err
 	}
+	return model.Validate()
 }
 
 func (db *ETCDDB) fetchRecursiveRaw(logger lager.Logger, key string) (*etcd.Node, *models.Error) {
@@ -69,6 +89,29 @@ func (db *ETCDDB) fetchRaw(logger lager.Logger, key string) (*etcd.Node, *models
 	return response.Node, nil
 }
 
+// nodesAfter returns the nodes, sorted by key, whose base name comes after
+// after.
+func nodesAfter(nodes etcd.Nodes, after string) etcd.Nodes {
+	if after == "" {
+		return nodes
+	}
+	return nodes[sort.Search(len(nodes), func(i int) bool { return path.Base(nodes[i].Key) > after }):]
+}
+
+// storeError maps a failed write to the errors storedb expects: a missing or
+// modified node is a conflict.
+func storeError(logger lager.Logger, err error) *models.Error {
+	switch etcdErrCode(err) {
+	case ETCDErrKeyNotFound, ETCDErrCompareFailed:
+		logger.Info("node-changed-concurrently", lager.Data{"error": err.Error()})
+		return models.ErrResourceConflict
+	case ETCDErrKeyExists:
+		return models.ErrResourceExists
+	}
+	logger.Error("failed-writing-to-etcd", err)
+	return models.ErrUnknownError
+}
+
 func etcdErrCode(err error) int {
 	if err != nil {
 		switch err.(type) {
@@ -11,6 +11,9 @@ import (
 	"github.com/cloudfoundry-incubator/bbs/db/consul/internal/consul_helpers"
 	"github.com/cloudfoundry-incubator/bbs/db/etcd"
 	"github.com/cloudfoundry-incubator/bbs/db/etcd/internal/etcd_helpers"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/db_suites"
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool/fakes"
 	"github.com/cloudfoundry-incubator/consuladapter"
 	"github.com/cloudfoundry-incubator/consuladapter/consulrunner"
 	"github.com/cloudfoundry/storeadapter/storerunner/etcdstorerunner"
@@ -34,15 +37,19 @@ var consulSession *consuladapter.Session
 
 var auctioneerClient *fakeauctioneer.FakeClient
 var cellClient *fakecellhandlers.FakeClient
+var fakeTaskCompletionClient *fakes.FakeTaskCompletionClient
 
 var logger *lagertest.TestLogger
 var clock *fakeclock.FakeClock
 var etcdHelper *etcd_helpers.ETCDHelper
 var consulHelper *consul_helpers.ConsulHelper
 
+var serializer format.Serializer
 var cellDB db.CellDB
 var etcdDB db.DB
 
+var backend = &db_suites.Backend{}
+
 func TestDB(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "ETCD DB Suite")
@@ -79,6 +86,7 @@ var _ = AfterSuite(func() {
 var _ = BeforeEach(func() {
 	auctioneerClient = new(fakeauctioneer.FakeClient)
 	cellClient = new(fakecellhandlers.FakeClient)
+	fakeTaskCompletionClient = new(fakes.FakeTaskCompletionClient)
 	etcdRunner.Reset()
 
 	consulRunner.Reset()
@@ -86,8 +94,44 @@ var _ = BeforeEach(func() {
 
 	etcdClient = etcdRunner.Client()
 	etcdClient.SetConsistency(etcdclient.STRONG_CONSISTENCY)
-	etcdHelper = etcd_helpers.NewETCDHelper(etcdClient)
+	serializer = format.NewSerializer(format.PROTO, nil)
+	etcdHelper = etcd_helpers.NewETCDHelper(serializer, etcdClient)
 	consulHelper = consul_helpers.NewConsulHelper(consulSession)
 	cellDB = consul.NewConsul(consulSession)
-	etcdDB = etcd.NewETCD(etcdClient, auctioneerClient, cellClient, cellDB, clock)
+	etcdDB = etcd.NewETCD(serializer, etcdClient, auctioneerClient, cellClient, cellDB, clock, fakeTaskCompletionClient)
+
+	*backend = db_suites.Backend{
+		DB:     etcdDB,
+		Logger: logger,
+		Clock:  clock,
+
+		AuctioneerClient:     auctioneerClient,
+		CellClient:           cellClient,
+		TaskCompletionClient: fakeTaskCompletionClient,
+
+		RegisterCell:              consulHelper.RegisterCell,
+		SetRawTask:                etcdHelper.SetRawTask,
+		SetRawTaskData:            setRawTaskData,
+		SetRawDesiredLRP:          etcdHelper.SetRawDesiredLRP,
+		SetRawDesiredLRPData:      setRawDesiredLRPData,
+		SetRawActualLRP:           etcdHelper.SetRawActualLRP,
+		SetRawEvacuatingActualLRP: etcdHelper.SetRawEvacuatingActualLRP,
+	}
 })
+
+var _ = db_suites.ActualLRPDBSuite(backend)
+var _ = db_suites.DesiredLRPDBSuite(backend)
+var _ = db_suites.TaskDBSuite(backend)
+var _ = db_suites.EvacuationSuite(backend)
+var _ = db_suites.LRPConvergenceSuite(backend)
+var _ = db_suites.TaskConvergenceSuite(backend)
+
+func setRawTaskData(taskGuid string, data []byte) {
+	_, err := etcdClient.Set(etcd.TaskSchemaPathByGuid(taskGuid), string(data), 0)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func setRawDesiredLRPData(processGuid string, data []byte) {
+	_, err := etcdClient.Set(etcd.DesiredLRPSchemaPathByProcessGuid(processGuid), string(data), 0)
+	Expect(err).NotTo(HaveOccurred())
+}
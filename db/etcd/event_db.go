@@ -39,7 +39,7 @@ func (db *ETCDDB) WatchForDesiredLRPChanges(logger lager.Logger,
 				logger.Debug("received-create")
 
 				var desiredLRP models.DesiredLRP
-				err := models.FromJSON([]byte(event.Node.Value), &desiredLRP)
+				err := db.deserializeModel(event.Node, &desiredLRP)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-desired-lrp", err, lager.Data{"value": event.Node.Value})
 					continue
@@ -52,14 +52,14 @@ func (db *ETCDDB) WatchForDesiredLRPChanges(logger lager.Logger,
 				logger.Debug("received-update")
 
 				var before models.DesiredLRP
-				err := models.FromJSON([]byte(event.PrevNode.Value), &before)
+				err := db.deserializeModel(event.PrevNode, &before)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-desired-lrp", err, lager.Data{"value": event.PrevNode.Value})
 					continue
 				}
 
 				var after models.DesiredLRP
-				err = models.FromJSON([]byte(event.Node.Value), &after)
+				err = db.deserializeModel(event.Node, &after)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-desired-lrp", err, lager.Data{"value": event.Node.Value})
 					continue
@@ -72,7 +72,7 @@ func (db *ETCDDB) WatchForDesiredLRPChanges(logger lager.Logger,
 				logger.Debug("received-delete")
 
 				var desiredLRP models.DesiredLRP
-				err := models.FromJSON([]byte(event.PrevNode.Value), &desiredLRP)
+				err := db.deserializeModel(event.PrevNode, &desiredLRP)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-desired-lrp", err, lager.Data{"value": event.PrevNode.Value})
 					continue
@@ -109,7 +109,7 @@ func (db *ETCDDB) WatchForActualLRPChanges(logger lager.Logger,
 				logger.Debug("received-create")
 
 				var actualLRP models.ActualLRP
-				err := models.FromJSON([]byte(event.Node.Value), &actualLRP)
+				err := db.deserializeModel(event.Node, &actualLRP)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-actual-lrp-on-create", err, lager.Data{"key": event.Node.Key, "value": event.Node.Value})
 					continue
@@ -130,14 +130,14 @@ func (db *ETCDDB) WatchForActualLRPChanges(logger lager.Logger,
 				logger.Debug("received-change")
 
 				var before models.ActualLRP
-				err := models.FromJSON([]byte(event.PrevNode.Value), &before)
+				err := db.deserializeModel(event.PrevNode, &before)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-prev-actual-lrp-on-change", err, lager.Data{"key": event.PrevNode.Key, "value": event.PrevNode.Value})
 					continue
 				}
 
 				var after models.ActualLRP
-				err = models.FromJSON([]byte(event.Node.Value), &after)
+				err = db.deserializeModel(event.Node, &after)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-actual-lrp-on-change", err, lager.Data{"key": event.Node.Key, "value": event.Node.Value})
 					continue
@@ -164,7 +164,7 @@ func (db *ETCDDB) WatchForActualLRPChanges(logger lager.Logger,
 				if event.PrevNode.Dir {
 					continue
 				}
-				err := models.FromJSON([]byte(event.PrevNode.Value), &actualLRP)
+				err := db.deserializeModel(event.PrevNode, &actualLRP)
 				if err != nil {
 					logger.Error("failed-to-unmarshal-prev-actual-lrp-on-delete", err, lager.Data{"key": event.PrevNode.Key, "value": event.PrevNode.Value})
 				} else {
@@ -189,6 +189,76 @@ func (db *ETCDDB) WatchForActualLRPChanges(logger lager.Logger,
 	return stop, err
 }
 
+func (db *ETCDDB) WatchForTaskChanges(logger lager.Logger,
+	created func(*models.Task),
+	changed func(*models.TaskChange),
+	deleted func(*models.Task),
+) (chan<- bool, <-chan error) {
+	logger = logger.Session("watching-for-task-changes")
+
+	events, stop, err := db.watch(TaskSchemaRoot)
+
+	go func() {
+		logger.Info("started-watching")
+		defer logger.Info("finished-watching")
+
+		for event := range events {
+			switch {
+			case event.Node != nil && event.PrevNode == nil:
+				logger.Debug("received-create")
+
+				var task models.Task
+				err := db.deserializeModel(event.Node, &task)
+				if err != nil {
+					logger.Error("failed-to-unmarshal-task", err, lager.Data{"value": event.Node.Value})
+					continue
+				}
+
+				logger.Debug("sending-create", lager.Data{"task-guid": task.TaskGuid})
+				created(&task)
+
+			case event.Node != nil && event.PrevNode != nil: // update
+				logger.Debug("received-update")
+
+				var before models.Task
+				err := db.deserializeModel(event.PrevNode, &before)
+				if err != nil {
+					logger.Error("failed-to-unmarshal-task", err, lager.Data{"value": event.PrevNode.Value})
+					continue
+				}
+
+				var after models.Task
+				err = db.deserializeModel(event.Node, &after)
+				if err != nil {
+					logger.Error("failed-to-unmarshal-task", err, lager.Data{"value": event.Node.Value})
+					continue
+				}
+
+				logger.Debug("sending-update", lager.Data{"task-guid": after.TaskGuid, "before-state": before.State, "after-state": after.State})
+				changed(&models.TaskChange{Before: &before, After: &after})
+
+			case event.Node == nil && event.PrevNode != nil: // delete
+				logger.Debug("received-delete")
+
+				var task models.Task
+				err := db.deserializeModel(event.PrevNode, &task)
+				if err != nil {
+					logger.Error("failed-to-unmarshal-task", err, lager.Data{"value": event.PrevNode.Value})
+					continue
+				}
+
+				logger.Debug("sending-delete", lager.Data{"task-guid": task.TaskGuid})
+				deleted(&task)
+
+			default:
+				logger.Debug("received-event-with-both-nodes-nil")
+			}
+		}
+	}()
+
+	return stop, err
+}
+
 func (db *ETCDDB) watch(key string) (<-chan watchEvent, chan<- bool, <-chan error) {
 	events := make(chan watchEvent)
 	errors := make(chan error)
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/cloudfoundry-incubator/bbs/db/etcd"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -99,6 +100,80 @@ var _ = Describe("Watchers", func() {
 		})
 	})
 
+	Describe("WatchForTaskChanges", func() {
+		var (
+			creates chan *models.Task
+			changes chan *models.TaskChange
+			deletes chan *models.Task
+			stop    chan<- bool
+			errors  <-chan error
+			task    *models.Task
+		)
+
+		BeforeEach(func() {
+			task = model_helpers.NewValidTask("some-task-guid")
+
+			creates = make(chan *models.Task)
+			changes = make(chan *models.TaskChange)
+			deletes = make(chan *models.Task)
+
+			stop, errors = etcdDB.WatchForTaskChanges(logger,
+				func(created *models.Task) { creates <- created },
+				func(changed *models.TaskChange) { changes <- changed },
+				func(deleted *models.Task) { deletes <- deleted },
+			)
+		})
+
+		AfterEach(func() {
+			close(stop)
+			Consistently(errors).ShouldNot(Receive())
+			Eventually(errors).Should(BeClosed())
+		})
+
+		It("sends an event down the pipe for creates", func() {
+			etcdHelper.SetRawTask(task)
+
+			persisted, err := etcdDB.TaskByGuid(logger, task.TaskGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(creates).Should(Receive(Equal(persisted)))
+		})
+
+		It("sends an event down the pipe for updates", func() {
+			etcdHelper.SetRawTask(task)
+
+			Eventually(creates).Should(Receive())
+
+			taskBeforeUpdate, err := etcdDB.TaskByGuid(logger, task.TaskGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = etcdDB.StartTask(logger, task.TaskGuid, "cell-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			taskAfterUpdate, err := etcdDB.TaskByGuid(logger, task.TaskGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(changes).Should(Receive(Equal(&models.TaskChange{
+				Before: taskBeforeUpdate,
+				After:  taskAfterUpdate,
+			})))
+		})
+
+		It("sends an event down the pipe for deletes", func() {
+			etcdHelper.SetRawTask(task)
+
+			Eventually(creates).Should(Receive())
+
+			persisted, bbsErr := etcdDB.TaskByGuid(logger, task.TaskGuid)
+			Expect(bbsErr).NotTo(HaveOccurred())
+
+			_, err := etcdClient.Delete(TaskSchemaPath(persisted), true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(deletes).Should(Receive(Equal(persisted)))
+		})
+	})
+
 	Describe("WatchForActualLRPChanges", func() {
 		const (
 			lrpProcessGuid = "some-process-guid"
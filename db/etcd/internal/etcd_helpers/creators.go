@@ -1,7 +1,6 @@
 package etcd_helpers
 
 import (
-	"encoding/json"
 	"fmt"
 
 	etcddb "github.com/cloudfoundry-incubator/bbs/db/etcd"
@@ -11,40 +10,40 @@ import (
 )
 
 func (t *ETCDHelper) SetRawActualLRP(lrp *models.ActualLRP) {
-	value, err := json.Marshal(lrp) // do NOT use models.ToJSON; don't want validations
+	key := etcddb.ActualLRPSchemaPath(lrp.GetProcessGuid(), lrp.GetIndex())
+	value, err := t.serializer.Marshal(key, lrp) // do NOT validate; tests store invalid records
 	Expect(err).NotTo(HaveOccurred())
 
-	key := etcddb.ActualLRPSchemaPath(lrp.GetProcessGuid(), lrp.GetIndex())
 	_, err = t.etcdClient.Set(key, string(value), 0)
 
 	Expect(err).NotTo(HaveOccurred())
 }
 
 func (t *ETCDHelper) SetRawEvacuatingActualLRP(lrp *models.ActualLRP, ttlInSeconds uint64) {
-	value, err := json.Marshal(lrp) // do NOT use models.ToJSON; don't want validations
+	key := etcddb.EvacuatingActualLRPSchemaPath(lrp.GetProcessGuid(), lrp.GetIndex())
+	value, err := t.serializer.Marshal(key, lrp) // do NOT validate; tests store invalid records
 	Expect(err).NotTo(HaveOccurred())
 
-	key := etcddb.EvacuatingActualLRPSchemaPath(lrp.GetProcessGuid(), lrp.GetIndex())
 	_, err = t.etcdClient.Set(key, string(value), ttlInSeconds)
 
 	Expect(err).NotTo(HaveOccurred())
 }
 
 func (t *ETCDHelper) SetRawDesiredLRP(lrp *models.DesiredLRP) {
-	value, err := json.Marshal(lrp) // do NOT use models.ToJSON; don't want validations
+	key := etcddb.DesiredLRPSchemaPath(lrp)
+	value, err := t.serializer.Marshal(key, lrp) // do NOT validate; tests store invalid records
 	Expect(err).NotTo(HaveOccurred())
 
-	key := etcddb.DesiredLRPSchemaPath(lrp)
 	_, err = t.etcdClient.Set(key, string(value), 0)
 
 	Expect(err).NotTo(HaveOccurred())
 }
 
 func (t *ETCDHelper) SetRawTask(task *models.Task) {
-	value, err := json.Marshal(task) // do NOT use models.ToJSON; don't want validations
+	key := etcddb.TaskSchemaPath(task)
+	value, err := t.serializer.Marshal(key, task) // do NOT validate; tests store invalid records
 	Expect(err).NotTo(HaveOccurred())
 
-	key := etcddb.TaskSchemaPath(task)
 	_, err = t.etcdClient.Set(key, string(value), 0)
 
 	Expect(err).NotTo(HaveOccurred())
@@ -108,10 +107,12 @@ func (t *ETCDHelper) CreateDesiredLRPsInDomains(domainCounts map[string]int) map
 				Instances:   1,
 				Action:      action,
 			}
-			value, err := models.ToJSON(desiredLRP)
+			Expect(desiredLRP.Validate()).NotTo(HaveOccurred())
+			key := etcddb.DesiredLRPSchemaPath(desiredLRP)
+			value, err := t.serializer.Marshal(key, desiredLRP)
 			Expect(err).NotTo(HaveOccurred())
 
-			t.etcdClient.Set(etcddb.DesiredLRPSchemaPath(desiredLRP), string(value), 0)
+			t.etcdClient.Set(key, string(value), 0)
 			Expect(err).NotTo(HaveOccurred())
 
 			createdDesiredLRPs[domain] = append(createdDesiredLRPs[domain], desiredLRP)
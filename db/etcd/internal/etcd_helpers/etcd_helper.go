@@ -1,11 +1,15 @@
 package etcd_helpers
 
-import etcdclient "github.com/coreos/go-etcd/etcd"
+import (
+	"github.com/cloudfoundry-incubator/bbs/format"
+	etcdclient "github.com/coreos/go-etcd/etcd"
+)
 
-func NewETCDHelper(etcdClient *etcdclient.Client) *ETCDHelper {
-	return &ETCDHelper{etcdClient: etcdClient}
+func NewETCDHelper(serializer format.Serializer, etcdClient *etcdclient.Client) *ETCDHelper {
+	return &ETCDHelper{serializer: serializer, etcdClient: etcdClient}
 }
 
 type ETCDHelper struct {
+	serializer format.Serializer
 	etcdClient *etcdclient.Client
 }
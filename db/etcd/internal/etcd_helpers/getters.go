@@ -16,8 +16,9 @@ func (t *ETCDHelper) GetInstanceActualLRP(lrpKey *models.ActualLRPKey) (*models.
 	Expect(err).NotTo(HaveOccurred())
 
 	var lrp models.ActualLRP
-	err = models.FromJSON([]byte(resp.Node.Value), &lrp)
+	err = t.serializer.Unmarshal(resp.Node.Key, []byte(resp.Node.Value), &lrp)
 	Expect(err).NotTo(HaveOccurred())
+	Expect(lrp.Validate()).NotTo(HaveOccurred())
 
 	return &lrp, nil
 }
@@ -2,8 +2,9 @@ package etcd
 
 import (
 	"path"
+	"sort"
 
-	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/storedb"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/pivotal-golang/lager"
 )
@@ -18,52 +19,92 @@ func TaskSchemaPathByGuid(taskGuid string) string {
 	return path.Join(TaskSchemaRoot, taskGuid)
 }
 
-func (db *ETCDDB) Tasks(logger lager.Logger, taskFilter db.TaskFilter) (*models.Tasks, *models.Error) {
-	root, bbsErr := db.fetchRecursiveRaw(logger, TaskSchemaRoot)
+// Tasks decodes the task nodes in key order from the one after AfterTaskGuid,
+// stopping once the page is full.
+func (store etcdStore) Tasks(logger lager.Logger, filter models.TaskFilter) ([]storedb.TaskRecord, *models.Error) {
+	root, bbsErr := store.fetchRecursiveRaw(logger, TaskSchemaRoot)
 	if bbsErr.Equal(models.ErrResourceNotFound) {
-		return &models.Tasks{}, nil
+		return []storedb.TaskRecord{}, nil
 	}
 	if bbsErr != nil {
 		return nil, bbsErr
 	}
-	if root.Nodes.Len() == 0 {
-		return &models.Tasks{}, nil
-	}
 
-	tasks := models.Tasks{}
+	sort.Sort(root.Nodes)
 
-	for _, node := range root.Nodes {
-		node := node
+	records := []storedb.TaskRecord{}
+	for _, node := range nodesAfter(root.Nodes, filter.AfterTaskGuid) {
+		if filter.Limit > 0 && len(records) == filter.Limit {
+			break
+		}
 
 		var task models.Task
-		deserializeErr := models.FromJSON([]byte(node.Value), &task)
+		deserializeErr := store.deserializeModel(node, &task)
 		if deserializeErr != nil {
 			logger.Error("failed-parsing-task", deserializeErr, lager.Data{"key": node.Key})
 			return nil, models.ErrUnknownError
 		}
 
-		if taskFilter == nil || taskFilter(&task) {
-			tasks.Tasks = append(tasks.Tasks, &task)
+		if filter.Domain != "" && task.Domain != filter.Domain {
+			continue
+		}
+		if filter.CellID != "" && task.CellId != filter.CellID {
+			continue
 		}
+		records = append(records, storedb.TaskRecord{Task: &task, Index: node.ModifiedIndex})
 	}
 
-	logger.Debug("succeeded-performing-deserialization", lager.Data{"num-tasks": len(tasks.GetTasks())})
-
-	return &tasks, nil
+	return records, nil
 }
 
-func (db *ETCDDB) TaskByGuid(logger lager.Logger, taskGuid string) (*models.Task, *models.Error) {
-	node, bbsErr := db.fetchRaw(logger, TaskSchemaPathByGuid(taskGuid))
+func (store etcdStore) Task(logger lager.Logger, taskGuid string) (*models.Task, uint64, *models.Error) {
+	node, bbsErr := store.fetchRaw(logger, TaskSchemaPathByGuid(taskGuid))
 	if bbsErr != nil {
-		return nil, bbsErr
+		return nil, 0, bbsErr
 	}
 
 	var task models.Task
-	deserializeErr := models.FromJSON([]byte(node.Value), &task)
+	deserializeErr := store.deserializeModel(node, &task)
 	if deserializeErr != nil {
 		logger.Error("failed-parsing-desired-task", deserializeErr)
-		return nil, models.ErrDeserializeJSON
+		return nil, 0, models.ErrDeserializeJSON
+	}
+
+	return &task, node.ModifiedIndex, nil
+}
+
+func (store etcdStore) CreateTask(logger lager.Logger, task *models.Task) *models.Error {
+	key := TaskSchemaPath(task)
+	taskData, err := store.serializer.Marshal(key, task)
+	if err != nil {
+		return models.ErrSerializeJSON
 	}
 
-	return &task, nil
+	_, err = store.client.Create(key, string(taskData), 0)
+	if err != nil {
+		return storeError(logger, err)
+	}
+	return nil
+}
+
+func (store etcdStore) CompareAndSwapTask(logger lager.Logger, before, after *models.Task, prevIndex uint64) *models.Error {
+	key := TaskSchemaPath(after)
+	taskData, err := store.serializer.Marshal(key, after)
+	if err != nil {
+		return models.ErrSerializeJSON
+	}
+
+	_, err = store.client.CompareAndSwap(key, string(taskData), 0, "", prevIndex)
+	if err != nil {
+		return storeError(logger, err)
+	}
+	return nil
+}
+
+func (store etcdStore) CompareAndDeleteTask(logger lager.Logger, task *models.Task, prevIndex uint64) *models.Error {
+	_, err := store.client.CompareAndDelete(TaskSchemaPath(task), "", prevIndex)
+	if err != nil {
+		return storeError(logger, err)
+	}
+	return nil
 }
@@ -17,6 +17,7 @@ var _ = Describe("TaskDB", func() {
 				expectedTasks = []*models.Task{
 					model_helpers.NewValidTask("a-guid"), model_helpers.NewValidTask("b-guid"),
 				}
+				expectedTasks[1].Domain = "b-domain"
 
 				for _, t := range expectedTasks {
 					etcdHelper.SetRawTask(t)
@@ -24,22 +25,36 @@ var _ = Describe("TaskDB", func() {
 			})
 
 			It("returns all the tasks", func() {
-				tasks, err := etcdDB.Tasks(logger, nil)
+				tasks, err := etcdDB.Tasks(logger, models.TaskFilter{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(tasks.GetTasks()).To(ConsistOf(expectedTasks))
 			})
 
 			It("can filter", func() {
-				tasks, err := etcdDB.Tasks(logger, func(t *models.Task) bool { return t.TaskGuid == "b-guid" })
+				tasks, err := etcdDB.Tasks(logger, models.TaskFilter{Domain: "b-domain"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(tasks.Tasks).To(HaveLen(1))
 				Expect(tasks.Tasks[0]).To(Equal(expectedTasks[1]))
 			})
+
+			It("can page in task guid order", func() {
+				tasks, err := etcdDB.Tasks(logger, models.TaskFilter{Limit: 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks.GetTasks()).To(Equal([]*models.Task{expectedTasks[0]}))
+
+				tasks, err = etcdDB.Tasks(logger, models.TaskFilter{Limit: 1, AfterTaskGuid: "a-guid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks.GetTasks()).To(Equal([]*models.Task{expectedTasks[1]}))
+
+				tasks, err = etcdDB.Tasks(logger, models.TaskFilter{Limit: 1, AfterTaskGuid: "b-guid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks.GetTasks()).To(BeEmpty())
+			})
 		})
 
 		Context("when there are no tasks", func() {
 			It("returns an empty list", func() {
-				tasks, err := etcdDB.Tasks(logger, nil)
+				tasks, err := etcdDB.Tasks(logger, models.TaskFilter{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(tasks).NotTo(BeNil())
 				Expect(tasks.GetTasks()).To(BeEmpty())
@@ -54,7 +69,7 @@ var _ = Describe("TaskDB", func() {
 			})
 
 			It("errors", func() {
-				_, err := etcdDB.Tasks(logger, nil)
+				_, err := etcdDB.Tasks(logger, models.TaskFilter{})
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -69,7 +84,7 @@ var _ = Describe("TaskDB", func() {
 			})
 
 			It("errors", func() {
-				_, err := etcdDB.Tasks(logger, nil)
+				_, err := etcdDB.Tasks(logger, models.TaskFilter{})
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -124,4 +139,344 @@ var _ = Describe("TaskDB", func() {
 			})
 		})
 	})
+
+	Describe("DesireTask", func() {
+		var task *models.Task
+
+		BeforeEach(func() {
+			task = model_helpers.NewValidTask("task-guid")
+			task.State = models.Task_Invalid
+		})
+
+		Context("when the task does not yet exist", func() {
+			It("persists the task in the pending state", func() {
+				err := etcdDB.DesireTask(logger, task)
+				Expect(err).NotTo(HaveOccurred())
+
+				persisted, err := etcdDB.TaskByGuid(logger, "task-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persisted.State).To(Equal(models.Task_Pending))
+				Expect(persisted.CreatedAt).To(Equal(clock.Now().UnixNano()))
+				Expect(persisted.UpdatedAt).To(Equal(clock.Now().UnixNano()))
+			})
+
+			It("requests an auction for the task", func() {
+				err := etcdDB.DesireTask(logger, task)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(auctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+				requestedTasks := auctioneerClient.RequestTaskAuctionsArgsForCall(0)
+				Expect(requestedTasks).To(HaveLen(1))
+				Expect(requestedTasks[0].TaskGuid).To(Equal("task-guid"))
+			})
+		})
+
+		Context("when the task already exists", func() {
+			BeforeEach(func() {
+				etcdHelper.SetRawTask(model_helpers.NewValidTask("task-guid"))
+			})
+
+			It("returns a ResourceConflict error", func() {
+				err := etcdDB.DesireTask(logger, task)
+				Expect(err).To(Equal(models.ErrResourceExists))
+				Expect(auctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Task lifecycle transitions", func() {
+		const taskGuid = "task-guid"
+		var task *models.Task
+
+		BeforeEach(func() {
+			task = model_helpers.NewValidTask(taskGuid)
+			task.CellId = ""
+			task.Failed = false
+			task.FailureReason = ""
+			task.Result = ""
+			task.FirstCompletedAt = 0
+		})
+
+		JustBeforeEach(func() {
+			etcdHelper.SetRawTask(task)
+		})
+
+		fetchTask := func() *models.Task {
+			persisted, err := etcdDB.TaskByGuid(logger, taskGuid)
+			Expect(err).NotTo(HaveOccurred())
+			return persisted
+		}
+
+		Describe("StartTask", func() {
+			Context("when the task is pending", func() {
+				It("transitions the task to running on the cell", func() {
+					shouldStart, err := etcdDB.StartTask(logger, taskGuid, "cell-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(shouldStart).To(BeTrue())
+
+					persisted := fetchTask()
+					Expect(persisted.State).To(Equal(models.Task_Running))
+					Expect(persisted.CellId).To(Equal("cell-id"))
+					Expect(persisted.UpdatedAt).To(Equal(clock.Now().UnixNano()))
+				})
+			})
+
+			Context("when the task is already running on the same cell", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Running
+					task.CellId = "cell-id"
+				})
+
+				It("tells the cell not to start the task again", func() {
+					shouldStart, err := etcdDB.StartTask(logger, taskGuid, "cell-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(shouldStart).To(BeFalse())
+				})
+			})
+
+			Context("when the task is running on another cell", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Running
+					task.CellId = "other-cell-id"
+				})
+
+				It("returns an ErrTaskCannotBeStarted", func() {
+					_, err := etcdDB.StartTask(logger, taskGuid, "cell-id")
+					Expect(err).To(Equal(models.ErrTaskCannotBeStarted))
+				})
+			})
+
+			Context("when the task does not exist", func() {
+				It("returns a ResourceNotFound error", func() {
+					_, err := etcdDB.StartTask(logger, "bogus-guid", "cell-id")
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+
+		Describe("CancelTask", func() {
+			Context("when the task is pending", func() {
+				It("completes the task as failed", func() {
+					err := etcdDB.CancelTask(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted := fetchTask()
+					Expect(persisted.State).To(Equal(models.Task_Completed))
+					Expect(persisted.Failed).To(BeTrue())
+					Expect(persisted.FailureReason).To(Equal("task was cancelled"))
+					Expect(persisted.FirstCompletedAt).To(Equal(clock.Now().UnixNano()))
+					Expect(cellClient.CancelTaskCallCount()).To(Equal(0))
+				})
+
+				Context("when the task has a completion callback url", func() {
+					BeforeEach(func() {
+						task.CompletionCallbackUrl = "http://example.com/callback"
+					})
+
+					It("submits the cancelled task for the callback", func() {
+						err := etcdDB.CancelTask(logger, taskGuid)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeTaskCompletionClient.SubmitCallCount()).To(Equal(1))
+						_, submittedTask := fakeTaskCompletionClient.SubmitArgsForCall(0)
+						Expect(submittedTask.FailureReason).To(Equal("task was cancelled"))
+					})
+				})
+			})
+
+			Context("when the task is running", func() {
+				var cellPresence models.CellPresence
+
+				BeforeEach(func() {
+					task.State = models.Task_Running
+					task.CellId = "cell-id"
+
+					cellPresence = models.NewCellPresence(
+						"cell-id",
+						"cell.example.com",
+						"the-zone",
+						models.NewCellCapacity(128, 1024, 6),
+						[]string{},
+						[]string{},
+					)
+					consulHelper.RegisterCell(cellPresence)
+				})
+
+				It("cancels the task on the owning cell", func() {
+					err := etcdDB.CancelTask(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(cellClient.CancelTaskCallCount()).To(Equal(1))
+					addr, cancelledGuid := cellClient.CancelTaskArgsForCall(0)
+					Expect(addr).To(Equal(cellPresence.RepAddress))
+					Expect(cancelledGuid).To(Equal(taskGuid))
+				})
+			})
+
+			Context("when the task is already completed", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Completed
+				})
+
+				It("returns an ErrTaskCannotBeCancelled", func() {
+					err := etcdDB.CancelTask(logger, taskGuid)
+					Expect(err).To(Equal(models.ErrTaskCannotBeCancelled))
+				})
+			})
+		})
+
+		Describe("FailTask", func() {
+			Context("when the task is pending", func() {
+				It("completes the task with the failure reason", func() {
+					err := etcdDB.FailTask(logger, taskGuid, "just-because")
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted := fetchTask()
+					Expect(persisted.State).To(Equal(models.Task_Completed))
+					Expect(persisted.Failed).To(BeTrue())
+					Expect(persisted.FailureReason).To(Equal("just-because"))
+				})
+
+				Context("when the task has a completion callback url", func() {
+					BeforeEach(func() {
+						task.CompletionCallbackUrl = "http://example.com/callback"
+					})
+
+					It("submits the failed task for the callback", func() {
+						err := etcdDB.FailTask(logger, taskGuid, "just-because")
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeTaskCompletionClient.SubmitCallCount()).To(Equal(1))
+						_, submittedTask := fakeTaskCompletionClient.SubmitArgsForCall(0)
+						Expect(submittedTask.TaskGuid).To(Equal(taskGuid))
+						Expect(submittedTask.Failed).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when the task is resolving", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Resolving
+				})
+
+				It("returns an ErrTaskCannotBeFailed", func() {
+					err := etcdDB.FailTask(logger, taskGuid, "just-because")
+					Expect(err).To(Equal(models.ErrTaskCannotBeFailed))
+				})
+			})
+		})
+
+		Describe("CompleteTask", func() {
+			Context("when the task is running on the cell", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Running
+					task.CellId = "cell-id"
+				})
+
+				It("completes the task with the result", func() {
+					err := etcdDB.CompleteTask(logger, taskGuid, "cell-id", false, "", "the-result")
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted := fetchTask()
+					Expect(persisted.State).To(Equal(models.Task_Completed))
+					Expect(persisted.Failed).To(BeFalse())
+					Expect(persisted.Result).To(Equal("the-result"))
+					Expect(persisted.UpdatedAt).To(Equal(clock.Now().UnixNano()))
+					Expect(persisted.FirstCompletedAt).To(Equal(clock.Now().UnixNano()))
+				})
+
+				It("does not submit the task for completion callbacks", func() {
+					err := etcdDB.CompleteTask(logger, taskGuid, "cell-id", false, "", "the-result")
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTaskCompletionClient.SubmitCallCount()).To(Equal(0))
+				})
+
+				Context("when the task has a completion callback url", func() {
+					BeforeEach(func() {
+						task.CompletionCallbackUrl = "http://example.com/callback"
+					})
+
+					It("submits the completed task for the callback", func() {
+						err := etcdDB.CompleteTask(logger, taskGuid, "cell-id", false, "", "the-result")
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeTaskCompletionClient.SubmitCallCount()).To(Equal(1))
+						submittedDB, submittedTask := fakeTaskCompletionClient.SubmitArgsForCall(0)
+						resolved, err := submittedDB.TaskByGuid(logger, taskGuid)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(resolved.State).To(Equal(models.Task_Completed))
+						Expect(submittedTask.TaskGuid).To(Equal(taskGuid))
+						Expect(submittedTask.State).To(Equal(models.Task_Completed))
+						Expect(submittedTask.Result).To(Equal("the-result"))
+					})
+				})
+
+				Context("when completing from a different cell", func() {
+					It("returns an ErrTaskRunningOnDifferentCell", func() {
+						err := etcdDB.CompleteTask(logger, taskGuid, "other-cell-id", false, "", "the-result")
+						Expect(err).To(Equal(models.ErrTaskRunningOnDifferentCell))
+					})
+				})
+			})
+
+			Context("when the task is pending", func() {
+				It("returns an ErrTaskCannotBeCompleted", func() {
+					err := etcdDB.CompleteTask(logger, taskGuid, "cell-id", false, "", "the-result")
+					Expect(err).To(Equal(models.ErrTaskCannotBeCompleted))
+				})
+			})
+		})
+
+		Describe("ResolvingTask", func() {
+			Context("when the task is completed", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Completed
+				})
+
+				It("transitions the task to resolving", func() {
+					err := etcdDB.ResolvingTask(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted := fetchTask()
+					Expect(persisted.State).To(Equal(models.Task_Resolving))
+					Expect(persisted.UpdatedAt).To(Equal(clock.Now().UnixNano()))
+				})
+			})
+
+			Context("when the task is not completed", func() {
+				It("returns an ErrTaskCannotBeMarkedAsResolving", func() {
+					err := etcdDB.ResolvingTask(logger, taskGuid)
+					Expect(err).To(Equal(models.ErrTaskCannotBeMarkedAsResolving))
+				})
+			})
+		})
+
+		Describe("ResolveTask", func() {
+			Context("when the task is resolving", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Resolving
+				})
+
+				It("deletes the task", func() {
+					err := etcdDB.ResolveTask(logger, taskGuid)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = etcdDB.TaskByGuid(logger, taskGuid)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+
+			Context("when the task is not resolving", func() {
+				BeforeEach(func() {
+					task.State = models.Task_Completed
+				})
+
+				It("returns an ErrTaskCannotBeResolved", func() {
+					err := etcdDB.ResolveTask(logger, taskGuid)
+					Expect(err).To(Equal(models.ErrTaskCannotBeResolved))
+				})
+			})
+		})
+	})
 })
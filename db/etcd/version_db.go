@@ -0,0 +1,89 @@
+package etcd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// VersionKey records the schema version of the stored data. It lives outside
+// DataSchemaRoot, which migrations may move.
+const VersionKey = "/version"
+
+// MigrationLockKey holds the owner of the migration lock while a BBS
+// migrates.
+const MigrationLockKey = "/migration-lock"
+
+func (db *ETCDDB) Version(logger lager.Logger) (int64, *models.Error) {
+	logger = logger.Session("version")
+
+	node, bbsErr := db.fetchRaw(logger, VersionKey)
+	if bbsErr != nil {
+		return 0, bbsErr
+	}
+
+	version, err := strconv.ParseInt(node.Value, 10, 64)
+	if err != nil {
+		logger.Error("failed-to-parse-version", err, lager.Data{"value": node.Value})
+		return 0, models.ErrDeserializeJSON
+	}
+
+	return version, nil
+}
+
+func (db *ETCDDB) SetVersion(logger lager.Logger, prevVersion, version int64) *models.Error {
+	logger = logger.Session("set-version", lager.Data{"prev-version": prevVersion, "version": version})
+
+	value := strconv.FormatInt(version, 10)
+	_, err := db.client.CompareAndSwap(VersionKey, value, 0, strconv.FormatInt(prevVersion, 10), 0)
+	if etcdErrCode(err) == ETCDErrKeyNotFound && prevVersion == 0 {
+		_, err = db.client.Create(VersionKey, value, 0)
+		if etcdErrCode(err) == ETCDErrKeyExists {
+			logger.Info("version-set-concurrently")
+			return models.ErrResourceConflict
+		}
+	}
+	if err != nil {
+		return storeError(logger, err)
+	}
+
+	return nil
+}
+
+func (db *ETCDDB) LockMigrations(logger lager.Logger, owner string, ttl time.Duration) *models.Error {
+	logger = logger.Session("lock-migrations", lager.Data{"owner": owner})
+
+	ttlInSeconds := uint64(ttl.Seconds())
+	_, err := db.client.Create(MigrationLockKey, owner, ttlInSeconds)
+	if etcdErrCode(err) == ETCDErrKeyExists {
+		_, err = db.client.CompareAndSwap(MigrationLockKey, owner, ttlInSeconds, owner, 0)
+		switch etcdErrCode(err) {
+		case ETCDErrCompareFailed, ETCDErrKeyNotFound:
+			logger.Info("held-by-another-owner")
+			return models.ErrResourceExists
+		}
+	}
+	if err != nil {
+		return storeError(logger, err)
+	}
+
+	return nil
+}
+
+func (db *ETCDDB) UnlockMigrations(logger lager.Logger, owner string) *models.Error {
+	logger = logger.Session("unlock-migrations", lager.Data{"owner": owner})
+
+	_, err := db.client.CompareAndDelete(MigrationLockKey, owner, 0)
+	switch etcdErrCode(err) {
+	case ETCDErrCompareFailed, ETCDErrKeyNotFound:
+		logger.Info("not-held")
+		return nil
+	}
+	if err != nil {
+		return storeError(logger, err)
+	}
+
+	return nil
+}
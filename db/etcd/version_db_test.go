@@ -0,0 +1,126 @@
+package etcd_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/db/etcd"
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionDB", func() {
+	var versionDB db.VersionDB
+
+	BeforeEach(func() {
+		versionDB = etcdDB.(db.VersionDB)
+	})
+
+	Describe("Version", func() {
+		It("returns ResourceNotFound when no version has been recorded", func() {
+			_, err := versionDB.Version(logger)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("returns the version last set", func() {
+			Expect(versionDB.SetVersion(logger, 0, 3)).NotTo(HaveOccurred())
+
+			version, err := versionDB.Version(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(BeEquivalentTo(3))
+		})
+
+		Context("when the stored version is malformed", func() {
+			BeforeEach(func() {
+				_, err := etcdClient.Set(etcd.VersionKey, "not-a-number", 0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("errors", func() {
+				_, err := versionDB.Version(logger)
+				Expect(err).To(Equal(models.ErrDeserializeJSON))
+			})
+		})
+	})
+
+	Describe("SetVersion", func() {
+		It("records the version when none has been recorded", func() {
+			Expect(versionDB.SetVersion(logger, 0, 1)).NotTo(HaveOccurred())
+
+			version, err := versionDB.Version(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(BeEquivalentTo(1))
+		})
+
+		It("replaces the previous version", func() {
+			Expect(versionDB.SetVersion(logger, 0, 1)).NotTo(HaveOccurred())
+			Expect(versionDB.SetVersion(logger, 1, 2)).NotTo(HaveOccurred())
+
+			version, err := versionDB.Version(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(BeEquivalentTo(2))
+		})
+
+		Context("when the stored version is not the previous version", func() {
+			BeforeEach(func() {
+				Expect(versionDB.SetVersion(logger, 0, 2)).NotTo(HaveOccurred())
+			})
+
+			It("returns a conflict and leaves the version alone", func() {
+				Expect(versionDB.SetVersion(logger, 1, 3)).To(Equal(models.ErrResourceConflict))
+
+				version, err := versionDB.Version(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(version).To(BeEquivalentTo(2))
+			})
+		})
+
+		Context("when another writer recorded a version first", func() {
+			BeforeEach(func() {
+				_, err := etcdClient.Create(etcd.VersionKey, "1", 0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a conflict", func() {
+				Expect(versionDB.SetVersion(logger, 0, 1)).To(Equal(models.ErrResourceConflict))
+			})
+		})
+	})
+
+	Describe("LockMigrations", func() {
+		It("takes the lock when no one holds it", func() {
+			Expect(versionDB.LockMigrations(logger, "owner-1", time.Minute)).NotTo(HaveOccurred())
+
+			response, err := etcdClient.Get(etcd.MigrationLockKey, false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Node.Value).To(Equal("owner-1"))
+			Expect(response.Node.TTL).To(BeNumerically(">", 0))
+		})
+
+		It("renews the lock for its owner", func() {
+			Expect(versionDB.LockMigrations(logger, "owner-1", time.Minute)).NotTo(HaveOccurred())
+			Expect(versionDB.LockMigrations(logger, "owner-1", time.Minute)).NotTo(HaveOccurred())
+		})
+
+		It("fails with ResourceExists while another owner holds the lock", func() {
+			Expect(versionDB.LockMigrations(logger, "owner-1", time.Minute)).NotTo(HaveOccurred())
+			Expect(versionDB.LockMigrations(logger, "owner-2", time.Minute)).To(Equal(models.ErrResourceExists))
+		})
+	})
+
+	Describe("UnlockMigrations", func() {
+		It("lets another owner take the lock", func() {
+			Expect(versionDB.LockMigrations(logger, "owner-1", time.Minute)).NotTo(HaveOccurred())
+			Expect(versionDB.UnlockMigrations(logger, "owner-1")).NotTo(HaveOccurred())
+			Expect(versionDB.LockMigrations(logger, "owner-2", time.Minute)).NotTo(HaveOccurred())
+		})
+
+		It("leaves a lock held by another owner alone", func() {
+			Expect(versionDB.LockMigrations(logger, "owner-1", time.Minute)).NotTo(HaveOccurred())
+			Expect(versionDB.UnlockMigrations(logger, "owner-2")).NotTo(HaveOccurred())
+			Expect(versionDB.LockMigrations(logger, "owner-2", time.Minute)).To(Equal(models.ErrResourceExists))
+		})
+	})
+})
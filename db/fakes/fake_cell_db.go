@@ -10,6 +10,15 @@ import (
 )
 
 type FakeCellDB struct {
+	CellsStub        func(logger lager.Logger) ([]*models.CellPresence, *models.Error)
+	cellsMutex       sync.RWMutex
+	cellsArgsForCall []struct {
+		logger lager.Logger
+	}
+	cellsReturns struct {
+		result1 []*models.CellPresence
+		result2 *models.Error
+	}
 	CellByIdStub        func(logger lager.Logger, cellId string) (*models.CellPresence, *models.Error)
 	cellByIdMutex       sync.RWMutex
 	cellByIdArgsForCall []struct {
@@ -22,6 +31,39 @@ type FakeCellDB struct {
 	}
 }
 
+func (fake *FakeCellDB) Cells(logger lager.Logger) ([]*models.CellPresence, *models.Error) {
+	fake.cellsMutex.Lock()
+	fake.cellsArgsForCall = append(fake.cellsArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.cellsMutex.Unlock()
+	if fake.CellsStub != nil {
+		return fake.CellsStub(logger)
+	} else {
+		return fake.cellsReturns.result1, fake.cellsReturns.result2
+	}
+}
+
+func (fake *FakeCellDB) CellsCallCount() int {
+	fake.cellsMutex.RLock()
+	defer fake.cellsMutex.RUnlock()
+	return len(fake.cellsArgsForCall)
+}
+
+func (fake *FakeCellDB) CellsArgsForCall(i int) lager.Logger {
+	fake.cellsMutex.RLock()
+	defer fake.cellsMutex.RUnlock()
+	return fake.cellsArgsForCall[i].logger
+}
+
+func (fake *FakeCellDB) CellsReturns(result1 []*models.CellPresence, result2 *models.Error) {
+	fake.CellsStub = nil
+	fake.cellsReturns = struct {
+		result1 []*models.CellPresence
+		result2 *models.Error
+	}{result1, result2}
+}
+
 func (fake *FakeCellDB) CellById(logger lager.Logger, cellId string) (*models.CellPresence, *models.Error) {
 	fake.cellByIdMutex.Lock()
 	fake.cellByIdArgsForCall = append(fake.cellByIdArgsForCall, struct {
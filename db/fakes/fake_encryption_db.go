@@ -0,0 +1,139 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeEncryptionDB struct {
+	EncryptionKeyLabelStub        func(logger lager.Logger) (string, *models.Error)
+	encryptionKeyLabelMutex       sync.RWMutex
+	encryptionKeyLabelArgsForCall []struct {
+		logger lager.Logger
+	}
+	encryptionKeyLabelReturns struct {
+		result1 string
+		result2 *models.Error
+	}
+	SetEncryptionKeyLabelStub        func(logger lager.Logger, label string) *models.Error
+	setEncryptionKeyLabelMutex       sync.RWMutex
+	setEncryptionKeyLabelArgsForCall []struct {
+		logger lager.Logger
+		label  string
+	}
+	setEncryptionKeyLabelReturns struct {
+		result1 *models.Error
+	}
+	PerformEncryptionStub        func(logger lager.Logger) *models.Error
+	performEncryptionMutex       sync.RWMutex
+	performEncryptionArgsForCall []struct {
+		logger lager.Logger
+	}
+	performEncryptionReturns struct {
+		result1 *models.Error
+	}
+}
+
+func (fake *FakeEncryptionDB) EncryptionKeyLabel(logger lager.Logger) (string, *models.Error) {
+	fake.encryptionKeyLabelMutex.Lock()
+	fake.encryptionKeyLabelArgsForCall = append(fake.encryptionKeyLabelArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.encryptionKeyLabelMutex.Unlock()
+	if fake.EncryptionKeyLabelStub != nil {
+		return fake.EncryptionKeyLabelStub(logger)
+	} else {
+		return fake.encryptionKeyLabelReturns.result1, fake.encryptionKeyLabelReturns.result2
+	}
+}
+
+func (fake *FakeEncryptionDB) EncryptionKeyLabelCallCount() int {
+	fake.encryptionKeyLabelMutex.RLock()
+	defer fake.encryptionKeyLabelMutex.RUnlock()
+	return len(fake.encryptionKeyLabelArgsForCall)
+}
+
+func (fake *FakeEncryptionDB) EncryptionKeyLabelArgsForCall(i int) lager.Logger {
+	fake.encryptionKeyLabelMutex.RLock()
+	defer fake.encryptionKeyLabelMutex.RUnlock()
+	return fake.encryptionKeyLabelArgsForCall[i].logger
+}
+
+func (fake *FakeEncryptionDB) EncryptionKeyLabelReturns(result1 string, result2 *models.Error) {
+	fake.EncryptionKeyLabelStub = nil
+	fake.encryptionKeyLabelReturns = struct {
+		result1 string
+		result2 *models.Error
+	}{result1, result2}
+}
+
+func (fake *FakeEncryptionDB) SetEncryptionKeyLabel(logger lager.Logger, label string) *models.Error {
+	fake.setEncryptionKeyLabelMutex.Lock()
+	fake.setEncryptionKeyLabelArgsForCall = append(fake.setEncryptionKeyLabelArgsForCall, struct {
+		logger lager.Logger
+		label  string
+	}{logger, label})
+	fake.setEncryptionKeyLabelMutex.Unlock()
+	if fake.SetEncryptionKeyLabelStub != nil {
+		return fake.SetEncryptionKeyLabelStub(logger, label)
+	} else {
+		return fake.setEncryptionKeyLabelReturns.result1
+	}
+}
+
+func (fake *FakeEncryptionDB) SetEncryptionKeyLabelCallCount() int {
+	fake.setEncryptionKeyLabelMutex.RLock()
+	defer fake.setEncryptionKeyLabelMutex.RUnlock()
+	return len(fake.setEncryptionKeyLabelArgsForCall)
+}
+
+func (fake *FakeEncryptionDB) SetEncryptionKeyLabelArgsForCall(i int) (lager.Logger, string) {
+	fake.setEncryptionKeyLabelMutex.RLock()
+	defer fake.setEncryptionKeyLabelMutex.RUnlock()
+	return fake.setEncryptionKeyLabelArgsForCall[i].logger, fake.setEncryptionKeyLabelArgsForCall[i].label
+}
+
+func (fake *FakeEncryptionDB) SetEncryptionKeyLabelReturns(result1 *models.Error) {
+	fake.SetEncryptionKeyLabelStub = nil
+	fake.setEncryptionKeyLabelReturns = struct {
+		result1 *models.Error
+	}{result1}
+}
+
+func (fake *FakeEncryptionDB) PerformEncryption(logger lager.Logger) *models.Error {
+	fake.performEncryptionMutex.Lock()
+	fake.performEncryptionArgsForCall = append(fake.performEncryptionArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.performEncryptionMutex.Unlock()
+	if fake.PerformEncryptionStub != nil {
+		return fake.PerformEncryptionStub(logger)
+	} else {
+		return fake.performEncryptionReturns.result1
+	}
+}
+
+func (fake *FakeEncryptionDB) PerformEncryptionCallCount() int {
+	fake.performEncryptionMutex.RLock()
+	defer fake.performEncryptionMutex.RUnlock()
+	return len(fake.performEncryptionArgsForCall)
+}
+
+func (fake *FakeEncryptionDB) PerformEncryptionArgsForCall(i int) lager.Logger {
+	fake.performEncryptionMutex.RLock()
+	defer fake.performEncryptionMutex.RUnlock()
+	return fake.performEncryptionArgsForCall[i].logger
+}
+
+func (fake *FakeEncryptionDB) PerformEncryptionReturns(result1 *models.Error) {
+	fake.PerformEncryptionStub = nil
+	fake.performEncryptionReturns = struct {
+		result1 *models.Error
+	}{result1}
+}
+
+var _ db.EncryptionDB = new(FakeEncryptionDB)
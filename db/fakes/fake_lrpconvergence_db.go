@@ -0,0 +1,55 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeLRPConvergenceDB struct {
+	ConvergeLRPsStub        func(logger lager.Logger) models.LRPConvergenceSummary
+	convergeLRPsMutex       sync.RWMutex
+	convergeLRPsArgsForCall []struct {
+		logger lager.Logger
+	}
+	convergeLRPsReturns struct {
+		result1 models.LRPConvergenceSummary
+	}
+}
+
+func (fake *FakeLRPConvergenceDB) ConvergeLRPs(logger lager.Logger) models.LRPConvergenceSummary {
+	fake.convergeLRPsMutex.Lock()
+	fake.convergeLRPsArgsForCall = append(fake.convergeLRPsArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.convergeLRPsMutex.Unlock()
+	if fake.ConvergeLRPsStub != nil {
+		return fake.ConvergeLRPsStub(logger)
+	} else {
+		return fake.convergeLRPsReturns.result1
+	}
+}
+
+func (fake *FakeLRPConvergenceDB) ConvergeLRPsCallCount() int {
+	fake.convergeLRPsMutex.RLock()
+	defer fake.convergeLRPsMutex.RUnlock()
+	return len(fake.convergeLRPsArgsForCall)
+}
+
+func (fake *FakeLRPConvergenceDB) ConvergeLRPsArgsForCall(i int) lager.Logger {
+	fake.convergeLRPsMutex.RLock()
+	defer fake.convergeLRPsMutex.RUnlock()
+	return fake.convergeLRPsArgsForCall[i].logger
+}
+
+func (fake *FakeLRPConvergenceDB) ConvergeLRPsReturns(result1 models.LRPConvergenceSummary) {
+	fake.ConvergeLRPsStub = nil
+	fake.convergeLRPsReturns = struct {
+		result1 models.LRPConvergenceSummary
+	}{result1}
+}
+
+var _ db.LRPConvergenceDB = new(FakeLRPConvergenceDB)
@@ -0,0 +1,62 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeTaskConvergenceDB struct {
+	ConvergeTasksStub        func(logger lager.Logger, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) models.TaskConvergenceSummary
+	convergeTasksMutex       sync.RWMutex
+	convergeTasksArgsForCall []struct {
+		logger                      lager.Logger
+		kickTaskDuration            time.Duration
+		expirePendingTaskDuration   time.Duration
+		expireCompletedTaskDuration time.Duration
+	}
+	convergeTasksReturns struct {
+		result1 models.TaskConvergenceSummary
+	}
+}
+
+func (fake *FakeTaskConvergenceDB) ConvergeTasks(logger lager.Logger, kickTaskDuration time.Duration, expirePendingTaskDuration time.Duration, expireCompletedTaskDuration time.Duration) models.TaskConvergenceSummary {
+	fake.convergeTasksMutex.Lock()
+	fake.convergeTasksArgsForCall = append(fake.convergeTasksArgsForCall, struct {
+		logger                      lager.Logger
+		kickTaskDuration            time.Duration
+		expirePendingTaskDuration   time.Duration
+		expireCompletedTaskDuration time.Duration
+	}{logger, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration})
+	fake.convergeTasksMutex.Unlock()
+	if fake.ConvergeTasksStub != nil {
+		return fake.ConvergeTasksStub(logger, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+	} else {
+		return fake.convergeTasksReturns.result1
+	}
+}
+
+func (fake *FakeTaskConvergenceDB) ConvergeTasksCallCount() int {
+	fake.convergeTasksMutex.RLock()
+	defer fake.convergeTasksMutex.RUnlock()
+	return len(fake.convergeTasksArgsForCall)
+}
+
+func (fake *FakeTaskConvergenceDB) ConvergeTasksArgsForCall(i int) (lager.Logger, time.Duration, time.Duration, time.Duration) {
+	fake.convergeTasksMutex.RLock()
+	defer fake.convergeTasksMutex.RUnlock()
+	return fake.convergeTasksArgsForCall[i].logger, fake.convergeTasksArgsForCall[i].kickTaskDuration, fake.convergeTasksArgsForCall[i].expirePendingTaskDuration, fake.convergeTasksArgsForCall[i].expireCompletedTaskDuration
+}
+
+func (fake *FakeTaskConvergenceDB) ConvergeTasksReturns(result1 models.TaskConvergenceSummary) {
+	fake.ConvergeTasksStub = nil
+	fake.convergeTasksReturns = struct {
+		result1 models.TaskConvergenceSummary
+	}{result1}
+}
+
+var _ db.TaskConvergenceDB = new(FakeTaskConvergenceDB)
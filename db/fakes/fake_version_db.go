@@ -0,0 +1,188 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeVersionDB struct {
+	VersionStub        func(logger lager.Logger) (int64, *models.Error)
+	versionMutex       sync.RWMutex
+	versionArgsForCall []struct {
+		logger lager.Logger
+	}
+	versionReturns struct {
+		result1 int64
+		result2 *models.Error
+	}
+	SetVersionStub        func(logger lager.Logger, prevVersion, version int64) *models.Error
+	setVersionMutex       sync.RWMutex
+	setVersionArgsForCall []struct {
+		logger      lager.Logger
+		prevVersion int64
+		version     int64
+	}
+	setVersionReturns struct {
+		result1 *models.Error
+	}
+	LockMigrationsStub        func(logger lager.Logger, owner string, ttl time.Duration) *models.Error
+	lockMigrationsMutex       sync.RWMutex
+	lockMigrationsArgsForCall []struct {
+		logger lager.Logger
+		owner  string
+		ttl    time.Duration
+	}
+	lockMigrationsReturns struct {
+		result1 *models.Error
+	}
+	UnlockMigrationsStub        func(logger lager.Logger, owner string) *models.Error
+	unlockMigrationsMutex       sync.RWMutex
+	unlockMigrationsArgsForCall []struct {
+		logger lager.Logger
+		owner  string
+	}
+	unlockMigrationsReturns struct {
+		result1 *models.Error
+	}
+}
+
+func (fake *FakeVersionDB) Version(logger lager.Logger) (int64, *models.Error) {
+	fake.versionMutex.Lock()
+	fake.versionArgsForCall = append(fake.versionArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.versionMutex.Unlock()
+	if fake.VersionStub != nil {
+		return fake.VersionStub(logger)
+	} else {
+		return fake.versionReturns.result1, fake.versionReturns.result2
+	}
+}
+
+func (fake *FakeVersionDB) VersionCallCount() int {
+	fake.versionMutex.RLock()
+	defer fake.versionMutex.RUnlock()
+	return len(fake.versionArgsForCall)
+}
+
+func (fake *FakeVersionDB) VersionArgsForCall(i int) lager.Logger {
+	fake.versionMutex.RLock()
+	defer fake.versionMutex.RUnlock()
+	return fake.versionArgsForCall[i].logger
+}
+
+func (fake *FakeVersionDB) VersionReturns(result1 int64, result2 *models.Error) {
+	fake.VersionStub = nil
+	fake.versionReturns = struct {
+		result1 int64
+		result2 *models.Error
+	}{result1, result2}
+}
+
+func (fake *FakeVersionDB) SetVersion(logger lager.Logger, prevVersion int64, version int64) *models.Error {
+	fake.setVersionMutex.Lock()
+	fake.setVersionArgsForCall = append(fake.setVersionArgsForCall, struct {
+		logger      lager.Logger
+		prevVersion int64
+		version     int64
+	}{logger, prevVersion, version})
+	fake.setVersionMutex.Unlock()
+	if fake.SetVersionStub != nil {
+		return fake.SetVersionStub(logger, prevVersion, version)
+	} else {
+		return fake.setVersionReturns.result1
+	}
+}
+
+func (fake *FakeVersionDB) SetVersionCallCount() int {
+	fake.setVersionMutex.RLock()
+	defer fake.setVersionMutex.RUnlock()
+	return len(fake.setVersionArgsForCall)
+}
+
+func (fake *FakeVersionDB) SetVersionArgsForCall(i int) (lager.Logger, int64, int64) {
+	fake.setVersionMutex.RLock()
+	defer fake.setVersionMutex.RUnlock()
+	return fake.setVersionArgsForCall[i].logger, fake.setVersionArgsForCall[i].prevVersion, fake.setVersionArgsForCall[i].version
+}
+
+func (fake *FakeVersionDB) SetVersionReturns(result1 *models.Error) {
+	fake.SetVersionStub = nil
+	fake.setVersionReturns = struct {
+		result1 *models.Error
+	}{result1}
+}
+
+func (fake *FakeVersionDB) LockMigrations(logger lager.Logger, owner string, ttl time.Duration) *models.Error {
+	fake.lockMigrationsMutex.Lock()
+	fake.lockMigrationsArgsForCall = append(fake.lockMigrationsArgsForCall, struct {
+		logger lager.Logger
+		owner  string
+		ttl    time.Duration
+	}{logger, owner, ttl})
+	fake.lockMigrationsMutex.Unlock()
+	if fake.LockMigrationsStub != nil {
+		return fake.LockMigrationsStub(logger, owner, ttl)
+	} else {
+		return fake.lockMigrationsReturns.result1
+	}
+}
+
+func (fake *FakeVersionDB) LockMigrationsCallCount() int {
+	fake.lockMigrationsMutex.RLock()
+	defer fake.lockMigrationsMutex.RUnlock()
+	return len(fake.lockMigrationsArgsForCall)
+}
+
+func (fake *FakeVersionDB) LockMigrationsArgsForCall(i int) (lager.Logger, string, time.Duration) {
+	fake.lockMigrationsMutex.RLock()
+	defer fake.lockMigrationsMutex.RUnlock()
+	return fake.lockMigrationsArgsForCall[i].logger, fake.lockMigrationsArgsForCall[i].owner, fake.lockMigrationsArgsForCall[i].ttl
+}
+
+func (fake *FakeVersionDB) LockMigrationsReturns(result1 *models.Error) {
+	fake.LockMigrationsStub = nil
+	fake.lockMigrationsReturns = struct {
+		result1 *models.Error
+	}{result1}
+}
+
+func (fake *FakeVersionDB) UnlockMigrations(logger lager.Logger, owner string) *models.Error {
+	fake.unlockMigrationsMutex.Lock()
+	fake.unlockMigrationsArgsForCall = append(fake.unlockMigrationsArgsForCall, struct {
+		logger lager.Logger
+		owner  string
+	}{logger, owner})
+	fake.unlockMigrationsMutex.Unlock()
+	if fake.UnlockMigrationsStub != nil {
+		return fake.UnlockMigrationsStub(logger, owner)
+	} else {
+		return fake.unlockMigrationsReturns.result1
+	}
+}
+
+func (fake *FakeVersionDB) UnlockMigrationsCallCount() int {
+	fake.unlockMigrationsMutex.RLock()
+	defer fake.unlockMigrationsMutex.RUnlock()
+	return len(fake.unlockMigrationsArgsForCall)
+}
+
+func (fake *FakeVersionDB) UnlockMigrationsArgsForCall(i int) (lager.Logger, string) {
+	fake.unlockMigrationsMutex.RLock()
+	defer fake.unlockMigrationsMutex.RUnlock()
+	return fake.unlockMigrationsArgsForCall[i].logger, fake.unlockMigrationsArgsForCall[i].owner
+}
+
+func (fake *FakeVersionDB) UnlockMigrationsReturns(result1 *models.Error) {
+	fake.UnlockMigrationsStub = nil
+	fake.unlockMigrationsReturns = struct {
+		result1 *models.Error
+	}{result1}
+}
+
+var _ db.VersionDB = new(FakeVersionDB)
@@ -0,0 +1,359 @@
+package db_suites
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func ActualLRPDBSuite(b *Backend) bool {
+	return Describe("ActualLRPDB", func() {
+		const (
+			processGuid = "some-process-guid"
+			domain      = "some-domain"
+			cellID      = "cell-id"
+		)
+
+		var (
+			instanceKey      models.ActualLRPInstanceKey
+			otherInstanceKey models.ActualLRPInstanceKey
+			netInfo          models.ActualLRPNetInfo
+		)
+
+		BeforeEach(func() {
+			instanceKey = models.NewActualLRPInstanceKey("instance-guid", cellID)
+			otherInstanceKey = models.NewActualLRPInstanceKey("other-instance-guid", "other-cell-id")
+			netInfo = models.NewActualLRPNetInfo("127.0.0.1", models.NewPortMapping(8080, 80))
+
+			desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+			desiredLRP.Domain = domain
+			desiredLRP.Instances = 2
+			Expect(b.DB.DesireLRP(b.Logger, desiredLRP)).To(Succeed())
+		})
+
+		claim := func(index int32, key models.ActualLRPInstanceKey) (*models.ActualLRP, *models.Error) {
+			return b.DB.ClaimActualLRP(b.Logger, &models.ClaimActualLRPRequest{
+				ProcessGuid:          processGuid,
+				Index:                index,
+				ActualLrpInstanceKey: &key,
+			})
+		}
+
+		Describe("ActualLRPGroups", func() {
+			BeforeEach(func() {
+				otherLRP := model_helpers.NewValidDesiredLRP("other-process-guid")
+				otherLRP.Domain = "other-domain"
+				Expect(b.DB.DesireLRP(b.Logger, otherLRP)).To(Succeed())
+
+				_, err := claim(1, instanceKey)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns all the actual LRP groups", func() {
+				groups, err := b.DB.ActualLRPGroups(b.Logger, models.ActualLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups.ActualLrpGroups).To(HaveLen(3))
+			})
+
+			It("can filter by domain", func() {
+				groups, err := b.DB.ActualLRPGroups(b.Logger, models.ActualLRPFilter{Domain: "other-domain"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups.ActualLrpGroups).To(HaveLen(1))
+				Expect(groups.ActualLrpGroups[0].Instance.ProcessGuid).To(Equal("other-process-guid"))
+			})
+
+			It("can filter by cell id", func() {
+				groups, err := b.DB.ActualLRPGroups(b.Logger, models.ActualLRPFilter{CellID: cellID})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups.ActualLrpGroups).To(HaveLen(1))
+				Expect(groups.ActualLrpGroups[0].Instance.ActualLRPInstanceKey).To(Equal(instanceKey))
+			})
+		})
+
+		Describe("ActualLRPGroupByProcessGuidAndIndex", func() {
+			It("returns the group", func() {
+				group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(group.Instance.ActualLRPKey).To(Equal(models.NewActualLRPKey(processGuid, 1, domain)))
+				Expect(group.Evacuating).To(BeNil())
+			})
+
+			Context("when the index does not exist", func() {
+				It("returns a ResourceNotFound error", func() {
+					_, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 7)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+
+		Describe("ClaimActualLRP", func() {
+			Context("when the actual LRP is unclaimed", func() {
+				It("claims it and increments the modification tag", func() {
+					before, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 1)
+					Expect(err).NotTo(HaveOccurred())
+
+					claimed, err := claim(1, instanceKey)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(claimed.State).To(Equal(models.ActualLRPStateClaimed))
+					Expect(claimed.ActualLRPInstanceKey).To(Equal(instanceKey))
+					Expect(claimed.ModificationTag.Index).To(Equal(before.Instance.ModificationTag.Index + 1))
+
+					group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 1)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(group.Instance).To(Equal(claimed))
+				})
+			})
+
+			Context("when the actual LRP is claimed by another instance", func() {
+				BeforeEach(func() {
+					_, err := claim(1, otherInstanceKey)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an ActualLRPCannotBeClaimed error", func() {
+					_, err := claim(1, instanceKey)
+					Expect(err).To(Equal(models.ErrActualLRPCannotBeClaimed))
+				})
+			})
+
+			Context("when an expected modification tag does not match", func() {
+				It("returns a ResourceConflict error", func() {
+					_, err := b.DB.ClaimActualLRP(b.Logger, &models.ClaimActualLRPRequest{
+						ProcessGuid:             processGuid,
+						Index:                   1,
+						ActualLrpInstanceKey:    &instanceKey,
+						ExpectedModificationTag: &models.ModificationTag{Epoch: "some-other-epoch"},
+					})
+					Expect(err).To(Equal(models.ErrResourceConflict))
+				})
+			})
+
+			Context("when several cells claim the same actual LRP at once", func() {
+				It("lets exactly one of them win", func() {
+					var wg sync.WaitGroup
+					results := make(chan *models.Error, 5)
+					for i := 0; i < 5; i++ {
+						key := models.NewActualLRPInstanceKey("instance-guid", "cell-"+string('a'+rune(i)))
+						wg.Add(1)
+						go func() {
+							defer GinkgoRecover()
+							defer wg.Done()
+							_, err := claim(1, key)
+							results <- err
+						}()
+					}
+					wg.Wait()
+					close(results)
+
+					succeeded := 0
+					for err := range results {
+						if err == nil {
+							succeeded++
+						} else {
+							Expect(err).To(Equal(models.ErrActualLRPCannotBeClaimed))
+						}
+					}
+					Expect(succeeded).To(Equal(1))
+				})
+			})
+
+			Context("when the actual LRP does not exist", func() {
+				It("returns a ResourceNotFound error", func() {
+					_, err := claim(7, instanceKey)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+
+		Describe("StartActualLRP", func() {
+			var key models.ActualLRPKey
+
+			BeforeEach(func() {
+				key = models.NewActualLRPKey(processGuid, 1, domain)
+			})
+
+			Context("when the actual LRP is claimed", func() {
+				BeforeEach(func() {
+					_, err := claim(1, instanceKey)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("starts it", func() {
+					started, err := b.DB.StartActualLRP(b.Logger, &models.StartActualLRPRequest{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &instanceKey,
+						ActualLrpNetInfo:     &netInfo,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(started.State).To(Equal(models.ActualLRPStateRunning))
+					Expect(started.ActualLRPNetInfo).To(Equal(netInfo))
+				})
+
+				Context("when it is already running on another instance", func() {
+					BeforeEach(func() {
+						_, err := b.DB.StartActualLRP(b.Logger, &models.StartActualLRPRequest{
+							ActualLrpKey:         &key,
+							ActualLrpInstanceKey: &instanceKey,
+							ActualLrpNetInfo:     &netInfo,
+						})
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns an ActualLRPCannotBeStarted error", func() {
+						_, err := b.DB.StartActualLRP(b.Logger, &models.StartActualLRPRequest{
+							ActualLrpKey:         &key,
+							ActualLrpInstanceKey: &otherInstanceKey,
+							ActualLrpNetInfo:     &netInfo,
+						})
+						Expect(err).To(Equal(models.ErrActualLRPCannotBeStarted))
+					})
+				})
+			})
+
+			Context("when the actual LRP does not exist", func() {
+				BeforeEach(func() {
+					key = models.NewActualLRPKey(processGuid, 7, domain)
+				})
+
+				It("creates a running actual LRP", func() {
+					_, err := b.DB.StartActualLRP(b.Logger, &models.StartActualLRPRequest{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &instanceKey,
+						ActualLrpNetInfo:     &netInfo,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 7)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(group.Instance.State).To(Equal(models.ActualLRPStateRunning))
+					Expect(group.Instance.ModificationTag.Epoch).NotTo(BeEmpty())
+				})
+
+				Context("and an expected modification tag is given", func() {
+					It("returns a ResourceConflict error", func() {
+						_, err := b.DB.StartActualLRP(b.Logger, &models.StartActualLRPRequest{
+							ActualLrpKey:            &key,
+							ActualLrpInstanceKey:    &instanceKey,
+							ActualLrpNetInfo:        &netInfo,
+							ExpectedModificationTag: &models.ModificationTag{Epoch: "some-epoch"},
+						})
+						Expect(err).To(Equal(models.ErrResourceConflict))
+					})
+				})
+
+				Context("and the zero modification tag is expected", func() {
+					It("creates a running actual LRP", func() {
+						_, err := b.DB.StartActualLRP(b.Logger, &models.StartActualLRPRequest{
+							ActualLrpKey:            &key,
+							ActualLrpInstanceKey:    &instanceKey,
+							ActualLrpNetInfo:        &netInfo,
+							ExpectedModificationTag: &models.ModificationTag{},
+						})
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+			})
+		})
+
+		Describe("CrashActualLRP", func() {
+			var key models.ActualLRPKey
+
+			BeforeEach(func() {
+				key = models.NewActualLRPKey(processGuid, 1, domain)
+				_, err := claim(1, instanceKey)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("unclaims the actual LRP and requests an immediate restart", func() {
+				err := b.DB.CrashActualLRP(b.Logger, &models.CrashActualLRPRequest{
+					ActualLrpKey:         &key,
+					ActualLrpInstanceKey: &instanceKey,
+					ErrorMessage:         "some-error",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 1)
+				Expect(err).NotTo(HaveOccurred())
+				crashed := group.Instance
+				Expect(crashed.State).To(Equal(models.ActualLRPStateUnclaimed))
+				Expect(crashed.CrashCount).To(BeEquivalentTo(1))
+				Expect(crashed.CrashReason).To(Equal("some-error"))
+
+				Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(2))
+				requestedAuctions := b.AuctioneerClient.RequestLRPAuctionsArgsForCall(1)
+				Expect(requestedAuctions[0].Indices).To(ConsistOf(uint(1)))
+			})
+
+			Context("when the instance key does not match", func() {
+				It("returns an ActualLRPCannotBeCrashed error", func() {
+					err := b.DB.CrashActualLRP(b.Logger, &models.CrashActualLRPRequest{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &otherInstanceKey,
+					})
+					Expect(err).To(Equal(models.ErrActualLRPCannotBeCrashed))
+				})
+			})
+		})
+
+		Describe("FailActualLRP", func() {
+			var key models.ActualLRPKey
+
+			BeforeEach(func() {
+				key = models.NewActualLRPKey(processGuid, 1, domain)
+			})
+
+			It("records the placement error", func() {
+				err := b.DB.FailActualLRP(b.Logger, &models.FailActualLRPRequest{ActualLrpKey: &key, ErrorMessage: "no room"})
+				Expect(err).NotTo(HaveOccurred())
+
+				group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(group.Instance.PlacementError).To(Equal("no room"))
+			})
+
+			Context("when the actual LRP is claimed", func() {
+				BeforeEach(func() {
+					_, err := claim(1, instanceKey)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an ActualLRPCannotBeFailed error", func() {
+					err := b.DB.FailActualLRP(b.Logger, &models.FailActualLRPRequest{ActualLrpKey: &key, ErrorMessage: "no room"})
+					Expect(err).To(Equal(models.ErrActualLRPCannotBeFailed))
+				})
+			})
+		})
+
+		Describe("RetireActualLRP", func() {
+			It("removes an unclaimed actual LRP", func() {
+				key := models.NewActualLRPKey(processGuid, 1, domain)
+				err := b.DB.RetireActualLRP(b.Logger, &models.RetireActualLRPRequest{ActualLrpKey: &key})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 1)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Describe("RemoveActualLRP", func() {
+			It("removes the actual LRP", func() {
+				err := b.DB.RemoveActualLRP(b.Logger, processGuid, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, 0)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+
+			Context("when the actual LRP does not exist", func() {
+				It("returns a ResourceNotFound error", func() {
+					err := b.DB.RemoveActualLRP(b.Logger, processGuid, 7)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+// Package db_suites holds the ginkgo specs that every db.DB backend must pass.
+package db_suites
+
+import (
+	fakeauctioneer "github.com/cloudfoundry-incubator/bbs/auctionhandlers/fakes"
+	fakecellhandlers "github.com/cloudfoundry-incubator/bbs/cellhandlers/fakes"
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool/fakes"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
+)
+
+// Backend is filled in by each backend's suite before every spec runs.
+type Backend struct {
+	DB     db.DB
+	Logger lager.Logger
+	Clock  *fakeclock.FakeClock
+
+	AuctioneerClient     *fakeauctioneer.FakeClient
+	CellClient           *fakecellhandlers.FakeClient
+	TaskCompletionClient *fakes.FakeTaskCompletionClient
+
+	RegisterCell              func(cell models.CellPresence)
+	SetRawTask                func(task *models.Task)
+	SetRawTaskData            func(taskGuid string, data []byte)
+	SetRawDesiredLRP          func(lrp *models.DesiredLRP)
+	SetRawDesiredLRPData      func(processGuid string, data []byte)
+	SetRawActualLRP           func(lrp *models.ActualLRP)
+	SetRawEvacuatingActualLRP func(lrp *models.ActualLRP, ttlInSeconds uint64)
+}
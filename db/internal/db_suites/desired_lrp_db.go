@@ -0,0 +1,338 @@
+package db_suites
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func DesiredLRPDBSuite(b *Backend) bool {
+	return Describe("DesiredLRPDB", func() {
+		Describe("DesiredLRPs", func() {
+			var filter models.DesiredLRPFilter
+
+			BeforeEach(func() {
+				filter = models.DesiredLRPFilter{}
+			})
+
+			Context("when there are desired LRPs", func() {
+				var lrp1, lrp2 *models.DesiredLRP
+
+				BeforeEach(func() {
+					lrp1 = model_helpers.NewValidDesiredLRP("guid-1")
+					lrp1.Domain = "domain-1"
+					Expect(b.DB.DesireLRP(b.Logger, lrp1)).To(Succeed())
+
+					lrp2 = model_helpers.NewValidDesiredLRP("guid-2")
+					lrp2.Domain = "domain-2"
+					Expect(b.DB.DesireLRP(b.Logger, lrp2)).To(Succeed())
+				})
+
+				It("returns all the desired LRPs", func() {
+					desiredLRPs, err := b.DB.DesiredLRPs(b.Logger, filter)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(desiredLRPs.GetDesiredLrps()).To(ConsistOf(lrp1, lrp2))
+				})
+
+				It("can filter by domain", func() {
+					filter.Domain = "domain-2"
+					desiredLRPs, err := b.DB.DesiredLRPs(b.Logger, filter)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(desiredLRPs.GetDesiredLrps()).To(ConsistOf(lrp2))
+				})
+			})
+
+			Context("when there are no LRPs", func() {
+				It("returns an empty list", func() {
+					desiredLRPs, err := b.DB.DesiredLRPs(b.Logger, filter)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(desiredLRPs).NotTo(BeNil())
+					Expect(desiredLRPs.GetDesiredLrps()).To(BeEmpty())
+				})
+			})
+
+			Context("when there is invalid data", func() {
+				BeforeEach(func() {
+					b.SetRawDesiredLRPData("bad-guid", []byte("{{{{{"))
+				})
+
+				It("errors", func() {
+					_, err := b.DB.DesiredLRPs(b.Logger, filter)
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("DesiredLRPByProcessGuid", func() {
+			Context("when there is a desired lrp", func() {
+				var desiredLRP *models.DesiredLRP
+
+				BeforeEach(func() {
+					desiredLRP = model_helpers.NewValidDesiredLRP("process-guid")
+					Expect(b.DB.DesireLRP(b.Logger, desiredLRP)).To(Succeed())
+				})
+
+				It("returns the desired lrp", func() {
+					lrp, err := b.DB.DesiredLRPByProcessGuid(b.Logger, "process-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lrp).To(Equal(desiredLRP))
+				})
+			})
+
+			Context("when there is no LRP", func() {
+				It("returns a ResourceNotFound", func() {
+					_, err := b.DB.DesiredLRPByProcessGuid(b.Logger, "nota-guid")
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+
+		Describe("DesireLRP", func() {
+			var lrp *models.DesiredLRP
+
+			BeforeEach(func() {
+				lrp = model_helpers.NewValidDesiredLRP("some-process-guid")
+				lrp.Instances = 5
+			})
+
+			Context("when the desired LRP does not yet exist", func() {
+				It("persists the desired LRP with a fresh modification tag", func() {
+					err := b.DB.DesireLRP(b.Logger, lrp)
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted, err := b.DB.DesiredLRPByProcessGuid(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(persisted.ModificationTag.Epoch).NotTo(BeEmpty())
+					Expect(persisted.ModificationTag.Index).To(BeEquivalentTo(0))
+					Expect(persisted).To(Equal(lrp))
+				})
+
+				It("creates an unclaimed actual LRP for each instance", func() {
+					err := b.DB.DesireLRP(b.Logger, lrp)
+					Expect(err).NotTo(HaveOccurred())
+
+					groups, err := b.DB.ActualLRPGroupsByProcessGuid(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(groups.ActualLrpGroups).To(HaveLen(5))
+
+					indices := []int32{}
+					for _, group := range groups.ActualLrpGroups {
+						Expect(group.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+						Expect(group.Instance.Domain).To(Equal(lrp.Domain))
+						Expect(group.Instance.Since).To(Equal(b.Clock.Now().UnixNano()))
+						indices = append(indices, group.Instance.Index)
+					}
+					Expect(indices).To(ConsistOf(int32(0), int32(1), int32(2), int32(3), int32(4)))
+				})
+
+				It("requests a single auction for all of the instances", func() {
+					err := b.DB.DesireLRP(b.Logger, lrp)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+					requestedAuctions := b.AuctioneerClient.RequestLRPAuctionsArgsForCall(0)
+					Expect(requestedAuctions).To(HaveLen(1))
+					Expect(requestedAuctions[0].DesiredLRP.ProcessGuid).To(Equal("some-process-guid"))
+					Expect(requestedAuctions[0].Indices).To(ConsistOf(uint(0), uint(1), uint(2), uint(3), uint(4)))
+				})
+			})
+
+			Context("when the desired LRP already exists", func() {
+				BeforeEach(func() {
+					Expect(b.DB.DesireLRP(b.Logger, model_helpers.NewValidDesiredLRP("some-process-guid"))).To(Succeed())
+					b.AuctioneerClient.RequestLRPAuctionsReturns(nil)
+				})
+
+				It("returns a ResourceExists error and does not request more auctions", func() {
+					err := b.DB.DesireLRP(b.Logger, lrp)
+					Expect(err).To(Equal(models.ErrResourceExists))
+					Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Describe("UpdateDesiredLRP", func() {
+			var (
+				desiredLRP *models.DesiredLRP
+				update     *models.DesiredLRPUpdate
+			)
+
+			BeforeEach(func() {
+				desiredLRP = model_helpers.NewValidDesiredLRP("some-process-guid")
+				desiredLRP.Instances = 2
+				Expect(b.DB.DesireLRP(b.Logger, desiredLRP)).To(Succeed())
+
+				update = &models.DesiredLRPUpdate{}
+			})
+
+			Context("when updating the annotation", func() {
+				BeforeEach(func() {
+					annotation := "new-annotation"
+					update.Annotation = &annotation
+				})
+
+				It("persists the update and increments the modification tag", func() {
+					err := b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted, err := b.DB.DesiredLRPByProcessGuid(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(persisted.Annotation).To(Equal("new-annotation"))
+					Expect(persisted.ModificationTag.Epoch).To(Equal(desiredLRP.ModificationTag.Epoch))
+					Expect(persisted.ModificationTag.Index).To(Equal(desiredLRP.ModificationTag.Index + 1))
+				})
+
+				Context("when an expected modification tag does not match the persisted tag", func() {
+					It("returns a ResourceConflict error and does not persist the update", func() {
+						err := b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{
+							ProcessGuid: "some-process-guid",
+							Update:      update,
+							ExpectedModificationTag: &models.ModificationTag{
+								Epoch: desiredLRP.ModificationTag.Epoch,
+								Index: desiredLRP.ModificationTag.Index + 1,
+							},
+						})
+						Expect(err).To(Equal(models.ErrResourceConflict))
+
+						persisted, getErr := b.DB.DesiredLRPByProcessGuid(b.Logger, "some-process-guid")
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(persisted.ModificationTag).To(Equal(desiredLRP.ModificationTag))
+					})
+				})
+			})
+
+			Context("when scaling up", func() {
+				BeforeEach(func() {
+					instances := int32(4)
+					update.Instances = &instances
+				})
+
+				It("creates and auctions the new indices", func() {
+					err := b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+					Expect(err).NotTo(HaveOccurred())
+
+					groups, err := b.DB.ActualLRPGroupsByProcessGuid(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(groups.ActualLrpGroups).To(HaveLen(4))
+
+					Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(2))
+					requestedAuctions := b.AuctioneerClient.RequestLRPAuctionsArgsForCall(1)
+					Expect(requestedAuctions).To(HaveLen(1))
+					Expect(requestedAuctions[0].Indices).To(ConsistOf(uint(2), uint(3)))
+				})
+			})
+
+			Context("when scaling down", func() {
+				BeforeEach(func() {
+					instances := int32(1)
+					update.Instances = &instances
+				})
+
+				It("retires the indices above the new instance count", func() {
+					err := b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+					Expect(err).NotTo(HaveOccurred())
+
+					groups, err := b.DB.ActualLRPGroupsByProcessGuid(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(groups.ActualLrpGroups).To(HaveLen(1))
+					Expect(groups.ActualLrpGroups[0].Instance.Index).To(BeEquivalentTo(0))
+				})
+			})
+
+			Context("when the desired LRP does not exist", func() {
+				It("returns a ResourceNotFound error", func() {
+					err := b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "bogus-guid", Update: update})
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+
+			Context("when the update is invalid", func() {
+				BeforeEach(func() {
+					instances := int32(-1)
+					update.Instances = &instances
+				})
+
+				It("returns an InvalidRecord error and does not persist the update", func() {
+					err := b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{ProcessGuid: "some-process-guid", Update: update})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Type).To(Equal(models.InvalidRecord))
+
+					persisted, getErr := b.DB.DesiredLRPByProcessGuid(b.Logger, "some-process-guid")
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(persisted.Instances).To(BeEquivalentTo(2))
+				})
+			})
+		})
+
+		Describe("RemoveDesiredLRP", func() {
+			Context("when the desired LRP exists", func() {
+				var (
+					claimedKey   models.ActualLRPKey
+					instanceKey  models.ActualLRPInstanceKey
+					cellPresence models.CellPresence
+				)
+
+				BeforeEach(func() {
+					desiredLRP := model_helpers.NewValidDesiredLRP("some-process-guid")
+					desiredLRP.Instances = 2
+					Expect(b.DB.DesireLRP(b.Logger, desiredLRP)).To(Succeed())
+
+					claimedKey = models.NewActualLRPKey("some-process-guid", 1, desiredLRP.Domain)
+					instanceKey = models.NewActualLRPInstanceKey("some-instance-guid", "cell-id")
+					_, err := b.DB.ClaimActualLRP(b.Logger, &models.ClaimActualLRPRequest{
+						ProcessGuid:          "some-process-guid",
+						Index:                1,
+						ActualLrpInstanceKey: &instanceKey,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					cellPresence = models.NewCellPresence(
+						"cell-id",
+						"cell.example.com",
+						"the-zone",
+						models.NewCellCapacity(128, 1024, 6),
+						[]string{},
+						[]string{},
+					)
+					b.RegisterCell(cellPresence)
+				})
+
+				It("removes the desired LRP", func() {
+					err := b.DB.RemoveDesiredLRP(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = b.DB.DesiredLRPByProcessGuid(b.Logger, "some-process-guid")
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+
+				It("removes the unclaimed actual LRPs", func() {
+					err := b.DB.RemoveDesiredLRP(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, "some-process-guid", 0)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+
+				It("stops the claimed actual LRPs on their cells", func() {
+					err := b.DB.RemoveDesiredLRP(b.Logger, "some-process-guid")
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(b.CellClient.StopLRPInstanceCallCount()).To(Equal(1))
+					addr, stoppedKey, stoppedInstanceKey := b.CellClient.StopLRPInstanceArgsForCall(0)
+					Expect(addr).To(Equal(cellPresence.RepAddress))
+					Expect(stoppedKey).To(Equal(claimedKey))
+					Expect(stoppedInstanceKey).To(Equal(instanceKey))
+				})
+			})
+
+			Context("when the desired LRP does not exist", func() {
+				It("returns a ResourceNotFound error", func() {
+					err := b.DB.RemoveDesiredLRP(b.Logger, "bogus-guid")
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package db_suites
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func DomainDBSuite(b *Backend) bool {
+	return Describe("DomainDB", func() {
+		Describe("UpsertDomain", func() {
+			Context("when the domain is not present in the DB", func() {
+				It("inserts a new domain that expires after the requested TTL", func() {
+					Expect(b.DB.UpsertDomain(b.Logger, "my-awesome-domain", 5)).To(Succeed())
+
+					domains, err := b.DB.GetAllDomains(b.Logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domains.GetDomains()).To(ConsistOf("my-awesome-domain"))
+
+					b.Clock.Increment(5 * time.Second)
+
+					domains, err = b.DB.GetAllDomains(b.Logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domains.GetDomains()).To(BeEmpty())
+				})
+			})
+
+			Context("when the domain is already present in the DB", func() {
+				BeforeEach(func() {
+					Expect(b.DB.UpsertDomain(b.Logger, "existing-domain", 5)).To(Succeed())
+				})
+
+				It("updates the TTL on the existing record", func() {
+					Expect(b.DB.UpsertDomain(b.Logger, "existing-domain", 100)).To(Succeed())
+
+					b.Clock.Increment(5 * time.Second)
+
+					domains, err := b.DB.GetAllDomains(b.Logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domains.GetDomains()).To(ConsistOf("existing-domain"))
+				})
+			})
+
+			Context("when the TTL is zero", func() {
+				It("never expires the domain", func() {
+					Expect(b.DB.UpsertDomain(b.Logger, "forever-domain", 0)).To(Succeed())
+
+					b.Clock.Increment(1000 * time.Hour)
+
+					domains, err := b.DB.GetAllDomains(b.Logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domains.GetDomains()).To(ConsistOf("forever-domain"))
+				})
+			})
+		})
+
+		Describe("GetAllDomains", func() {
+			Context("when there are domains in the DB", func() {
+				BeforeEach(func() {
+					Expect(b.DB.UpsertDomain(b.Logger, "domain-1", 100)).To(Succeed())
+					Expect(b.DB.UpsertDomain(b.Logger, "domain-2", 100)).To(Succeed())
+				})
+
+				It("returns all the existing domains in the DB", func() {
+					domains, err := b.DB.GetAllDomains(b.Logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domains.GetDomains()).To(ConsistOf("domain-1", "domain-2"))
+				})
+			})
+
+			Context("when there are no domains in the DB", func() {
+				It("returns no domains", func() {
+					domains, err := b.DB.GetAllDomains(b.Logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domains.GetDomains()).To(HaveLen(0))
+				})
+			})
+		})
+	})
+}
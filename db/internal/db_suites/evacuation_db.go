@@ -0,0 +1,346 @@
+package db_suites
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func EvacuationSuite(b *Backend) bool {
+	return Describe("Evacuation", func() {
+		const (
+			processGuid     = "process-guid"
+			index           = int32(0)
+			evacuationTTL   = 60
+			noExpirationTTL = 0
+		)
+
+		var (
+			key              models.ActualLRPKey
+			instanceKey      models.ActualLRPInstanceKey
+			otherInstanceKey models.ActualLRPInstanceKey
+			netInfo          models.ActualLRPNetInfo
+		)
+
+		newActualLRP := func(state string, instanceKey models.ActualLRPInstanceKey) *models.ActualLRP {
+			lrp := model_helpers.NewValidActualLRP(processGuid, index)
+			lrp.State = state
+			lrp.CrashCount = 0
+			lrp.CrashReason = ""
+			lrp.ActualLRPInstanceKey = instanceKey
+			lrp.ActualLRPNetInfo = netInfo
+			if state == models.ActualLRPStateUnclaimed || state == models.ActualLRPStateCrashed {
+				lrp.ActualLRPInstanceKey = models.ActualLRPInstanceKey{}
+				lrp.ActualLRPNetInfo = models.EmptyActualLRPNetInfo()
+			}
+			return lrp
+		}
+
+		fetchGroup := func() *models.ActualLRPGroup {
+			group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, index)
+			Expect(err).NotTo(HaveOccurred())
+			return group
+		}
+
+		BeforeEach(func() {
+			desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+			desiredLRP.Instances = 1
+			b.SetRawDesiredLRP(desiredLRP)
+
+			key = models.NewActualLRPKey(processGuid, index, "some-domain")
+			instanceKey = models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+			otherInstanceKey = models.NewActualLRPInstanceKey("other-instance-guid", "other-cell-id")
+			netInfo = models.NewActualLRPNetInfo("1.2.3.4", models.NewPortMapping(8080, 80))
+		})
+
+		Describe("EvacuateRunningActualLRP", func() {
+			var (
+				keepContainer bool
+				bbsErr        *models.Error
+			)
+
+			JustBeforeEach(func() {
+				keepContainer, bbsErr = b.DB.EvacuateRunningActualLRP(b.Logger, &models.EvacuateRunningActualLRPRequest{
+					ActualLrpKey:         &key,
+					ActualLrpInstanceKey: &instanceKey,
+					ActualLrpNetInfo:     &netInfo,
+					Ttl:                  evacuationTTL,
+				})
+			})
+
+			Context("when the instance is running on the evacuating cell", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey))
+				})
+
+				It("keeps the container", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeTrue())
+				})
+
+				It("records an evacuating actual lrp", func() {
+					evacuating := fetchGroup().Evacuating
+					Expect(evacuating).NotTo(BeNil())
+					Expect(evacuating.State).To(Equal(models.ActualLRPStateRunning))
+					Expect(evacuating.ActualLRPInstanceKey).To(Equal(instanceKey))
+					Expect(evacuating.ActualLRPNetInfo).To(Equal(netInfo))
+				})
+
+				It("unclaims the instance and requests an auction", func() {
+					instance := fetchGroup().Instance
+					Expect(instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+					Expect(instance.ActualLRPInstanceKey).To(Equal(models.ActualLRPInstanceKey{}))
+
+					Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+					startRequests := b.AuctioneerClient.RequestLRPAuctionsArgsForCall(0)
+					Expect(startRequests).To(HaveLen(1))
+					Expect(startRequests[0].Indices).To(ConsistOf(uint(index)))
+				})
+
+				It("resolves the group to the evacuating actual lrp", func() {
+					resolved, evacuating := fetchGroup().Resolve()
+					Expect(evacuating).To(BeTrue())
+					Expect(resolved.ActualLRPInstanceKey).To(Equal(instanceKey))
+				})
+			})
+
+			Context("when the instance has been unclaimed for the evacuating cell", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateUnclaimed, models.ActualLRPInstanceKey{}))
+					b.SetRawEvacuatingActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey), noExpirationTTL)
+				})
+
+				It("keeps the container without another auction", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeTrue())
+					Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+					Expect(fetchGroup().Evacuating.ActualLRPInstanceKey).To(Equal(instanceKey))
+				})
+			})
+
+			Context("when another cell is already evacuating the instance", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateUnclaimed, models.ActualLRPInstanceKey{}))
+					b.SetRawEvacuatingActualLRP(newActualLRP(models.ActualLRPStateRunning, otherInstanceKey), noExpirationTTL)
+				})
+
+				It("refuses to evacuate and drops the container", func() {
+					Expect(bbsErr).To(Equal(models.ErrActualLRPCannotBeEvacuated))
+					Expect(keepContainer).To(BeFalse())
+					Expect(fetchGroup().Evacuating.ActualLRPInstanceKey).To(Equal(otherInstanceKey))
+				})
+			})
+
+			Context("when the replacement is running elsewhere", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateRunning, otherInstanceKey))
+					b.SetRawEvacuatingActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey), noExpirationTTL)
+				})
+
+				It("removes the evacuating actual lrp and drops the container", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeFalse())
+					Expect(fetchGroup().Evacuating).To(BeNil())
+				})
+			})
+
+			Context("when the instance failed placement", func() {
+				BeforeEach(func() {
+					lrp := newActualLRP(models.ActualLRPStateUnclaimed, models.ActualLRPInstanceKey{})
+					lrp.PlacementError = "insufficient resources"
+					b.SetRawActualLRP(lrp)
+					b.SetRawEvacuatingActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey), noExpirationTTL)
+				})
+
+				It("removes the evacuating actual lrp and drops the container", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeFalse())
+					Expect(fetchGroup().Evacuating).To(BeNil())
+				})
+			})
+
+			Context("when the actual lrp does not exist", func() {
+				It("drops the container", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeFalse())
+				})
+			})
+		})
+
+		Describe("EvacuateClaimedActualLRP", func() {
+			var (
+				keepContainer bool
+				bbsErr        *models.Error
+			)
+
+			JustBeforeEach(func() {
+				keepContainer, bbsErr = b.DB.EvacuateClaimedActualLRP(b.Logger, &models.EvacuateClaimedActualLRPRequest{
+					ActualLrpKey:         &key,
+					ActualLrpInstanceKey: &instanceKey,
+				})
+			})
+
+			Context("when the instance is claimed by the evacuating cell", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateClaimed, instanceKey))
+				})
+
+				It("unclaims and re-auctions the instance", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeFalse())
+					Expect(fetchGroup().Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+					Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+				})
+
+				Context("when the auction request fails", func() {
+					BeforeEach(func() {
+						b.AuctioneerClient.RequestLRPAuctionsReturns(errors.New("boom"))
+					})
+
+					It("still unclaims the instance", func() {
+						Expect(bbsErr).NotTo(HaveOccurred())
+						Expect(keepContainer).To(BeFalse())
+						Expect(fetchGroup().Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+					})
+				})
+			})
+
+			Context("when the instance is claimed by another cell", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateClaimed, otherInstanceKey))
+				})
+
+				It("leaves the instance alone", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeFalse())
+					Expect(fetchGroup().Instance.ActualLRPInstanceKey).To(Equal(otherInstanceKey))
+					Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Describe("EvacuateStoppedActualLRP", func() {
+			var (
+				keepContainer bool
+				bbsErr        *models.Error
+			)
+
+			JustBeforeEach(func() {
+				keepContainer, bbsErr = b.DB.EvacuateStoppedActualLRP(b.Logger, &models.EvacuateStoppedActualLRPRequest{
+					ActualLrpKey:         &key,
+					ActualLrpInstanceKey: &instanceKey,
+				})
+			})
+
+			Context("when the evacuating actual lrp belongs to the instance", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateUnclaimed, models.ActualLRPInstanceKey{}))
+					b.SetRawEvacuatingActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey), noExpirationTTL)
+				})
+
+				It("removes the evacuating actual lrp only", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(keepContainer).To(BeFalse())
+
+					group := fetchGroup()
+					Expect(group.Evacuating).To(BeNil())
+					Expect(group.Instance).NotTo(BeNil())
+				})
+			})
+
+			Context("when the instance belongs to the evacuating cell", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey))
+				})
+
+				It("removes the instance", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					_, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, index)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+
+			Context("when neither record belongs to the instance", func() {
+				BeforeEach(func() {
+					b.SetRawActualLRP(newActualLRP(models.ActualLRPStateRunning, otherInstanceKey))
+				})
+
+				It("returns an error", func() {
+					Expect(bbsErr).To(Equal(models.ErrActualLRPCannotBeRemoved))
+					Expect(fetchGroup().Instance).NotTo(BeNil())
+				})
+			})
+		})
+
+		Describe("EvacuateCrashedActualLRP", func() {
+			var (
+				keepContainer bool
+				bbsErr        *models.Error
+			)
+
+			BeforeEach(func() {
+				b.SetRawActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey))
+				b.SetRawEvacuatingActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey), noExpirationTTL)
+			})
+
+			JustBeforeEach(func() {
+				keepContainer, bbsErr = b.DB.EvacuateCrashedActualLRP(b.Logger, &models.EvacuateCrashedActualLRPRequest{
+					ActualLrpKey:         &key,
+					ActualLrpInstanceKey: &instanceKey,
+					ErrorMessage:         "oh no",
+				})
+			})
+
+			It("removes the evacuating actual lrp and crashes the instance", func() {
+				Expect(bbsErr).NotTo(HaveOccurred())
+				Expect(keepContainer).To(BeFalse())
+
+				group := fetchGroup()
+				Expect(group.Evacuating).To(BeNil())
+				Expect(group.Instance.CrashCount).To(Equal(int32(1)))
+				Expect(group.Instance.CrashReason).To(Equal("oh no"))
+			})
+		})
+
+		Describe("RemoveEvacuatingActualLRP", func() {
+			var bbsErr *models.Error
+
+			BeforeEach(func() {
+				b.SetRawActualLRP(newActualLRP(models.ActualLRPStateUnclaimed, models.ActualLRPInstanceKey{}))
+				b.SetRawEvacuatingActualLRP(newActualLRP(models.ActualLRPStateRunning, instanceKey), noExpirationTTL)
+			})
+
+			Context("when the evacuating actual lrp belongs to the instance", func() {
+				JustBeforeEach(func() {
+					bbsErr = b.DB.RemoveEvacuatingActualLRP(b.Logger, &models.RemoveEvacuatingActualLRPRequest{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &instanceKey,
+					})
+				})
+
+				It("removes it", func() {
+					Expect(bbsErr).NotTo(HaveOccurred())
+					Expect(fetchGroup().Evacuating).To(BeNil())
+				})
+			})
+
+			Context("when the evacuating actual lrp belongs to another instance", func() {
+				JustBeforeEach(func() {
+					bbsErr = b.DB.RemoveEvacuatingActualLRP(b.Logger, &models.RemoveEvacuatingActualLRPRequest{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &otherInstanceKey,
+					})
+				})
+
+				It("leaves it alone", func() {
+					Expect(bbsErr).To(Equal(models.ErrActualLRPCannotBeRemoved))
+					Expect(fetchGroup().Evacuating).NotTo(BeNil())
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,286 @@
+package db_suites
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func EventDBSuite(b *Backend) bool {
+	return Describe("Watchers", func() {
+		Describe("WatchForDesiredLRPChanges", func() {
+			var (
+				creates chan *models.DesiredLRP
+				changes chan *models.DesiredLRPChange
+				deletes chan *models.DesiredLRP
+				stop    chan<- bool
+				errors  <-chan error
+				lrp     *models.DesiredLRP
+			)
+
+			BeforeEach(func() {
+				lrp = model_helpers.NewValidDesiredLRP("some-process-guid")
+				lrp.Instances = 0
+
+				creates = make(chan *models.DesiredLRP, 10)
+				changes = make(chan *models.DesiredLRPChange, 10)
+				deletes = make(chan *models.DesiredLRP, 10)
+
+				stop, errors = b.DB.WatchForDesiredLRPChanges(b.Logger,
+					func(created *models.DesiredLRP) { creates <- created },
+					func(changed *models.DesiredLRPChange) { changes <- changed },
+					func(deleted *models.DesiredLRP) { deletes <- deleted },
+				)
+			})
+
+			AfterEach(func() {
+				close(stop)
+				Consistently(errors).ShouldNot(Receive())
+				Eventually(errors).Should(BeClosed())
+			})
+
+			It("sends an event down the pipe for creates", func() {
+				Expect(b.DB.DesireLRP(b.Logger, lrp)).To(Succeed())
+
+				desiredLRP, err := b.DB.DesiredLRPByProcessGuid(b.Logger, lrp.GetProcessGuid())
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(creates).Should(Receive(Equal(desiredLRP)))
+			})
+
+			It("sends an event down the pipe for updates", func() {
+				Expect(b.DB.DesireLRP(b.Logger, lrp)).To(Succeed())
+				Eventually(creates).Should(Receive())
+
+				before, err := b.DB.DesiredLRPByProcessGuid(b.Logger, lrp.GetProcessGuid())
+				Expect(err).NotTo(HaveOccurred())
+
+				annotation := "new-annotation"
+				err = b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{
+					ProcessGuid: lrp.ProcessGuid,
+					Update:      &models.DesiredLRPUpdate{Annotation: &annotation},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				after, err := b.DB.DesiredLRPByProcessGuid(b.Logger, lrp.GetProcessGuid())
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(changes).Should(Receive(Equal(&models.DesiredLRPChange{Before: before, After: after})))
+			})
+
+			It("sends concurrent updates in the order they were committed", func() {
+				Expect(b.DB.DesireLRP(b.Logger, lrp)).To(Succeed())
+				Eventually(creates).Should(Receive())
+
+				const updates = 5
+				wg := sync.WaitGroup{}
+				for i := 0; i < updates; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						annotation := "concurrent-annotation"
+						for {
+							err := b.DB.UpdateDesiredLRP(b.Logger, &models.UpdateDesiredLRPRequest{
+								ProcessGuid: lrp.ProcessGuid,
+								Update:      &models.DesiredLRPUpdate{Annotation: &annotation},
+							})
+							if err == nil {
+								return
+							}
+							Expect(err).To(Equal(models.ErrResourceConflict))
+						}
+					}()
+				}
+				wg.Wait()
+
+				var previous *models.DesiredLRP
+				for i := 0; i < updates; i++ {
+					var change *models.DesiredLRPChange
+					Eventually(changes).Should(Receive(&change))
+					if previous != nil {
+						Expect(change.Before.ModificationTag).To(Equal(previous.ModificationTag))
+					}
+					previous = change.After
+				}
+			})
+
+			It("sends an event down the pipe for deletes", func() {
+				Expect(b.DB.DesireLRP(b.Logger, lrp)).To(Succeed())
+				Eventually(creates).Should(Receive())
+
+				desiredLRP, err := b.DB.DesiredLRPByProcessGuid(b.Logger, lrp.GetProcessGuid())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(b.DB.RemoveDesiredLRP(b.Logger, lrp.ProcessGuid)).To(Succeed())
+				Eventually(deletes).Should(Receive(Equal(desiredLRP)))
+			})
+		})
+
+		Describe("WatchForTaskChanges", func() {
+			var (
+				creates chan *models.Task
+				changes chan *models.TaskChange
+				deletes chan *models.Task
+				stop    chan<- bool
+				errors  <-chan error
+				task    *models.Task
+			)
+
+			BeforeEach(func() {
+				task = model_helpers.NewValidTask("some-task-guid")
+
+				creates = make(chan *models.Task, 10)
+				changes = make(chan *models.TaskChange, 10)
+				deletes = make(chan *models.Task, 10)
+
+				stop, errors = b.DB.WatchForTaskChanges(b.Logger,
+					func(created *models.Task) { creates <- created },
+					func(changed *models.TaskChange) { changes <- changed },
+					func(deleted *models.Task) { deletes <- deleted },
+				)
+			})
+
+			AfterEach(func() {
+				close(stop)
+				Consistently(errors).ShouldNot(Receive())
+				Eventually(errors).Should(BeClosed())
+			})
+
+			It("sends an event down the pipe for creates", func() {
+				Expect(b.DB.DesireTask(b.Logger, task)).To(Succeed())
+
+				persisted, err := b.DB.TaskByGuid(b.Logger, task.TaskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(creates).Should(Receive(Equal(persisted)))
+			})
+
+			It("sends an event down the pipe for updates", func() {
+				Expect(b.DB.DesireTask(b.Logger, task)).To(Succeed())
+				Eventually(creates).Should(Receive())
+
+				before, err := b.DB.TaskByGuid(b.Logger, task.TaskGuid)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = b.DB.StartTask(b.Logger, task.TaskGuid, "cell-id")
+				Expect(err).NotTo(HaveOccurred())
+
+				after, err := b.DB.TaskByGuid(b.Logger, task.TaskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(changes).Should(Receive(Equal(&models.TaskChange{Before: before, After: after})))
+			})
+
+			It("sends an event down the pipe for deletes", func() {
+				task.State = models.Task_Resolving
+				b.SetRawTask(task)
+
+				Expect(b.DB.ResolveTask(b.Logger, task.TaskGuid)).To(Succeed())
+				Eventually(deletes).Should(Receive(Equal(task)))
+			})
+		})
+
+		Describe("WatchForActualLRPChanges", func() {
+			var (
+				creates chan *models.ActualLRPGroup
+				changes chan *models.ActualLRPChange
+				deletes chan *models.ActualLRPGroup
+				stop    chan<- bool
+				errors  <-chan error
+				key     models.ActualLRPKey
+			)
+
+			BeforeEach(func() {
+				creates = make(chan *models.ActualLRPGroup, 10)
+				changes = make(chan *models.ActualLRPChange, 10)
+				deletes = make(chan *models.ActualLRPGroup, 10)
+
+				stop, errors = b.DB.WatchForActualLRPChanges(b.Logger,
+					func(created *models.ActualLRPGroup) { creates <- created },
+					func(changed *models.ActualLRPChange) { changes <- changed },
+					func(deleted *models.ActualLRPGroup) { deletes <- deleted },
+				)
+
+				desiredLRP := model_helpers.NewValidDesiredLRP("some-process-guid")
+				desiredLRP.Instances = 1
+				Expect(b.DB.DesireLRP(b.Logger, desiredLRP)).To(Succeed())
+				key = models.NewActualLRPKey("some-process-guid", 0, desiredLRP.Domain)
+			})
+
+			AfterEach(func() {
+				close(stop)
+				Consistently(errors).ShouldNot(Receive())
+				Eventually(errors).Should(BeClosed())
+			})
+
+			It("sends an event down the pipe for create", func() {
+				group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, key.ProcessGuid, key.Index)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(creates).Should(Receive(Equal(group)))
+			})
+
+			It("sends an event down the pipe for updates", func() {
+				Eventually(creates).Should(Receive())
+
+				before, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, key.ProcessGuid, key.Index)
+				Expect(err).NotTo(HaveOccurred())
+
+				instanceKey := models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+				_, err = b.DB.ClaimActualLRP(b.Logger, &models.ClaimActualLRPRequest{
+					ProcessGuid:          key.ProcessGuid,
+					Index:                key.Index,
+					ActualLrpInstanceKey: &instanceKey,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				after, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, key.ProcessGuid, key.Index)
+				Expect(err).NotTo(HaveOccurred())
+
+				var change *models.ActualLRPChange
+				Eventually(changes).Should(Receive(&change))
+				Expect(change.Before).To(Equal(before))
+				Expect(change.After).To(Equal(after))
+			})
+
+			It("sends an event down the pipe for delete", func() {
+				var created *models.ActualLRPGroup
+				Eventually(creates).Should(Receive(&created))
+
+				Expect(b.DB.RemoveActualLRP(b.Logger, key.ProcessGuid, key.Index)).To(Succeed())
+				Eventually(deletes).Should(Receive(Equal(created)))
+			})
+
+			Context("when an evacuating actual LRP changes", func() {
+				It("passes the evacuating record in the Evacuating half of the group", func() {
+					Eventually(creates).Should(Receive())
+
+					instanceKey := models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+					netInfo := models.NewActualLRPNetInfo("1.2.3.4", models.NewPortMapping(8080, 80))
+					_, err := b.DB.StartActualLRP(b.Logger, &models.StartActualLRPRequest{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &instanceKey,
+						ActualLrpNetInfo:     &netInfo,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(changes).Should(Receive())
+
+					_, err = b.DB.EvacuateRunningActualLRP(b.Logger, &models.EvacuateRunningActualLRPRequest{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &instanceKey,
+						ActualLrpNetInfo:     &netInfo,
+						Ttl:                  60,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					var created *models.ActualLRPGroup
+					Eventually(creates).Should(Receive(&created))
+					Expect(created.Instance).To(BeNil())
+					Expect(created.Evacuating).NotTo(BeNil())
+					Expect(created.Evacuating.ActualLRPInstanceKey).To(Equal(instanceKey))
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,193 @@
+package db_suites
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func LRPConvergenceSuite(b *Backend) bool {
+	return Describe("LRP Convergence", func() {
+		const (
+			presentCellId = "present-cell"
+			missingCellId = "missing-cell"
+		)
+
+		var (
+			cellPresence models.CellPresence
+			summary      models.LRPConvergenceSummary
+		)
+
+		newActualLRP := func(processGuid string, index int32, state string, cellId string) *models.ActualLRP {
+			lrp := model_helpers.NewValidActualLRP(processGuid, index)
+			lrp.State = state
+			lrp.ActualLRPInstanceKey = models.NewActualLRPInstanceKey("instance-guid", cellId)
+			if cellId == "" {
+				lrp.ActualLRPInstanceKey = models.ActualLRPInstanceKey{}
+				lrp.ActualLRPNetInfo = models.EmptyActualLRPNetInfo()
+			}
+			return lrp
+		}
+
+		fetchActual := func(processGuid string, index int32) *models.ActualLRP {
+			group, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, processGuid, index)
+			Expect(err).NotTo(HaveOccurred())
+			return group.Instance
+		}
+
+		BeforeEach(func() {
+			cellPresence = models.NewCellPresence(
+				presentCellId,
+				"cell.example.com",
+				"the-zone",
+				models.NewCellCapacity(128, 1024, 6),
+				[]string{},
+				[]string{},
+			)
+			b.RegisterCell(cellPresence)
+		})
+
+		JustBeforeEach(func() {
+			summary = b.DB.ConvergeLRPs(b.Logger)
+		})
+
+		Context("when the actual state matches the desired state", func() {
+			BeforeEach(func() {
+				desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+				desiredLRP.Instances = 1
+				b.SetRawDesiredLRP(desiredLRP)
+				b.SetRawActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, presentCellId))
+			})
+
+			It("changes nothing", func() {
+				Expect(summary).To(Equal(models.LRPConvergenceSummary{}))
+				Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+				Expect(b.CellClient.StopLRPInstanceCallCount()).To(Equal(0))
+				Expect(fetchActual("desired-guid", 0).State).To(Equal(models.ActualLRPStateRunning))
+			})
+		})
+
+		Context("when indices are missing", func() {
+			BeforeEach(func() {
+				desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+				desiredLRP.Instances = 3
+				b.SetRawDesiredLRP(desiredLRP)
+				b.SetRawActualLRP(newActualLRP("desired-guid", 1, models.ActualLRPStateRunning, presentCellId))
+			})
+
+			It("creates and auctions the missing indices", func() {
+				Expect(summary.MissingLRPs).To(Equal(2))
+
+				Expect(fetchActual("desired-guid", 0).State).To(Equal(models.ActualLRPStateUnclaimed))
+				Expect(fetchActual("desired-guid", 2).State).To(Equal(models.ActualLRPStateUnclaimed))
+
+				Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+				startRequests := b.AuctioneerClient.RequestLRPAuctionsArgsForCall(0)
+				Expect(startRequests).To(HaveLen(1))
+				Expect(startRequests[0].DesiredLRP.ProcessGuid).To(Equal("desired-guid"))
+				Expect(startRequests[0].Indices).To(ConsistOf(uint(0), uint(2)))
+			})
+		})
+
+		Context("when there are indices at or above the desired number of instances", func() {
+			BeforeEach(func() {
+				desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+				desiredLRP.Instances = 1
+				b.SetRawDesiredLRP(desiredLRP)
+				b.SetRawActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, presentCellId))
+				b.SetRawActualLRP(newActualLRP("desired-guid", 1, models.ActualLRPStateRunning, presentCellId))
+				b.SetRawActualLRP(newActualLRP("desired-guid", 2, models.ActualLRPStateUnclaimed, ""))
+			})
+
+			It("retires the extra indices", func() {
+				Expect(summary.ExtraLRPs).To(Equal(2))
+
+				Expect(b.CellClient.StopLRPInstanceCallCount()).To(Equal(1))
+				cellAddr, key, _ := b.CellClient.StopLRPInstanceArgsForCall(0)
+				Expect(cellAddr).To(Equal(cellPresence.RepAddress))
+				Expect(key).To(Equal(models.NewActualLRPKey("desired-guid", 1, "some-domain")))
+
+				_, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, "desired-guid", 2)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Context("when there are crashed actual LRPs", func() {
+			BeforeEach(func() {
+				desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+				desiredLRP.Instances = 2
+
+				restartable := newActualLRP("desired-guid", 0, models.ActualLRPStateCrashed, "")
+				restartable.CrashCount = 1
+
+				backingOff := newActualLRP("desired-guid", 1, models.ActualLRPStateCrashed, "")
+				backingOff.CrashCount = models.DefaultImmediateRestarts + 1
+				backingOff.Since = b.Clock.Now().UnixNano()
+
+				b.SetRawDesiredLRP(desiredLRP)
+				b.SetRawActualLRP(restartable)
+				b.SetRawActualLRP(backingOff)
+			})
+
+			It("restarts the ones whose backoff has elapsed", func() {
+				Expect(summary.RestartedCrashedLRPs).To(Equal(1))
+
+				restarted := fetchActual("desired-guid", 0)
+				Expect(restarted.State).To(Equal(models.ActualLRPStateUnclaimed))
+				Expect(restarted.CrashCount).To(Equal(int32(1)))
+				Expect(fetchActual("desired-guid", 1).State).To(Equal(models.ActualLRPStateCrashed))
+
+				Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+				startRequests := b.AuctioneerClient.RequestLRPAuctionsArgsForCall(0)
+				Expect(startRequests).To(HaveLen(1))
+				Expect(startRequests[0].Indices).To(ConsistOf(uint(0)))
+			})
+		})
+
+		Context("when actual LRPs are on cells that are not present", func() {
+			BeforeEach(func() {
+				desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+				desiredLRP.Instances = 2
+				b.SetRawDesiredLRP(desiredLRP)
+				b.SetRawActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, presentCellId))
+				b.SetRawActualLRP(newActualLRP("desired-guid", 1, models.ActualLRPStateClaimed, missingCellId))
+			})
+
+			It("unclaims and auctions them", func() {
+				Expect(summary.LRPsOnMissingCells).To(Equal(1))
+
+				unclaimed := fetchActual("desired-guid", 1)
+				Expect(unclaimed.State).To(Equal(models.ActualLRPStateUnclaimed))
+				Expect(unclaimed.ActualLRPInstanceKey).To(Equal(models.ActualLRPInstanceKey{}))
+				Expect(fetchActual("desired-guid", 0).State).To(Equal(models.ActualLRPStateRunning))
+
+				Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+				startRequests := b.AuctioneerClient.RequestLRPAuctionsArgsForCall(0)
+				Expect(startRequests[0].Indices).To(ConsistOf(uint(1)))
+			})
+		})
+
+		Context("when actual LRPs have no desired LRP", func() {
+			BeforeEach(func() {
+				b.SetRawActualLRP(newActualLRP("orphan-guid", 0, models.ActualLRPStateRunning, presentCellId))
+				b.SetRawActualLRP(newActualLRP("orphan-guid", 1, models.ActualLRPStateUnclaimed, ""))
+			})
+
+			It("retires them", func() {
+				Expect(summary.OrphanedLRPs).To(Equal(2))
+
+				Expect(b.CellClient.StopLRPInstanceCallCount()).To(Equal(1))
+				cellAddr, key, _ := b.CellClient.StopLRPInstanceArgsForCall(0)
+				Expect(cellAddr).To(Equal(cellPresence.RepAddress))
+				Expect(key).To(Equal(models.NewActualLRPKey("orphan-guid", 0, "some-domain")))
+				Expect(fetchActual("orphan-guid", 0).State).To(Equal(models.ActualLRPStateRunning))
+
+				_, err := b.DB.ActualLRPGroupByProcessGuidAndIndex(b.Logger, "orphan-guid", 1)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+				Expect(b.AuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+			})
+		})
+	})
+}
@@ -0,0 +1,197 @@
+package db_suites
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TaskConvergenceSuite(b *Backend) bool {
+	return Describe("Task Convergence", func() {
+		const (
+			kickTaskDuration            = 30 * time.Second
+			expirePendingTaskDuration   = 30 * time.Minute
+			expireCompletedTaskDuration = 2 * time.Minute
+
+			presentCellId = "present-cell"
+			missingCellId = "missing-cell"
+		)
+
+		var summary models.TaskConvergenceSummary
+
+		ago := func(duration time.Duration) int64 {
+			return b.Clock.Now().Add(-duration).UnixNano()
+		}
+
+		newTask := func(guid string, state models.Task_State) *models.Task {
+			task := model_helpers.NewValidTask(guid)
+			task.State = state
+			task.CreatedAt = b.Clock.Now().UnixNano()
+			task.UpdatedAt = b.Clock.Now().UnixNano()
+			task.FirstCompletedAt = 0
+			task.CellId = ""
+			return task
+		}
+
+		fetchTask := func(guid string) *models.Task {
+			task, err := b.DB.TaskByGuid(b.Logger, guid)
+			Expect(err).NotTo(HaveOccurred())
+			return task
+		}
+
+		BeforeEach(func() {
+			b.RegisterCell(models.NewCellPresence(
+				presentCellId,
+				"cell.example.com",
+				"the-zone",
+				models.NewCellCapacity(128, 1024, 6),
+				[]string{},
+				[]string{},
+			))
+		})
+
+		JustBeforeEach(func() {
+			summary = b.DB.ConvergeTasks(b.Logger, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+		})
+
+		Context("when there are no tasks", func() {
+			It("does nothing", func() {
+				Expect(summary).To(Equal(models.TaskConvergenceSummary{}))
+			})
+		})
+
+		Describe("pending tasks", func() {
+			BeforeEach(func() {
+				fresh := newTask("fresh-guid", models.Task_Pending)
+
+				stale := newTask("stale-guid", models.Task_Pending)
+				stale.CreatedAt = ago(kickTaskDuration + time.Second)
+				stale.UpdatedAt = ago(kickTaskDuration + time.Second)
+
+				expired := newTask("expired-guid", models.Task_Pending)
+				expired.CreatedAt = ago(expirePendingTaskDuration + time.Second)
+				expired.UpdatedAt = ago(expirePendingTaskDuration + time.Second)
+
+				b.SetRawTask(fresh)
+				b.SetRawTask(stale)
+				b.SetRawTask(expired)
+			})
+
+			It("re-auctions the tasks older than the kick threshold", func() {
+				Expect(summary.PendingTasksKicked).To(Equal(1))
+
+				Expect(b.AuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+				requested := b.AuctioneerClient.RequestTaskAuctionsArgsForCall(0)
+				Expect(requested).To(HaveLen(1))
+				Expect(requested[0].TaskGuid).To(Equal("stale-guid"))
+			})
+
+			It("fails the tasks older than the expiry threshold", func() {
+				Expect(summary.PendingTasksExpired).To(Equal(1))
+
+				expired := fetchTask("expired-guid")
+				Expect(expired.State).To(Equal(models.Task_Completed))
+				Expect(expired.Failed).To(BeTrue())
+				Expect(expired.FailureReason).To(Equal("not started within time limit"))
+			})
+
+			It("leaves fresh tasks alone", func() {
+				Expect(fetchTask("fresh-guid").State).To(Equal(models.Task_Pending))
+			})
+		})
+
+		Describe("running tasks", func() {
+			BeforeEach(func() {
+				present := newTask("present-guid", models.Task_Running)
+				present.CellId = presentCellId
+
+				missing := newTask("missing-guid", models.Task_Running)
+				missing.CellId = missingCellId
+				missing.CompletionCallbackUrl = "http://example.com/callback"
+
+				b.SetRawTask(present)
+				b.SetRawTask(missing)
+			})
+
+			It("fails the tasks whose cell has disappeared", func() {
+				Expect(summary.RunningTasksFailed).To(Equal(1))
+
+				failed := fetchTask("missing-guid")
+				Expect(failed.State).To(Equal(models.Task_Completed))
+				Expect(failed.Failed).To(BeTrue())
+				Expect(failed.FailureReason).To(Equal("cell disappeared before completion"))
+
+				Expect(b.TaskCompletionClient.SubmitCallCount()).To(Equal(1))
+				_, submitted := b.TaskCompletionClient.SubmitArgsForCall(0)
+				Expect(submitted.TaskGuid).To(Equal("missing-guid"))
+			})
+
+			It("leaves tasks on present cells alone", func() {
+				Expect(fetchTask("present-guid").State).To(Equal(models.Task_Running))
+			})
+		})
+
+		Describe("completed and resolving tasks", func() {
+			BeforeEach(func() {
+				fresh := newTask("fresh-guid", models.Task_Completed)
+				fresh.FirstCompletedAt = b.Clock.Now().UnixNano()
+				fresh.CompletionCallbackUrl = "http://example.com/callback"
+
+				stalledCompleted := newTask("stalled-completed-guid", models.Task_Completed)
+				stalledCompleted.FirstCompletedAt = ago(kickTaskDuration + time.Second)
+				stalledCompleted.UpdatedAt = ago(kickTaskDuration + time.Second)
+				stalledCompleted.CompletionCallbackUrl = "http://example.com/callback"
+
+				stalledResolving := newTask("stalled-resolving-guid", models.Task_Resolving)
+				stalledResolving.FirstCompletedAt = ago(kickTaskDuration + time.Second)
+				stalledResolving.UpdatedAt = ago(kickTaskDuration + time.Second)
+				stalledResolving.CompletionCallbackUrl = "http://example.com/callback"
+
+				expiredCompleted := newTask("expired-completed-guid", models.Task_Completed)
+				expiredCompleted.FirstCompletedAt = ago(expireCompletedTaskDuration + time.Second)
+				expiredCompleted.UpdatedAt = ago(expireCompletedTaskDuration + time.Second)
+
+				expiredResolving := newTask("expired-resolving-guid", models.Task_Resolving)
+				expiredResolving.FirstCompletedAt = ago(expireCompletedTaskDuration + time.Second)
+				expiredResolving.UpdatedAt = ago(expireCompletedTaskDuration + time.Second)
+
+				b.SetRawTask(fresh)
+				b.SetRawTask(stalledCompleted)
+				b.SetRawTask(stalledResolving)
+				b.SetRawTask(expiredCompleted)
+				b.SetRawTask(expiredResolving)
+			})
+
+			It("re-kicks the tasks whose callback has stalled", func() {
+				Expect(summary.CompletedTasksKicked).To(Equal(2))
+
+				Expect(b.TaskCompletionClient.SubmitCallCount()).To(Equal(2))
+				_, first := b.TaskCompletionClient.SubmitArgsForCall(0)
+				_, second := b.TaskCompletionClient.SubmitArgsForCall(1)
+				Expect([]string{first.TaskGuid, second.TaskGuid}).To(ConsistOf("stalled-completed-guid", "stalled-resolving-guid"))
+			})
+
+			It("demotes stalled resolving tasks back to completed", func() {
+				Expect(fetchTask("stalled-resolving-guid").State).To(Equal(models.Task_Completed))
+			})
+
+			It("deletes the tasks past the retention window", func() {
+				Expect(summary.TasksPruned).To(Equal(2))
+
+				_, err := b.DB.TaskByGuid(b.Logger, "expired-completed-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+
+				_, err = b.DB.TaskByGuid(b.Logger, "expired-resolving-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+
+			It("leaves fresh tasks alone", func() {
+				Expect(fetchTask("fresh-guid").State).To(Equal(models.Task_Completed))
+			})
+		})
+	})
+}
@@ -0,0 +1,473 @@
+package db_suites
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TaskDBSuite(b *Backend) bool {
+	return Describe("TaskDB", func() {
+		Describe("Tasks", func() {
+			Context("when there are tasks", func() {
+				var expectedTasks []*models.Task
+
+				BeforeEach(func() {
+					expectedTasks = []*models.Task{
+						model_helpers.NewValidTask("a-guid"), model_helpers.NewValidTask("b-guid"),
+					}
+					expectedTasks[1].Domain = "b-domain"
+
+					for _, t := range expectedTasks {
+						b.SetRawTask(t)
+					}
+				})
+
+				It("returns all the tasks", func() {
+					tasks, err := b.DB.Tasks(b.Logger, models.TaskFilter{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(tasks.GetTasks()).To(ConsistOf(expectedTasks))
+				})
+
+				It("can filter", func() {
+					tasks, err := b.DB.Tasks(b.Logger, models.TaskFilter{Domain: "b-domain"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(tasks.Tasks).To(HaveLen(1))
+					Expect(tasks.Tasks[0]).To(Equal(expectedTasks[1]))
+				})
+
+				It("can page in task guid order", func() {
+					tasks, err := b.DB.Tasks(b.Logger, models.TaskFilter{Limit: 1})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(tasks.GetTasks()).To(Equal([]*models.Task{expectedTasks[0]}))
+
+					tasks, err = b.DB.Tasks(b.Logger, models.TaskFilter{Limit: 1, AfterTaskGuid: "a-guid"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(tasks.GetTasks()).To(Equal([]*models.Task{expectedTasks[1]}))
+
+					tasks, err = b.DB.Tasks(b.Logger, models.TaskFilter{Limit: 1, AfterTaskGuid: "b-guid"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(tasks.GetTasks()).To(BeEmpty())
+				})
+			})
+
+			Context("when there are no tasks", func() {
+				It("returns an empty list", func() {
+					tasks, err := b.DB.Tasks(b.Logger, models.TaskFilter{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(tasks).NotTo(BeNil())
+					Expect(tasks.GetTasks()).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("TaskByGuid", func() {
+			Context("when there is a task", func() {
+				var expectedTask *models.Task
+
+				BeforeEach(func() {
+					expectedTask = model_helpers.NewValidTask("task-guid")
+					b.SetRawTask(expectedTask)
+				})
+
+				It("returns the task", func() {
+					task, err := b.DB.TaskByGuid(b.Logger, "task-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task).To(Equal(expectedTask))
+				})
+			})
+
+			Context("when there is no task", func() {
+				It("returns a ResourceNotFound", func() {
+					_, err := b.DB.TaskByGuid(b.Logger, "nota-guid")
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+
+			Context("when there is invalid data", func() {
+				BeforeEach(func() {
+					b.SetRawTaskData("some-other-guid", []byte("{{{{{"))
+				})
+
+				It("errors", func() {
+					_, err := b.DB.TaskByGuid(b.Logger, "some-other-guid")
+					Expect(err).To(Equal(models.ErrDeserializeJSON))
+				})
+			})
+		})
+
+		Describe("DesireTask", func() {
+			var task *models.Task
+
+			BeforeEach(func() {
+				task = model_helpers.NewValidTask("task-guid")
+				task.State = models.Task_Invalid
+			})
+
+			Context("when the task does not yet exist", func() {
+				It("persists the task in the pending state", func() {
+					err := b.DB.DesireTask(b.Logger, task)
+					Expect(err).NotTo(HaveOccurred())
+
+					persisted, err := b.DB.TaskByGuid(b.Logger, "task-guid")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(persisted.State).To(Equal(models.Task_Pending))
+					Expect(persisted.CreatedAt).To(Equal(b.Clock.Now().UnixNano()))
+					Expect(persisted.UpdatedAt).To(Equal(b.Clock.Now().UnixNano()))
+				})
+
+				It("requests an auction for the task", func() {
+					err := b.DB.DesireTask(b.Logger, task)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(b.AuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(1))
+					requestedTasks := b.AuctioneerClient.RequestTaskAuctionsArgsForCall(0)
+					Expect(requestedTasks).To(HaveLen(1))
+					Expect(requestedTasks[0].TaskGuid).To(Equal("task-guid"))
+				})
+			})
+
+			Context("when the task already exists", func() {
+				BeforeEach(func() {
+					b.SetRawTask(model_helpers.NewValidTask("task-guid"))
+				})
+
+				It("returns a ResourceExists error", func() {
+					err := b.DB.DesireTask(b.Logger, task)
+					Expect(err).To(Equal(models.ErrResourceExists))
+					Expect(b.AuctioneerClient.RequestTaskAuctionsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Describe("Task lifecycle transitions", func() {
+			const taskGuid = "task-guid"
+			var task *models.Task
+
+			BeforeEach(func() {
+				task = model_helpers.NewValidTask(taskGuid)
+				task.CellId = ""
+				task.Failed = false
+				task.FailureReason = ""
+				task.Result = ""
+				task.FirstCompletedAt = 0
+			})
+
+			JustBeforeEach(func() {
+				b.SetRawTask(task)
+			})
+
+			fetchTask := func() *models.Task {
+				persisted, err := b.DB.TaskByGuid(b.Logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				return persisted
+			}
+
+			Describe("StartTask", func() {
+				Context("when the task is pending", func() {
+					It("transitions the task to running on the cell", func() {
+						shouldStart, err := b.DB.StartTask(b.Logger, taskGuid, "cell-id")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(shouldStart).To(BeTrue())
+
+						persisted := fetchTask()
+						Expect(persisted.State).To(Equal(models.Task_Running))
+						Expect(persisted.CellId).To(Equal("cell-id"))
+						Expect(persisted.UpdatedAt).To(Equal(b.Clock.Now().UnixNano()))
+					})
+				})
+
+				Context("when several cells start the task at once", func() {
+					It("lets exactly one of them win", func() {
+						var wg sync.WaitGroup
+						results := make(chan bool, 5)
+						for i := 0; i < 5; i++ {
+							cellId := "cell-" + string('a'+rune(i))
+							wg.Add(1)
+							go func() {
+								defer GinkgoRecover()
+								defer wg.Done()
+								shouldStart, err := b.DB.StartTask(b.Logger, taskGuid, cellId)
+								if err != nil {
+									Expect(err).To(Equal(models.ErrTaskCannotBeStarted))
+								}
+								results <- shouldStart
+							}()
+						}
+						wg.Wait()
+						close(results)
+
+						started := 0
+						for shouldStart := range results {
+							if shouldStart {
+								started++
+							}
+						}
+						Expect(started).To(Equal(1))
+					})
+				})
+
+				Context("when the task is already running on the same cell", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Running
+						task.CellId = "cell-id"
+					})
+
+					It("tells the cell not to start the task again", func() {
+						shouldStart, err := b.DB.StartTask(b.Logger, taskGuid, "cell-id")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(shouldStart).To(BeFalse())
+					})
+				})
+
+				Context("when the task is running on another cell", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Running
+						task.CellId = "other-cell-id"
+					})
+
+					It("returns an ErrTaskCannotBeStarted", func() {
+						_, err := b.DB.StartTask(b.Logger, taskGuid, "cell-id")
+						Expect(err).To(Equal(models.ErrTaskCannotBeStarted))
+					})
+				})
+
+				Context("when the task does not exist", func() {
+					It("returns a ResourceNotFound error", func() {
+						_, err := b.DB.StartTask(b.Logger, "bogus-guid", "cell-id")
+						Expect(err).To(Equal(models.ErrResourceNotFound))
+					})
+				})
+			})
+
+			Describe("CancelTask", func() {
+				Context("when the task is pending", func() {
+					It("completes the task as failed", func() {
+						err := b.DB.CancelTask(b.Logger, taskGuid)
+						Expect(err).NotTo(HaveOccurred())
+
+						persisted := fetchTask()
+						Expect(persisted.State).To(Equal(models.Task_Completed))
+						Expect(persisted.Failed).To(BeTrue())
+						Expect(persisted.FailureReason).To(Equal("task was cancelled"))
+						Expect(persisted.FirstCompletedAt).To(Equal(b.Clock.Now().UnixNano()))
+						Expect(b.CellClient.CancelTaskCallCount()).To(Equal(0))
+					})
+
+					Context("when the task has a completion callback url", func() {
+						BeforeEach(func() {
+							task.CompletionCallbackUrl = "http://example.com/callback"
+						})
+
+						It("submits the cancelled task for the callback", func() {
+							err := b.DB.CancelTask(b.Logger, taskGuid)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(b.TaskCompletionClient.SubmitCallCount()).To(Equal(1))
+							_, submittedTask := b.TaskCompletionClient.SubmitArgsForCall(0)
+							Expect(submittedTask.FailureReason).To(Equal("task was cancelled"))
+						})
+					})
+				})
+
+				Context("when the task is running", func() {
+					var cellPresence models.CellPresence
+
+					BeforeEach(func() {
+						task.State = models.Task_Running
+						task.CellId = "cell-id"
+
+						cellPresence = models.NewCellPresence(
+							"cell-id",
+							"cell.example.com",
+							"the-zone",
+							models.NewCellCapacity(128, 1024, 6),
+							[]string{},
+							[]string{},
+						)
+						b.RegisterCell(cellPresence)
+					})
+
+					It("cancels the task on the owning cell", func() {
+						err := b.DB.CancelTask(b.Logger, taskGuid)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(b.CellClient.CancelTaskCallCount()).To(Equal(1))
+						addr, cancelledGuid := b.CellClient.CancelTaskArgsForCall(0)
+						Expect(addr).To(Equal(cellPresence.RepAddress))
+						Expect(cancelledGuid).To(Equal(taskGuid))
+					})
+				})
+
+				Context("when the task is already completed", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Completed
+					})
+
+					It("returns an ErrTaskCannotBeCancelled", func() {
+						err := b.DB.CancelTask(b.Logger, taskGuid)
+						Expect(err).To(Equal(models.ErrTaskCannotBeCancelled))
+					})
+				})
+			})
+
+			Describe("FailTask", func() {
+				Context("when the task is pending", func() {
+					It("completes the task with the failure reason", func() {
+						err := b.DB.FailTask(b.Logger, taskGuid, "just-because")
+						Expect(err).NotTo(HaveOccurred())
+
+						persisted := fetchTask()
+						Expect(persisted.State).To(Equal(models.Task_Completed))
+						Expect(persisted.Failed).To(BeTrue())
+						Expect(persisted.FailureReason).To(Equal("just-because"))
+					})
+
+					Context("when the task has a completion callback url", func() {
+						BeforeEach(func() {
+							task.CompletionCallbackUrl = "http://example.com/callback"
+						})
+
+						It("submits the failed task for the callback", func() {
+							err := b.DB.FailTask(b.Logger, taskGuid, "just-because")
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(b.TaskCompletionClient.SubmitCallCount()).To(Equal(1))
+							_, submittedTask := b.TaskCompletionClient.SubmitArgsForCall(0)
+							Expect(submittedTask.TaskGuid).To(Equal(taskGuid))
+							Expect(submittedTask.Failed).To(BeTrue())
+						})
+					})
+				})
+
+				Context("when the task is resolving", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Resolving
+					})
+
+					It("returns an ErrTaskCannotBeFailed", func() {
+						err := b.DB.FailTask(b.Logger, taskGuid, "just-because")
+						Expect(err).To(Equal(models.ErrTaskCannotBeFailed))
+					})
+				})
+			})
+
+			Describe("CompleteTask", func() {
+				Context("when the task is running on the cell", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Running
+						task.CellId = "cell-id"
+					})
+
+					It("completes the task with the result", func() {
+						err := b.DB.CompleteTask(b.Logger, taskGuid, "cell-id", false, "", "the-result")
+						Expect(err).NotTo(HaveOccurred())
+
+						persisted := fetchTask()
+						Expect(persisted.State).To(Equal(models.Task_Completed))
+						Expect(persisted.Failed).To(BeFalse())
+						Expect(persisted.Result).To(Equal("the-result"))
+						Expect(persisted.UpdatedAt).To(Equal(b.Clock.Now().UnixNano()))
+						Expect(persisted.FirstCompletedAt).To(Equal(b.Clock.Now().UnixNano()))
+					})
+
+					It("does not submit the task for completion callbacks", func() {
+						err := b.DB.CompleteTask(b.Logger, taskGuid, "cell-id", false, "", "the-result")
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(b.TaskCompletionClient.SubmitCallCount()).To(Equal(0))
+					})
+
+					Context("when the task has a completion callback url", func() {
+						BeforeEach(func() {
+							task.CompletionCallbackUrl = "http://example.com/callback"
+						})
+
+						It("submits the completed task for the callback", func() {
+							err := b.DB.CompleteTask(b.Logger, taskGuid, "cell-id", false, "", "the-result")
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(b.TaskCompletionClient.SubmitCallCount()).To(Equal(1))
+							submittedDB, submittedTask := b.TaskCompletionClient.SubmitArgsForCall(0)
+							resolved, err := submittedDB.TaskByGuid(b.Logger, taskGuid)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(resolved.State).To(Equal(models.Task_Completed))
+							Expect(submittedTask.TaskGuid).To(Equal(taskGuid))
+							Expect(submittedTask.State).To(Equal(models.Task_Completed))
+							Expect(submittedTask.Result).To(Equal("the-result"))
+						})
+					})
+
+					Context("when completing from a different cell", func() {
+						It("returns an ErrTaskRunningOnDifferentCell", func() {
+							err := b.DB.CompleteTask(b.Logger, taskGuid, "other-cell-id", false, "", "the-result")
+							Expect(err).To(Equal(models.ErrTaskRunningOnDifferentCell))
+						})
+					})
+				})
+
+				Context("when the task is pending", func() {
+					It("returns an ErrTaskCannotBeCompleted", func() {
+						err := b.DB.CompleteTask(b.Logger, taskGuid, "cell-id", false, "", "the-result")
+						Expect(err).To(Equal(models.ErrTaskCannotBeCompleted))
+					})
+				})
+			})
+
+			Describe("ResolvingTask", func() {
+				Context("when the task is completed", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Completed
+					})
+
+					It("transitions the task to resolving", func() {
+						err := b.DB.ResolvingTask(b.Logger, taskGuid)
+						Expect(err).NotTo(HaveOccurred())
+
+						persisted := fetchTask()
+						Expect(persisted.State).To(Equal(models.Task_Resolving))
+						Expect(persisted.UpdatedAt).To(Equal(b.Clock.Now().UnixNano()))
+					})
+				})
+
+				Context("when the task is not completed", func() {
+					It("returns an ErrTaskCannotBeMarkedAsResolving", func() {
+						err := b.DB.ResolvingTask(b.Logger, taskGuid)
+						Expect(err).To(Equal(models.ErrTaskCannotBeMarkedAsResolving))
+					})
+				})
+			})
+
+			Describe("ResolveTask", func() {
+				Context("when the task is resolving", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Resolving
+					})
+
+					It("deletes the task", func() {
+						err := b.DB.ResolveTask(b.Logger, taskGuid)
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = b.DB.TaskByGuid(b.Logger, taskGuid)
+						Expect(err).To(Equal(models.ErrResourceNotFound))
+					})
+				})
+
+				Context("when the task is not resolving", func() {
+					BeforeEach(func() {
+						task.State = models.Task_Completed
+					})
+
+					It("returns an ErrTaskCannotBeResolved", func() {
+						err := b.DB.ResolveTask(b.Logger, taskGuid)
+						Expect(err).To(Equal(models.ErrTaskCannotBeResolved))
+					})
+				})
+			})
+		})
+	})
+}
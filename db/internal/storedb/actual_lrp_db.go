@@ -0,0 +1,427 @@
+package storedb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/nu7hatch/gouuid"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *DB) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFilter) (*models.ActualLRPGroups, *models.Error) {
+	groups, bbsErr := db.store.ActualLRPGroups(logger, "", filter)
+	if bbsErr != nil {
+		return nil, bbsErr
+	}
+	return &models.ActualLRPGroups{ActualLrpGroups: groups}, nil
+}
+
+func (db *DB) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) (*models.ActualLRPGroups, *models.Error) {
+	groups, bbsErr := db.store.ActualLRPGroups(logger, processGuid, models.ActualLRPFilter{})
+	if bbsErr != nil {
+		return nil, bbsErr
+	}
+	return &models.ActualLRPGroups{ActualLrpGroups: groups}, nil
+}
+
+func (db *DB) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, *models.Error) {
+	return db.store.ActualLRPGroup(logger, processGuid, index)
+}
+
+func (db *DB) ClaimActualLRP(logger lager.Logger, request *models.ClaimActualLRPRequest) (*models.ActualLRP, *models.Error) {
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, request.ProcessGuid, request.Index, false)
+	if bbsErr != nil {
+		return nil, bbsErr
+	}
+
+	if request.ExpectedModificationTag != nil && !request.ExpectedModificationTag.Equal(&lrp.ModificationTag) {
+		logger.Info("modification-tag-mismatch")
+		return nil, models.ErrResourceConflict
+	}
+
+	if !lrp.AllowsTransitionTo(&lrp.ActualLRPKey, request.ActualLrpInstanceKey, models.ActualLRPStateClaimed) {
+		return nil, models.ErrActualLRPCannotBeClaimed
+	}
+
+	before := *lrp
+	lrp.PlacementError = ""
+	lrp.State = models.ActualLRPStateClaimed
+	lrp.ActualLRPInstanceKey = *request.ActualLrpInstanceKey
+	lrp.ActualLRPNetInfo = models.ActualLRPNetInfo{}
+	lrp.ModificationTag.Increment()
+
+	err := lrp.Validate()
+	if err != nil {
+		return nil, &models.Error{Type: models.InvalidRecord, Message: err.Error()}
+	}
+
+	logger.Info("starting")
+	bbsErr = db.store.CompareAndSwapActualLRP(logger, &before, lrp, false, prevIndex, 0)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return nil, models.ErrActualLRPCannotBeClaimed
+	}
+	logger.Info("succeeded")
+
+	return lrp, nil
+}
+
+func (db *DB) createRunningActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (*models.ActualLRP, *models.Error) {
+	guid, err := uuid.NewV4()
+	if err != nil {
+		return nil, models.ErrActualLRPCannotBeStarted
+	}
+	lrp := &models.ActualLRP{
+		ActualLRPKey:         *key,
+		ActualLRPInstanceKey: *instanceKey,
+		ActualLRPNetInfo:     *netInfo,
+		Since:                db.clock.Now().UnixNano(),
+		State:                models.ActualLRPStateRunning,
+		ModificationTag: models.ModificationTag{
+			Epoch: guid.String(),
+			Index: 0,
+		},
+	}
+
+	bbsErr := db.setActualLRP(logger, lrp)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return nil, models.ErrActualLRPCannotBeStarted
+	}
+	return lrp, nil
+}
+
+// setActualLRP stores lrp over whatever instance record is there, as etcd's
+// Set does, retrying if the record changes underneath it.
+func (db *DB) setActualLRP(logger lager.Logger, lrp *models.ActualLRP) *models.Error {
+	for {
+		existing, prevIndex, bbsErr := db.store.ActualLRP(logger, lrp.ProcessGuid, lrp.Index, false)
+		if bbsErr.Equal(models.ErrResourceNotFound) {
+			bbsErr = db.store.CreateActualLRP(logger, lrp, false, 0)
+		} else if bbsErr == nil {
+			bbsErr = db.store.CompareAndSwapActualLRP(logger, existing, lrp, false, prevIndex, 0)
+		}
+
+		if !bbsErr.Equal(models.ErrResourceExists) && !bbsErr.Equal(models.ErrResourceConflict) {
+			return bbsErr
+		}
+	}
+}
+
+// deleteActualLRP removes the instance record whatever its state, as etcd's
+// Delete does, retrying if the record changes underneath it.
+func (db *DB) deleteActualLRP(logger lager.Logger, key *models.ActualLRPKey) *models.Error {
+	for {
+		lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, false)
+		if bbsErr != nil {
+			return bbsErr
+		}
+
+		bbsErr = db.store.CompareAndDeleteActualLRP(logger, lrp, false, prevIndex)
+		if !bbsErr.Equal(models.ErrResourceConflict) {
+			return bbsErr
+		}
+	}
+}
+
+func (db *DB) StartActualLRP(logger lager.Logger, request *models.StartActualLRPRequest) (*models.ActualLRP, *models.Error) {
+	key := request.ActualLrpKey
+	instanceKey := request.ActualLrpInstanceKey
+	netInfo := request.ActualLrpNetInfo
+
+	logger.Info("starting")
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, false)
+	if bbsErr == models.ErrResourceNotFound {
+		// the zero tag expects there to be no instance record yet
+		if request.ExpectedModificationTag != nil && !request.ExpectedModificationTag.Equal(&models.ModificationTag{}) {
+			logger.Info("modification-tag-mismatch")
+			return nil, models.ErrResourceConflict
+		}
+		return db.createRunningActualLRP(logger, key, instanceKey, netInfo)
+	} else if bbsErr != nil {
+		logger.Error("failed-to-get-actual-lrp", bbsErr)
+		return nil, bbsErr
+	}
+
+	if request.ExpectedModificationTag != nil && !request.ExpectedModificationTag.Equal(&lrp.ModificationTag) {
+		logger.Info("modification-tag-mismatch")
+		return nil, models.ErrResourceConflict
+	}
+
+	if lrp.ActualLRPKey.Equal(key) &&
+		lrp.ActualLRPInstanceKey.Equal(instanceKey) &&
+		lrp.ActualLRPNetInfo.Equal(netInfo) &&
+		lrp.State == models.ActualLRPStateRunning {
+		logger.Info("succeeded")
+		return lrp, nil
+	}
+
+	if !lrp.AllowsTransitionTo(key, instanceKey, models.ActualLRPStateRunning) {
+		logger.Error("failed-to-transition-actual-lrp-to-started", nil)
+		return nil, models.ErrActualLRPCannotBeStarted
+	}
+
+	before := *lrp
+	lrp.ModificationTag.Increment()
+	lrp.State = models.ActualLRPStateRunning
+	lrp.Since = db.clock.Now().UnixNano()
+	lrp.ActualLRPInstanceKey = *instanceKey
+	lrp.ActualLRPNetInfo = *netInfo
+	lrp.PlacementError = ""
+
+	bbsErr = db.store.CompareAndSwapActualLRP(logger, &before, lrp, false, prevIndex, 0)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return nil, models.ErrActualLRPCannotBeStarted
+	}
+
+	logger.Info("succeeded")
+	return lrp, nil
+}
+
+func (db *DB) CrashActualLRP(logger lager.Logger, request *models.CrashActualLRPRequest) *models.Error {
+	key := request.ActualLrpKey
+	instanceKey := request.ActualLrpInstanceKey
+	logger.Info("starting")
+
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, false)
+	if bbsErr != nil {
+		logger.Error("failed-to-get-actual-lrp", bbsErr)
+		return bbsErr
+	}
+
+	latestChangeTime := time.Duration(db.clock.Now().UnixNano() - lrp.Since)
+
+	var newCrashCount int32
+	if latestChangeTime > models.CrashResetTimeout && lrp.State == models.ActualLRPStateRunning {
+		newCrashCount = 1
+	} else {
+		newCrashCount = lrp.CrashCount + 1
+	}
+
+	if !lrp.AllowsTransitionTo(key, instanceKey, models.ActualLRPStateCrashed) {
+		err := fmt.Errorf("cannot transition crashed lrp from state %s to state %s", lrp.State, models.ActualLRPStateCrashed)
+		logger.Error("failed-to-transition-actual", err)
+		return models.ErrActualLRPCannotBeCrashed
+	}
+
+	if lrp.State == models.ActualLRPStateUnclaimed || lrp.State == models.ActualLRPStateCrashed ||
+		((lrp.State == models.ActualLRPStateClaimed || lrp.State == models.ActualLRPStateRunning) &&
+			!lrp.ActualLRPInstanceKey.Equal(instanceKey)) {
+		return models.ErrActualLRPCannotBeCrashed
+	}
+
+	before := *lrp
+	lrp.State = models.ActualLRPStateCrashed
+	lrp.Since = db.clock.Now().UnixNano()
+	lrp.CrashCount = newCrashCount
+	lrp.ActualLRPInstanceKey = models.ActualLRPInstanceKey{}
+	lrp.ActualLRPNetInfo = models.EmptyActualLRPNetInfo()
+	lrp.ModificationTag.Increment()
+	lrp.CrashReason = request.ErrorMessage
+
+	var immediateRestart bool
+	if lrp.ShouldRestartImmediately(models.NewDefaultRestartCalculator()) {
+		lrp.State = models.ActualLRPStateUnclaimed
+		immediateRestart = true
+	}
+
+	bbsErr = db.store.CompareAndSwapActualLRP(logger, &before, lrp, false, prevIndex, 0)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return models.ErrActualLRPCannotBeCrashed
+	}
+
+	if immediateRestart {
+		auctionErr := db.requestLRPAuctionForLRPKey(logger, key)
+		if auctionErr != nil {
+			return auctionErr
+		}
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+// requestLRPAuctionForLRPKey asks the auctioneer to place the instance, or
+// removes it if its desired LRP is gone.
+func (db *DB) requestLRPAuctionForLRPKey(logger lager.Logger, key *models.ActualLRPKey) *models.Error {
+	desiredLRP, bbsErr := db.DesiredLRPByProcessGuid(logger, key.ProcessGuid)
+	if bbsErr == models.ErrResourceNotFound {
+		bbsErr := db.deleteActualLRP(logger, key)
+		if bbsErr == models.ErrResourceNotFound {
+			return nil
+		} else if bbsErr != nil {
+			logger.Error("failed-to-delete-actual", bbsErr)
+			return models.ErrUnknownError
+		}
+		return nil
+	} else if bbsErr != nil {
+		return bbsErr
+	}
+
+	lrpStart := models.NewLRPStartRequest(desiredLRP, uint(key.Index))
+	err := db.auctioneerClient.RequestLRPAuctions([]*models.LRPStartRequest{&lrpStart})
+	if err != nil {
+		logger.Error("failed-to-request-auction", err)
+		return models.ErrUnknownError
+	}
+	return nil
+}
+
+func (db *DB) createAndStartActualLRPs(logger lager.Logger, desiredLRP *models.DesiredLRP, indices []int32) {
+	createdIndices := make([]uint, 0, len(indices))
+	for _, index := range indices {
+		key := models.NewActualLRPKey(desiredLRP.ProcessGuid, index, desiredLRP.Domain)
+		bbsErr := db.createUnclaimedActualLRP(logger, &key)
+		if bbsErr != nil {
+			continue
+		}
+		createdIndices = append(createdIndices, uint(index))
+	}
+
+	if len(createdIndices) == 0 {
+		return
+	}
+
+	lrpStart := models.NewLRPStartRequest(desiredLRP, createdIndices...)
+	err := db.auctioneerClient.RequestLRPAuctions([]*models.LRPStartRequest{&lrpStart})
+	if err != nil {
+		logger.Error("failed-to-request-auction", err, lager.Data{"indices": createdIndices})
+	}
+}
+
+func (db *DB) createUnclaimedActualLRP(logger lager.Logger, key *models.ActualLRPKey) *models.Error {
+	guid, err := uuid.NewV4()
+	if err != nil {
+		logger.Error("failed-to-generate-epoch", err)
+		return models.ErrUnknownError
+	}
+
+	lrp := models.NewUnclaimedActualLRP(*key, db.clock.Now().UnixNano())
+	lrp.ModificationTag = models.ModificationTag{
+		Epoch: guid.String(),
+		Index: 0,
+	}
+
+	return db.store.CreateActualLRP(logger, lrp, false, 0)
+}
+
+func (db *DB) FailActualLRP(logger lager.Logger, request *models.FailActualLRPRequest) *models.Error {
+	key := request.ActualLrpKey
+
+	logger.Info("failing")
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, false)
+	if bbsErr != nil {
+		logger.Error("failed-to-get-actual-lrp", bbsErr)
+		return bbsErr
+	}
+
+	if lrp.State != models.ActualLRPStateUnclaimed {
+		return models.ErrActualLRPCannotBeFailed
+	}
+
+	before := *lrp
+	lrp.ModificationTag.Increment()
+	lrp.PlacementError = request.ErrorMessage
+	lrp.Since = db.clock.Now().UnixNano()
+
+	bbsErr = db.store.CompareAndSwapActualLRP(logger, &before, lrp, false, prevIndex, 0)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return models.ErrActualLRPCannotBeFailed
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) RemoveActualLRP(logger lager.Logger, processGuid string, index int32) *models.Error {
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, processGuid, index, false)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	return db.removeActualLRP(logger, lrp, prevIndex)
+}
+
+func (db *DB) RetireActualLRP(logger lager.Logger, request *models.RetireActualLRPRequest) *models.Error {
+	var err *models.Error
+	var prevIndex uint64
+	var lrp *models.ActualLRP
+	processGuid := request.ActualLrpKey.ProcessGuid
+	index := request.ActualLrpKey.Index
+
+	for i := 0; i < models.RetireActualLRPRetryAttempts; i++ {
+		lrp, prevIndex, err = db.store.ActualLRP(logger, processGuid, index, false)
+		if err != nil {
+			break
+		}
+
+		switch lrp.State {
+		case models.ActualLRPStateUnclaimed, models.ActualLRPStateCrashed:
+			err = db.removeActualLRP(logger, lrp, prevIndex)
+		default:
+			var cell *models.CellPresence
+			key := lrp.ActualLRPKey
+			instanceKey := lrp.ActualLRPInstanceKey
+			cell, err = db.cellDB.CellById(logger, instanceKey.CellId)
+			if err != nil {
+				if err == models.ErrResourceNotFound {
+					err = db.removeActualLRP(logger, lrp, prevIndex)
+				}
+				break
+			}
+
+			logger.Info("stopping-lrp-instance", lager.Data{
+				"actual-lrp-key": key,
+			})
+			cellErr := db.cellClient.StopLRPInstance(cell.RepAddress, key, instanceKey)
+			if cellErr != nil {
+				err = models.ErrActualLRPCannotBeStopped
+			}
+		}
+
+		if err == nil {
+			break
+		}
+
+		if i+1 < models.RetireActualLRPRetryAttempts {
+			logger.Error("retrying-failed-retire-of-actual-lrp", err, lager.Data{
+				"actual-lrp-key": request.ActualLrpKey,
+				"attempt":        i + 1,
+			})
+		}
+	}
+
+	return err
+}
+
+func (db *DB) removeActualLRP(logger lager.Logger, lrp *models.ActualLRP, prevIndex uint64) *models.Error {
+	logger.Info("starting")
+	bbsErr := db.store.CompareAndDeleteActualLRP(logger, lrp, false, prevIndex)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return models.ErrActualLRPCannotBeRemoved
+	}
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) unclaimActualLRP(logger lager.Logger, lrp *models.ActualLRP, prevIndex uint64) *models.Error {
+	before := *lrp
+	lrp.State = models.ActualLRPStateUnclaimed
+	lrp.ActualLRPInstanceKey = models.ActualLRPInstanceKey{}
+	lrp.ActualLRPNetInfo = models.EmptyActualLRPNetInfo()
+	lrp.Since = db.clock.Now().UnixNano()
+	lrp.ModificationTag.Increment()
+
+	bbsErr := db.store.CompareAndSwapActualLRP(logger, &before, lrp, false, prevIndex, 0)
+	if bbsErr != nil {
+		logger.Error("failed-to-unclaim-actual-lrp", bbsErr)
+		return models.ErrActualLRPCannotBeUnclaimed
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package storedb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// presentCells lists the registered cells once so a convergence pass can
+// tell a missing cell from a failed lookup.
+func (db *DB) presentCells(logger lager.Logger) (models.CellSet, *models.Error) {
+	cells, bbsErr := db.cellDB.Cells(logger)
+	if bbsErr != nil {
+		logger.Error("failed-fetching-cells", bbsErr)
+		return nil, bbsErr
+	}
+
+	cellSet := models.CellSet{}
+	for _, cell := range cells {
+		cellSet.Add(*cell)
+	}
+	return cellSet, nil
+}
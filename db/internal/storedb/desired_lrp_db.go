@@ -0,0 +1,163 @@
+package storedb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/nu7hatch/gouuid"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *DB) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) (*models.DesiredLRPs, *models.Error) {
+	lrps, bbsErr := db.store.DesiredLRPs(logger, filter)
+	if bbsErr != nil {
+		return nil, bbsErr
+	}
+	return &models.DesiredLRPs{DesiredLrps: lrps}, nil
+}
+
+func (db *DB) DesiredLRPByProcessGuid(logger lager.Logger, processGuid string) (*models.DesiredLRP, *models.Error) {
+	lrp, _, bbsErr := db.store.DesiredLRP(logger, processGuid)
+	return lrp, bbsErr
+}
+
+func (db *DB) DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) *models.Error {
+	logger = logger.Session("desire-lrp", lager.Data{"process-guid": desiredLRP.GetProcessGuid()})
+	logger.Info("starting")
+
+	guid, err := uuid.NewV4()
+	if err != nil {
+		logger.Error("failed-to-generate-epoch", err)
+		return models.ErrUnknownError
+	}
+	desiredLRP.ModificationTag = &models.ModificationTag{
+		Epoch: guid.String(),
+		Index: 0,
+	}
+
+	bbsErr := db.store.CreateDesiredLRP(logger, desiredLRP)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return bbsErr
+	}
+
+	indices := make([]int32, desiredLRP.Instances)
+	for i := range indices {
+		indices[i] = int32(i)
+	}
+	db.createAndStartActualLRPs(logger, desiredLRP, indices)
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) UpdateDesiredLRP(logger lager.Logger, request *models.UpdateDesiredLRPRequest) *models.Error {
+	processGuid := request.ProcessGuid
+	update := request.Update
+	logger = logger.Session("update-desired-lrp", lager.Data{"process-guid": processGuid})
+	logger.Info("starting")
+
+	existing, prevIndex, bbsErr := db.store.DesiredLRP(logger, processGuid)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if request.ExpectedModificationTag != nil && !request.ExpectedModificationTag.Equal(existing.ModificationTag) {
+		logger.Info("modification-tag-mismatch")
+		return models.ErrResourceConflict
+	}
+
+	updated := existing.ApplyUpdate(update)
+	err := existing.ValidateModifications(updated)
+	if err != nil {
+		logger.Error("invalid-modification", err)
+		return &models.Error{Type: models.InvalidRecord, Message: err.Error()}
+	}
+
+	err = updated.Validate()
+	if err != nil {
+		return &models.Error{Type: models.InvalidRecord, Message: err.Error()}
+	}
+
+	modificationTag := models.ModificationTag{}
+	if existing.ModificationTag != nil {
+		modificationTag = *existing.ModificationTag
+	}
+	modificationTag.Increment()
+	updated.ModificationTag = &modificationTag
+
+	bbsErr = db.store.CompareAndSwapDesiredLRP(logger, existing, &updated, prevIndex)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return bbsErr
+	}
+
+	switch diff := updated.Instances - existing.Instances; {
+	case diff > 0:
+		indices := make([]int32, 0, diff)
+		for i := existing.Instances; i < updated.Instances; i++ {
+			indices = append(indices, i)
+		}
+		logger.Info("scaling-up", lager.Data{"from": existing.Instances, "to": updated.Instances})
+		db.createAndStartActualLRPs(logger, &updated, indices)
+
+	case diff < 0:
+		logger.Info("scaling-down", lager.Data{"from": existing.Instances, "to": updated.Instances})
+		for i := updated.Instances; i < existing.Instances; i++ {
+			key := models.NewActualLRPKey(processGuid, i, updated.Domain)
+			retireErr := db.RetireActualLRP(logger, &models.RetireActualLRPRequest{ActualLrpKey: &key})
+			if retireErr != nil && !retireErr.Equal(models.ErrResourceNotFound) {
+				logger.Error("failed-to-retire-actual-lrp", retireErr, lager.Data{"index": i})
+			}
+		}
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) RemoveDesiredLRP(logger lager.Logger, processGuid string) *models.Error {
+	logger = logger.Session("remove-desired-lrp", lager.Data{"process-guid": processGuid})
+	logger.Info("starting")
+
+	bbsErr := db.deleteDesiredLRP(logger, processGuid)
+	if bbsErr != nil {
+		logger.Error("failed-to-remove-desired-lrp", bbsErr)
+		return bbsErr
+	}
+
+	groups, bbsErr := db.ActualLRPGroupsByProcessGuid(logger, processGuid)
+	if bbsErr != nil {
+		logger.Error("failed-to-fetch-actual-lrps", bbsErr)
+		return nil
+	}
+
+	for _, group := range groups.ActualLrpGroups {
+		if group.Instance == nil {
+			continue
+		}
+
+		key := group.Instance.ActualLRPKey
+		retireErr := db.RetireActualLRP(logger, &models.RetireActualLRPRequest{ActualLrpKey: &key})
+		if retireErr != nil && !retireErr.Equal(models.ErrResourceNotFound) {
+			logger.Error("failed-to-retire-actual-lrp", retireErr, lager.Data{"index": key.Index})
+		}
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+// deleteDesiredLRP removes the desired LRP whatever its contents, as etcd's
+// Delete does, retrying if it changes underneath it.
+func (db *DB) deleteDesiredLRP(logger lager.Logger, processGuid string) *models.Error {
+	for {
+		existing, prevIndex, bbsErr := db.store.DesiredLRP(logger, processGuid)
+		if bbsErr != nil {
+			return bbsErr
+		}
+
+		bbsErr = db.store.CompareAndDeleteDesiredLRP(logger, existing, prevIndex)
+		if !bbsErr.Equal(models.ErrResourceConflict) {
+			return bbsErr
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package storedb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/nu7hatch/gouuid"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *DB) EvacuateClaimedActualLRP(logger lager.Logger, request *models.EvacuateClaimedActualLRPRequest) (bool, *models.Error) {
+	key := request.ActualLrpKey
+	instanceKey := request.ActualLrpInstanceKey
+	logger = logger.Session("evacuate-claimed", lager.Data{"actual-lrp-key": key, "actual-lrp-instance-key": instanceKey})
+	logger.Info("starting")
+
+	bbsErr := db.removeEvacuatingActualLRP(logger, key, instanceKey)
+	if bbsErr != nil && !bbsErr.Equal(models.ErrActualLRPCannotBeRemoved) {
+		logger.Error("failed-to-remove-evacuating-actual-lrp", bbsErr)
+	}
+
+	bbsErr = db.unclaimActualLRPForInstance(logger, key, instanceKey)
+	if bbsErr.Equal(models.ErrResourceNotFound) || bbsErr.Equal(models.ErrActualLRPCannotBeUnclaimed) {
+		logger.Info("succeeded")
+		return false, nil
+	}
+	if bbsErr != nil {
+		logger.Error("failed-to-unclaim-actual-lrp", bbsErr)
+		return false, bbsErr
+	}
+
+	bbsErr = db.requestLRPAuctionForLRPKey(logger, key)
+	if bbsErr != nil {
+		logger.Error("failed-to-request-auction", bbsErr)
+	}
+
+	logger.Info("succeeded")
+	return false, nil
+}
+
+func (db *DB) EvacuateRunningActualLRP(logger lager.Logger, request *models.EvacuateRunningActualLRPRequest) (bool, *models.Error) {
+	key := request.ActualLrpKey
+	instanceKey := request.ActualLrpInstanceKey
+	netInfo := request.ActualLrpNetInfo
+	logger = logger.Session("evacuate-running", lager.Data{"actual-lrp-key": key, "actual-lrp-instance-key": instanceKey})
+	logger.Info("starting")
+
+	group, bbsErr := db.ActualLRPGroupByProcessGuidAndIndex(logger, key.ProcessGuid, key.Index)
+	if bbsErr.Equal(models.ErrResourceNotFound) {
+		logger.Info("succeeded")
+		return false, nil
+	}
+	if bbsErr != nil {
+		logger.Error("failed-to-get-actual-lrp-group", bbsErr)
+		return true, bbsErr
+	}
+
+	instance := group.Instance
+
+	switch {
+	case instance == nil:
+		logger.Info("instance-is-missing")
+		bbsErr = db.removeEvacuatingActualLRP(logger, key, instanceKey)
+		if bbsErr != nil && !bbsErr.Equal(models.ErrActualLRPCannotBeRemoved) {
+			return true, bbsErr
+		}
+		return false, nil
+
+	case instance.State == models.ActualLRPStateUnclaimed && instance.PlacementError != "":
+		logger.Info("instance-failed-placement")
+		bbsErr = db.removeEvacuatingActualLRP(logger, key, instanceKey)
+		if bbsErr != nil && !bbsErr.Equal(models.ErrActualLRPCannotBeRemoved) {
+			return true, bbsErr
+		}
+		return false, nil
+
+	case instance.State == models.ActualLRPStateUnclaimed ||
+		(instance.State == models.ActualLRPStateClaimed && !instance.ActualLRPInstanceKey.Equal(instanceKey)):
+		logger.Info("instance-is-being-replaced")
+		bbsErr = db.evacuateActualLRP(logger, key, instanceKey, netInfo, request.Ttl, false)
+		if bbsErr.Equal(models.ErrActualLRPCannotBeEvacuated) {
+			return false, bbsErr
+		}
+		if bbsErr != nil {
+			return true, bbsErr
+		}
+		return true, nil
+
+	case (instance.State == models.ActualLRPStateClaimed || instance.State == models.ActualLRPStateRunning) &&
+		instance.ActualLRPInstanceKey.Equal(instanceKey):
+		logger.Info("instance-belongs-to-evacuating-cell")
+		bbsErr = db.evacuateActualLRP(logger, key, instanceKey, netInfo, request.Ttl, true)
+		if bbsErr != nil {
+			return true, bbsErr
+		}
+
+		bbsErr = db.unclaimActualLRPForInstance(logger, key, instanceKey)
+		if bbsErr != nil {
+			logger.Error("failed-to-unclaim-actual-lrp", bbsErr)
+			return true, bbsErr
+		}
+
+		bbsErr = db.requestLRPAuctionForLRPKey(logger, key)
+		if bbsErr != nil {
+			logger.Error("failed-to-request-auction", bbsErr)
+		}
+
+		logger.Info("succeeded")
+		return true, nil
+
+	case instance.State == models.ActualLRPStateRunning || instance.State == models.ActualLRPStateCrashed:
+		logger.Info("instance-no-longer-needs-evacuation", lager.Data{"state": instance.State})
+		bbsErr = db.removeEvacuatingActualLRP(logger, key, instanceKey)
+		if bbsErr.Equal(models.ErrActualLRPCannotBeRemoved) {
+			return false, models.ErrActualLRPCannotBeEvacuated
+		}
+		if bbsErr != nil {
+			return true, bbsErr
+		}
+		return false, nil
+	}
+
+	logger.Info("succeeded")
+	return true, nil
+}
+
+func (db *DB) EvacuateStoppedActualLRP(logger lager.Logger, request *models.EvacuateStoppedActualLRPRequest) (bool, *models.Error) {
+	key := request.ActualLrpKey
+	instanceKey := request.ActualLrpInstanceKey
+	logger = logger.Session("evacuate-stopped", lager.Data{"actual-lrp-key": key, "actual-lrp-instance-key": instanceKey})
+	logger.Info("starting")
+
+	group, bbsErr := db.ActualLRPGroupByProcessGuidAndIndex(logger, key.ProcessGuid, key.Index)
+	if bbsErr != nil {
+		logger.Error("failed-to-get-actual-lrp-group", bbsErr)
+		return false, bbsErr
+	}
+
+	removed := false
+	if group.Evacuating != nil && group.Evacuating.ActualLRPInstanceKey.Equal(instanceKey) {
+		bbsErr = db.removeEvacuatingActualLRP(logger, key, instanceKey)
+		if bbsErr != nil {
+			return false, bbsErr
+		}
+		removed = true
+	}
+
+	if group.Instance != nil && group.Instance.ActualLRPInstanceKey.Equal(instanceKey) {
+		lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, false)
+		if bbsErr != nil {
+			return false, bbsErr
+		}
+		if !lrp.ActualLRPInstanceKey.Equal(instanceKey) {
+			return false, models.ErrActualLRPCannotBeRemoved
+		}
+		bbsErr = db.removeActualLRP(logger, lrp, prevIndex)
+		if bbsErr != nil {
+			return false, bbsErr
+		}
+		removed = true
+	}
+
+	if !removed {
+		logger.Info("no-actual-lrp-for-instance")
+		return false, models.ErrActualLRPCannotBeRemoved
+	}
+
+	logger.Info("succeeded")
+	return false, nil
+}
+
+func (db *DB) EvacuateCrashedActualLRP(logger lager.Logger, request *models.EvacuateCrashedActualLRPRequest) (bool, *models.Error) {
+	key := request.ActualLrpKey
+	instanceKey := request.ActualLrpInstanceKey
+	logger = logger.Session("evacuate-crashed", lager.Data{"actual-lrp-key": key, "actual-lrp-instance-key": instanceKey})
+	logger.Info("starting")
+
+	bbsErr := db.removeEvacuatingActualLRP(logger, key, instanceKey)
+	if bbsErr != nil && !bbsErr.Equal(models.ErrActualLRPCannotBeRemoved) {
+		logger.Error("failed-to-remove-evacuating-actual-lrp", bbsErr)
+		return false, bbsErr
+	}
+
+	bbsErr = db.CrashActualLRP(logger, &models.CrashActualLRPRequest{
+		ActualLrpKey:         key,
+		ActualLrpInstanceKey: instanceKey,
+		ErrorMessage:         request.ErrorMessage,
+	})
+	if bbsErr != nil && !bbsErr.Equal(models.ErrResourceNotFound) && !bbsErr.Equal(models.ErrActualLRPCannotBeCrashed) {
+		logger.Error("failed-to-crash-actual-lrp", bbsErr)
+		return false, bbsErr
+	}
+
+	logger.Info("succeeded")
+	return false, nil
+}
+
+func (db *DB) RemoveEvacuatingActualLRP(logger lager.Logger, request *models.RemoveEvacuatingActualLRPRequest) *models.Error {
+	logger = logger.Session("remove-evacuating", lager.Data{"actual-lrp-key": request.ActualLrpKey, "actual-lrp-instance-key": request.ActualLrpInstanceKey})
+	return db.removeEvacuatingActualLRP(logger, request.ActualLrpKey, request.ActualLrpInstanceKey)
+}
+
+// evacuateActualLRP records a RUNNING evacuating actual LRP for the given
+// instance that expires after ttl seconds. An existing evacuating record for a
+// different instance is only overwritten when replaceExisting is set.
+func (db *DB) evacuateActualLRP(
+	logger lager.Logger,
+	key *models.ActualLRPKey,
+	instanceKey *models.ActualLRPInstanceKey,
+	netInfo *models.ActualLRPNetInfo,
+	ttl uint64,
+	replaceExisting bool,
+) *models.Error {
+	existing, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, true)
+	if bbsErr != nil && !bbsErr.Equal(models.ErrResourceNotFound) {
+		return bbsErr
+	}
+
+	if existing != nil &&
+		existing.ActualLRPInstanceKey.Equal(instanceKey) &&
+		existing.ActualLRPNetInfo.Equal(netInfo) {
+		logger.Debug("evacuating-actual-lrp-already-recorded")
+		return nil
+	}
+
+	if existing != nil && !existing.ActualLRPInstanceKey.Equal(instanceKey) && !replaceExisting {
+		logger.Info("evacuating-actual-lrp-belongs-to-another-instance")
+		return models.ErrActualLRPCannotBeEvacuated
+	}
+
+	lrp := &models.ActualLRP{
+		ActualLRPKey:         *key,
+		ActualLRPInstanceKey: *instanceKey,
+		ActualLRPNetInfo:     *netInfo,
+		State:                models.ActualLRPStateRunning,
+		Since:                db.clock.Now().UnixNano(),
+	}
+
+	if existing != nil {
+		lrp.ModificationTag = existing.ModificationTag
+		lrp.ModificationTag.Increment()
+		bbsErr = db.store.CompareAndSwapActualLRP(logger, existing, lrp, true, prevIndex, ttl)
+	} else {
+		guid, err := uuid.NewV4()
+		if err != nil {
+			logger.Error("failed-to-generate-epoch", err)
+			return models.ErrUnknownError
+		}
+		lrp.ModificationTag = models.ModificationTag{Epoch: guid.String(), Index: 0}
+		bbsErr = db.store.CreateActualLRP(logger, lrp, true, ttl)
+	}
+	if bbsErr != nil {
+		logger.Error("failed-to-record-evacuating-actual-lrp", bbsErr)
+		return models.ErrActualLRPCannotBeEvacuated
+	}
+
+	return nil
+}
+
+// removeEvacuatingActualLRP deletes the evacuating actual LRP if it belongs to
+// the given instance. A missing evacuating record is not an error.
+func (db *DB) removeEvacuatingActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) *models.Error {
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, true)
+	if bbsErr.Equal(models.ErrResourceNotFound) {
+		logger.Debug("evacuating-actual-lrp-not-found")
+		return nil
+	}
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if !lrp.ActualLRPInstanceKey.Equal(instanceKey) {
+		logger.Debug("evacuating-actual-lrp-belongs-to-another-instance")
+		return models.ErrActualLRPCannotBeRemoved
+	}
+
+	bbsErr = db.store.CompareAndDeleteActualLRP(logger, lrp, true, prevIndex)
+	if bbsErr != nil {
+		logger.Error("failed-to-remove-evacuating-actual-lrp", bbsErr)
+		return models.ErrActualLRPCannotBeRemoved
+	}
+
+	return nil
+}
+
+// unclaimActualLRPForInstance moves the actual LRP back to UNCLAIMED, provided
+// it is still claimed or running on the given instance.
+func (db *DB) unclaimActualLRPForInstance(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) *models.Error {
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, key.ProcessGuid, key.Index, false)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if !lrp.ActualLRPInstanceKey.Equal(instanceKey) ||
+		(lrp.State != models.ActualLRPStateClaimed && lrp.State != models.ActualLRPStateRunning) {
+		logger.Info("actual-lrp-not-claimed-by-instance", lager.Data{"state": lrp.State})
+		return models.ErrActualLRPCannotBeUnclaimed
+	}
+
+	return db.unclaimActualLRP(logger, lrp, prevIndex)
+}
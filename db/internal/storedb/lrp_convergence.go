@@ -0,0 +1,136 @@
+package storedb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *DB) ConvergeLRPs(logger lager.Logger) models.LRPConvergenceSummary {
+	logger = logger.Session("converge-lrps")
+	logger.Info("starting")
+
+	summary := models.LRPConvergenceSummary{}
+
+	desiredLRPs, bbsErr := db.DesiredLRPs(logger, models.DesiredLRPFilter{})
+	if bbsErr != nil {
+		logger.Error("failed-fetching-desired-lrps", bbsErr)
+		return summary
+	}
+
+	groups, bbsErr := db.ActualLRPGroups(logger, models.ActualLRPFilter{})
+	if bbsErr != nil {
+		logger.Error("failed-fetching-actual-lrps", bbsErr)
+		return summary
+	}
+
+	desiredByGuid := map[string]*models.DesiredLRP{}
+	for _, desiredLRP := range desiredLRPs.GetDesiredLrps() {
+		desiredByGuid[desiredLRP.ProcessGuid] = desiredLRP
+	}
+
+	actualsByGuid := map[string]map[int32]*models.ActualLRP{}
+	for _, group := range groups.GetActualLrpGroups() {
+		actual := group.GetInstance()
+		if actual == nil {
+			continue
+		}
+		if actualsByGuid[actual.ProcessGuid] == nil {
+			actualsByGuid[actual.ProcessGuid] = map[int32]*models.ActualLRP{}
+		}
+		actualsByGuid[actual.ProcessGuid][actual.Index] = actual
+	}
+
+	cells, cellsErr := db.presentCells(logger)
+	cellMissing := func(cellId string) bool {
+		return cellsErr == nil && !cells.HasCellID(cellId)
+	}
+
+	now := db.clock.Now()
+	restartCalculator := models.NewDefaultRestartCalculator()
+	startRequests := []*models.LRPStartRequest{}
+
+	for processGuid, actuals := range actualsByGuid {
+		desiredLRP, desired := desiredByGuid[processGuid]
+
+		restartIndices := []uint{}
+		for index, actual := range actuals {
+			switch {
+			case !desired:
+				logger.Info("retiring-orphaned-actual-lrp", lager.Data{"actual-lrp-key": actual.ActualLRPKey})
+				key := actual.ActualLRPKey
+				if db.RetireActualLRP(logger, &models.RetireActualLRPRequest{ActualLrpKey: &key}) == nil {
+					summary.OrphanedLRPs++
+				}
+
+			case index >= desiredLRP.Instances:
+				logger.Info("retiring-extra-actual-lrp", lager.Data{"actual-lrp-key": actual.ActualLRPKey})
+				key := actual.ActualLRPKey
+				if db.RetireActualLRP(logger, &models.RetireActualLRPRequest{ActualLrpKey: &key}) == nil {
+					summary.ExtraLRPs++
+				}
+
+			case actual.ShouldRestartCrash(now, restartCalculator):
+				logger.Info("restarting-crashed-actual-lrp", lager.Data{"actual-lrp-key": actual.ActualLRPKey, "crash-count": actual.CrashCount})
+				if db.unclaimUnchangedActualLRP(logger, actual) == nil {
+					restartIndices = append(restartIndices, uint(index))
+					summary.RestartedCrashedLRPs++
+				}
+
+			case (actual.State == models.ActualLRPStateClaimed || actual.State == models.ActualLRPStateRunning) && cellMissing(actual.CellId):
+				logger.Info("unclaiming-actual-lrp-on-missing-cell", lager.Data{"actual-lrp-key": actual.ActualLRPKey, "cell-id": actual.CellId})
+				if db.unclaimUnchangedActualLRP(logger, actual) == nil {
+					restartIndices = append(restartIndices, uint(index))
+					summary.LRPsOnMissingCells++
+				}
+			}
+		}
+
+		if len(restartIndices) > 0 {
+			startRequest := models.NewLRPStartRequest(desiredLRP, restartIndices...)
+			startRequests = append(startRequests, &startRequest)
+		}
+	}
+
+	if len(startRequests) > 0 {
+		err := db.auctioneerClient.RequestLRPAuctions(startRequests)
+		if err != nil {
+			logger.Error("failed-to-request-auctions", err)
+		}
+	}
+
+	for processGuid, desiredLRP := range desiredByGuid {
+		actuals := actualsByGuid[processGuid]
+
+		missingIndices := []int32{}
+		for index := int32(0); index < desiredLRP.Instances; index++ {
+			if _, ok := actuals[index]; !ok {
+				missingIndices = append(missingIndices, index)
+			}
+		}
+
+		if len(missingIndices) > 0 {
+			logger.Info("creating-missing-actual-lrps", lager.Data{"process-guid": processGuid, "indices": missingIndices})
+			db.createAndStartActualLRPs(logger, desiredLRP, missingIndices)
+			summary.MissingLRPs += len(missingIndices)
+		}
+	}
+
+	logger.Info("succeeded", lager.Data{"summary": summary})
+	return summary
+}
+
+// unclaimUnchangedActualLRP moves the actual LRP back to UNCLAIMED, provided
+// it has not been modified since it was observed by convergence.
+func (db *DB) unclaimUnchangedActualLRP(logger lager.Logger, observed *models.ActualLRP) *models.Error {
+	lrp, prevIndex, bbsErr := db.store.ActualLRP(logger, observed.ProcessGuid, observed.Index, false)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if lrp.ModificationTag != observed.ModificationTag {
+		logger.Info("actual-lrp-changed-during-convergence", lager.Data{"actual-lrp-key": lrp.ActualLRPKey})
+		return models.ErrResourceConflict
+	}
+
+	return db.unclaimActualLRP(logger, lrp, prevIndex)
+}
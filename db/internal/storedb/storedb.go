@@ -0,0 +1,80 @@
+// Package storedb implements the BBS state transitions once, on top of the
+// record storage each backend provides.
+package storedb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/auctionhandlers"
+	"github.com/cloudfoundry-incubator/bbs/cellhandlers"
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+const (
+	TaskCancelledFailureReason       = "task was cancelled"
+	TaskCellDisappearedFailureReason = "cell disappeared before completion"
+	TaskNotStartedFailureReason      = "not started within time limit"
+)
+
+// Store reads and writes individual records. Reads return the index the
+// record was read at; CompareAndSwap and CompareAndDelete only apply to a
+// record still at that index and fail with models.ErrResourceConflict
+// otherwise. Creates fail with models.ErrResourceExists if the record is
+// already there. Lists apply the whole filter, including its Limit and After
+// fields, reading no more records than the page needs where the backend can
+// avoid it.
+type Store interface {
+	DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) ([]*models.DesiredLRP, *models.Error)
+	DesiredLRP(logger lager.Logger, processGuid string) (*models.DesiredLRP, uint64, *models.Error)
+	CreateDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP) *models.Error
+	CompareAndSwapDesiredLRP(logger lager.Logger, before, after *models.DesiredLRP, prevIndex uint64) *models.Error
+	CompareAndDeleteDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP, prevIndex uint64) *models.Error
+
+	// ActualLRPGroups lists the groups of processGuid, or of every process
+	// if processGuid is empty.
+	ActualLRPGroups(logger lager.Logger, processGuid string, filter models.ActualLRPFilter) ([]*models.ActualLRPGroup, *models.Error)
+	ActualLRP(logger lager.Logger, processGuid string, index int32, evacuating bool) (*models.ActualLRP, uint64, *models.Error)
+	// ActualLRPGroup reads the instance and evacuating records of an index
+	// together, failing with models.ErrResourceNotFound if neither is there.
+	ActualLRPGroup(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, *models.Error)
+	CreateActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, ttl uint64) *models.Error
+	CompareAndSwapActualLRP(logger lager.Logger, before, after *models.ActualLRP, evacuating bool, prevIndex, ttl uint64) *models.Error
+	CompareAndDeleteActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, prevIndex uint64) *models.Error
+
+	Tasks(logger lager.Logger, filter models.TaskFilter) ([]TaskRecord, *models.Error)
+	Task(logger lager.Logger, taskGuid string) (*models.Task, uint64, *models.Error)
+	CreateTask(logger lager.Logger, task *models.Task) *models.Error
+	CompareAndSwapTask(logger lager.Logger, before, after *models.Task, prevIndex uint64) *models.Error
+	CompareAndDeleteTask(logger lager.Logger, task *models.Task, prevIndex uint64) *models.Error
+}
+
+// TaskRecord is a stored task and the index it was read at.
+type TaskRecord struct {
+	Task  *models.Task
+	Index uint64
+}
+
+// DB implements every db.DB operation other than domains and watches.
+type DB struct {
+	store            Store
+	clock            clock.Clock
+	auctioneerClient auctionhandlers.Client
+	cellClient       cellhandlers.Client
+
+	cellDB db.CellDB
+
+	taskCompletionClient taskworkpool.TaskCompletionClient
+}
+
+func New(store Store, auctioneerClient auctionhandlers.Client, cellClient cellhandlers.Client, cellDB db.CellDB, clock clock.Clock, taskCompletionClient taskworkpool.TaskCompletionClient) *DB {
+	return &DB{
+		store:                store,
+		clock:                clock,
+		auctioneerClient:     auctioneerClient,
+		cellClient:           cellClient,
+		cellDB:               cellDB,
+		taskCompletionClient: taskCompletionClient,
+	}
+}
@@ -0,0 +1,102 @@
+package storedb
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *DB) ConvergeTasks(
+	logger lager.Logger,
+	kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration,
+) models.TaskConvergenceSummary {
+	logger = logger.Session("converge-tasks", lager.Data{
+		"kick-task-duration":             kickTaskDuration.String(),
+		"expire-pending-task-duration":   expirePendingTaskDuration.String(),
+		"expire-completed-task-duration": expireCompletedTaskDuration.String(),
+	})
+	logger.Info("starting")
+
+	summary := models.TaskConvergenceSummary{}
+
+	records, bbsErr := db.store.Tasks(logger, models.TaskFilter{})
+	if bbsErr != nil {
+		logger.Error("failed-fetching-tasks", bbsErr)
+		return summary
+	}
+
+	cells, cellsErr := db.presentCells(logger)
+	cellMissing := func(cellId string) bool {
+		return cellsErr == nil && !cells.HasCellID(cellId)
+	}
+
+	now := db.clock.Now().UnixNano()
+	olderThan := func(timestamp int64, duration time.Duration) bool {
+		return now-timestamp >= duration.Nanoseconds()
+	}
+
+	tasksToAuction := []*models.Task{}
+
+	for _, record := range records {
+		task := record.Task
+		taskLogger := logger.WithData(lager.Data{"task-guid": task.TaskGuid})
+		prevIndex := record.Index
+
+		switch task.State {
+		case models.Task_Pending:
+			if olderThan(task.CreatedAt, expirePendingTaskDuration) {
+				taskLogger.Info("failing-expired-pending-task")
+				before := *task
+				db.markTaskCompleted(task, true, TaskNotStartedFailureReason, "")
+				if db.compareAndSwapTask(taskLogger, &before, task, prevIndex, models.ErrTaskCannotBeFailed) == nil {
+					db.submitCompletedTask(task)
+					summary.PendingTasksExpired++
+				}
+			} else if olderThan(task.UpdatedAt, kickTaskDuration) {
+				taskLogger.Info("requesting-auction-for-pending-task")
+				tasksToAuction = append(tasksToAuction, task)
+				summary.PendingTasksKicked++
+			}
+
+		case models.Task_Running:
+			if cellMissing(task.CellId) {
+				taskLogger.Info("failing-task-on-missing-cell", lager.Data{"cell-id": task.CellId})
+				before := *task
+				db.markTaskCompleted(task, true, TaskCellDisappearedFailureReason, "")
+				if db.compareAndSwapTask(taskLogger, &before, task, prevIndex, models.ErrTaskCannotBeFailed) == nil {
+					db.submitCompletedTask(task)
+					summary.RunningTasksFailed++
+				}
+			}
+
+		case models.Task_Completed, models.Task_Resolving:
+			if olderThan(task.FirstCompletedAt, expireCompletedTaskDuration) {
+				taskLogger.Info("pruning-completed-task", lager.Data{"state": task.State})
+				if db.compareAndDeleteTask(taskLogger, task, prevIndex, models.ErrResourceConflict) != nil {
+					continue
+				}
+				summary.TasksPruned++
+			} else if olderThan(task.UpdatedAt, kickTaskDuration) && task.CompletionCallbackUrl != "" {
+				taskLogger.Info("kicking-completed-task", lager.Data{"state": task.State})
+				if task.State == models.Task_Resolving {
+					before := *task
+					task.State = models.Task_Completed
+					task.UpdatedAt = now
+					if db.compareAndSwapTask(taskLogger, &before, task, prevIndex, models.ErrTaskCannotBeCompleted) != nil {
+						continue
+					}
+				}
+				db.submitCompletedTask(task)
+				summary.CompletedTasksKicked++
+			}
+		}
+	}
+
+	if len(tasksToAuction) > 0 {
+		db.requestTaskAuctions(logger, tasksToAuction)
+	}
+
+	logger.Info("succeeded", lager.Data{"summary": summary})
+	return summary
+}
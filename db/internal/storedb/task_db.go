@@ -0,0 +1,297 @@
+package storedb
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	oldmodels "github.com/cloudfoundry-incubator/runtime-schema/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *DB) Tasks(logger lager.Logger, filter models.TaskFilter) (*models.Tasks, *models.Error) {
+	records, bbsErr := db.store.Tasks(logger, filter)
+	if bbsErr != nil {
+		return nil, bbsErr
+	}
+
+	tasks := &models.Tasks{}
+	for _, record := range records {
+		tasks.Tasks = append(tasks.Tasks, record.Task)
+	}
+
+	logger.Debug("succeeded-performing-deserialization", lager.Data{"num-tasks": len(tasks.GetTasks())})
+	return tasks, nil
+}
+
+func (db *DB) TaskByGuid(logger lager.Logger, taskGuid string) (*models.Task, *models.Error) {
+	task, _, bbsErr := db.store.Task(logger, taskGuid)
+	return task, bbsErr
+}
+
+func (db *DB) DesireTask(logger lager.Logger, task *models.Task) *models.Error {
+	logger = logger.Session("desire-task", lager.Data{"task-guid": task.TaskGuid})
+	logger.Info("starting")
+
+	now := db.clock.Now().UnixNano()
+	task.State = models.Task_Pending
+	task.CreatedAt = now
+	task.UpdatedAt = now
+
+	bbsErr := db.store.CreateTask(logger, task)
+	if bbsErr != nil {
+		logger.Error("failed", bbsErr)
+		return bbsErr
+	}
+
+	db.requestTaskAuctions(logger, []*models.Task{task})
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) StartTask(logger lager.Logger, taskGuid, cellId string) (bool, *models.Error) {
+	logger = logger.Session("start-task", lager.Data{"task-guid": taskGuid, "cell-id": cellId})
+	logger.Info("starting")
+
+	task, prevIndex, bbsErr := db.store.Task(logger, taskGuid)
+	if bbsErr != nil {
+		return false, bbsErr
+	}
+
+	if task.State == models.Task_Running && task.CellId == cellId {
+		logger.Info("task-already-running-on-cell")
+		return false, nil
+	}
+
+	if task.State != models.Task_Pending {
+		logger.Error("invalid-state-transition", nil, lager.Data{"state": task.State})
+		return false, models.ErrTaskCannotBeStarted
+	}
+
+	before := *task
+	task.State = models.Task_Running
+	task.CellId = cellId
+	task.UpdatedAt = db.clock.Now().UnixNano()
+
+	bbsErr = db.compareAndSwapTask(logger, &before, task, prevIndex, models.ErrTaskCannotBeStarted)
+	if bbsErr != nil {
+		return false, bbsErr
+	}
+
+	logger.Info("succeeded")
+	return true, nil
+}
+
+func (db *DB) CancelTask(logger lager.Logger, taskGuid string) *models.Error {
+	logger = logger.Session("cancel-task", lager.Data{"task-guid": taskGuid})
+	logger.Info("starting")
+
+	task, prevIndex, bbsErr := db.store.Task(logger, taskGuid)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if task.State == models.Task_Completed || task.State == models.Task_Resolving {
+		logger.Error("invalid-state-transition", nil, lager.Data{"state": task.State})
+		return models.ErrTaskCannotBeCancelled
+	}
+
+	before := *task
+	cellId := task.CellId
+
+	db.markTaskCompleted(task, true, TaskCancelledFailureReason, "")
+	bbsErr = db.compareAndSwapTask(logger, &before, task, prevIndex, models.ErrTaskCannotBeCancelled)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	db.submitCompletedTask(task)
+
+	if before.State == models.Task_Running {
+		cell, bbsErr := db.cellDB.CellById(logger, cellId)
+		if bbsErr != nil {
+			logger.Error("failed-to-find-cell", bbsErr, lager.Data{"cell-id": cellId})
+			return nil
+		}
+
+		err := db.cellClient.CancelTask(cell.RepAddress, taskGuid)
+		if err != nil {
+			logger.Error("failed-to-cancel-task-on-cell", err, lager.Data{"cell-id": cellId})
+		}
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) FailTask(logger lager.Logger, taskGuid, failureReason string) *models.Error {
+	logger = logger.Session("fail-task", lager.Data{"task-guid": taskGuid})
+	logger.Info("starting")
+
+	task, prevIndex, bbsErr := db.store.Task(logger, taskGuid)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if task.State == models.Task_Completed || task.State == models.Task_Resolving {
+		logger.Error("invalid-state-transition", nil, lager.Data{"state": task.State})
+		return models.ErrTaskCannotBeFailed
+	}
+
+	before := *task
+	db.markTaskCompleted(task, true, failureReason, "")
+	bbsErr = db.compareAndSwapTask(logger, &before, task, prevIndex, models.ErrTaskCannotBeFailed)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	db.submitCompletedTask(task)
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) CompleteTask(logger lager.Logger, taskGuid, cellId string, failed bool, failureReason, result string) *models.Error {
+	logger = logger.Session("complete-task", lager.Data{"task-guid": taskGuid, "cell-id": cellId})
+	logger.Info("starting")
+
+	task, prevIndex, bbsErr := db.store.Task(logger, taskGuid)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if task.State != models.Task_Running {
+		logger.Error("invalid-state-transition", nil, lager.Data{"state": task.State})
+		return models.ErrTaskCannotBeCompleted
+	}
+
+	if task.CellId != cellId {
+		logger.Error("running-on-different-cell", nil, lager.Data{"running-on-cell": task.CellId})
+		return models.ErrTaskRunningOnDifferentCell
+	}
+
+	before := *task
+	db.markTaskCompleted(task, failed, failureReason, result)
+	bbsErr = db.compareAndSwapTask(logger, &before, task, prevIndex, models.ErrTaskCannotBeCompleted)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	db.submitCompletedTask(task)
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) ResolvingTask(logger lager.Logger, taskGuid string) *models.Error {
+	logger = logger.Session("resolving-task", lager.Data{"task-guid": taskGuid})
+	logger.Info("starting")
+
+	task, prevIndex, bbsErr := db.store.Task(logger, taskGuid)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if task.State != models.Task_Completed {
+		logger.Error("invalid-state-transition", nil, lager.Data{"state": task.State})
+		return models.ErrTaskCannotBeMarkedAsResolving
+	}
+
+	before := *task
+	task.State = models.Task_Resolving
+	task.UpdatedAt = db.clock.Now().UnixNano()
+
+	bbsErr = db.compareAndSwapTask(logger, &before, task, prevIndex, models.ErrTaskCannotBeMarkedAsResolving)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) ResolveTask(logger lager.Logger, taskGuid string) *models.Error {
+	logger = logger.Session("resolve-task", lager.Data{"task-guid": taskGuid})
+	logger.Info("starting")
+
+	task, prevIndex, bbsErr := db.store.Task(logger, taskGuid)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	if task.State != models.Task_Resolving {
+		logger.Error("invalid-state-transition", nil, lager.Data{"state": task.State})
+		return models.ErrTaskCannotBeResolved
+	}
+
+	bbsErr = db.compareAndDeleteTask(logger, task, prevIndex, models.ErrTaskCannotBeResolved)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	logger.Info("succeeded")
+	return nil
+}
+
+func (db *DB) markTaskCompleted(task *models.Task, failed bool, failureReason, result string) {
+	now := db.clock.Now().UnixNano()
+	task.State = models.Task_Completed
+	task.UpdatedAt = now
+	task.FirstCompletedAt = now
+	task.Failed = failed
+	task.FailureReason = failureReason
+	task.Result = result
+}
+
+func (db *DB) submitCompletedTask(task *models.Task) {
+	if task.CompletionCallbackUrl != "" {
+		db.taskCompletionClient.Submit(db, task)
+	}
+}
+
+// compareAndSwapTask replaces before with after, provided the task is still
+// at prevIndex. It fails with casErr otherwise.
+func (db *DB) compareAndSwapTask(logger lager.Logger, before, after *models.Task, prevIndex uint64, casErr *models.Error) *models.Error {
+	bbsErr := db.store.CompareAndSwapTask(logger, before, after, prevIndex)
+	if bbsErr.Equal(models.ErrResourceConflict) {
+		return casErr
+	}
+	return bbsErr
+}
+
+func (db *DB) compareAndDeleteTask(logger lager.Logger, task *models.Task, prevIndex uint64, casErr *models.Error) *models.Error {
+	bbsErr := db.store.CompareAndDeleteTask(logger, task, prevIndex)
+	if bbsErr.Equal(models.ErrResourceConflict) {
+		return casErr
+	}
+	return bbsErr
+}
+
+func (db *DB) requestTaskAuctions(logger lager.Logger, tasks []*models.Task) {
+	oldTasks := make([]oldmodels.Task, 0, len(tasks))
+	for _, task := range tasks {
+		taskRawJSON, err := json.Marshal(task)
+		if err != nil {
+			logger.Error("failed-to-serialize-task", err, lager.Data{"task-guid": task.TaskGuid})
+			continue
+		}
+
+		var oldTask oldmodels.Task
+		err = json.Unmarshal(taskRawJSON, &oldTask)
+		if err != nil {
+			logger.Error("failed-to-convert-task", err, lager.Data{"task-guid": task.TaskGuid})
+			continue
+		}
+		oldTasks = append(oldTasks, oldTask)
+	}
+
+	if len(oldTasks) == 0 {
+		return
+	}
+
+	err := db.auctioneerClient.RequestTaskAuctions(oldTasks)
+	if err != nil {
+		logger.Error("failed-to-request-auctions", err, lager.Data{"num-tasks": len(oldTasks)})
+	}
+}
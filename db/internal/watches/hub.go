@@ -0,0 +1,242 @@
+// Package watches fans changes out to EventDB watchers from within the
+// process that made them, for backends that have no watch of their own.
+package watches
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// Hub holds the active watches. Backends publish each write before releasing
+// the lock that serializes their writes, so events queue in commit order.
+type Hub struct {
+	lock    sync.Mutex
+	watches map[*watch]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{watches: map[*watch]struct{}{}}
+}
+
+func (h *Hub) WatchForDesiredLRPChanges(logger lager.Logger,
+	created func(*models.DesiredLRP),
+	changed func(*models.DesiredLRPChange),
+	deleted func(*models.DesiredLRP),
+) (chan<- bool, <-chan error) {
+	logger = logger.Session("watching-for-desired-lrp-changes")
+	return h.watch(logger, func(w *watch) {
+		w.desiredLRPCreated = created
+		w.desiredLRPChanged = changed
+		w.desiredLRPDeleted = deleted
+	})
+}
+
+func (h *Hub) WatchForActualLRPChanges(logger lager.Logger,
+	created func(*models.ActualLRPGroup),
+	changed func(*models.ActualLRPChange),
+	deleted func(*models.ActualLRPGroup),
+) (chan<- bool, <-chan error) {
+	logger = logger.Session("watching-for-actual-lrp-changes")
+	return h.watch(logger, func(w *watch) {
+		w.actualLRPCreated = created
+		w.actualLRPChanged = changed
+		w.actualLRPDeleted = deleted
+	})
+}
+
+func (h *Hub) WatchForTaskChanges(logger lager.Logger,
+	created func(*models.Task),
+	changed func(*models.TaskChange),
+	deleted func(*models.Task),
+) (chan<- bool, <-chan error) {
+	logger = logger.Session("watching-for-task-changes")
+	return h.watch(logger, func(w *watch) {
+		w.taskCreated = created
+		w.taskChanged = changed
+		w.taskDeleted = deleted
+	})
+}
+
+// A watch delivers events to its callbacks in order, on its own goroutine, so
+// that slow callbacks never hold up writes.
+type watch struct {
+	desiredLRPCreated func(*models.DesiredLRP)
+	desiredLRPChanged func(*models.DesiredLRPChange)
+	desiredLRPDeleted func(*models.DesiredLRP)
+
+	actualLRPCreated func(*models.ActualLRPGroup)
+	actualLRPChanged func(*models.ActualLRPChange)
+	actualLRPDeleted func(*models.ActualLRPGroup)
+
+	taskCreated func(*models.Task)
+	taskChanged func(*models.TaskChange)
+	taskDeleted func(*models.Task)
+
+	lock    sync.Mutex
+	pending []func()
+	notify  chan struct{}
+}
+
+func (w *watch) enqueue(event func()) {
+	w.lock.Lock()
+	w.pending = append(w.pending, event)
+	w.lock.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watch) dispatch(stop <-chan bool) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-w.notify:
+		}
+
+		w.lock.Lock()
+		pending := w.pending
+		w.pending = nil
+		w.lock.Unlock()
+
+		for _, event := range pending {
+			event()
+		}
+	}
+}
+
+func (h *Hub) watch(logger lager.Logger, register func(*watch)) (chan<- bool, <-chan error) {
+	w := &watch{notify: make(chan struct{}, 1)}
+	register(w)
+
+	stop := make(chan bool, 1)
+	errors := make(chan error)
+
+	h.lock.Lock()
+	h.watches[w] = struct{}{}
+	h.lock.Unlock()
+
+	go func() {
+		logger.Info("started-watching")
+		defer logger.Info("finished-watching")
+
+		w.dispatch(stop)
+
+		h.lock.Lock()
+		delete(h.watches, w)
+		h.lock.Unlock()
+		close(errors)
+	}()
+
+	return stop, errors
+}
+
+func (h *Hub) publish(event func(w *watch) func()) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for w := range h.watches {
+		if callback := event(w); callback != nil {
+			w.enqueue(callback)
+		}
+	}
+}
+
+func (h *Hub) DesiredLRPCreated(lrp *models.DesiredLRP) {
+	h.publish(func(w *watch) func() {
+		if w.desiredLRPCreated == nil {
+			return nil
+		}
+		return func() { w.desiredLRPCreated(lrp) }
+	})
+}
+
+func (h *Hub) DesiredLRPChanged(before, after *models.DesiredLRP) {
+	h.publish(func(w *watch) func() {
+		if w.desiredLRPChanged == nil {
+			return nil
+		}
+		return func() { w.desiredLRPChanged(&models.DesiredLRPChange{Before: before, After: after}) }
+	})
+}
+
+func (h *Hub) DesiredLRPDeleted(lrp *models.DesiredLRP) {
+	h.publish(func(w *watch) func() {
+		if w.desiredLRPDeleted == nil {
+			return nil
+		}
+		return func() { w.desiredLRPDeleted(lrp) }
+	})
+}
+
+func (h *Hub) ActualLRPCreated(lrp models.ActualLRP, evacuating bool) {
+	h.publish(func(w *watch) func() {
+		if w.actualLRPCreated == nil {
+			return nil
+		}
+		return func() { w.actualLRPCreated(actualLRPGroup(lrp, evacuating)) }
+	})
+}
+
+func (h *Hub) ActualLRPChanged(before, after models.ActualLRP, evacuating bool) {
+	h.publish(func(w *watch) func() {
+		if w.actualLRPChanged == nil {
+			return nil
+		}
+		return func() {
+			w.actualLRPChanged(&models.ActualLRPChange{
+				Before: actualLRPGroup(before, evacuating),
+				After:  actualLRPGroup(after, evacuating),
+			})
+		}
+	})
+}
+
+func (h *Hub) ActualLRPDeleted(lrp models.ActualLRP, evacuating bool) {
+	h.publish(func(w *watch) func() {
+		if w.actualLRPDeleted == nil {
+			return nil
+		}
+		return func() { w.actualLRPDeleted(actualLRPGroup(lrp, evacuating)) }
+	})
+}
+
+func (h *Hub) TaskCreated(task models.Task) {
+	h.publish(func(w *watch) func() {
+		if w.taskCreated == nil {
+			return nil
+		}
+		return func() { w.taskCreated(&task) }
+	})
+}
+
+func (h *Hub) TaskChanged(before, after models.Task) {
+	h.publish(func(w *watch) func() {
+		if w.taskChanged == nil {
+			return nil
+		}
+		return func() { w.taskChanged(&models.TaskChange{Before: &before, After: &after}) }
+	})
+}
+
+func (h *Hub) TaskDeleted(task models.Task) {
+	h.publish(func(w *watch) func() {
+		if w.taskDeleted == nil {
+			return nil
+		}
+		return func() { w.taskDeleted(&task) }
+	})
+}
+
+// actualLRPGroup wraps a single actual LRP record in a group, the way the
+// etcd DB reports changes to individual records.
+func actualLRPGroup(lrp models.ActualLRP, evacuating bool) *models.ActualLRPGroup {
+	if evacuating {
+		return &models.ActualLRPGroup{Evacuating: &lrp}
+	}
+	return &models.ActualLRPGroup{Instance: &lrp}
+}
@@ -0,0 +1,11 @@
+package db
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . LRPConvergenceDB
+type LRPConvergenceDB interface {
+	ConvergeLRPs(logger lager.Logger) models.LRPConvergenceSummary
+}
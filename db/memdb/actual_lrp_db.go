@@ -0,0 +1,201 @@
+package memdb
+
+import (
+	"sort"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// ActualLRPGroups gathers the unexpired actual LRPs into groups, ordered by
+// process guid and index.
+func (store memStore) ActualLRPGroups(logger lager.Logger, processGuid string, filter models.ActualLRPFilter) ([]*models.ActualLRPGroup, *models.Error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	keys := make(actualLRPKeys, 0, len(store.actualLRPs))
+	for key, r := range store.actualLRPs {
+		if !store.expired(r) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Sort(keys)
+
+	groups := []*models.ActualLRPGroup{}
+	var group *models.ActualLRPGroup
+	var groupKey actualLRPKey
+
+	for _, key := range keys {
+		if processGuid != "" && key.processGuid != processGuid {
+			continue
+		}
+		if !key.follows(filter.AfterProcessGuid, filter.AfterIndex) {
+			continue
+		}
+
+		var lrp models.ActualLRP
+		bbsErr := deserialize(logger, store.actualLRPs[key].data, &lrp)
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+
+		if filter.Domain != "" && lrp.Domain != filter.Domain {
+			continue
+		}
+		if filter.CellID != "" && lrp.CellId != filter.CellID {
+			continue
+		}
+
+		if group == nil || key.processGuid != groupKey.processGuid || key.index != groupKey.index {
+			if filter.Limit > 0 && len(groups) == filter.Limit {
+				break
+			}
+			group = &models.ActualLRPGroup{}
+			groupKey = key
+			groups = append(groups, group)
+		}
+
+		if key.evacuating {
+			group.Evacuating = &lrp
+		} else {
+			group.Instance = &lrp
+		}
+	}
+
+	logger.Debug("succeeded-fetching-actual-lrp-groups", lager.Data{"num-actual-lrp-groups": len(groups)})
+	return groups, nil
+}
+
+// follows reports whether the key sorts after the group of processGuid and
+// index, or whether processGuid is empty.
+func (key actualLRPKey) follows(processGuid string, index int32) bool {
+	if processGuid == "" || key.processGuid != processGuid {
+		return key.processGuid > processGuid
+	}
+	return key.index > index
+}
+
+type actualLRPKeys []actualLRPKey
+
+func (keys actualLRPKeys) Len() int      { return len(keys) }
+func (keys actualLRPKeys) Swap(i, j int) { keys[i], keys[j] = keys[j], keys[i] }
+func (keys actualLRPKeys) Less(i, j int) bool {
+	a, b := keys[i], keys[j]
+	if a.processGuid != b.processGuid {
+		return a.processGuid < b.processGuid
+	}
+	if a.index != b.index {
+		return a.index < b.index
+	}
+	return !a.evacuating && b.evacuating
+}
+
+func (store memStore) ActualLRP(logger lager.Logger, processGuid string, index int32, evacuating bool) (*models.ActualLRP, uint64, *models.Error) {
+	store.lock.Lock()
+	r, ok := store.actualLRPs[actualLRPKey{processGuid, index, evacuating}]
+	if ok && store.expired(r) {
+		ok = false
+	}
+	store.lock.Unlock()
+	if !ok {
+		return nil, 0, models.ErrResourceNotFound
+	}
+
+	var lrp models.ActualLRP
+	bbsErr := deserialize(logger, r.data, &lrp)
+	if bbsErr != nil {
+		return nil, 0, bbsErr
+	}
+
+	return &lrp, r.index, nil
+}
+
+func (store memStore) ActualLRPGroup(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, *models.Error) {
+	store.lock.Lock()
+	instance, instanceOK := store.actualLRPs[actualLRPKey{processGuid, index, false}]
+	evacuating, evacuatingOK := store.actualLRPs[actualLRPKey{processGuid, index, true}]
+	instanceOK = instanceOK && !store.expired(instance)
+	evacuatingOK = evacuatingOK && !store.expired(evacuating)
+	store.lock.Unlock()
+
+	if !instanceOK && !evacuatingOK {
+		return nil, models.ErrResourceNotFound
+	}
+
+	group := &models.ActualLRPGroup{}
+	if instanceOK {
+		group.Instance = &models.ActualLRP{}
+		bbsErr := deserialize(logger, instance.data, group.Instance)
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+	}
+	if evacuatingOK {
+		group.Evacuating = &models.ActualLRP{}
+		bbsErr := deserialize(logger, evacuating.data, group.Evacuating)
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+	}
+
+	return group, nil
+}
+
+// CreateActualLRP stores the record, expiring it after ttl seconds if ttl is
+// set. It fails with ErrResourceExists if an unexpired record is in the way.
+func (store memStore) CreateActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, ttl uint64) *models.Error {
+	data, bbsErr := serialize(logger, lrp)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	key := actualLRPKey{lrp.ProcessGuid, lrp.Index, evacuating}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if r, ok := store.actualLRPs[key]; ok && !store.expired(r) {
+		return models.ErrResourceExists
+	}
+	store.actualLRPs[key] = store.newRecord(data, store.expireTime(ttl))
+
+	store.watches.ActualLRPCreated(*lrp, evacuating)
+	return nil
+}
+
+func (store memStore) CompareAndSwapActualLRP(logger lager.Logger, before, after *models.ActualLRP, evacuating bool, prevIndex, ttl uint64) *models.Error {
+	data, bbsErr := serialize(logger, after)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	key := actualLRPKey{after.ProcessGuid, after.Index, evacuating}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if r, ok := store.actualLRPs[key]; !ok || r.index != prevIndex {
+		logger.Info("actual-lrp-changed-concurrently", lager.Data{"actual-lrp-key": after.ActualLRPKey})
+		return models.ErrResourceConflict
+	}
+	store.actualLRPs[key] = store.newRecord(data, store.expireTime(ttl))
+
+	store.watches.ActualLRPChanged(*before, *after, evacuating)
+	return nil
+}
+
+func (store memStore) CompareAndDeleteActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, prevIndex uint64) *models.Error {
+	key := actualLRPKey{lrp.ProcessGuid, lrp.Index, evacuating}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if r, ok := store.actualLRPs[key]; !ok || r.index != prevIndex {
+		logger.Info("actual-lrp-changed-concurrently", lager.Data{"actual-lrp-key": lrp.ActualLRPKey})
+		return models.ErrResourceConflict
+	}
+	delete(store.actualLRPs, key)
+
+	store.watches.ActualLRPDeleted(*lrp, evacuating)
+	return nil
+}
@@ -0,0 +1,53 @@
+package memdb
+
+import (
+	"sort"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// Cells register with the MemDB directly, rather than through consul.
+
+func (db *MemDB) RegisterCell(cell models.CellPresence) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.cells[cell.CellId] = cell
+}
+
+func (db *MemDB) UnregisterCell(cellId string) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	delete(db.cells, cellId)
+}
+
+func (db *MemDB) Cells(logger lager.Logger) ([]*models.CellPresence, *models.Error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	cellIds := make([]string, 0, len(db.cells))
+	for cellId := range db.cells {
+		cellIds = append(cellIds, cellId)
+	}
+	sort.Strings(cellIds)
+
+	cells := make([]*models.CellPresence, 0, len(cellIds))
+	for _, cellId := range cellIds {
+		cell := db.cells[cellId]
+		cells = append(cells, &cell)
+	}
+	return cells, nil
+}
+
+func (db *MemDB) CellById(logger lager.Logger, cellId string) (*models.CellPresence, *models.Error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	cell, ok := db.cells[cellId]
+	if !ok {
+		return nil, models.ErrResourceNotFound
+	}
+	return &cell, nil
+}
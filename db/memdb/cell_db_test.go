@@ -0,0 +1,54 @@
+package memdb_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CellDB", func() {
+	var cell models.CellPresence
+
+	BeforeEach(func() {
+		cell = models.NewCellPresence("cell-id", "1.2.3.4", "the-zone", models.NewCellCapacity(128, 1024, 6), []string{}, []string{})
+	})
+
+	Describe("CellById", func() {
+		It("returns a registered cell", func() {
+			registerCell(cell)
+
+			presence, err := memDB.CellById(logger, "cell-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*presence).To(Equal(cell))
+		})
+
+		It("returns ResourceNotFound for an unknown cell", func() {
+			_, err := memDB.CellById(logger, "cell-id")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("forgets unregistered cells", func() {
+			registerCell(cell)
+			memDB.UnregisterCell("cell-id")
+
+			_, err := memDB.CellById(logger, "cell-id")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+	})
+
+	Describe("Cells", func() {
+		It("returns every registered cell", func() {
+			registerCell(cell)
+			other := cell
+			other.CellId = "other-cell-id"
+			registerCell(other)
+
+			cells, err := memDB.Cells(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cells).To(HaveLen(2))
+			Expect(cells[0].CellId).To(Equal("cell-id"))
+			Expect(cells[1].CellId).To(Equal("other-cell-id"))
+		})
+	})
+})
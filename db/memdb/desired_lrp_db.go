@@ -0,0 +1,101 @@
+package memdb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (store memStore) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) ([]*models.DesiredLRP, *models.Error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	desiredLRPs := []*models.DesiredLRP{}
+	for _, processGuid := range keysAfter(sortedKeys(store.desiredLRPs), filter.AfterProcessGuid) {
+		if filter.Limit > 0 && len(desiredLRPs) == filter.Limit {
+			break
+		}
+
+		var lrp models.DesiredLRP
+		bbsErr := deserialize(logger, store.desiredLRPs[processGuid].data, &lrp)
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+
+		if filter.Domain == "" || lrp.Domain == filter.Domain {
+			desiredLRPs = append(desiredLRPs, &lrp)
+		}
+	}
+
+	logger.Debug("succeeded-fetching-desired-lrps", lager.Data{"num-desired-lrps": len(desiredLRPs)})
+	return desiredLRPs, nil
+}
+
+func (store memStore) DesiredLRP(logger lager.Logger, processGuid string) (*models.DesiredLRP, uint64, *models.Error) {
+	store.lock.Lock()
+	r, ok := store.desiredLRPs[processGuid]
+	store.lock.Unlock()
+	if !ok {
+		return nil, 0, models.ErrResourceNotFound
+	}
+
+	var lrp models.DesiredLRP
+	bbsErr := deserialize(logger, r.data, &lrp)
+	if bbsErr != nil {
+		return nil, 0, bbsErr
+	}
+
+	return &lrp, r.index, nil
+}
+
+func (store memStore) CreateDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP) *models.Error {
+	data, bbsErr := serialize(logger, lrp)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if _, ok := store.desiredLRPs[lrp.ProcessGuid]; ok {
+		return models.ErrResourceExists
+	}
+	store.desiredLRPs[lrp.ProcessGuid] = store.newRecord(data, 0)
+
+	created := *lrp
+	store.watches.DesiredLRPCreated(&created)
+	return nil
+}
+
+func (store memStore) CompareAndSwapDesiredLRP(logger lager.Logger, before, after *models.DesiredLRP, prevIndex uint64) *models.Error {
+	data, bbsErr := serialize(logger, after)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if r, ok := store.desiredLRPs[after.ProcessGuid]; !ok || r.index != prevIndex {
+		logger.Info("desired-lrp-changed-concurrently")
+		return models.ErrResourceConflict
+	}
+	store.desiredLRPs[after.ProcessGuid] = store.newRecord(data, 0)
+
+	changed := *after
+	store.watches.DesiredLRPChanged(before, &changed)
+	return nil
+}
+
+func (store memStore) CompareAndDeleteDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP, prevIndex uint64) *models.Error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if r, ok := store.desiredLRPs[lrp.ProcessGuid]; !ok || r.index != prevIndex {
+		logger.Info("desired-lrp-changed-during-removal")
+		return models.ErrResourceConflict
+	}
+	delete(store.desiredLRPs, lrp.ProcessGuid)
+
+	store.watches.DesiredLRPDeleted(lrp)
+	return nil
+}
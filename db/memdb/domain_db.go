@@ -0,0 +1,33 @@
+package memdb
+
+import (
+	"sort"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *MemDB) GetAllDomains(logger lager.Logger) (*models.Domains, *models.Error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	now := db.clock.Now().UnixNano()
+	domains := []string{}
+	for domain, expireTime := range db.domains {
+		if expireTime == 0 || expireTime > now {
+			domains = append(domains, domain)
+		}
+	}
+	sort.Strings(domains)
+
+	return &models.Domains{Domains: domains}, nil
+}
+
+// UpsertDomain marks the domain fresh for ttl seconds, or forever if ttl is 0.
+func (db *MemDB) UpsertDomain(logger lager.Logger, domain string, ttl int) *models.Error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.domains[domain] = db.expireTime(uint64(ttl))
+	return nil
+}
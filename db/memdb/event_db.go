@@ -0,0 +1,30 @@
+package memdb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *MemDB) WatchForDesiredLRPChanges(logger lager.Logger,
+	created func(*models.DesiredLRP),
+	changed func(*models.DesiredLRPChange),
+	deleted func(*models.DesiredLRP),
+) (chan<- bool, <-chan error) {
+	return db.watches.WatchForDesiredLRPChanges(logger, created, changed, deleted)
+}
+
+func (db *MemDB) WatchForActualLRPChanges(logger lager.Logger,
+	created func(*models.ActualLRPGroup),
+	changed func(*models.ActualLRPChange),
+	deleted func(*models.ActualLRPGroup),
+) (chan<- bool, <-chan error) {
+	return db.watches.WatchForActualLRPChanges(logger, created, changed, deleted)
+}
+
+func (db *MemDB) WatchForTaskChanges(logger lager.Logger,
+	created func(*models.Task),
+	changed func(*models.TaskChange),
+	deleted func(*models.Task),
+) (chan<- bool, <-chan error) {
+	return db.watches.WatchForTaskChanges(logger, created, changed, deleted)
+}
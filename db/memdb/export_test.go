@@ -0,0 +1,38 @@
+package memdb
+
+import "github.com/cloudfoundry-incubator/bbs/models"
+
+func (db *MemDB) SetRawTaskData(taskGuid string, data []byte) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.tasks[taskGuid] = db.newRecord(data, 0)
+}
+
+func (db *MemDB) SetRawDesiredLRPData(processGuid string, data []byte) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.desiredLRPs[processGuid] = db.newRecord(data, 0)
+}
+
+func (db *MemDB) SetRawActualLRPData(lrp *models.ActualLRP, evacuating bool, ttl uint64, data []byte) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	key := actualLRPKey{processGuid: lrp.ProcessGuid, index: lrp.Index, evacuating: evacuating}
+	db.actualLRPs[key] = db.newRecord(data, db.expireTime(ttl))
+}
+
+func (db *MemDB) EvacuatingActualLRPCount() int {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	count := 0
+	for key := range db.actualLRPs {
+		if key.evacuating {
+			count++
+		}
+	}
+	return count
+}
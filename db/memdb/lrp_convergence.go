@@ -0,0 +1,32 @@
+package memdb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *MemDB) ConvergeLRPs(logger lager.Logger) models.LRPConvergenceSummary {
+	db.pruneExpiredEvacuatingActualLRPs(logger.Session("converge-lrps"))
+	return db.DB.ConvergeLRPs(logger)
+}
+
+// pruneExpiredEvacuatingActualLRPs deletes evacuating records whose ttl has
+// run out, so that watchers see them go just as they would in etcd.
+func (db *MemDB) pruneExpiredEvacuatingActualLRPs(logger lager.Logger) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	for key, r := range db.actualLRPs {
+		if !key.evacuating || !db.expired(r) {
+			continue
+		}
+
+		var lrp models.ActualLRP
+		if deserialize(logger, r.data, &lrp) != nil {
+			continue
+		}
+		logger.Info("pruning-expired-evacuating-actual-lrp", lager.Data{"actual-lrp-key": lrp.ActualLRPKey})
+		delete(db.actualLRPs, key)
+		db.watches.ActualLRPDeleted(lrp, true)
+	}
+}
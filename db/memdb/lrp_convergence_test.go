@@ -0,0 +1,71 @@
+package memdb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LRP Convergence", func() {
+	const (
+		presentCellId = "present-cell"
+		missingCellId = "missing-cell"
+	)
+
+	var cellPresence models.CellPresence
+
+	newActualLRP := func(processGuid string, index int32, state string, cellId string) *models.ActualLRP {
+		lrp := model_helpers.NewValidActualLRP(processGuid, index)
+		lrp.State = state
+		lrp.ActualLRPInstanceKey = models.NewActualLRPInstanceKey("instance-guid", cellId)
+		if cellId == "" {
+			lrp.ActualLRPInstanceKey = models.ActualLRPInstanceKey{}
+			lrp.ActualLRPNetInfo = models.EmptyActualLRPNetInfo()
+		}
+		return lrp
+	}
+
+	fetchActual := func(processGuid string, index int32) *models.ActualLRP {
+		group, err := memDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, index)
+		Expect(err).NotTo(HaveOccurred())
+		return group.Instance
+	}
+
+	BeforeEach(func() {
+		cellPresence = models.NewCellPresence(
+			presentCellId,
+			"cell.example.com",
+			"the-zone",
+			models.NewCellCapacity(128, 1024, 6),
+			[]string{},
+			[]string{},
+		)
+		registerCell(cellPresence)
+	})
+
+	JustBeforeEach(func() {
+		memDB.ConvergeLRPs(logger)
+	})
+
+	Context("when evacuating actual LRPs have expired", func() {
+		BeforeEach(func() {
+			desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+			desiredLRP.Instances = 1
+			setRawDesiredLRP(desiredLRP)
+			setRawActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, presentCellId))
+			setRawEvacuatingActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, missingCellId), 60)
+
+			clock.Increment(61 * time.Second)
+		})
+
+		It("prunes them", func() {
+			Expect(memDB.EvacuatingActualLRPCount()).To(Equal(0))
+
+			Expect(fetchActual("desired-guid", 0).State).To(Equal(models.ActualLRPStateRunning))
+		})
+	})
+})
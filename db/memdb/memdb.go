@@ -0,0 +1,124 @@
+package memdb
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/auctionhandlers"
+	"github.com/cloudfoundry-incubator/bbs/cellhandlers"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/storedb"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/watches"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+// MemDB keeps BBS records and cell presences in memory, for tests and
+// single-node development. Records are stored serialized, so callers never
+// share state with the store, and every write is stamped with an index that
+// stands in for etcd's ModifiedIndex in compare-and-swap operations.
+type MemDB struct {
+	*storedb.DB
+
+	clock clock.Clock
+
+	lock        sync.Mutex
+	index       uint64
+	domains     map[string]int64
+	desiredLRPs map[string]record
+	actualLRPs  map[actualLRPKey]record
+	tasks       map[string]record
+	cells       map[string]models.CellPresence
+
+	watches *watches.Hub
+}
+
+type record struct {
+	data       []byte
+	index      uint64
+	expireTime int64
+}
+
+type actualLRPKey struct {
+	processGuid string
+	index       int32
+	evacuating  bool
+}
+
+func NewMemDB(auctioneerClient auctionhandlers.Client, cellClient cellhandlers.Client, clock clock.Clock, taskCompletionClient taskworkpool.TaskCompletionClient) *MemDB {
+	db := &MemDB{
+		clock:       clock,
+		domains:     map[string]int64{},
+		desiredLRPs: map[string]record{},
+		actualLRPs:  map[actualLRPKey]record{},
+		tasks:       map[string]record{},
+		cells:       map[string]models.CellPresence{},
+		watches:     watches.NewHub(),
+	}
+	db.DB = storedb.New(memStore{db}, auctioneerClient, cellClient, db, clock, taskCompletionClient)
+	return db
+}
+
+// memStore gives storedb access to the records of a MemDB. Writes publish to
+// the watches with the lock still held, so watchers see them in commit order.
+type memStore struct {
+	*MemDB
+}
+
+// newRecord must be called with the lock held.
+func (db *MemDB) newRecord(data []byte, expireTime int64) record {
+	db.index++
+	return record{data: data, index: db.index, expireTime: expireTime}
+}
+
+// expired must be called with the lock held.
+func (db *MemDB) expired(r record) bool {
+	return r.expireTime != 0 && r.expireTime <= db.clock.Now().UnixNano()
+}
+
+// expireTime converts a ttl in seconds to an absolute expiry, where 0 means
+// the record never expires.
+func (db *MemDB) expireTime(ttl uint64) int64 {
+	if ttl == 0 {
+		return 0
+	}
+	return db.clock.Now().Add(time.Duration(ttl) * time.Second).UnixNano()
+}
+
+func sortedKeys(records map[string]record) []string {
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keysAfter returns the sorted keys that come after the key after.
+func keysAfter(keys []string, after string) []string {
+	if after == "" {
+		return keys
+	}
+	return keys[sort.Search(len(keys), func(i int) bool { return keys[i] > after }):]
+}
+
+func serialize(logger lager.Logger, record proto.Message) ([]byte, *models.Error) {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		logger.Error("failed-to-serialize-record", err)
+		return nil, models.ErrSerializeJSON
+	}
+	return data, nil
+}
+
+func deserialize(logger lager.Logger, data []byte, record proto.Message) *models.Error {
+	err := proto.Unmarshal(data, record)
+	if err != nil {
+		logger.Error("failed-to-deserialize-record", err)
+		return models.ErrDeserializeJSON
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package memdb_test
+
+import (
+	"time"
+
+	fakeauctioneer "github.com/cloudfoundry-incubator/bbs/auctionhandlers/fakes"
+	fakecellhandlers "github.com/cloudfoundry-incubator/bbs/cellhandlers/fakes"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/db_suites"
+	"github.com/cloudfoundry-incubator/bbs/db/memdb"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool/fakes"
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"testing"
+)
+
+var auctioneerClient *fakeauctioneer.FakeClient
+var cellClient *fakecellhandlers.FakeClient
+var fakeTaskCompletionClient *fakes.FakeTaskCompletionClient
+
+var logger *lagertest.TestLogger
+var clock *fakeclock.FakeClock
+
+var memDB *memdb.MemDB
+
+var backend = &db_suites.Backend{}
+
+func TestMemDB(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MemDB Suite")
+}
+
+var _ = BeforeEach(func() {
+	logger = lagertest.NewTestLogger("test")
+	clock = fakeclock.NewFakeClock(time.Unix(0, 1138))
+
+	auctioneerClient = new(fakeauctioneer.FakeClient)
+	cellClient = new(fakecellhandlers.FakeClient)
+	fakeTaskCompletionClient = new(fakes.FakeTaskCompletionClient)
+
+	memDB = memdb.NewMemDB(auctioneerClient, cellClient, clock, fakeTaskCompletionClient)
+
+	*backend = db_suites.Backend{
+		DB:     memDB,
+		Logger: logger,
+		Clock:  clock,
+
+		AuctioneerClient:     auctioneerClient,
+		CellClient:           cellClient,
+		TaskCompletionClient: fakeTaskCompletionClient,
+
+		RegisterCell:              registerCell,
+		SetRawTask:                setRawTask,
+		SetRawTaskData:            setRawTaskData,
+		SetRawDesiredLRP:          setRawDesiredLRP,
+		SetRawDesiredLRPData:      setRawDesiredLRPData,
+		SetRawActualLRP:           setRawActualLRP,
+		SetRawEvacuatingActualLRP: setRawEvacuatingActualLRP,
+	}
+})
+
+var _ = db_suites.ActualLRPDBSuite(backend)
+var _ = db_suites.DesiredLRPDBSuite(backend)
+var _ = db_suites.TaskDBSuite(backend)
+var _ = db_suites.EvacuationSuite(backend)
+var _ = db_suites.LRPConvergenceSuite(backend)
+var _ = db_suites.TaskConvergenceSuite(backend)
+var _ = db_suites.DomainDBSuite(backend)
+var _ = db_suites.EventDBSuite(backend)
+
+func registerCell(cell models.CellPresence) {
+	memDB.RegisterCell(cell)
+}
+
+func setRawTask(task *models.Task) {
+	data, err := proto.Marshal(task)
+	Expect(err).NotTo(HaveOccurred())
+	memDB.SetRawTaskData(task.TaskGuid, data)
+}
+
+func setRawTaskData(taskGuid string, data []byte) {
+	memDB.SetRawTaskData(taskGuid, data)
+}
+
+func setRawDesiredLRP(lrp *models.DesiredLRP) {
+	data, err := proto.Marshal(lrp)
+	Expect(err).NotTo(HaveOccurred())
+	memDB.SetRawDesiredLRPData(lrp.ProcessGuid, data)
+}
+
+func setRawDesiredLRPData(processGuid string, data []byte) {
+	memDB.SetRawDesiredLRPData(processGuid, data)
+}
+
+func setRawActualLRP(lrp *models.ActualLRP) {
+	data, err := proto.Marshal(lrp)
+	Expect(err).NotTo(HaveOccurred())
+	memDB.SetRawActualLRPData(lrp, false, 0, data)
+}
+
+func setRawEvacuatingActualLRP(lrp *models.ActualLRP, ttlInSeconds uint64) {
+	data, err := proto.Marshal(lrp)
+	Expect(err).NotTo(HaveOccurred())
+	memDB.SetRawActualLRPData(lrp, true, ttlInSeconds, data)
+}
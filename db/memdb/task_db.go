@@ -0,0 +1,106 @@
+package memdb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/db/internal/storedb"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// Tasks returns the tasks matching the filter and the index they were read
+// at, in order of task guid.
+func (store memStore) Tasks(logger lager.Logger, filter models.TaskFilter) ([]storedb.TaskRecord, *models.Error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	tasks := []storedb.TaskRecord{}
+	for _, taskGuid := range keysAfter(sortedKeys(store.tasks), filter.AfterTaskGuid) {
+		if filter.Limit > 0 && len(tasks) == filter.Limit {
+			break
+		}
+
+		r := store.tasks[taskGuid]
+		var task models.Task
+		bbsErr := deserialize(logger, r.data, &task)
+		if bbsErr != nil {
+			return nil, models.ErrUnknownError
+		}
+
+		if filter.Domain != "" && task.Domain != filter.Domain {
+			continue
+		}
+		if filter.CellID != "" && task.CellId != filter.CellID {
+			continue
+		}
+		tasks = append(tasks, storedb.TaskRecord{Task: &task, Index: r.index})
+	}
+
+	return tasks, nil
+}
+
+func (store memStore) Task(logger lager.Logger, taskGuid string) (*models.Task, uint64, *models.Error) {
+	store.lock.Lock()
+	r, ok := store.tasks[taskGuid]
+	store.lock.Unlock()
+	if !ok {
+		return nil, 0, models.ErrResourceNotFound
+	}
+
+	var task models.Task
+	bbsErr := deserialize(logger, r.data, &task)
+	if bbsErr != nil {
+		return nil, 0, bbsErr
+	}
+
+	return &task, r.index, nil
+}
+
+func (store memStore) CreateTask(logger lager.Logger, task *models.Task) *models.Error {
+	data, bbsErr := serialize(logger, task)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if _, ok := store.tasks[task.TaskGuid]; ok {
+		return models.ErrResourceExists
+	}
+	store.tasks[task.TaskGuid] = store.newRecord(data, 0)
+
+	store.watches.TaskCreated(*task)
+	return nil
+}
+
+func (store memStore) CompareAndSwapTask(logger lager.Logger, before, after *models.Task, prevIndex uint64) *models.Error {
+	data, bbsErr := serialize(logger, after)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if r, ok := store.tasks[after.TaskGuid]; !ok || r.index != prevIndex {
+		logger.Info("task-changed-concurrently")
+		return models.ErrResourceConflict
+	}
+	store.tasks[after.TaskGuid] = store.newRecord(data, 0)
+
+	store.watches.TaskChanged(*before, *after)
+	return nil
+}
+
+func (store memStore) CompareAndDeleteTask(logger lager.Logger, task *models.Task, prevIndex uint64) *models.Error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if r, ok := store.tasks[task.TaskGuid]; !ok || r.index != prevIndex {
+		logger.Info("task-changed-concurrently")
+		return models.ErrResourceConflict
+	}
+	delete(store.tasks, task.TaskGuid)
+
+	store.watches.TaskDeleted(*task)
+	return nil
+}
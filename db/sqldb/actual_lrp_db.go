@@ -0,0 +1,294 @@
+package sqldb
+
+import (
+	"database/sql"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (store sqlStore) ActualLRPGroups(logger lager.Logger, processGuid string, filter models.ActualLRPFilter) ([]*models.ActualLRPGroup, *models.Error) {
+	var conditions []string
+	var args []interface{}
+	if processGuid != "" {
+		conditions = append(conditions, "process_guid = ?")
+		args = append(args, processGuid)
+	}
+	if filter.Domain != "" {
+		conditions = append(conditions, "domain = ?")
+		args = append(args, filter.Domain)
+	}
+	if filter.CellID != "" {
+		conditions = append(conditions, "cell_id = ?")
+		args = append(args, filter.CellID)
+	}
+	if filter.AfterProcessGuid != "" {
+		conditions = append(conditions, "(process_guid > ? OR (process_guid = ? AND instance_index > ?))")
+		args = append(args, filter.AfterProcessGuid, filter.AfterProcessGuid, filter.AfterIndex)
+	}
+	conditions = append(conditions, "(expire_time = 0 OR expire_time > ?)")
+	args = append(args, store.clock.Now().UnixNano())
+
+	query := `SELECT process_guid, instance_index, evacuating, data FROM actual_lrps` + where(conditions) +
+		` ORDER BY process_guid, instance_index, evacuating`
+	if filter.Limit > 0 {
+		// a group has at most an instance and an evacuating row
+		query += ` LIMIT ?`
+		args = append(args, 2*filter.Limit)
+	}
+
+	rows, err := store.query(query, args...)
+	if err != nil {
+		logger.Error("failed-to-fetch-actual-lrps", err)
+		return nil, models.ErrUnknownError
+	}
+	defer rows.Close()
+
+	groups := []*models.ActualLRPGroup{}
+	var group *models.ActualLRPGroup
+	var groupGuid string
+	var groupIndex int32
+
+	for rows.Next() {
+		var processGuid string
+		var index int32
+		var evacuating int
+		var data []byte
+		err := rows.Scan(&processGuid, &index, &evacuating, &data)
+		if err != nil {
+			logger.Error("failed-to-scan-actual-lrp", err)
+			return nil, models.ErrUnknownError
+		}
+
+		var lrp models.ActualLRP
+		bbsErr := deserialize(logger, data, &lrp)
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+
+		if group == nil || processGuid != groupGuid || index != groupIndex {
+			if filter.Limit > 0 && len(groups) == filter.Limit {
+				break
+			}
+			group = &models.ActualLRPGroup{}
+			groupGuid, groupIndex = processGuid, index
+			groups = append(groups, group)
+		}
+
+		if evacuating != 0 {
+			group.Evacuating = &lrp
+		} else {
+			group.Instance = &lrp
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed-to-fetch-actual-lrps", err)
+		return nil, models.ErrUnknownError
+	}
+
+	logger.Debug("succeeded-fetching-actual-lrp-groups", lager.Data{"num-actual-lrp-groups": len(groups)})
+	return groups, nil
+}
+
+func (store sqlStore) ActualLRP(logger lager.Logger, processGuid string, index int32, evacuating bool) (*models.ActualLRP, uint64, *models.Error) {
+	var data []byte
+	var revision int64
+	err := store.queryRow(
+		`SELECT data, revision FROM actual_lrps
+			WHERE process_guid = ? AND instance_index = ? AND evacuating = ? AND (expire_time = 0 OR expire_time > ?)`,
+		processGuid, index, evacuatingFlag(evacuating), store.clock.Now().UnixNano(),
+	).Scan(&data, &revision)
+	if err == sql.ErrNoRows {
+		return nil, 0, models.ErrResourceNotFound
+	} else if err != nil {
+		logger.Error("failed-to-fetch-actual-lrp", err)
+		return nil, 0, models.ErrUnknownError
+	}
+
+	var lrp models.ActualLRP
+	bbsErr := deserialize(logger, data, &lrp)
+	if bbsErr != nil {
+		return nil, 0, bbsErr
+	}
+
+	return &lrp, uint64(revision), nil
+}
+
+func (store sqlStore) ActualLRPGroup(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, *models.Error) {
+	rows, err := store.query(
+		`SELECT evacuating, data FROM actual_lrps
+			WHERE process_guid = ? AND instance_index = ? AND (expire_time = 0 OR expire_time > ?)`,
+		processGuid, index, store.clock.Now().UnixNano(),
+	)
+	if err != nil {
+		logger.Error("failed-to-fetch-actual-lrp-group", err)
+		return nil, models.ErrUnknownError
+	}
+	defer rows.Close()
+
+	group := &models.ActualLRPGroup{}
+	for rows.Next() {
+		var evacuating int
+		var data []byte
+		err := rows.Scan(&evacuating, &data)
+		if err != nil {
+			logger.Error("failed-to-scan-actual-lrp", err)
+			return nil, models.ErrUnknownError
+		}
+
+		var lrp models.ActualLRP
+		bbsErr := deserialize(logger, data, &lrp)
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+
+		if evacuating != 0 {
+			group.Evacuating = &lrp
+		} else {
+			group.Instance = &lrp
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed-to-fetch-actual-lrp-group", err)
+		return nil, models.ErrUnknownError
+	}
+
+	if group.Instance == nil && group.Evacuating == nil {
+		return nil, models.ErrResourceNotFound
+	}
+
+	return group, nil
+}
+
+// CreateActualLRP inserts the record, expiring it after ttl seconds if ttl is
+// set. It fails with ErrResourceExists if an unexpired record is in the way.
+func (store sqlStore) CreateActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, ttl uint64) *models.Error {
+	data, bbsErr := serialize(logger, lrp)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		err := tx.deleteExpiredActualLRP(lrp.ProcessGuid, lrp.Index, evacuating)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.exec(
+			`INSERT INTO actual_lrps (process_guid, instance_index, evacuating, domain, cell_id, expire_time, revision, data)
+				VALUES (?, ?, ?, ?, ?, ?, 0, ?)`,
+			lrp.ProcessGuid, lrp.Index, evacuatingFlag(evacuating), lrp.Domain, lrp.CellId, store.expireTime(ttl), data,
+		)
+		if err != nil {
+			return err
+		}
+		return tx.record(actualLRPRecordType(evacuating), nil, data)
+	})
+	if err != nil {
+		if _, _, findErr := store.ActualLRP(logger, lrp.ProcessGuid, lrp.Index, evacuating); findErr == nil {
+			return models.ErrResourceExists
+		}
+		logger.Error("failed-to-create-actual-lrp", err, lager.Data{"actual-lrp-key": lrp.ActualLRPKey})
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+func (store sqlStore) CompareAndSwapActualLRP(logger lager.Logger, before, after *models.ActualLRP, evacuating bool, prevIndex, ttl uint64) *models.Error {
+	beforeData, bbsErr := serialize(logger, before)
+	if bbsErr != nil {
+		return bbsErr
+	}
+	data, bbsErr := serialize(logger, after)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		ok, err := swapped(tx.exec(
+			`UPDATE actual_lrps SET domain = ?, cell_id = ?, expire_time = ?, revision = revision + 1, data = ?
+				WHERE process_guid = ? AND instance_index = ? AND evacuating = ? AND revision = ?`,
+			after.Domain, after.CellId, store.expireTime(ttl), data,
+			after.ProcessGuid, after.Index, evacuatingFlag(evacuating), int64(prevIndex),
+		))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errConflict
+		}
+		return tx.record(actualLRPRecordType(evacuating), beforeData, data)
+	})
+	if err == errConflict {
+		logger.Info("actual-lrp-changed-concurrently", lager.Data{"actual-lrp-key": after.ActualLRPKey})
+		return models.ErrResourceConflict
+	} else if err != nil {
+		logger.Error("failed-to-update-actual-lrp", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+func (store sqlStore) CompareAndDeleteActualLRP(logger lager.Logger, lrp *models.ActualLRP, evacuating bool, prevIndex uint64) *models.Error {
+	data, bbsErr := serialize(logger, lrp)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		ok, err := swapped(tx.exec(
+			`DELETE FROM actual_lrps WHERE process_guid = ? AND instance_index = ? AND evacuating = ? AND revision = ?`,
+			lrp.ProcessGuid, lrp.Index, evacuatingFlag(evacuating), int64(prevIndex),
+		))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errConflict
+		}
+		return tx.record(actualLRPRecordType(evacuating), data, nil)
+	})
+	if err == errConflict {
+		logger.Info("actual-lrp-changed-concurrently", lager.Data{"actual-lrp-key": lrp.ActualLRPKey})
+		return models.ErrResourceConflict
+	} else if err != nil {
+		logger.Error("failed-to-delete-actual-lrp", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+// deleteExpiredActualLRP removes the record if its ttl has run out, recording
+// the removal just as etcd reports an expiry.
+func (tx *sqlTx) deleteExpiredActualLRP(processGuid string, index int32, evacuating bool) error {
+	var data []byte
+	err := tx.queryRow(
+		`SELECT data FROM actual_lrps
+			WHERE process_guid = ? AND instance_index = ? AND evacuating = ? AND expire_time != 0 AND expire_time <= ?`,
+		processGuid, index, evacuatingFlag(evacuating), tx.db.clock.Now().UnixNano(),
+	).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	_, err = tx.exec(
+		`DELETE FROM actual_lrps WHERE process_guid = ? AND instance_index = ? AND evacuating = ?`,
+		processGuid, index, evacuatingFlag(evacuating),
+	)
+	if err != nil {
+		return err
+	}
+	return tx.record(actualLRPRecordType(evacuating), data, nil)
+}
+
+func evacuatingFlag(evacuating bool) int {
+	if evacuating {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,217 @@
+package sqldb
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+// The record types stored in the changes table.
+const (
+	desiredLRPRecord          = "desired_lrp"
+	actualLRPRecord           = "actual_lrp"
+	evacuatingActualLRPRecord = "evacuating_actual_lrp"
+	taskRecord                = "task"
+)
+
+const (
+	changePollInterval = 100 * time.Millisecond
+	changeRetention    = 10 * time.Minute
+	maxChangesPerPoll  = 1000
+)
+
+var (
+	errConflict       = errors.New("record changed concurrently")
+	errChangesExpired = errors.New("changes were pruned before the watch read them")
+)
+
+// A change is a write as recorded in the changes table. Before is empty for
+// creates and after is empty for deletes.
+type change struct {
+	revision   int64
+	recordType string
+	before     []byte
+	after      []byte
+}
+
+func actualLRPRecordType(evacuating bool) string {
+	if evacuating {
+		return evacuatingActualLRPRecord
+	}
+	return actualLRPRecord
+}
+
+// sqlTx is a write transaction that records each change it makes in the
+// changes table, which every BBS polls to feed its watches.
+type sqlTx struct {
+	tx       *sql.Tx
+	db       *SQLDB
+	revision int64
+	recorded bool
+}
+
+func (tx *sqlTx) exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.tx.Exec(tx.db.rebind(query), args...)
+}
+
+func (tx *sqlTx) queryRow(query string, args ...interface{}) *sql.Row {
+	return tx.tx.QueryRow(tx.db.rebind(query), args...)
+}
+
+// nextRevision bumps the change revision counter. The counter row stays
+// locked until the transaction ends, so writers on every BBS commit one at a
+// time and revisions follow commit order.
+func (tx *sqlTx) nextRevision() error {
+	_, err := tx.exec(`UPDATE change_revision SET revision = revision + 1`)
+	if err != nil {
+		return err
+	}
+	return tx.queryRow(`SELECT revision FROM change_revision`).Scan(&tx.revision)
+}
+
+func (tx *sqlTx) record(recordType string, before, after []byte) error {
+	if tx.recorded {
+		err := tx.nextRevision()
+		if err != nil {
+			return err
+		}
+	}
+
+	if before == nil {
+		before = []byte{}
+	}
+	if after == nil {
+		after = []byte{}
+	}
+
+	_, err := tx.exec(
+		`INSERT INTO changes (revision, record_type, before_data, after_data, created_at) VALUES (?, ?, ?, ?, ?)`,
+		tx.revision, recordType, before, after, tx.db.clock.Now().UnixNano(),
+	)
+	if err != nil {
+		return err
+	}
+	tx.recorded = true
+	return nil
+}
+
+// transact runs write in a transaction, committing it only if it recorded a
+// change. The revision counter is taken before write runs, so that every
+// transaction locks it first.
+func (db *SQLDB) transact(write func(tx *sqlTx) error) error {
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	t := &sqlTx{tx: tx, db: db}
+	err = t.nextRevision()
+	if err == nil {
+		err = write(t)
+	}
+	if err != nil || !t.recorded {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *SQLDB) changeRevision() (int64, error) {
+	var revision int64
+	err := db.queryRow(`SELECT revision FROM change_revision`).Scan(&revision)
+	return revision, err
+}
+
+func (db *SQLDB) changesSince(revision int64) ([]change, error) {
+	rows, err := db.query(
+		`SELECT revision, record_type, before_data, after_data FROM changes WHERE revision > ? ORDER BY revision LIMIT ?`,
+		revision, maxChangesPerPoll,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []change{}
+	for rows.Next() {
+		var c change
+		err := rows.Scan(&c.revision, &c.recordType, &c.before, &c.after)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// pruneChanges drops changes old enough that no watch should still need them.
+func (db *SQLDB) pruneChanges(logger lager.Logger) {
+	_, err := db.exec(`DELETE FROM changes WHERE created_at < ?`, db.clock.Now().Add(-changeRetention).UnixNano())
+	if err != nil {
+		logger.Error("failed-to-prune-changes", err)
+	}
+}
+
+// watchChanges hands handle every change committed after the watch starts, by
+// this or any other BBS, in revision order. Revisions have no gaps, so a
+// missing revision means the watch fell behind the pruning of old changes,
+// and it fails so that the watcher can start over. beforePoll, if set, runs
+// before each poll.
+func (db *SQLDB) watchChanges(logger lager.Logger, beforePoll func(), handle func(change)) (chan<- bool, <-chan error) {
+	stop := make(chan bool, 1)
+	errors := make(chan error, 1)
+
+	revision, err := db.changeRevision()
+	if err != nil {
+		logger.Error("failed-to-fetch-change-revision", err)
+		errors <- err
+		close(errors)
+		return stop, errors
+	}
+
+	go func() {
+		logger.Info("started-watching")
+		defer logger.Info("finished-watching")
+		defer close(errors)
+
+		ticker := time.NewTicker(changePollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			if beforePoll != nil {
+				beforePoll()
+			}
+
+			changes, err := db.changesSince(revision)
+			if err != nil {
+				logger.Error("failed-to-fetch-changes", err)
+				errors <- err
+				return
+			}
+
+			for _, c := range changes {
+				if c.revision != revision+1 {
+					logger.Error("missed-changes", errChangesExpired, lager.Data{"expected-revision": revision + 1, "revision": c.revision})
+					errors <- errChangesExpired
+					return
+				}
+				handle(c)
+				revision = c.revision
+			}
+		}
+	}()
+
+	return stop, errors
+}
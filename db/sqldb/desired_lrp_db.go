@@ -0,0 +1,168 @@
+package sqldb
+
+import (
+	"database/sql"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (store sqlStore) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) ([]*models.DesiredLRP, *models.Error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Domain != "" {
+		conditions = append(conditions, "domain = ?")
+		args = append(args, filter.Domain)
+	}
+	if filter.AfterProcessGuid != "" {
+		conditions = append(conditions, "process_guid > ?")
+		args = append(args, filter.AfterProcessGuid)
+	}
+	query := `SELECT data FROM desired_lrps` + where(conditions) + ` ORDER BY process_guid`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := store.query(query, args...)
+	if err != nil {
+		logger.Error("failed-to-fetch-desired-lrps", err)
+		return nil, models.ErrUnknownError
+	}
+	defer rows.Close()
+
+	desiredLRPs := []*models.DesiredLRP{}
+	for rows.Next() {
+		var data []byte
+		err := rows.Scan(&data)
+		if err != nil {
+			logger.Error("failed-to-scan-desired-lrp", err)
+			return nil, models.ErrUnknownError
+		}
+
+		var lrp models.DesiredLRP
+		bbsErr := deserialize(logger, data, &lrp)
+		if bbsErr != nil {
+			return nil, bbsErr
+		}
+		desiredLRPs = append(desiredLRPs, &lrp)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed-to-fetch-desired-lrps", err)
+		return nil, models.ErrUnknownError
+	}
+
+	logger.Debug("succeeded-fetching-desired-lrps", lager.Data{"num-desired-lrps": len(desiredLRPs)})
+	return desiredLRPs, nil
+}
+
+func (store sqlStore) DesiredLRP(logger lager.Logger, processGuid string) (*models.DesiredLRP, uint64, *models.Error) {
+	var data []byte
+	var revision int64
+	err := store.queryRow(`SELECT data, revision FROM desired_lrps WHERE process_guid = ?`, processGuid).Scan(&data, &revision)
+	if err == sql.ErrNoRows {
+		return nil, 0, models.ErrResourceNotFound
+	} else if err != nil {
+		logger.Error("failed-to-fetch-desired-lrp", err)
+		return nil, 0, models.ErrUnknownError
+	}
+
+	var lrp models.DesiredLRP
+	bbsErr := deserialize(logger, data, &lrp)
+	if bbsErr != nil {
+		return nil, 0, bbsErr
+	}
+
+	return &lrp, uint64(revision), nil
+}
+
+func (store sqlStore) CreateDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP) *models.Error {
+	data, bbsErr := serialize(logger, lrp)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		_, err := tx.exec(
+			`INSERT INTO desired_lrps (process_guid, domain, revision, data) VALUES (?, ?, 0, ?)`,
+			lrp.ProcessGuid, lrp.Domain, data,
+		)
+		if err != nil {
+			return err
+		}
+		return tx.record(desiredLRPRecord, nil, data)
+	})
+	if err != nil {
+		if _, _, findErr := store.DesiredLRP(logger, lrp.ProcessGuid); findErr == nil {
+			return models.ErrResourceExists
+		}
+		logger.Error("failed-to-create-desired-lrp", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+func (store sqlStore) CompareAndSwapDesiredLRP(logger lager.Logger, before, after *models.DesiredLRP, prevIndex uint64) *models.Error {
+	beforeData, bbsErr := serialize(logger, before)
+	if bbsErr != nil {
+		return bbsErr
+	}
+	data, bbsErr := serialize(logger, after)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		ok, err := swapped(tx.exec(
+			`UPDATE desired_lrps SET data = ?, revision = revision + 1 WHERE process_guid = ? AND revision = ?`,
+			data, after.ProcessGuid, int64(prevIndex),
+		))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errConflict
+		}
+		return tx.record(desiredLRPRecord, beforeData, data)
+	})
+	if err == errConflict {
+		logger.Info("desired-lrp-changed-concurrently")
+		return models.ErrResourceConflict
+	} else if err != nil {
+		logger.Error("failed-to-update-desired-lrp", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+func (store sqlStore) CompareAndDeleteDesiredLRP(logger lager.Logger, lrp *models.DesiredLRP, prevIndex uint64) *models.Error {
+	data, bbsErr := serialize(logger, lrp)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		ok, err := swapped(tx.exec(
+			`DELETE FROM desired_lrps WHERE process_guid = ? AND revision = ?`,
+			lrp.ProcessGuid, int64(prevIndex),
+		))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errConflict
+		}
+		return tx.record(desiredLRPRecord, data, nil)
+	})
+	if err == errConflict {
+		logger.Info("desired-lrp-changed-during-removal")
+		return models.ErrResourceConflict
+	} else if err != nil {
+		logger.Error("failed-to-remove-desired-lrp", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package sqldb
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *SQLDB) GetAllDomains(logger lager.Logger) (*models.Domains, *models.Error) {
+	rows, err := db.query(
+		`SELECT domain FROM domains WHERE expire_time = 0 OR expire_time > ? ORDER BY domain`,
+		db.clock.Now().UnixNano(),
+	)
+	if err != nil {
+		logger.Error("failed-to-fetch-domains", err)
+		return nil, models.ErrUnknownError
+	}
+	defer rows.Close()
+
+	domains := []string{}
+	for rows.Next() {
+		var domain string
+		err := rows.Scan(&domain)
+		if err != nil {
+			logger.Error("failed-to-scan-domain", err)
+			return nil, models.ErrUnknownError
+		}
+		domains = append(domains, domain)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed-to-fetch-domains", err)
+		return nil, models.ErrUnknownError
+	}
+
+	return &models.Domains{Domains: domains}, nil
+}
+
+// UpsertDomain marks the domain fresh for ttl seconds, or forever if ttl is 0.
+func (db *SQLDB) UpsertDomain(logger lager.Logger, domain string, ttl int) *models.Error {
+	expireTime := db.expireTime(uint64(ttl))
+
+	updated, err := swapped(db.exec(`UPDATE domains SET expire_time = ? WHERE domain = ?`, expireTime, domain))
+	if err == nil && !updated {
+		_, err = db.exec(`INSERT INTO domains (domain, expire_time) VALUES (?, ?)`, domain, expireTime)
+		if err != nil {
+			// lost a race to insert the domain; the winner's row can be updated
+			_, err = db.exec(`UPDATE domains SET expire_time = ? WHERE domain = ?`, expireTime, domain)
+		}
+	}
+	if err != nil {
+		logger.Error("failed-to-upsert-domain", err)
+		return models.ErrUnknownError
+	}
+	return nil
+}
+
+// expireTime converts a ttl in seconds to the expire_time column, where 0
+// means the row never expires.
+func (db *SQLDB) expireTime(ttl uint64) int64 {
+	if ttl == 0 {
+		return 0
+	}
+	return db.clock.Now().Add(time.Duration(ttl) * time.Second).UnixNano()
+}
@@ -0,0 +1,127 @@
+package sqldb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *SQLDB) WatchForDesiredLRPChanges(logger lager.Logger,
+	created func(*models.DesiredLRP),
+	changed func(*models.DesiredLRPChange),
+	deleted func(*models.DesiredLRP),
+) (chan<- bool, <-chan error) {
+	logger = logger.Session("watching-for-desired-lrp-changes")
+
+	return db.watchChanges(logger, nil, func(c change) {
+		if c.recordType != desiredLRPRecord {
+			return
+		}
+
+		before, after := &models.DesiredLRP{}, &models.DesiredLRP{}
+		if !unmarshalChange(logger, c, before, after) {
+			return
+		}
+
+		switch {
+		case len(c.before) == 0:
+			logger.Debug("sending-create", lager.Data{"desired-lrp": after})
+			created(after)
+		case len(c.after) == 0:
+			logger.Debug("sending-delete", lager.Data{"desired-lrp": before})
+			deleted(before)
+		default:
+			logger.Debug("sending-update", lager.Data{"before": before, "after": after})
+			changed(&models.DesiredLRPChange{Before: before, After: after})
+		}
+	})
+}
+
+// WatchForActualLRPChanges also removes expired evacuating records before
+// each poll, so that their removal is seen promptly as it is in etcd.
+func (db *SQLDB) WatchForActualLRPChanges(logger lager.Logger,
+	created func(*models.ActualLRPGroup),
+	changed func(*models.ActualLRPChange),
+	deleted func(*models.ActualLRPGroup),
+) (chan<- bool, <-chan error) {
+	logger = logger.Session("watching-for-actual-lrp-changes")
+
+	prune := func() { db.pruneExpiredEvacuatingActualLRPs(logger) }
+
+	return db.watchChanges(logger, prune, func(c change) {
+		if c.recordType != actualLRPRecord && c.recordType != evacuatingActualLRPRecord {
+			return
+		}
+		evacuating := c.recordType == evacuatingActualLRPRecord
+
+		before, after := &models.ActualLRP{}, &models.ActualLRP{}
+		if !unmarshalChange(logger, c, before, after) {
+			return
+		}
+
+		switch {
+		case len(c.before) == 0:
+			logger.Debug("sending-create", lager.Data{"actual-lrp": after, "evacuating": evacuating})
+			created(actualLRPGroup(after, evacuating))
+		case len(c.after) == 0:
+			logger.Debug("sending-delete", lager.Data{"actual-lrp": before, "evacuating": evacuating})
+			deleted(actualLRPGroup(before, evacuating))
+		default:
+			logger.Debug("sending-change", lager.Data{"before": before, "after": after, "evacuating": evacuating})
+			changed(&models.ActualLRPChange{
+				Before: actualLRPGroup(before, evacuating),
+				After:  actualLRPGroup(after, evacuating),
+			})
+		}
+	})
+}
+
+func (db *SQLDB) WatchForTaskChanges(logger lager.Logger,
+	created func(*models.Task),
+	changed func(*models.TaskChange),
+	deleted func(*models.Task),
+) (chan<- bool, <-chan error) {
+	logger = logger.Session("watching-for-task-changes")
+
+	return db.watchChanges(logger, nil, func(c change) {
+		if c.recordType != taskRecord {
+			return
+		}
+
+		before, after := &models.Task{}, &models.Task{}
+		if !unmarshalChange(logger, c, before, after) {
+			return
+		}
+
+		switch {
+		case len(c.before) == 0:
+			logger.Debug("sending-create", lager.Data{"task-guid": after.TaskGuid})
+			created(after)
+		case len(c.after) == 0:
+			logger.Debug("sending-delete", lager.Data{"task-guid": before.TaskGuid})
+			deleted(before)
+		default:
+			logger.Debug("sending-update", lager.Data{"task-guid": after.TaskGuid, "before-state": before.State, "after-state": after.State})
+			changed(&models.TaskChange{Before: before, After: after})
+		}
+	})
+}
+
+// unmarshalChange decodes whichever sides of the change are present, skipping
+// the change if either cannot be read.
+func unmarshalChange(logger lager.Logger, c change, before, after proto.Message) bool {
+	if len(c.before) > 0 && deserialize(logger, c.before, before) != nil {
+		return false
+	}
+	if len(c.after) > 0 && deserialize(logger, c.after, after) != nil {
+		return false
+	}
+	return true
+}
+
+func actualLRPGroup(lrp *models.ActualLRP, evacuating bool) *models.ActualLRPGroup {
+	if evacuating {
+		return &models.ActualLRPGroup{Evacuating: lrp}
+	}
+	return &models.ActualLRPGroup{Instance: lrp}
+}
@@ -0,0 +1,116 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watchers", func() {
+	Describe("WatchForDesiredLRPChanges", func() {
+		var (
+			creates chan *models.DesiredLRP
+			stop    chan<- bool
+			errors  <-chan error
+		)
+
+		BeforeEach(func() {
+			creates = make(chan *models.DesiredLRP, 10)
+
+			stop, errors = sqlDB.WatchForDesiredLRPChanges(logger,
+				func(created *models.DesiredLRP) { creates <- created },
+				func(changed *models.DesiredLRPChange) {},
+				func(deleted *models.DesiredLRP) {},
+			)
+		})
+
+		AfterEach(func() {
+			close(stop)
+			Consistently(errors).ShouldNot(Receive())
+			Eventually(errors).Should(BeClosed())
+		})
+
+		It("sends an event down the pipe for creates made by another BBS", func() {
+			lrp := model_helpers.NewValidDesiredLRP("some-process-guid")
+			lrp.Instances = 0
+
+			otherDB := sqldb.NewSQL(sqlConn, sqldb.SQLite, auctioneerClient, cellClient, cellDB, clock, fakeTaskCompletionClient)
+			Expect(otherDB.DesireLRP(logger, lrp)).To(Succeed())
+
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, lrp.GetProcessGuid())
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(creates).Should(Receive(Equal(desiredLRP)))
+		})
+	})
+
+	Describe("WatchForActualLRPChanges", func() {
+		var (
+			creates chan *models.ActualLRPGroup
+			changes chan *models.ActualLRPChange
+			deletes chan *models.ActualLRPGroup
+			stop    chan<- bool
+			errors  <-chan error
+			key     models.ActualLRPKey
+		)
+
+		BeforeEach(func() {
+			creates = make(chan *models.ActualLRPGroup, 10)
+			changes = make(chan *models.ActualLRPChange, 10)
+			deletes = make(chan *models.ActualLRPGroup, 10)
+
+			stop, errors = sqlDB.WatchForActualLRPChanges(logger,
+				func(created *models.ActualLRPGroup) { creates <- created },
+				func(changed *models.ActualLRPChange) { changes <- changed },
+				func(deleted *models.ActualLRPGroup) { deletes <- deleted },
+			)
+
+			desiredLRP := model_helpers.NewValidDesiredLRP("some-process-guid")
+			desiredLRP.Instances = 1
+			Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+			key = models.NewActualLRPKey("some-process-guid", 0, desiredLRP.Domain)
+		})
+
+		AfterEach(func() {
+			close(stop)
+			Consistently(errors).ShouldNot(Receive())
+			Eventually(errors).Should(BeClosed())
+		})
+
+		It("sends an event down the pipe when an evacuating actual LRP expires", func() {
+			Eventually(creates).Should(Receive())
+
+			instanceKey := models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+			netInfo := models.NewActualLRPNetInfo("1.2.3.4", models.NewPortMapping(8080, 80))
+			_, err := sqlDB.StartActualLRP(logger, &models.StartActualLRPRequest{
+				ActualLrpKey:         &key,
+				ActualLrpInstanceKey: &instanceKey,
+				ActualLrpNetInfo:     &netInfo,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(changes).Should(Receive())
+
+			_, err = sqlDB.EvacuateRunningActualLRP(logger, &models.EvacuateRunningActualLRPRequest{
+				ActualLrpKey:         &key,
+				ActualLrpInstanceKey: &instanceKey,
+				ActualLrpNetInfo:     &netInfo,
+				Ttl:                  60,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var created *models.ActualLRPGroup
+			Eventually(creates).Should(Receive(&created))
+
+			clock.Increment(61 * time.Second)
+
+			var deleted *models.ActualLRPGroup
+			Eventually(deletes).Should(Receive(&deleted))
+			Expect(deleted.Instance).To(BeNil())
+			Expect(deleted.Evacuating).To(Equal(created.Evacuating))
+		})
+	})
+})
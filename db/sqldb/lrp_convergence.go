@@ -0,0 +1,53 @@
+package sqldb
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (db *SQLDB) ConvergeLRPs(logger lager.Logger) models.LRPConvergenceSummary {
+	db.pruneExpiredEvacuatingActualLRPs(logger.Session("converge-lrps"))
+	db.pruneChanges(logger.Session("converge-lrps"))
+	return db.DB.ConvergeLRPs(logger)
+}
+
+// pruneExpiredEvacuatingActualLRPs deletes evacuating records whose ttl has
+// run out, so that watchers see them go just as they would in etcd. A record
+// another BBS removes first is left to it.
+func (db *SQLDB) pruneExpiredEvacuatingActualLRPs(logger lager.Logger) {
+	rows, err := db.query(
+		`SELECT data, revision FROM actual_lrps WHERE evacuating = 1 AND expire_time != 0 AND expire_time <= ?`,
+		db.clock.Now().UnixNano(),
+	)
+	if err != nil {
+		logger.Error("failed-fetching-expired-evacuating-actual-lrps", err)
+		return
+	}
+
+	type expiredLRP struct {
+		lrp      *models.ActualLRP
+		revision int64
+	}
+	expired := []expiredLRP{}
+	for rows.Next() {
+		var data []byte
+		var revision int64
+		err := rows.Scan(&data, &revision)
+		if err != nil {
+			logger.Error("failed-to-scan-actual-lrp", err)
+			break
+		}
+
+		var lrp models.ActualLRP
+		if deserialize(logger, data, &lrp) != nil {
+			continue
+		}
+		expired = append(expired, expiredLRP{lrp: &lrp, revision: revision})
+	}
+	rows.Close()
+
+	for _, e := range expired {
+		logger.Info("pruning-expired-evacuating-actual-lrp", lager.Data{"actual-lrp-key": e.lrp.ActualLRPKey})
+		sqlStore{db}.CompareAndDeleteActualLRP(logger, e.lrp, true, uint64(e.revision))
+	}
+}
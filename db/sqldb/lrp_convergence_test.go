@@ -0,0 +1,117 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LRP Convergence", func() {
+	const (
+		presentCellId = "present-cell"
+		missingCellId = "missing-cell"
+	)
+
+	var cellPresence models.CellPresence
+
+	newActualLRP := func(processGuid string, index int32, state string, cellId string) *models.ActualLRP {
+		lrp := model_helpers.NewValidActualLRP(processGuid, index)
+		lrp.State = state
+		lrp.ActualLRPInstanceKey = models.NewActualLRPInstanceKey("instance-guid", cellId)
+		if cellId == "" {
+			lrp.ActualLRPInstanceKey = models.ActualLRPInstanceKey{}
+			lrp.ActualLRPNetInfo = models.EmptyActualLRPNetInfo()
+		}
+		return lrp
+	}
+
+	fetchActual := func(processGuid string, index int32) *models.ActualLRP {
+		group, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, index)
+		Expect(err).NotTo(HaveOccurred())
+		return group.Instance
+	}
+
+	BeforeEach(func() {
+		cellPresence = models.NewCellPresence(
+			presentCellId,
+			"cell.example.com",
+			"the-zone",
+			models.NewCellCapacity(128, 1024, 6),
+			[]string{},
+			[]string{},
+		)
+		registerCell(cellPresence)
+	})
+
+	JustBeforeEach(func() {
+		sqlDB.ConvergeLRPs(logger)
+	})
+
+	Context("when evacuating actual LRPs have expired", func() {
+		BeforeEach(func() {
+			desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+			desiredLRP.Instances = 1
+			setRawDesiredLRP(desiredLRP)
+			setRawActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, presentCellId))
+			setRawEvacuatingActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, missingCellId), 60)
+
+			clock.Increment(61 * time.Second)
+		})
+
+		It("prunes them", func() {
+			var count int
+			err := sqlConn.QueryRow(`SELECT COUNT(*) FROM actual_lrps WHERE evacuating = 1`).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+
+			Expect(fetchActual("desired-guid", 0).State).To(Equal(models.ActualLRPStateRunning))
+		})
+	})
+
+	Context("when changes are older than the watches need", func() {
+		BeforeEach(func() {
+			desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+			desiredLRP.Instances = 0
+			Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+
+			clock.Increment(11 * time.Minute)
+		})
+
+		It("prunes them", func() {
+			var count int
+			err := sqlConn.QueryRow(`SELECT COUNT(*) FROM changes`).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+		})
+	})
+
+	Context("when the cells cannot be fetched", func() {
+		BeforeEach(func() {
+			cellDB.CellsReturns(nil, models.ErrUnknownError)
+
+			desiredLRP := model_helpers.NewValidDesiredLRP("desired-guid")
+			desiredLRP.Instances = 3
+			setRawDesiredLRP(desiredLRP)
+			setRawActualLRP(newActualLRP("desired-guid", 0, models.ActualLRPStateRunning, missingCellId))
+			setRawActualLRP(newActualLRP("desired-guid", 1, models.ActualLRPStateClaimed, "other-missing-cell"))
+		})
+
+		It("fetches the cells once", func() {
+			Expect(cellDB.CellsCallCount()).To(Equal(1))
+		})
+
+		It("leaves actual LRPs on their cells", func() {
+			Expect(fetchActual("desired-guid", 0).State).To(Equal(models.ActualLRPStateRunning))
+			Expect(fetchActual("desired-guid", 0).CellId).To(Equal(missingCellId))
+			Expect(fetchActual("desired-guid", 1).State).To(Equal(models.ActualLRPStateClaimed))
+		})
+
+		It("still creates the missing indices", func() {
+			Expect(fetchActual("desired-guid", 2).State).To(Equal(models.ActualLRPStateUnclaimed))
+		})
+	})
+})
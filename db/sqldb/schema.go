@@ -0,0 +1,163 @@
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pivotal-golang/lager"
+)
+
+// schemaMigrations holds the statements that bring the schema from one
+// version to the next; the schema is at version i+1 once the statements at
+// index i have run. Released migrations must never be edited, only appended to.
+var schemaMigrations = []func(types columnTypes) []string{
+	func(types columnTypes) []string {
+		return []string{
+			`CREATE TABLE domains (
+				domain VARCHAR(255) PRIMARY KEY,
+				expire_time BIGINT NOT NULL
+			)`,
+
+			`CREATE TABLE desired_lrps (
+				process_guid VARCHAR(255) PRIMARY KEY,
+				domain VARCHAR(255) NOT NULL,
+				revision BIGINT NOT NULL,
+				data ` + types.blob + ` NOT NULL
+			)`,
+			`CREATE INDEX desired_lrps_domain ON desired_lrps (domain)`,
+
+			`CREATE TABLE actual_lrps (
+				process_guid VARCHAR(255) NOT NULL,
+				instance_index INTEGER NOT NULL,
+				evacuating INTEGER NOT NULL,
+				domain VARCHAR(255) NOT NULL,
+				cell_id VARCHAR(255) NOT NULL,
+				expire_time BIGINT NOT NULL,
+				revision BIGINT NOT NULL,
+				data ` + types.blob + ` NOT NULL,
+				PRIMARY KEY (process_guid, instance_index, evacuating)
+			)`,
+			`CREATE INDEX actual_lrps_domain ON actual_lrps (domain)`,
+			`CREATE INDEX actual_lrps_cell_id ON actual_lrps (cell_id)`,
+
+			`CREATE TABLE tasks (
+				task_guid VARCHAR(255) PRIMARY KEY,
+				domain VARCHAR(255) NOT NULL,
+				cell_id VARCHAR(255) NOT NULL,
+				revision BIGINT NOT NULL,
+				data ` + types.blob + ` NOT NULL
+			)`,
+			`CREATE INDEX tasks_domain ON tasks (domain)`,
+			`CREATE INDEX tasks_cell_id ON tasks (cell_id)`,
+		}
+	},
+	func(types columnTypes) []string {
+		return []string{
+			`CREATE TABLE change_revision (revision BIGINT NOT NULL)`,
+			`INSERT INTO change_revision (revision) VALUES (0)`,
+
+			`CREATE TABLE changes (
+				revision BIGINT PRIMARY KEY,
+				record_type VARCHAR(32) NOT NULL,
+				before_data ` + types.blob + ` NOT NULL,
+				after_data ` + types.blob + ` NOT NULL,
+				created_at BIGINT NOT NULL
+			)`,
+			`CREATE INDEX changes_created_at ON changes (created_at)`,
+
+			`CREATE INDEX actual_lrps_expire_time ON actual_lrps (evacuating, expire_time)`,
+		}
+	},
+}
+
+// SchemaVersion is the schema version this release of the BBS creates.
+var SchemaVersion = len(schemaMigrations)
+
+type columnTypes struct {
+	blob string
+}
+
+func (db *SQLDB) columnTypes() columnTypes {
+	switch db.driverName {
+	case Postgres:
+		return columnTypes{blob: "BYTEA"}
+	case MySQL:
+		return columnTypes{blob: "LONGBLOB"}
+	default:
+		return columnTypes{blob: "BLOB"}
+	}
+}
+
+// CreateSchema creates the BBS tables, or upgrades them from the version
+// recorded in the database. It refuses to touch a schema newer than
+// SchemaVersion.
+func (db *SQLDB) CreateSchema(logger lager.Logger) error {
+	logger = logger.Session("create-schema")
+	logger.Info("starting")
+
+	_, err := db.exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`)
+	if err != nil {
+		logger.Error("failed-to-create-schema-version-table", err)
+		return err
+	}
+
+	version, err := db.schemaVersion()
+	if err != nil {
+		logger.Error("failed-to-read-schema-version", err)
+		return err
+	}
+
+	if version > SchemaVersion {
+		err := fmt.Errorf("database schema is at version %d, but this BBS only understands up to version %d", version, SchemaVersion)
+		logger.Error("schema-too-new", err)
+		return err
+	}
+
+	for ; version < SchemaVersion; version++ {
+		logger.Info("migrating", lager.Data{"from-version": version, "to-version": version + 1})
+		err := db.migrate(version, schemaMigrations[version](db.columnTypes()))
+		if err != nil {
+			logger.Error("failed-to-migrate", err, lager.Data{"to-version": version + 1})
+			return err
+		}
+	}
+
+	logger.Info("succeeded", lager.Data{"version": version})
+	return nil
+}
+
+func (db *SQLDB) schemaVersion() (int, error) {
+	var version int
+	err := db.queryRow(`SELECT version FROM schema_version`).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (db *SQLDB) migrate(fromVersion int, statements []string) error {
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		_, err = tx.Exec(statement)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if fromVersion == 0 {
+		_, err = tx.Exec(db.rebind(`INSERT INTO schema_version (version) VALUES (?)`), fromVersion+1)
+	} else {
+		_, err = tx.Exec(db.rebind(`UPDATE schema_version SET version = ?`), fromVersion+1)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
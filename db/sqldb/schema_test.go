@@ -0,0 +1,39 @@
+package sqldb_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema", func() {
+	Describe("CreateSchema", func() {
+		It("records the schema version", func() {
+			var version int
+			err := sqlConn.QueryRow(`SELECT version FROM schema_version`).Scan(&version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(sqldb.SchemaVersion))
+		})
+
+		It("can be run again against an up to date schema", func() {
+			Expect(sqlDB.CreateSchema(logger)).To(Succeed())
+
+			var count int
+			err := sqlConn.QueryRow(`SELECT COUNT(*) FROM schema_version`).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+
+		Context("when the schema is newer than this BBS understands", func() {
+			BeforeEach(func() {
+				_, err := sqlConn.Exec(`UPDATE schema_version SET version = ?`, sqldb.SchemaVersion+1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("refuses to use it", func() {
+				Expect(sqlDB.CreateSchema(logger)).NotTo(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,127 @@
+package sqldb
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/auctionhandlers"
+	"github.com/cloudfoundry-incubator/bbs/cellhandlers"
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/storedb"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+	SQLite   = "sqlite3"
+)
+
+// SQLDB stores BBS records in a SQL database. Every row carries a revision
+// that is bumped on each write; updates and deletes only apply to the
+// revision that was read, which stands in for etcd's CompareAndSwap. Each
+// write also appends to a change log that the watches of every BBS poll.
+type SQLDB struct {
+	*storedb.DB
+
+	db         *sql.DB
+	driverName string
+	clock      clock.Clock
+
+	// writeLock keeps this process's writes from contending for the change
+	// revision counter, which SQLite cannot wait on.
+	writeLock sync.Mutex
+}
+
+func NewSQL(sqlDB *sql.DB, driverName string, auctioneerClient auctionhandlers.Client, cellClient cellhandlers.Client, cellDB db.CellDB, clock clock.Clock, taskCompletionClient taskworkpool.TaskCompletionClient) *SQLDB {
+	db := &SQLDB{
+		db:         sqlDB,
+		driverName: driverName,
+		clock:      clock,
+	}
+	db.DB = storedb.New(sqlStore{db}, auctioneerClient, cellClient, cellDB, clock, taskCompletionClient)
+	return db
+}
+
+// sqlStore gives storedb access to the rows of a SQLDB.
+type sqlStore struct {
+	*SQLDB
+}
+
+// rebind rewrites ? placeholders into the form the driver expects.
+func (db *SQLDB) rebind(query string) string {
+	if db.driverName != Postgres {
+		return query
+	}
+
+	var rebound []byte
+	n := 0
+	for i := 0; i < len(query); i++ {
+		if query[i] == '?' {
+			n++
+			rebound = append(rebound, '$')
+			rebound = strconv.AppendInt(rebound, int64(n), 10)
+			continue
+		}
+		rebound = append(rebound, query[i])
+	}
+	return string(rebound)
+}
+
+func (db *SQLDB) exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.db.Exec(db.rebind(query), args...)
+}
+
+func (db *SQLDB) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.db.Query(db.rebind(query), args...)
+}
+
+func (db *SQLDB) queryRow(query string, args ...interface{}) *sql.Row {
+	return db.db.QueryRow(db.rebind(query), args...)
+}
+
+// swapped reports whether a conditional write matched exactly one row.
+func swapped(result sql.Result, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// where joins conditions into a WHERE clause, or returns an empty string if
+// there are none.
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func serialize(logger lager.Logger, record proto.Message) ([]byte, *models.Error) {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		logger.Error("failed-to-serialize-record", err)
+		return nil, models.ErrSerializeJSON
+	}
+	return data, nil
+}
+
+func deserialize(logger lager.Logger, data []byte, record proto.Message) *models.Error {
+	err := proto.Unmarshal(data, record)
+	if err != nil {
+		logger.Error("failed-to-deserialize-record", err)
+		return models.ErrDeserializeJSON
+	}
+	return nil
+}
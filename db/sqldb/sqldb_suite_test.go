@@ -0,0 +1,181 @@
+package sqldb_test
+
+import (
+	"database/sql"
+	"time"
+
+	fakeauctioneer "github.com/cloudfoundry-incubator/bbs/auctionhandlers/fakes"
+	fakecellhandlers "github.com/cloudfoundry-incubator/bbs/cellhandlers/fakes"
+	fakedb "github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/db/internal/db_suites"
+	"github.com/cloudfoundry-incubator/bbs/db/sqldb"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool/fakes"
+	"github.com/gogo/protobuf/proto"
+	_ "github.com/mattn/go-sqlite3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"testing"
+)
+
+var auctioneerClient *fakeauctioneer.FakeClient
+var cellClient *fakecellhandlers.FakeClient
+var fakeTaskCompletionClient *fakes.FakeTaskCompletionClient
+var cellDB *fakedb.FakeCellDB
+
+var logger *lagertest.TestLogger
+var clock *fakeclock.FakeClock
+
+var cells map[string]*models.CellPresence
+
+var sqlConn *sql.DB
+var sqlDB *sqldb.SQLDB
+
+var backend = &db_suites.Backend{}
+
+func TestSQLDB(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SQL DB Suite")
+}
+
+var _ = BeforeEach(func() {
+	logger = lagertest.NewTestLogger("test")
+	clock = fakeclock.NewFakeClock(time.Unix(0, 1138))
+
+	auctioneerClient = new(fakeauctioneer.FakeClient)
+	cellClient = new(fakecellhandlers.FakeClient)
+	fakeTaskCompletionClient = new(fakes.FakeTaskCompletionClient)
+	cellDB = new(fakedb.FakeCellDB)
+	cells = map[string]*models.CellPresence{}
+	cellDB.CellByIdStub = func(_ lager.Logger, cellId string) (*models.CellPresence, *models.Error) {
+		cell, ok := cells[cellId]
+		if !ok {
+			return nil, models.ErrResourceNotFound
+		}
+		return cell, nil
+	}
+	cellDB.CellsStub = func(_ lager.Logger) ([]*models.CellPresence, *models.Error) {
+		presences := []*models.CellPresence{}
+		for _, cell := range cells {
+			presences = append(presences, cell)
+		}
+		return presences, nil
+	}
+
+	var err error
+	sqlConn, err = sql.Open(sqldb.SQLite, ":memory:")
+	Expect(err).NotTo(HaveOccurred())
+	// every connection to :memory: opens a separate database
+	sqlConn.SetMaxOpenConns(1)
+
+	sqlDB = sqldb.NewSQL(sqlConn, sqldb.SQLite, auctioneerClient, cellClient, cellDB, clock, fakeTaskCompletionClient)
+	Expect(sqlDB.CreateSchema(logger)).To(Succeed())
+
+	*backend = db_suites.Backend{
+		DB:     sqlDB,
+		Logger: logger,
+		Clock:  clock,
+
+		AuctioneerClient:     auctioneerClient,
+		CellClient:           cellClient,
+		TaskCompletionClient: fakeTaskCompletionClient,
+
+		RegisterCell:              registerCell,
+		SetRawTask:                setRawTask,
+		SetRawTaskData:            setRawTaskData,
+		SetRawDesiredLRP:          setRawDesiredLRP,
+		SetRawDesiredLRPData:      setRawDesiredLRPData,
+		SetRawActualLRP:           setRawActualLRP,
+		SetRawEvacuatingActualLRP: setRawEvacuatingActualLRP,
+	}
+})
+
+var _ = db_suites.ActualLRPDBSuite(backend)
+var _ = db_suites.DesiredLRPDBSuite(backend)
+var _ = db_suites.TaskDBSuite(backend)
+var _ = db_suites.EvacuationSuite(backend)
+var _ = db_suites.LRPConvergenceSuite(backend)
+var _ = db_suites.TaskConvergenceSuite(backend)
+var _ = db_suites.DomainDBSuite(backend)
+var _ = db_suites.EventDBSuite(backend)
+
+var _ = AfterEach(func() {
+	sqlConn.Close()
+})
+
+func setRawTask(task *models.Task) {
+	data, err := proto.Marshal(task)
+	Expect(err).NotTo(HaveOccurred())
+	insertTask(task.TaskGuid, task.Domain, task.CellId, data)
+}
+
+func setRawTaskData(taskGuid string, data []byte) {
+	insertTask(taskGuid, "domain", "", data)
+}
+
+func insertTask(taskGuid, domain, cellId string, data []byte) {
+	_, err := sqlConn.Exec(`DELETE FROM tasks WHERE task_guid = ?`, taskGuid)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = sqlConn.Exec(
+		`INSERT INTO tasks (task_guid, domain, cell_id, revision, data) VALUES (?, ?, ?, 0, ?)`,
+		taskGuid, domain, cellId, data,
+	)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func registerCell(cell models.CellPresence) {
+	cells[cell.CellId] = &cell
+}
+
+func setRawDesiredLRP(lrp *models.DesiredLRP) {
+	data, err := proto.Marshal(lrp)
+	Expect(err).NotTo(HaveOccurred())
+	insertDesiredLRP(lrp.ProcessGuid, lrp.Domain, data)
+}
+
+func setRawDesiredLRPData(processGuid string, data []byte) {
+	insertDesiredLRP(processGuid, "domain", data)
+}
+
+func insertDesiredLRP(processGuid, domain string, data []byte) {
+	_, err := sqlConn.Exec(`DELETE FROM desired_lrps WHERE process_guid = ?`, processGuid)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = sqlConn.Exec(
+		`INSERT INTO desired_lrps (process_guid, domain, revision, data) VALUES (?, ?, 0, ?)`,
+		processGuid, domain, data,
+	)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func setRawActualLRP(lrp *models.ActualLRP) {
+	insertActualLRP(lrp, 0, 0)
+}
+
+func setRawEvacuatingActualLRP(lrp *models.ActualLRP, ttlInSeconds uint64) {
+	var expireTime int64
+	if ttlInSeconds > 0 {
+		expireTime = clock.Now().Add(time.Duration(ttlInSeconds) * time.Second).UnixNano()
+	}
+	insertActualLRP(lrp, 1, expireTime)
+}
+
+func insertActualLRP(lrp *models.ActualLRP, evacuating int, expireTime int64) {
+	data, err := proto.Marshal(lrp)
+	Expect(err).NotTo(HaveOccurred())
+
+	_, err = sqlConn.Exec(
+		`DELETE FROM actual_lrps WHERE process_guid = ? AND instance_index = ? AND evacuating = ?`,
+		lrp.ProcessGuid, lrp.Index, evacuating,
+	)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = sqlConn.Exec(
+		`INSERT INTO actual_lrps (process_guid, instance_index, evacuating, domain, cell_id, expire_time, revision, data)
+			VALUES (?, ?, ?, ?, ?, ?, 0, ?)`,
+		lrp.ProcessGuid, lrp.Index, evacuating, lrp.Domain, lrp.CellId, expireTime, data,
+	)
+	Expect(err).NotTo(HaveOccurred())
+}
@@ -0,0 +1,42 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Task Convergence", func() {
+	var summary models.TaskConvergenceSummary
+
+	JustBeforeEach(func() {
+		summary = sqlDB.ConvergeTasks(logger, 30*time.Second, 30*time.Minute, 2*time.Minute)
+	})
+
+	Context("when the cells cannot be fetched", func() {
+		BeforeEach(func() {
+			cellDB.CellsReturns(nil, models.ErrUnknownError)
+
+			task := model_helpers.NewValidTask("running-guid")
+			task.State = models.Task_Running
+			task.CellId = "some-cell"
+			task.CreatedAt = clock.Now().UnixNano()
+			task.UpdatedAt = clock.Now().UnixNano()
+			task.FirstCompletedAt = 0
+			setRawTask(task)
+		})
+
+		It("leaves running tasks alone", func() {
+			Expect(summary.RunningTasksFailed).To(Equal(0))
+
+			task, err := sqlDB.TaskByGuid(logger, "running-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.State).To(Equal(models.Task_Running))
+			Expect(fakeTaskCompletionClient.SubmitCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,170 @@
+package sqldb
+
+import (
+	"database/sql"
+
+	"github.com/cloudfoundry-incubator/bbs/db/internal/storedb"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+func (store sqlStore) Tasks(logger lager.Logger, filter models.TaskFilter) ([]storedb.TaskRecord, *models.Error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Domain != "" {
+		conditions = append(conditions, "domain = ?")
+		args = append(args, filter.Domain)
+	}
+	if filter.CellID != "" {
+		conditions = append(conditions, "cell_id = ?")
+		args = append(args, filter.CellID)
+	}
+	if filter.AfterTaskGuid != "" {
+		conditions = append(conditions, "task_guid > ?")
+		args = append(args, filter.AfterTaskGuid)
+	}
+	query := `SELECT data, revision FROM tasks` + where(conditions) + ` ORDER BY task_guid`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := store.query(query, args...)
+	if err != nil {
+		logger.Error("failed-to-fetch-tasks", err)
+		return nil, models.ErrUnknownError
+	}
+	defer rows.Close()
+
+	records := []storedb.TaskRecord{}
+	for rows.Next() {
+		var data []byte
+		var revision int64
+		err := rows.Scan(&data, &revision)
+		if err != nil {
+			logger.Error("failed-to-scan-task", err)
+			return nil, models.ErrUnknownError
+		}
+
+		var task models.Task
+		bbsErr := deserialize(logger, data, &task)
+		if bbsErr != nil {
+			return nil, models.ErrUnknownError
+		}
+		records = append(records, storedb.TaskRecord{Task: &task, Index: uint64(revision)})
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed-to-fetch-tasks", err)
+		return nil, models.ErrUnknownError
+	}
+
+	return records, nil
+}
+
+func (store sqlStore) Task(logger lager.Logger, taskGuid string) (*models.Task, uint64, *models.Error) {
+	var data []byte
+	var revision int64
+	err := store.queryRow(`SELECT data, revision FROM tasks WHERE task_guid = ?`, taskGuid).Scan(&data, &revision)
+	if err == sql.ErrNoRows {
+		return nil, 0, models.ErrResourceNotFound
+	} else if err != nil {
+		logger.Error("failed-to-fetch-task", err)
+		return nil, 0, models.ErrUnknownError
+	}
+
+	var task models.Task
+	bbsErr := deserialize(logger, data, &task)
+	if bbsErr != nil {
+		return nil, 0, bbsErr
+	}
+
+	return &task, uint64(revision), nil
+}
+
+func (store sqlStore) CreateTask(logger lager.Logger, task *models.Task) *models.Error {
+	data, bbsErr := serialize(logger, task)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		_, err := tx.exec(
+			`INSERT INTO tasks (task_guid, domain, cell_id, revision, data) VALUES (?, ?, ?, 0, ?)`,
+			task.TaskGuid, task.Domain, task.CellId, data,
+		)
+		if err != nil {
+			return err
+		}
+		return tx.record(taskRecord, nil, data)
+	})
+	if err != nil {
+		if _, _, findErr := store.Task(logger, task.TaskGuid); findErr == nil {
+			return models.ErrResourceExists
+		}
+		logger.Error("failed-to-create-task", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+func (store sqlStore) CompareAndSwapTask(logger lager.Logger, before, after *models.Task, prevIndex uint64) *models.Error {
+	beforeData, bbsErr := serialize(logger, before)
+	if bbsErr != nil {
+		return bbsErr
+	}
+	data, bbsErr := serialize(logger, after)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		ok, err := swapped(tx.exec(
+			`UPDATE tasks SET domain = ?, cell_id = ?, revision = revision + 1, data = ? WHERE task_guid = ? AND revision = ?`,
+			after.Domain, after.CellId, data, after.TaskGuid, int64(prevIndex),
+		))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errConflict
+		}
+		return tx.record(taskRecord, beforeData, data)
+	})
+	if err == errConflict {
+		logger.Info("task-changed-concurrently")
+		return models.ErrResourceConflict
+	} else if err != nil {
+		logger.Error("failed-to-update-task", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
+
+func (store sqlStore) CompareAndDeleteTask(logger lager.Logger, task *models.Task, prevIndex uint64) *models.Error {
+	data, bbsErr := serialize(logger, task)
+	if bbsErr != nil {
+		return bbsErr
+	}
+
+	err := store.transact(func(tx *sqlTx) error {
+		ok, err := swapped(tx.exec(`DELETE FROM tasks WHERE task_guid = ? AND revision = ?`, task.TaskGuid, int64(prevIndex)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errConflict
+		}
+		return tx.record(taskRecord, data, nil)
+	})
+	if err == errConflict {
+		logger.Info("task-changed-concurrently")
+		return models.ErrResourceConflict
+	} else if err != nil {
+		logger.Error("failed-to-delete-task", err)
+		return models.ErrUnknownError
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package db
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . TaskConvergenceDB
+type TaskConvergenceDB interface {
+	ConvergeTasks(logger lager.Logger, kickTaskDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) models.TaskConvergenceSummary
+}
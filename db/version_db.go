@@ -0,0 +1,22 @@
+package db
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . VersionDB
+type VersionDB interface {
+	Version(logger lager.Logger) (int64, *models.Error)
+	// SetVersion records version, provided the stored version is still
+	// prevVersion. Data with no recorded version counts as version 0.
+	SetVersion(logger lager.Logger, prevVersion, version int64) *models.Error
+
+	// LockMigrations takes the migration lock for owner, or renews it if
+	// owner already holds it, until ttl passes. It fails with
+	// models.ErrResourceExists while another owner holds the lock.
+	LockMigrations(logger lager.Logger, owner string, ttl time.Duration) *models.Error
+	UnlockMigrations(logger lager.Logger, owner string) *models.Error
+}
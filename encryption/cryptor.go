@@ -0,0 +1,78 @@
+package encryption
+
+import (
+	"crypto/cipher"
+	"fmt"
+	"io"
+)
+
+// Encrypted is a ciphertext together with what is needed to decrypt it.
+type Encrypted struct {
+	KeyLabel   string
+	Nonce      []byte
+	CipherText []byte
+}
+
+// Cryptor authenticates additionalData along with the ciphertext, so a record
+// only decrypts with the additional data it was encrypted with.
+type Cryptor interface {
+	Encrypt(plaintext, additionalData []byte) (Encrypted, error)
+	Decrypt(encrypted Encrypted, additionalData []byte) ([]byte, error)
+}
+
+type cryptor struct {
+	keyManager KeyManager
+	prng       io.Reader
+}
+
+// NewCryptor encrypts with AES-GCM, reading nonces from prng.
+func NewCryptor(keyManager KeyManager, prng io.Reader) Cryptor {
+	return &cryptor{
+		keyManager: keyManager,
+		prng:       prng,
+	}
+}
+
+func (c *cryptor) Encrypt(plaintext, additionalData []byte) (Encrypted, error) {
+	key := c.keyManager.EncryptionKey()
+
+	aead, err := cipher.NewGCM(key.BlockCipher())
+	if err != nil {
+		return Encrypted{}, fmt.Errorf("unable to create GCM-wrapped cipher: %s", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(c.prng, nonce)
+	if err != nil {
+		return Encrypted{}, fmt.Errorf("unable to generate random nonce: %s", err)
+	}
+
+	return Encrypted{
+		KeyLabel:   key.Label(),
+		Nonce:      nonce,
+		CipherText: aead.Seal(nil, nonce, plaintext, additionalData),
+	}, nil
+}
+
+func (c *cryptor) Decrypt(encrypted Encrypted, additionalData []byte) ([]byte, error) {
+	key := c.keyManager.DecryptionKey(encrypted.KeyLabel)
+	if key == nil {
+		return nil, fmt.Errorf("key with label %q was not found", encrypted.KeyLabel)
+	}
+
+	aead, err := cipher.NewGCM(key.BlockCipher())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GCM-wrapped cipher: %s", err)
+	}
+
+	if len(encrypted.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("nonce is %d bytes, expected %d", len(encrypted.Nonce), aead.NonceSize())
+	}
+
+	plaintext, err := aead.Open(nil, encrypted.Nonce, encrypted.CipherText, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt: %s", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,124 @@
+package encryption_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cryptor", func() {
+	var (
+		oldKey     encryption.Key
+		activeKey  encryption.Key
+		keyManager encryption.KeyManager
+		cryptor    encryption.Cryptor
+	)
+
+	additionalData := []byte("some-key")
+
+	BeforeEach(func() {
+		var err error
+		oldKey, err = encryption.NewKey("old", "old passphrase")
+		Expect(err).NotTo(HaveOccurred())
+		activeKey, err = encryption.NewKey("active", "active passphrase")
+		Expect(err).NotTo(HaveOccurred())
+
+		keyManager, err = encryption.NewKeyManager(activeKey, []encryption.Key{oldKey})
+		Expect(err).NotTo(HaveOccurred())
+		cryptor = encryption.NewCryptor(keyManager, rand.Reader)
+	})
+
+	It("encrypts with the active key and decrypts again", func() {
+		encrypted, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encrypted.KeyLabel).To(Equal("active"))
+		Expect(encrypted.CipherText).NotTo(ContainSubstring("some plaintext"))
+
+		plaintext, err := cryptor.Decrypt(encrypted, additionalData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plaintext).To(Equal([]byte("some plaintext")))
+	})
+
+	It("uses a fresh nonce for every encryption", func() {
+		first, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first.Nonce).NotTo(Equal(second.Nonce))
+		Expect(first.CipherText).NotTo(Equal(second.CipherText))
+	})
+
+	It("decrypts records encrypted with a key that is no longer active", func() {
+		oldKeyManager, err := encryption.NewKeyManager(oldKey, nil)
+		Expect(err).NotTo(HaveOccurred())
+		encrypted, err := encryption.NewCryptor(oldKeyManager, rand.Reader).Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encrypted.KeyLabel).To(Equal("old"))
+
+		plaintext, err := cryptor.Decrypt(encrypted, additionalData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plaintext).To(Equal([]byte("some plaintext")))
+	})
+
+	It("fails to decrypt with an unknown key label", func() {
+		encrypted, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+
+		encrypted.KeyLabel = "unknown"
+		_, err = cryptor.Decrypt(encrypted, additionalData)
+		Expect(err).To(MatchError(ContainSubstring("unknown")))
+	})
+
+	It("fails to decrypt a tampered ciphertext", func() {
+		encrypted, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+
+		encrypted.CipherText[0] ^= 0xff
+		_, err = cryptor.Decrypt(encrypted, additionalData)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails to decrypt with different additional data", func() {
+		encrypted, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = cryptor.Decrypt(encrypted, []byte("other-key"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails to decrypt with a nonce of the wrong size", func() {
+		encrypted, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+
+		encrypted.Nonce = encrypted.Nonce[1:]
+		_, err = cryptor.Decrypt(encrypted, additionalData)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails to encrypt when no nonce can be read", func() {
+		cryptor = encryption.NewCryptor(keyManager, &failingReader{})
+		_, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("reads nonces from the given source", func() {
+		nonce := bytes.Repeat([]byte{7}, 12)
+		cryptor = encryption.NewCryptor(keyManager, bytes.NewReader(nonce))
+
+		encrypted, err := cryptor.Encrypt([]byte("some plaintext"), additionalData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encrypted.Nonce).To(Equal(nonce))
+	})
+})
+
+type failingReader struct{}
+
+func (*failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("no randomness")
+}
@@ -0,0 +1,51 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// MaxLabelLength bounds key labels so a label's length fits in the single
+// byte that precedes it in an encrypted record.
+const MaxLabelLength = 127
+
+var ErrEmptyLabel = errors.New("key label must not be empty")
+
+type Key interface {
+	Label() string
+	BlockCipher() cipher.Block
+}
+
+type key struct {
+	label       string
+	blockCipher cipher.Block
+}
+
+// NewKey derives an AES-256 key from the passphrase.
+func NewKey(label, passphrase string) (Key, error) {
+	if label == "" {
+		return nil, ErrEmptyLabel
+	}
+	if len(label) > MaxLabelLength {
+		return nil, fmt.Errorf("key label %q is longer than %d bytes", label, MaxLabelLength)
+	}
+
+	hash := sha256.Sum256([]byte(passphrase))
+	blockCipher, err := aes.NewCipher(hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &key{label: label, blockCipher: blockCipher}, nil
+}
+
+func (k *key) Label() string {
+	return k.label
+}
+
+func (k *key) BlockCipher() cipher.Block {
+	return k.blockCipher
+}
@@ -0,0 +1,66 @@
+package encryption
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+)
+
+var ErrNoActiveKeyLabel = errors.New("an active key label is required when encryption keys are configured")
+
+// KeyConfig is a keyring as written in a key file, with keys given as
+// passphrases by label.
+type KeyConfig struct {
+	ActiveKeyLabel string            `json:"active_key_label"`
+	Keys           map[string]string `json:"keys"`
+}
+
+func LoadKeyConfig(path string) (KeyConfig, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return KeyConfig{}, err
+	}
+
+	var cfg KeyConfig
+	err = json.Unmarshal(payload, &cfg)
+	if err != nil {
+		return KeyConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+// KeyManager builds the keyring, encrypting with the active key and
+// decrypting with any of the keys.
+func (cfg KeyConfig) KeyManager() (KeyManager, error) {
+	if cfg.ActiveKeyLabel == "" {
+		return nil, ErrNoActiveKeyLabel
+	}
+
+	labels := make([]string, 0, len(cfg.Keys))
+	for label := range cfg.Keys {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var activeKey Key
+	keys := make([]Key, 0, len(labels))
+	for _, label := range labels {
+		key, err := NewKey(label, cfg.Keys[label])
+		if err != nil {
+			return nil, err
+		}
+		if label == cfg.ActiveKeyLabel {
+			activeKey = key
+		}
+		keys = append(keys, key)
+	}
+
+	if activeKey == nil {
+		return nil, fmt.Errorf("active key label %q does not name a configured key", cfg.ActiveKeyLabel)
+	}
+
+	return NewKeyManager(activeKey, keys)
+}
@@ -0,0 +1,70 @@
+package encryption_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyConfig", func() {
+	Describe("LoadKeyConfig", func() {
+		var path string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "keys")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.WriteString(`{"active_key_label": "active", "keys": {"active": "a", "old": "b"}}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+			path = file.Name()
+		})
+
+		AfterEach(func() {
+			os.Remove(path)
+		})
+
+		It("reads the keyring", func() {
+			cfg, err := encryption.LoadKeyConfig(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(Equal(encryption.KeyConfig{
+				ActiveKeyLabel: "active",
+				Keys:           map[string]string{"active": "a", "old": "b"},
+			}))
+		})
+
+		It("fails for a missing file", func() {
+			_, err := encryption.LoadKeyConfig(path + "-missing")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("KeyManager", func() {
+		It("encrypts with the active key and decrypts with every key", func() {
+			keyManager, err := encryption.KeyConfig{
+				ActiveKeyLabel: "active",
+				Keys:           map[string]string{"active": "a", "old": "b"},
+			}.KeyManager()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(keyManager.EncryptionKey().Label()).To(Equal("active"))
+			Expect(keyManager.DecryptionKey("old")).NotTo(BeNil())
+		})
+
+		It("requires an active key label", func() {
+			_, err := encryption.KeyConfig{Keys: map[string]string{"old": "b"}}.KeyManager()
+			Expect(err).To(Equal(encryption.ErrNoActiveKeyLabel))
+		})
+
+		It("requires the active key to be configured", func() {
+			_, err := encryption.KeyConfig{
+				ActiveKeyLabel: "active",
+				Keys:           map[string]string{"old": "b"},
+			}.KeyManager()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
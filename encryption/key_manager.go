@@ -0,0 +1,45 @@
+package encryption
+
+import "fmt"
+
+// KeyManager holds the keyring: the active key that encrypts new records,
+// and every key that may still be needed to decrypt old ones.
+type KeyManager interface {
+	EncryptionKey() Key
+	DecryptionKey(label string) Key
+}
+
+type keyManager struct {
+	encryptionKey  Key
+	decryptionKeys map[string]Key
+}
+
+// NewKeyManager always accepts the encryption key for decryption as well.
+func NewKeyManager(encryptionKey Key, decryptionKeys []Key) (KeyManager, error) {
+	keys := map[string]Key{
+		encryptionKey.Label(): encryptionKey,
+	}
+
+	for _, key := range decryptionKeys {
+		if key.Label() == encryptionKey.Label() {
+			continue
+		}
+		if _, ok := keys[key.Label()]; ok {
+			return nil, fmt.Errorf("multiple keys with label %q", key.Label())
+		}
+		keys[key.Label()] = key
+	}
+
+	return &keyManager{
+		encryptionKey:  encryptionKey,
+		decryptionKeys: keys,
+	}, nil
+}
+
+func (m *keyManager) EncryptionKey() Key {
+	return m.encryptionKey
+}
+
+func (m *keyManager) DecryptionKey(label string) Key {
+	return m.decryptionKeys[label]
+}
@@ -0,0 +1,59 @@
+package encryption_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Key", func() {
+	It("derives a 256 bit AES key from the passphrase", func() {
+		key, err := encryption.NewKey("label", "passphrase")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key.Label()).To(Equal("label"))
+		Expect(key.BlockCipher().BlockSize()).To(Equal(16))
+	})
+
+	It("requires a label", func() {
+		_, err := encryption.NewKey("", "passphrase")
+		Expect(err).To(Equal(encryption.ErrEmptyLabel))
+	})
+
+	It("rejects labels too long to record", func() {
+		_, err := encryption.NewKey(strings.Repeat("a", encryption.MaxLabelLength+1), "passphrase")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("KeyManager", func() {
+	var activeKey, oldKey encryption.Key
+
+	BeforeEach(func() {
+		var err error
+		activeKey, err = encryption.NewKey("active", "active passphrase")
+		Expect(err).NotTo(HaveOccurred())
+		oldKey, err = encryption.NewKey("old", "old passphrase")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("encrypts with the active key and decrypts with any key", func() {
+		keyManager, err := encryption.NewKeyManager(activeKey, []encryption.Key{oldKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(keyManager.EncryptionKey()).To(Equal(activeKey))
+		Expect(keyManager.DecryptionKey("active")).To(Equal(activeKey))
+		Expect(keyManager.DecryptionKey("old")).To(Equal(oldKey))
+		Expect(keyManager.DecryptionKey("unknown")).To(BeNil())
+	})
+
+	It("rejects two keys with the same label", func() {
+		otherOldKey, err := encryption.NewKey("old", "another passphrase")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = encryption.NewKeyManager(activeKey, []encryption.Key{oldKey, otherOldKey})
+		Expect(err).To(HaveOccurred())
+	})
+})
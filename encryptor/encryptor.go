@@ -0,0 +1,97 @@
+package encryptor
+
+import (
+	"os"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/runtime-schema/metric"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/ifrit"
+)
+
+const encryptionDuration = metric.Duration("EncryptionDuration")
+
+// encryptor rewrites every stored record with the active key, unless the
+// records are already known to have been rewritten with it. It reports ready
+// straight away, so the server does not wait on the rewrite: reads accept
+// any key in the keyring in the meantime.
+type encryptor struct {
+	logger     lager.Logger
+	db         db.EncryptionDB
+	keyManager encryption.KeyManager
+	clock      clock.Clock
+}
+
+func New(
+	logger lager.Logger,
+	db db.EncryptionDB,
+	keyManager encryption.KeyManager,
+	clock clock.Clock,
+) ifrit.Runner {
+	return &encryptor{
+		logger:     logger,
+		db:         db,
+		keyManager: keyManager,
+		clock:      clock,
+	}
+}
+
+func (e *encryptor) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := e.logger.Session("encryptor")
+	logger.Info("starting")
+
+	done := make(chan struct{})
+	go func() {
+		e.encryptRecords(logger)
+		close(done)
+	}()
+
+	close(ready)
+	logger.Info("started")
+	defer logger.Info("finished")
+
+	select {
+	case <-signals:
+	case <-done:
+		<-signals
+	}
+	return nil
+}
+
+func (e *encryptor) encryptRecords(logger lager.Logger) {
+	activeLabel := e.keyManager.EncryptionKey().Label()
+	logger = logger.Session("encrypt-records", lager.Data{"active-key-label": activeLabel})
+
+	currentLabel, bbsErr := e.db.EncryptionKeyLabel(logger)
+	if bbsErr != nil && !bbsErr.Equal(models.ErrResourceNotFound) {
+		logger.Error("failed-to-fetch-encryption-key-label", bbsErr)
+		return
+	}
+
+	if currentLabel == activeLabel {
+		logger.Info("records-already-encrypted-with-active-key")
+		return
+	}
+
+	logger.Info("encryption-started", lager.Data{"current-key-label": currentLabel})
+	startedAt := e.clock.Now()
+
+	bbsErr = e.db.PerformEncryption(logger)
+	if bbsErr != nil {
+		logger.Error("encryption-failed", bbsErr)
+		return
+	}
+
+	bbsErr = e.db.SetEncryptionKeyLabel(logger, activeLabel)
+	if bbsErr != nil {
+		logger.Error("failed-to-set-encryption-key-label", bbsErr)
+		return
+	}
+
+	duration := e.clock.Now().Sub(startedAt)
+	encryptionDuration.Send(duration)
+	logger.Info("encryption-finished", lager.Data{"duration": duration.String()})
+}
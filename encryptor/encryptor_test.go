@@ -0,0 +1,135 @@
+package encryptor_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/encryptor"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Encryptor", func() {
+	var (
+		encryptionDB *fakes.FakeEncryptionDB
+		keyManager   encryption.KeyManager
+		clock        *fakeclock.FakeClock
+		logger       *lagertest.TestLogger
+		process      ifrit.Process
+	)
+
+	BeforeEach(func() {
+		encryptionDB = new(fakes.FakeEncryptionDB)
+		clock = fakeclock.NewFakeClock(time.Now())
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		keyManager, err = encryption.KeyConfig{
+			ActiveKeyLabel: "active",
+			Keys:           map[string]string{"active": "a", "old": "b"},
+		}.KeyManager()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(encryptor.New(logger, encryptionDB, keyManager, clock))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	Context("when records were last written with another key", func() {
+		BeforeEach(func() {
+			encryptionDB.EncryptionKeyLabelReturns("old", nil)
+		})
+
+		It("rewrites them and records the active key", func() {
+			Eventually(encryptionDB.PerformEncryptionCallCount).Should(Equal(1))
+			Eventually(encryptionDB.SetEncryptionKeyLabelCallCount).Should(Equal(1))
+
+			_, label := encryptionDB.SetEncryptionKeyLabelArgsForCall(0)
+			Expect(label).To(Equal("active"))
+		})
+	})
+
+	Context("when records have never been encrypted", func() {
+		BeforeEach(func() {
+			encryptionDB.EncryptionKeyLabelReturns("", models.ErrResourceNotFound)
+		})
+
+		It("rewrites them", func() {
+			Eventually(encryptionDB.PerformEncryptionCallCount).Should(Equal(1))
+			Eventually(encryptionDB.SetEncryptionKeyLabelCallCount).Should(Equal(1))
+		})
+	})
+
+	Context("when records were last written with the active key", func() {
+		BeforeEach(func() {
+			encryptionDB.EncryptionKeyLabelReturns("active", nil)
+		})
+
+		It("leaves them alone", func() {
+			Consistently(encryptionDB.PerformEncryptionCallCount).Should(Equal(0))
+			Expect(encryptionDB.SetEncryptionKeyLabelCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the key label cannot be fetched", func() {
+		BeforeEach(func() {
+			encryptionDB.EncryptionKeyLabelReturns("", models.ErrUnknownError)
+		})
+
+		It("does not rewrite records", func() {
+			Consistently(encryptionDB.PerformEncryptionCallCount).Should(Equal(0))
+		})
+	})
+
+	Context("when rewriting fails", func() {
+		BeforeEach(func() {
+			encryptionDB.EncryptionKeyLabelReturns("old", nil)
+			encryptionDB.PerformEncryptionReturns(models.ErrUnknownError)
+		})
+
+		It("does not record the active key", func() {
+			Eventually(encryptionDB.PerformEncryptionCallCount).Should(Equal(1))
+			Consistently(encryptionDB.SetEncryptionKeyLabelCallCount).Should(Equal(0))
+		})
+	})
+
+	Context("while records are being rewritten", func() {
+		var blocked chan struct{}
+
+		BeforeEach(func() {
+			blocked = make(chan struct{})
+			encryptionDB.EncryptionKeyLabelReturns("old", nil)
+			encryptionDB.PerformEncryptionStub = func(lager.Logger) *models.Error {
+				<-blocked
+				return nil
+			}
+		})
+
+		AfterEach(func() {
+			close(blocked)
+		})
+
+		It("is already ready", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+			Expect(encryptionDB.SetEncryptionKeyLabelCallCount()).To(Equal(0))
+		})
+
+		It("exits when signalled", func() {
+			Eventually(encryptionDB.PerformEncryptionCallCount).Should(Equal(1))
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+})
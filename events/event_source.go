@@ -5,16 +5,56 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/gogo/protobuf/proto"
 	"github.com/vito/go-sse/sse"
 )
 
+// ResyncRequiredEventName names the event sent in place of events that can no
+// longer be replayed to a resuming subscriber.
+const ResyncRequiredEventName = "resync_required"
+
+// HeartbeatEventName names the events sent on idle streams to show that the
+// connection is still alive. They are never surfaced by EventSource.
+const HeartbeatEventName = "heartbeat"
+
+// MissedHeartbeatsBeforeReconnect is how many of the server's heartbeat
+// intervals a stream may stay silent before it is reconnected.
+const MissedHeartbeatsBeforeReconnect = 3
+
+// NewHeartbeatEvent announces the server's heartbeat interval, in
+// milliseconds, so that clients can derive how long to wait for the next one.
+func NewHeartbeatEvent(lastEventID string, interval time.Duration) sse.Event {
+	return sse.Event{
+		ID:   lastEventID,
+		Name: HeartbeatEventName,
+		Data: []byte(strconv.FormatInt(int64(interval/time.Millisecond), 10)),
+	}
+}
+
+func heartbeatInterval(event sse.Event) (time.Duration, bool) {
+	millis, err := strconv.ParseInt(string(event.Data), 10, 64)
+	if err != nil || millis < 0 {
+		return 0, false
+	}
+	return time.Duration(millis) * time.Millisecond, true
+}
+
 var ErrUnrecognizedEventType = errors.New("unrecognized event type")
 
+// ErrResyncRequired is returned when events were missed while reconnecting.
+// The consumer should re-list the state it tracks; the stream then carries on
+// from the current event.
+var ErrResyncRequired = errors.New("resync required")
+
 var ErrSourceClosed = errors.New("source closed")
 
+var errHeartbeatMissed = errors.New("heartbeat missed")
+
 type invalidPayloadError struct {
 	payloadType string
 	protoErr    error
@@ -61,7 +101,8 @@ type EventSource interface {
 	//
 	// If the end of the stream is reached cleanly (which should actually never
 	// happen), io.EOF is returned. If called after or during Close,
-	// ErrSourceClosed is returned.
+	// ErrSourceClosed is returned. If events were lost while reconnecting,
+	// ErrResyncRequired is returned once and the stream continues.
 	Next() (models.Event, error)
 
 	// Close releases the underlying response, interrupts any in-flight Next, and
@@ -76,8 +117,18 @@ type RawEventSource interface {
 	Close() error
 }
 
+// RawEventSourceConnector opens a raw event source that resumes after
+// lastEventID, or from the current event if lastEventID is empty.
+type RawEventSourceConnector func(lastEventID string) (RawEventSource, error)
+
 type eventSource struct {
 	rawEventSource RawEventSource
+	closed         bool
+	lock           sync.Mutex
+
+	connect          RawEventSourceConnector
+	heartbeatTimeout time.Duration
+	lastEventID      string
 }
 
 func NewEventSource(raw RawEventSource) EventSource {
@@ -86,26 +137,155 @@ func NewEventSource(raw RawEventSource) EventSource {
 	}
 }
 
-func (e *eventSource) Next() (models.Event, error) {
-	rawEvent, err := e.rawEventSource.Next()
+// NewHeartbeatEventSource returns an EventSource that reconnects through
+// connect, from the last event seen, when a connection misses
+// MissedHeartbeatsBeforeReconnect of the heartbeats the server announced.
+// Streams from servers that announce no interval, or an interval of 0, are
+// never timed out.
+func NewHeartbeatEventSource(connect RawEventSourceConnector) (EventSource, error) {
+	raw, err := connect("")
 	if err != nil {
-		switch err {
-		case io.EOF:
-			return nil, err
+		return nil, err
+	}
+
+	return &eventSource{
+		rawEventSource: raw,
+		connect:        connect,
+	}, nil
+}
+
+func (e *eventSource) Next() (models.Event, error) {
+	for {
+		rawEvent, err := e.nextRawEvent()
+		if err != nil {
+			switch err {
+			case errHeartbeatMissed:
+				continue
+
+			case io.EOF:
+				return nil, err
+
+			case sse.ErrSourceClosed:
+				return nil, ErrSourceClosed
+
+			default:
+				return nil, NewRawEventSourceError(err)
+			}
+		}
+
+		switch rawEvent.Name {
+		case HeartbeatEventName:
+			if interval, ok := heartbeatInterval(rawEvent); ok {
+				e.heartbeatTimeout = interval * MissedHeartbeatsBeforeReconnect
+			}
+			continue
+
+		case ResyncRequiredEventName:
+			return nil, ErrResyncRequired
+		}
+
+		return parseRawEvent(rawEvent)
+	}
+}
+
+type rawEventOrError struct {
+	event sse.Event
+	err   error
+}
+
+func (e *eventSource) nextRawEvent() (sse.Event, error) {
+	e.lock.Lock()
+	closed := e.closed
+	raw := e.rawEventSource
+	e.lock.Unlock()
+
+	if closed {
+		return sse.Event{}, sse.ErrSourceClosed
+	}
+
+	if e.connect == nil || e.heartbeatTimeout <= 0 {
+		event, err := raw.Next()
+		e.recordEventID(event, err)
+		return event, err
+	}
+
+	if raw == nil {
+		err := e.reconnect()
+		if err != nil {
+			return sse.Event{}, err
+		}
+		return sse.Event{}, errHeartbeatMissed
+	}
+
+	results := make(chan rawEventOrError, 1)
+	go func() {
+		event, err := raw.Next()
+		results <- rawEventOrError{event, err}
+	}()
+
+	timer := time.NewTimer(e.heartbeatTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		e.recordEventID(result.event, result.err)
+		return result.event, result.err
+
+	case <-timer.C:
+		raw.Close()
+		<-results
 
-		case sse.ErrSourceClosed:
-			return nil, ErrSourceClosed
+		e.lock.Lock()
+		if e.rawEventSource == raw {
+			e.rawEventSource = nil
+		}
+		e.lock.Unlock()
 
-		default:
-			return nil, NewRawEventSourceError(err)
+		err := e.reconnect()
+		if err != nil {
+			return sse.Event{}, err
 		}
+		return sse.Event{}, errHeartbeatMissed
+	}
+}
+
+// recordEventID remembers the ID of each event read, so that a reconnect
+// resumes after it. As in SSE, an event without an ID leaves it alone.
+func (e *eventSource) recordEventID(event sse.Event, err error) {
+	if err == nil && event.ID != "" {
+		e.lastEventID = event.ID
+	}
+}
+
+func (e *eventSource) reconnect() error {
+	raw, err := e.connect(e.lastEventID)
+	if err != nil {
+		return err
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.closed {
+		raw.Close()
+		return sse.ErrSourceClosed
 	}
 
-	return parseRawEvent(rawEvent)
+	e.rawEventSource = raw
+	return nil
 }
 
 func (e *eventSource) Close() error {
-	err := e.rawEventSource.Close()
+	e.lock.Lock()
+	e.closed = true
+	raw := e.rawEventSource
+	e.lock.Unlock()
+
+	if raw == nil {
+		return nil
+	}
+
+	err := raw.Close()
 	if err != nil {
 		return NewCloseError(err)
 	}
@@ -171,6 +351,42 @@ func parseRawEvent(rawEvent sse.Event) (models.Event, error) {
 			return nil, NewInvalidPayloadError(rawEvent.Name, err)
 		}
 
+		return event, nil
+
+	case models.EventTypeActualLRPCrashed:
+		event := new(models.ActualLRPCrashedEvent)
+		err := proto.Unmarshal(data, event)
+		if err != nil {
+			return nil, NewInvalidPayloadError(rawEvent.Name, err)
+		}
+
+		return event, nil
+
+	case models.EventTypeTaskCreated:
+		event := new(models.TaskCreatedEvent)
+		err := proto.Unmarshal(data, event)
+		if err != nil {
+			return nil, NewInvalidPayloadError(rawEvent.Name, err)
+		}
+
+		return event, nil
+
+	case models.EventTypeTaskChanged:
+		event := new(models.TaskChangedEvent)
+		err := proto.Unmarshal(data, event)
+		if err != nil {
+			return nil, NewInvalidPayloadError(rawEvent.Name, err)
+		}
+
+		return event, nil
+
+	case models.EventTypeTaskRemoved:
+		event := new(models.TaskRemovedEvent)
+		err := proto.Unmarshal(data, event)
+		if err != nil {
+			return nil, NewInvalidPayloadError(rawEvent.Name, err)
+		}
+
 		return event, nil
 	}
 
@@ -4,10 +4,12 @@ import (
 	"encoding/base64"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 	"github.com/gogo/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -223,6 +225,101 @@ var _ = Describe("EventSource", func() {
 			})
 		})
 
+		Describe("Task Events", func() {
+			var task *models.Task
+
+			BeforeEach(func() {
+				task = model_helpers.NewValidTask("some-guid")
+			})
+
+			Context("when receiving a TaskCreatedEvent", func() {
+				var expectedEvent *models.TaskCreatedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewTaskCreatedEvent(task)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					taskCreatedEvent, ok := event.(*models.TaskCreatedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(taskCreatedEvent).To(Equal(expectedEvent))
+				})
+			})
+
+			Context("when receiving a TaskChangedEvent", func() {
+				var expectedEvent *models.TaskChangedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewTaskChangedEvent(task, task)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					taskChangedEvent, ok := event.(*models.TaskChangedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(taskChangedEvent).To(Equal(expectedEvent))
+				})
+			})
+
+			Context("when receiving a TaskRemovedEvent", func() {
+				var expectedEvent *models.TaskRemovedEvent
+
+				BeforeEach(func() {
+					expectedEvent = models.NewTaskRemovedEvent(task)
+					payload, err := proto.Marshal(expectedEvent)
+					Expect(err).NotTo(HaveOccurred())
+					payload = []byte(base64.StdEncoding.EncodeToString(payload))
+
+					fakeRawEventSource.NextReturns(
+						sse.Event{
+							ID:   "sup",
+							Name: string(expectedEvent.EventType()),
+							Data: payload,
+						},
+						nil,
+					)
+				})
+
+				It("returns the event", func() {
+					event, err := eventSource.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					taskRemovedEvent, ok := event.(*models.TaskRemovedEvent)
+					Expect(ok).To(BeTrue())
+					Expect(taskRemovedEvent).To(Equal(expectedEvent))
+				})
+			})
+		})
+
 		Context("when receiving an unrecognized event", func() {
 			BeforeEach(func() {
 				payload := []byte(base64.StdEncoding.EncodeToString([]byte("garbage")))
@@ -242,6 +339,52 @@ var _ = Describe("EventSource", func() {
 			})
 		})
 
+		Context("when receiving a resync required event", func() {
+			BeforeEach(func() {
+				fakeRawEventSource.NextReturns(
+					sse.Event{
+						ID:   "42",
+						Name: events.ResyncRequiredEventName,
+					},
+					nil,
+				)
+			})
+
+			It("returns a resync required error", func() {
+				_, err := eventSource.Next()
+				Expect(err).To(Equal(events.ErrResyncRequired))
+			})
+		})
+
+		Context("when receiving a heartbeat", func() {
+			var taskEvent sse.Event
+
+			BeforeEach(func() {
+				payload, err := proto.Marshal(models.NewTaskRemovedEvent(model_helpers.NewValidTask("task-guid")))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskEvent = sse.Event{
+					ID:   "2",
+					Name: models.EventTypeTaskRemoved,
+					Data: []byte(base64.StdEncoding.EncodeToString(payload)),
+				}
+
+				fakeRawEventSource.NextStub = func() (sse.Event, error) {
+					if fakeRawEventSource.NextCallCount() == 1 {
+						return sse.Event{ID: "1", Name: events.HeartbeatEventName, Data: []byte("heartbeat")}, nil
+					}
+					return taskEvent, nil
+				}
+			})
+
+			It("skips it", func() {
+				event, err := eventSource.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.EventType()).To(Equal(models.EventTypeTaskRemoved))
+				Expect(fakeRawEventSource.NextCallCount()).To(Equal(2))
+			})
+		})
+
 		Context("when receiving a bad payload", func() {
 			BeforeEach(func() {
 				fakeRawEventSource.NextReturns(
@@ -334,6 +477,152 @@ var _ = Describe("EventSource", func() {
 		})
 	})
 
+	Describe("heartbeat deadlines", func() {
+		var (
+			staleRawEventSource *eventfakes.FakeRawEventSource
+			staleClosed         chan struct{}
+			freshRawEventSource *eventfakes.FakeRawEventSource
+			connectedFrom       chan string
+			connect             events.RawEventSourceConnector
+			taskEvent           sse.Event
+			announced           sse.Event
+		)
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(models.NewTaskRemovedEvent(model_helpers.NewValidTask("task-guid")))
+			Expect(err).NotTo(HaveOccurred())
+			taskEvent = sse.Event{
+				ID:   "8",
+				Name: models.EventTypeTaskRemoved,
+				Data: []byte(base64.StdEncoding.EncodeToString(payload)),
+			}
+
+			announced = events.NewHeartbeatEvent("7", 20*time.Millisecond)
+
+			staleClosed = make(chan struct{})
+			staleRawEventSource = new(eventfakes.FakeRawEventSource)
+			staleRawEventSource.NextStub = func() (sse.Event, error) {
+				if staleRawEventSource.NextCallCount() == 1 {
+					return announced, nil
+				}
+				<-staleClosed
+				return sse.Event{}, sse.ErrSourceClosed
+			}
+			staleRawEventSource.CloseStub = func() error {
+				close(staleClosed)
+				return nil
+			}
+
+			freshRawEventSource = new(eventfakes.FakeRawEventSource)
+			freshRawEventSource.NextReturns(taskEvent, nil)
+
+			connectedFrom = make(chan string, 2)
+			connect = func(lastEventID string) (events.RawEventSource, error) {
+				connectedFrom <- lastEventID
+				if lastEventID == "" {
+					return staleRawEventSource, nil
+				}
+				return freshRawEventSource, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			eventSource, err = events.NewHeartbeatEventSource(connect)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connectedFrom).To(Receive(Equal("")))
+		})
+
+		It("reconnects from the last event seen when the heartbeat is missed", func() {
+			event, err := eventSource.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.EventType()).To(Equal(models.EventTypeTaskRemoved))
+
+			Expect(staleRawEventSource.CloseCallCount()).To(Equal(1))
+			Expect(connectedFrom).To(Receive(Equal("7")))
+		})
+
+		It("closes the current raw source on Close", func() {
+			_, err := eventSource.Next()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = eventSource.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freshRawEventSource.CloseCallCount()).To(Equal(1))
+
+			_, err = eventSource.Next()
+			Expect(err).To(Equal(events.ErrSourceClosed))
+		})
+
+		Context("when an event arrives before the first heartbeat", func() {
+			var earlyEvent sse.Event
+
+			BeforeEach(func() {
+				earlyEvent = taskEvent
+				earlyEvent.ID = "6"
+				announced = events.NewHeartbeatEvent("", 20*time.Millisecond)
+
+				staleRawEventSource.NextStub = func() (sse.Event, error) {
+					switch staleRawEventSource.NextCallCount() {
+					case 1:
+						return earlyEvent, nil
+					case 2:
+						return announced, nil
+					}
+					<-staleClosed
+					return sse.Event{}, sse.ErrSourceClosed
+				}
+			})
+
+			It("reconnects from that event", func() {
+				_, err := eventSource.Next()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = eventSource.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(connectedFrom).To(Receive(Equal("6")))
+			})
+		})
+
+		Context("when the server announces a zero interval", func() {
+			BeforeEach(func() {
+				announced = events.NewHeartbeatEvent("7", 0)
+			})
+
+			It("never times out the stream", func() {
+				errs := make(chan error, 1)
+				go func() {
+					_, err := eventSource.Next()
+					errs <- err
+				}()
+
+				Consistently(connectedFrom, 200*time.Millisecond).ShouldNot(Receive())
+
+				Expect(eventSource.Close()).To(Succeed())
+				Eventually(errs).Should(Receive(Equal(events.ErrSourceClosed)))
+			})
+		})
+
+		Context("when the server does not announce an interval", func() {
+			BeforeEach(func() {
+				announced = sse.Event{ID: "7", Name: events.HeartbeatEventName, Data: []byte("heartbeat")}
+			})
+
+			It("never times out the stream", func() {
+				errs := make(chan error, 1)
+				go func() {
+					_, err := eventSource.Next()
+					errs <- err
+				}()
+
+				Consistently(connectedFrom, 200*time.Millisecond).ShouldNot(Receive())
+
+				Expect(eventSource.Close()).To(Succeed())
+				Eventually(errs).Should(Receive(Equal(events.ErrSourceClosed)))
+			})
+		})
+	})
+
 	Describe("Close", func() {
 		Context("when the raw source closes normally", func() {
 			It("closes the raw event source", func() {
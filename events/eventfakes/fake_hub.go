@@ -16,6 +16,32 @@ type FakeHub struct {
 		result1 events.EventSource
 		result2 error
 	}
+	SubscribeSequencedStub        func(filter models.EventFilter) (events.SequencedEventSource, error)
+	subscribeSequencedMutex       sync.RWMutex
+	subscribeSequencedArgsForCall []struct {
+		filter models.EventFilter
+	}
+	subscribeSequencedReturns struct {
+		result1 events.SequencedEventSource
+		result2 error
+	}
+	ResumeStub        func(epoch string, lastEventID uint64, filter models.EventFilter) (events.SequencedEventSource, error)
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+		epoch       string
+		lastEventID uint64
+		filter      models.EventFilter
+	}
+	resumeReturns struct {
+		result1 events.SequencedEventSource
+		result2 error
+	}
+	EpochStub        func() string
+	epochMutex       sync.RWMutex
+	epochArgsForCall []struct{}
+	epochReturns     struct {
+		result1 string
+	}
 	EmitStub        func(models.Event)
 	emitMutex       sync.RWMutex
 	emitArgsForCall []struct {
@@ -59,6 +85,98 @@ func (fake *FakeHub) SubscribeReturns(result1 events.EventSource, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeHub) SubscribeSequenced(filter models.EventFilter) (events.SequencedEventSource, error) {
+	fake.subscribeSequencedMutex.Lock()
+	fake.subscribeSequencedArgsForCall = append(fake.subscribeSequencedArgsForCall, struct {
+		filter models.EventFilter
+	}{filter})
+	fake.subscribeSequencedMutex.Unlock()
+	if fake.SubscribeSequencedStub != nil {
+		return fake.SubscribeSequencedStub(filter)
+	} else {
+		return fake.subscribeSequencedReturns.result1, fake.subscribeSequencedReturns.result2
+	}
+}
+
+func (fake *FakeHub) SubscribeSequencedCallCount() int {
+	fake.subscribeSequencedMutex.RLock()
+	defer fake.subscribeSequencedMutex.RUnlock()
+	return len(fake.subscribeSequencedArgsForCall)
+}
+
+func (fake *FakeHub) SubscribeSequencedArgsForCall(i int) models.EventFilter {
+	fake.subscribeSequencedMutex.RLock()
+	defer fake.subscribeSequencedMutex.RUnlock()
+	return fake.subscribeSequencedArgsForCall[i].filter
+}
+
+func (fake *FakeHub) SubscribeSequencedReturns(result1 events.SequencedEventSource, result2 error) {
+	fake.SubscribeSequencedStub = nil
+	fake.subscribeSequencedReturns = struct {
+		result1 events.SequencedEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHub) Resume(epoch string, lastEventID uint64, filter models.EventFilter) (events.SequencedEventSource, error) {
+	fake.resumeMutex.Lock()
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+		epoch       string
+		lastEventID uint64
+		filter      models.EventFilter
+	}{epoch, lastEventID, filter})
+	fake.resumeMutex.Unlock()
+	if fake.ResumeStub != nil {
+		return fake.ResumeStub(epoch, lastEventID, filter)
+	} else {
+		return fake.resumeReturns.result1, fake.resumeReturns.result2
+	}
+}
+
+func (fake *FakeHub) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *FakeHub) ResumeArgsForCall(i int) (string, uint64, models.EventFilter) {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return fake.resumeArgsForCall[i].epoch, fake.resumeArgsForCall[i].lastEventID, fake.resumeArgsForCall[i].filter
+}
+
+func (fake *FakeHub) ResumeReturns(result1 events.SequencedEventSource, result2 error) {
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 events.SequencedEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHub) Epoch() string {
+	fake.epochMutex.Lock()
+	fake.epochArgsForCall = append(fake.epochArgsForCall, struct{}{})
+	fake.epochMutex.Unlock()
+	if fake.EpochStub != nil {
+		return fake.EpochStub()
+	} else {
+		return fake.epochReturns.result1
+	}
+}
+
+func (fake *FakeHub) EpochCallCount() int {
+	fake.epochMutex.RLock()
+	defer fake.epochMutex.RUnlock()
+	return len(fake.epochArgsForCall)
+}
+
+func (fake *FakeHub) EpochReturns(result1 string) {
+	fake.EpochStub = nil
+	fake.epochReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeHub) Emit(arg1 models.Event) {
 	fake.emitMutex.Lock()
 	fake.emitArgsForCall = append(fake.emitArgsForCall, struct {
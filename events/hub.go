@@ -2,12 +2,19 @@ package events
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/nu7hatch/gouuid"
 )
 
 const MAX_PENDING_SUBSCRIBER_EVENTS = 1024
+const MAX_BUFFERED_EVENTS = 1024
+const DEFAULT_SLOW_CONSUMER_TIMEOUT = 5 * time.Second
 
 var ErrReadFromClosedSource = errors.New("read from closed source")
 var ErrSendToClosedSource = errors.New("send to closed source")
@@ -16,10 +23,101 @@ var ErrSlowConsumer = errors.New("slow consumer")
 
 var ErrSubscribedToClosedHub = errors.New("subscribed to closed hub")
 var ErrHubAlreadyClosed = errors.New("hub already closed")
+var ErrInvalidEventID = errors.New("invalid event ID")
+
+// FormatEventID renders the ID of an event emitted by the hub with the given
+// epoch, as sent to clients.
+func FormatEventID(epoch string, id uint64) string {
+	return epoch + "-" + strconv.FormatUint(id, 10)
+}
+
+// ParseEventID splits an ID rendered by FormatEventID. A bare sequence number
+// has no epoch, and so never matches a hub's.
+func ParseEventID(eventID string) (string, uint64, error) {
+	var epoch string
+	sequence := eventID
+	if dash := strings.LastIndex(eventID, "-"); dash >= 0 {
+		epoch, sequence = eventID[:dash], eventID[dash+1:]
+	}
+
+	id, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return "", 0, ErrInvalidEventID
+	}
+	return epoch, id, nil
+}
+
+// SequencedEvent carries its hub-wide ID; ResyncRequired events carry no Event.
+type SequencedEvent struct {
+	ID             uint64
+	Event          models.Event
+	ResyncRequired bool
+}
+
+type SequencedEventSource interface {
+	NextSequenced() (SequencedEvent, error)
+	Close() error
+}
+
+// SlowConsumerPolicy decides what happens to a subscriber whose queue is full.
+type SlowConsumerPolicy string
+
+const (
+	// DisconnectSlowConsumers closes the subscriber.
+	DisconnectSlowConsumers SlowConsumerPolicy = "disconnect"
+
+	// BlockSlowConsumers waits up to the timeout before closing the subscriber.
+	BlockSlowConsumers SlowConsumerPolicy = "block"
+
+	// CoalesceSlowConsumers overflows, keeping the latest ActualLRPChanged per LRP.
+	CoalesceSlowConsumers SlowConsumerPolicy = "coalesce"
+)
+
+func ParseSlowConsumerPolicy(policy string) (SlowConsumerPolicy, error) {
+	switch SlowConsumerPolicy(policy) {
+	case DisconnectSlowConsumers, BlockSlowConsumers, CoalesceSlowConsumers:
+		return SlowConsumerPolicy(policy), nil
+	default:
+		return "", fmt.Errorf("unknown slow consumer policy: %s", policy)
+	}
+}
+
+type HubConfig struct {
+	// Epoch distinguishes the IDs of this hub from those of other processes,
+	// which count from the same start. A random one is used if it is empty.
+	Epoch string
+
+	MaxPendingEvents   int
+	SlowConsumerPolicy SlowConsumerPolicy
+	// SlowConsumerTimeout only applies to BlockSlowConsumers.
+	SlowConsumerTimeout time.Duration
+}
+
+func DefaultHubConfig() HubConfig {
+	return HubConfig{
+		MaxPendingEvents:    MAX_PENDING_SUBSCRIBER_EVENTS,
+		SlowConsumerPolicy:  DisconnectSlowConsumers,
+		SlowConsumerTimeout: DEFAULT_SLOW_CONSUMER_TIMEOUT,
+	}
+}
 
 //go:generate counterfeiter -o eventfakes/fake_hub.go . Hub
 type Hub interface {
 	Subscribe() (EventSource, error)
+
+	// SubscribeSequenced delivers the events matching filter emitted from now
+	// on, tagged with their IDs.
+	SubscribeSequenced(filter models.EventFilter) (SequencedEventSource, error)
+
+	// Resume replays the buffered events matching filter emitted after
+	// lastEventID before delivering new ones. If events after lastEventID have
+	// already fallen out of the buffer, or lastEventID was never emitted by
+	// this hub, the first event delivered requires a resync.
+	Resume(epoch string, lastEventID uint64, filter models.EventFilter) (SequencedEventSource, error)
+
+	// Epoch is the epoch of the IDs this hub emits.
+	Epoch() string
+
 	Emit(models.Event)
 	Close() error
 
@@ -31,13 +129,47 @@ type hub struct {
 	closed      bool
 	lock        sync.Mutex
 
+	// emitLock keeps deliveries in ID order without holding lock while a
+	// subscriber blocks.
+	emitLock sync.Mutex
+
+	epoch       string
+	lastEventID uint64
+	buffer      *eventBuffer
+
+	config HubConfig
+
 	cb func(count int)
 }
 
 func NewHub() Hub {
+	return NewHubWithConfig(DefaultHubConfig())
+}
+
+func NewHubWithConfig(config HubConfig) Hub {
+	epoch := config.Epoch
+	if epoch == "" {
+		epoch = newEpoch()
+	}
+
 	return &hub{
 		subscribers: make(map[*hubSource]struct{}),
+		epoch:       epoch,
+		buffer:      newEventBuffer(MAX_BUFFERED_EVENTS),
+		config:      config,
+	}
+}
+
+func newEpoch() string {
+	guid, err := uuid.NewV4()
+	if err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
 	}
+	return guid.String()
+}
+
+func (hub *hub) Epoch() string {
+	return hub.epoch
 }
 
 func (hub *hub) RegisterCallback(cb func(int)) {
@@ -51,6 +183,43 @@ func (hub *hub) RegisterCallback(cb func(int)) {
 }
 
 func (hub *hub) Subscribe() (EventSource, error) {
+	sub, err := hub.subscribe(models.EventFilter{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (hub *hub) SubscribeSequenced(filter models.EventFilter) (SequencedEventSource, error) {
+	sub, err := hub.subscribe(filter, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (hub *hub) Resume(epoch string, lastEventID uint64, filter models.EventFilter) (SequencedEventSource, error) {
+	sub, err := hub.subscribe(filter, func() []SequencedEvent {
+		// IDs from another epoch say nothing about which of this hub's events
+		// the client has seen
+		if epoch != hub.epoch {
+			return []SequencedEvent{{ID: hub.lastEventID, ResyncRequired: true}}
+		}
+
+		replay, ok := hub.buffer.since(lastEventID, hub.lastEventID)
+		if !ok {
+			return []SequencedEvent{{ID: hub.lastEventID, ResyncRequired: true}}
+		}
+		return replay
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// subscribe seeds a new source with replay, called while the hub is locked.
+func (hub *hub) subscribe(filter models.EventFilter, replay func() []SequencedEvent) (*hubSource, error) {
 	hub.lock.Lock()
 
 	if hub.closed {
@@ -59,7 +228,20 @@ func (hub *hub) Subscribe() (EventSource, error) {
 		return nil, ErrSubscribedToClosedHub
 	}
 
-	sub := newSource(MAX_PENDING_SUBSCRIBER_EVENTS, hub.subscriberClosed)
+	var replayed []SequencedEvent
+	if replay != nil {
+		for _, event := range replay() {
+			if event.ResyncRequired || filter.Matches(event.Event) {
+				replayed = append(replayed, event)
+			}
+		}
+	}
+
+	sub := newSource(hub.config, len(replayed), filter, hub.subscriberClosed)
+	for _, event := range replayed {
+		sub.events <- event
+	}
+
 	hub.subscribers[sub] = struct{}{}
 	cb := hub.cb
 	size := len(hub.subscribers)
@@ -72,17 +254,38 @@ func (hub *hub) Subscribe() (EventSource, error) {
 }
 
 func (hub *hub) Emit(event models.Event) {
-	hub.lock.Lock()
+	hub.emitLock.Lock()
+	defer hub.emitLock.Unlock()
 
-	size := len(hub.subscribers)
+	hub.lock.Lock()
+	hub.lastEventID++
+	sequenced := SequencedEvent{ID: hub.lastEventID, Event: event}
+	hub.buffer.push(sequenced)
 
+	subscribers := make([]*hubSource, 0, len(hub.subscribers))
 	for sub, _ := range hub.subscribers {
-		err := sub.send(event)
+		subscribers = append(subscribers, sub)
+	}
+	hub.lock.Unlock()
+
+	var dropped []*hubSource
+	for _, sub := range subscribers {
+		err := sub.send(sequenced)
 		if err != nil {
-			delete(hub.subscribers, sub)
+			dropped = append(dropped, sub)
 		}
 	}
 
+	if len(dropped) == 0 {
+		return
+	}
+
+	hub.lock.Lock()
+	size := len(hub.subscribers)
+	for _, sub := range dropped {
+		delete(hub.subscribers, sub)
+	}
+
 	var cb func(int)
 	if len(hub.subscribers) != size {
 		cb = hub.cb
@@ -131,24 +334,49 @@ func (hub *hub) subscriberClosed(source *hubSource) {
 }
 
 type hubSource struct {
-	events        chan models.Event
+	events        chan SequencedEvent
+	filter        models.EventFilter
 	closeCallback func(*hubSource)
 	closed        bool
 	lock          sync.Mutex
+
+	maxPendingEvents int
+	policy           SlowConsumerPolicy
+	timeout          time.Duration
+
+	// overflow holds events queued past the limit by CoalesceSlowConsumers.
+	overflow []SequencedEvent
 }
 
-func newSource(maxPendingEvents int, closeCallback func(*hubSource)) *hubSource {
+// newSource leaves room for extraEvents replayed events beyond the limit.
+func newSource(config HubConfig, extraEvents int, filter models.EventFilter, closeCallback func(*hubSource)) *hubSource {
 	return &hubSource{
-		events:        make(chan models.Event, maxPendingEvents),
-		closeCallback: closeCallback,
+		events:           make(chan SequencedEvent, config.MaxPendingEvents+extraEvents),
+		filter:           filter,
+		closeCallback:    closeCallback,
+		maxPendingEvents: config.MaxPendingEvents,
+		policy:           config.SlowConsumerPolicy,
+		timeout:          config.SlowConsumerTimeout,
 	}
 }
 
 func (source *hubSource) Next() (models.Event, error) {
+	event, err := source.NextSequenced()
+	if err != nil {
+		return nil, err
+	}
+	if event.ResyncRequired {
+		return nil, ErrResyncRequired
+	}
+	return event.Event, nil
+}
+
+func (source *hubSource) NextSequenced() (SequencedEvent, error) {
 	event, ok := <-source.events
 	if !ok {
-		return nil, ErrReadFromClosedSource
+		return SequencedEvent{}, ErrReadFromClosedSource
 	}
+	source.drainOverflow()
 	return event, nil
 }
 
@@ -161,11 +389,18 @@ func (source *hubSource) Close() error {
 	}
 	close(source.events)
 	source.closed = true
+	source.overflow = nil
 	go source.closeCallback(source)
 	return nil
 }
 
-func (source *hubSource) send(event models.Event) error {
+func (source *hubSource) send(event SequencedEvent) error {
+	// unwanted events never take up room in the queue, so they cannot cause
+	// the source to be dropped as a slow consumer
+	if !source.filter.Matches(event.Event) {
+		return nil
+	}
+
 	source.lock.Lock()
 
 	if source.closed {
@@ -173,18 +408,141 @@ func (source *hubSource) send(event models.Event) error {
 		return ErrSendToClosedSource
 	}
 
-	select {
-	case source.events <- event:
-		source.lock.Unlock()
+	var queued bool
+	switch source.policy {
+	case BlockSlowConsumers:
+		queued = source.sendWithTimeout(event)
+	case CoalesceSlowConsumers:
+		queued = source.sendOrCoalesce(event)
+	default:
+		queued = source.trySend(event)
+	}
+	source.lock.Unlock()
+
+	if queued {
 		return nil
+	}
 
+	err := source.Close()
+	if err != nil {
+		return err
+	}
+
+	return ErrSlowConsumer
+}
+
+func (source *hubSource) trySend(event SequencedEvent) bool {
+	select {
+	case source.events <- event:
+		return true
 	default:
-		source.lock.Unlock()
-		err := source.Close()
-		if err != nil {
-			return err
+		return false
+	}
+}
+
+func (source *hubSource) sendWithTimeout(event SequencedEvent) bool {
+	if source.trySend(event) {
+		return true
+	}
+
+	timer := time.NewTimer(source.timeout)
+	defer timer.Stop()
+
+	select {
+	case source.events <- event:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (source *hubSource) sendOrCoalesce(event SequencedEvent) bool {
+	// events already waiting in the overflow must be delivered first
+	if len(source.overflow) == 0 && source.trySend(event) {
+		return true
+	}
+
+	if key, ok := coalesceKey(event.Event); ok {
+		for i, pending := range source.overflow {
+			if pendingKey, ok := coalesceKey(pending.Event); ok && pendingKey == key {
+				source.overflow = append(source.overflow[:i], source.overflow[i+1:]...)
+				break
+			}
 		}
+	}
+
+	source.overflow = append(source.overflow, event)
+	return len(source.overflow) <= source.maxPendingEvents
+}
+
+// coalesceKey identifies the actual LRP an ActualLRPChanged event is about.
+// Instance guids are empty for unclaimed LRPs, so the event's Key won't do.
+func coalesceKey(event models.Event) (models.ActualLRPKey, bool) {
+	changed, ok := event.(*models.ActualLRPChangedEvent)
+	if !ok || changed.After == nil || (changed.After.Instance == nil && changed.After.Evacuating == nil) {
+		return models.ActualLRPKey{}, false
+	}
+	lrp, _ := changed.After.Resolve()
+	return lrp.ActualLRPKey, true
+}
+
+func (source *hubSource) drainOverflow() {
+	source.lock.Lock()
+	defer source.lock.Unlock()
 
-		return ErrSlowConsumer
+	for !source.closed && len(source.overflow) > 0 && source.trySend(source.overflow[0]) {
+		source.overflow = source.overflow[1:]
+	}
+}
+
+// eventBuffer is a ring of the most recently emitted events, oldest first.
+type eventBuffer struct {
+	events []SequencedEvent
+	start  int
+	size   int
+}
+
+func newEventBuffer(capacity int) *eventBuffer {
+	return &eventBuffer{
+		events: make([]SequencedEvent, capacity),
+	}
+}
+
+func (b *eventBuffer) push(event SequencedEvent) {
+	if len(b.events) == 0 {
+		return
+	}
+
+	if b.size < len(b.events) {
+		b.events[(b.start+b.size)%len(b.events)] = event
+		b.size++
+		return
+	}
+
+	b.events[b.start] = event
+	b.start = (b.start + 1) % len(b.events)
+}
+
+// since returns false when events after lastEventID are no longer buffered.
+func (b *eventBuffer) since(lastEventID, latestEventID uint64) ([]SequencedEvent, bool) {
+	if lastEventID > latestEventID {
+		return nil, false
+	}
+
+	if lastEventID == latestEventID {
+		return nil, true
+	}
+
+	if b.size == 0 || b.events[b.start].ID > lastEventID+1 {
+		return nil, false
+	}
+
+	events := []SequencedEvent{}
+	for i := 0; i < b.size; i++ {
+		event := b.events[(b.start+i)%len(b.events)]
+		if event.ID > lastEventID {
+			events = append(events, event)
+		}
 	}
+	return events, true
 }
@@ -2,9 +2,11 @@ package events_test
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -134,6 +136,338 @@ var _ = Describe("Hub", func() {
 		Expect(err).To(Equal(events.ErrReadFromClosedSource))
 	})
 
+	Describe("sequencing events", func() {
+		It("tags emitted events with increasing hub-wide IDs", func() {
+			hub.Emit(eventfakes.FakeEvent{Token: "0"})
+
+			source, err := hub.SubscribeSequenced(models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(eventfakes.FakeEvent{Token: "1"})
+			hub.Emit(eventfakes.FakeEvent{Token: "2"})
+
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 2, Event: eventfakes.FakeEvent{Token: "1"}}))
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 3, Event: eventfakes.FakeEvent{Token: "2"}}))
+		})
+	})
+
+	Describe("filtering events", func() {
+		var filter models.EventFilter
+
+		BeforeEach(func() {
+			filter = models.EventFilter{EventTypes: []string{models.EventTypeTaskCreated}}
+		})
+
+		It("only delivers events matching the filter", func() {
+			source, err := hub.SubscribeSequenced(filter)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskCreated := models.NewTaskCreatedEvent(&models.Task{TaskGuid: "task-guid"})
+			hub.Emit(eventfakes.FakeEvent{Token: "1"})
+			hub.Emit(taskCreated)
+
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 2, Event: taskCreated}))
+		})
+
+		It("does not count unwanted events against slow consumers", func() {
+			source, err := hub.SubscribeSequenced(filter)
+			Expect(err).NotTo(HaveOccurred())
+
+			for eventToken := 0; eventToken < events.MAX_PENDING_SUBSCRIBER_EVENTS+1; eventToken++ {
+				hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(eventToken)})
+			}
+
+			taskCreated := models.NewTaskCreatedEvent(&models.Task{TaskGuid: "task-guid"})
+			hub.Emit(taskCreated)
+
+			event, err := source.NextSequenced()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Event).To(Equal(taskCreated))
+		})
+
+		It("filters replayed events", func() {
+			taskCreated := models.NewTaskCreatedEvent(&models.Task{TaskGuid: "task-guid"})
+			hub.Emit(eventfakes.FakeEvent{Token: "1"})
+			hub.Emit(eventfakes.FakeEvent{Token: "2"})
+			hub.Emit(taskCreated)
+
+			source, err := hub.Resume(hub.Epoch(), 1, filter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 3, Event: taskCreated}))
+		})
+	})
+
+	Describe("Epoch", func() {
+		It("differs between hubs", func() {
+			Expect(hub.Epoch()).NotTo(BeEmpty())
+			Expect(events.NewHub().Epoch()).NotTo(Equal(hub.Epoch()))
+		})
+
+		It("can be configured", func() {
+			config := events.DefaultHubConfig()
+			config.Epoch = "some-epoch"
+			Expect(events.NewHubWithConfig(config).Epoch()).To(Equal("some-epoch"))
+		})
+	})
+
+	Describe("event IDs", func() {
+		It("round-trip through their formatted form", func() {
+			epoch, id, err := events.ParseEventID(events.FormatEventID("8a2c-41f0", 42))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(epoch).To(Equal("8a2c-41f0"))
+			Expect(id).To(BeEquivalentTo(42))
+		})
+
+		It("have no epoch when they are a bare sequence number", func() {
+			epoch, id, err := events.ParseEventID("42")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(epoch).To(BeEmpty())
+			Expect(id).To(BeEquivalentTo(42))
+		})
+
+		It("are rejected when the sequence number is malformed", func() {
+			_, _, err := events.ParseEventID("some-epoch-x")
+			Expect(err).To(Equal(events.ErrInvalidEventID))
+		})
+	})
+
+	Describe("Resume", func() {
+		BeforeEach(func() {
+			for eventToken := 1; eventToken <= 3; eventToken++ {
+				hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(eventToken)})
+			}
+		})
+
+		It("replays the buffered events after the given ID, then delivers new ones", func() {
+			source, err := hub.Resume(hub.Epoch(), 1, models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(eventfakes.FakeEvent{Token: "4"})
+
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 2, Event: eventfakes.FakeEvent{Token: "2"}}))
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 3, Event: eventfakes.FakeEvent{Token: "3"}}))
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 4, Event: eventfakes.FakeEvent{Token: "4"}}))
+		})
+
+		It("replays nothing when resuming from the latest ID", func() {
+			source, err := hub.Resume(hub.Epoch(), 3, models.EventFilter{})
+			Expect(err).NotTo(HaveOccurred())
+
+			hub.Emit(eventfakes.FakeEvent{Token: "4"})
+			Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 4, Event: eventfakes.FakeEvent{Token: "4"}}))
+		})
+
+		Context("when the ID is from another epoch", func() {
+			It("requires a resync even though the hub has emitted that ID", func() {
+				source, err := hub.Resume("other-epoch", 1, models.EventFilter{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 3, ResyncRequired: true}))
+			})
+		})
+
+		Context("when the ID was never emitted by the hub", func() {
+			It("requires a resync from the latest ID", func() {
+				source, err := hub.Resume(hub.Epoch(), 17, models.EventFilter{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 3, ResyncRequired: true}))
+
+				hub.Emit(eventfakes.FakeEvent{Token: "4"})
+				Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 4, Event: eventfakes.FakeEvent{Token: "4"}}))
+			})
+		})
+
+		Context("when the events after the ID have fallen out of the buffer", func() {
+			BeforeEach(func() {
+				for eventToken := 4; eventToken <= events.MAX_BUFFERED_EVENTS+3; eventToken++ {
+					hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(eventToken)})
+				}
+			})
+
+			It("can still replay from the oldest buffered event", func() {
+				source, err := hub.Resume(hub.Epoch(), 3, models.EventFilter{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: 4, Event: eventfakes.FakeEvent{Token: "4"}}))
+			})
+
+			It("requires a resync", func() {
+				source, err := hub.Resume(hub.Epoch(), 2, models.EventFilter{})
+				Expect(err).NotTo(HaveOccurred())
+
+				latestID := uint64(events.MAX_BUFFERED_EVENTS + 3)
+				Expect(source.NextSequenced()).To(Equal(events.SequencedEvent{ID: latestID, ResyncRequired: true}))
+			})
+		})
+	})
+
+	Describe("slow consumer policies", func() {
+		const bufferSize = 2
+
+		var config events.HubConfig
+
+		BeforeEach(func() {
+			config = events.DefaultHubConfig()
+			config.MaxPendingEvents = bufferSize
+		})
+
+		JustBeforeEach(func() {
+			hub = events.NewHubWithConfig(config)
+		})
+
+		actualLRPChanged := func(index int32, since int64) models.Event {
+			group := &models.ActualLRPGroup{Instance: models.NewUnclaimedActualLRP(
+				models.NewActualLRPKey("process-guid", index, "domain"),
+				since,
+			)}
+			return models.NewActualLRPChangedEvent(group, group)
+		}
+
+		Context("when disconnecting slow consumers", func() {
+			It("honors the configured buffer size", func() {
+				source, err := hub.Subscribe()
+				Expect(err).NotTo(HaveOccurred())
+
+				for eventToken := 0; eventToken < bufferSize+1; eventToken++ {
+					hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(eventToken)})
+				}
+
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "0"}))
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "1"}))
+				_, err = source.Next()
+				Expect(err).To(Equal(events.ErrReadFromClosedSource))
+			})
+		})
+
+		Context("when blocking on slow consumers", func() {
+			BeforeEach(func() {
+				config.SlowConsumerPolicy = events.BlockSlowConsumers
+				config.SlowConsumerTimeout = time.Second
+			})
+
+			It("waits for the consumer to make room", func() {
+				source, err := hub.Subscribe()
+				Expect(err).NotTo(HaveOccurred())
+
+				hub.Emit(eventfakes.FakeEvent{Token: "0"})
+				hub.Emit(eventfakes.FakeEvent{Token: "1"})
+
+				emitted := make(chan struct{})
+				go func() {
+					hub.Emit(eventfakes.FakeEvent{Token: "2"})
+					close(emitted)
+				}()
+
+				Consistently(emitted, 100*time.Millisecond).ShouldNot(BeClosed())
+
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "0"}))
+				Eventually(emitted).Should(BeClosed())
+
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "1"}))
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "2"}))
+			})
+
+			It("does not hold up new subscriptions while waiting", func() {
+				_, err := hub.Subscribe()
+				Expect(err).NotTo(HaveOccurred())
+
+				hub.Emit(eventfakes.FakeEvent{Token: "0"})
+				hub.Emit(eventfakes.FakeEvent{Token: "1"})
+
+				emitted := make(chan struct{})
+				go func() {
+					hub.Emit(eventfakes.FakeEvent{Token: "2"})
+					close(emitted)
+				}()
+
+				Consistently(emitted, 100*time.Millisecond).ShouldNot(BeClosed())
+
+				subscribed := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					_, err := hub.Subscribe()
+					Expect(err).NotTo(HaveOccurred())
+					close(subscribed)
+				}()
+
+				Eventually(subscribed).Should(BeClosed())
+				Expect(emitted).NotTo(BeClosed())
+			})
+
+			Context("when the consumer does not make room in time", func() {
+				BeforeEach(func() {
+					config.SlowConsumerTimeout = 10 * time.Millisecond
+				})
+
+				It("closes the consumer", func() {
+					source, err := hub.Subscribe()
+					Expect(err).NotTo(HaveOccurred())
+
+					for eventToken := 0; eventToken < bufferSize+1; eventToken++ {
+						hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(eventToken)})
+					}
+
+					Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "0"}))
+					Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "1"}))
+					_, err = source.Next()
+					Expect(err).To(Equal(events.ErrReadFromClosedSource))
+				})
+			})
+		})
+
+		Context("when coalescing for slow consumers", func() {
+			BeforeEach(func() {
+				config.SlowConsumerPolicy = events.CoalesceSlowConsumers
+			})
+
+			It("keeps only the latest change per actual LRP while the consumer is behind", func() {
+				source, err := hub.Subscribe()
+				Expect(err).NotTo(HaveOccurred())
+
+				hub.Emit(eventfakes.FakeEvent{Token: "0"})
+				hub.Emit(eventfakes.FakeEvent{Token: "1"})
+				hub.Emit(actualLRPChanged(0, 1))
+				hub.Emit(actualLRPChanged(1, 1))
+				hub.Emit(actualLRPChanged(0, 2))
+
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "0"}))
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "1"}))
+				Expect(source.Next()).To(Equal(actualLRPChanged(1, 1)))
+				Expect(source.Next()).To(Equal(actualLRPChanged(0, 2)))
+
+				hub.Emit(eventfakes.FakeEvent{Token: "2"})
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "2"}))
+			})
+
+			It("closes the consumer once the overflow is full too", func() {
+				source, err := hub.Subscribe()
+				Expect(err).NotTo(HaveOccurred())
+
+				for eventToken := 0; eventToken < 2*bufferSize+1; eventToken++ {
+					hub.Emit(eventfakes.FakeEvent{Token: strconv.Itoa(eventToken)})
+				}
+
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "0"}))
+				Expect(source.Next()).To(Equal(eventfakes.FakeEvent{Token: "1"}))
+				_, err = source.Next()
+				Expect(err).To(Equal(events.ErrReadFromClosedSource))
+			})
+		})
+	})
+
+	Describe("ParseSlowConsumerPolicy", func() {
+		It("parses known policies", func() {
+			Expect(events.ParseSlowConsumerPolicy("coalesce")).To(Equal(events.CoalesceSlowConsumers))
+		})
+
+		It("errors on unknown policies", func() {
+			_, err := events.ParseSlowConsumerPolicy("drop-everything")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("closing an event source", func() {
 		It("prevents current events from propagating to the source", func() {
 			source, err := hub.Subscribe()
@@ -36,6 +36,15 @@ type FakeClient struct {
 		result1 []*models.ActualLRPGroup
 		result2 error
 	}
+	IterateActualLRPGroupsStub        func(filter models.ActualLRPFilter, pageSize int) bbs.ActualLRPGroupIterator
+	iterateActualLRPGroupsMutex       sync.RWMutex
+	iterateActualLRPGroupsArgsForCall []struct {
+		filter   models.ActualLRPFilter
+		pageSize int
+	}
+	iterateActualLRPGroupsReturns struct {
+		result1 bbs.ActualLRPGroupIterator
+	}
 	ActualLRPGroupsByProcessGuidStub        func(processGuid string) ([]*models.ActualLRPGroup, error)
 	actualLRPGroupsByProcessGuidMutex       sync.RWMutex
 	actualLRPGroupsByProcessGuidArgsForCall []struct {
@@ -66,6 +75,18 @@ type FakeClient struct {
 		result1 *models.ActualLRP
 		result2 error
 	}
+	ClaimActualLRPIfUnmodifiedStub        func(processGuid string, index int, instanceKey *models.ActualLRPInstanceKey, expectedTag *models.ModificationTag) (*models.ActualLRP, error)
+	claimActualLRPIfUnmodifiedMutex       sync.RWMutex
+	claimActualLRPIfUnmodifiedArgsForCall []struct {
+		processGuid string
+		index       int
+		instanceKey *models.ActualLRPInstanceKey
+		expectedTag *models.ModificationTag
+	}
+	claimActualLRPIfUnmodifiedReturns struct {
+		result1 *models.ActualLRP
+		result2 error
+	}
 	StartActualLRPStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (*models.ActualLRP, error)
 	startActualLRPMutex       sync.RWMutex
 	startActualLRPArgsForCall []struct {
@@ -77,6 +98,18 @@ type FakeClient struct {
 		result1 *models.ActualLRP
 		result2 error
 	}
+	StartActualLRPIfUnmodifiedStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, expectedTag *models.ModificationTag) (*models.ActualLRP, error)
+	startActualLRPIfUnmodifiedMutex       sync.RWMutex
+	startActualLRPIfUnmodifiedArgsForCall []struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+		netInfo     *models.ActualLRPNetInfo
+		expectedTag *models.ModificationTag
+	}
+	startActualLRPIfUnmodifiedReturns struct {
+		result1 *models.ActualLRP
+		result2 error
+	}
 	CrashActualLRPStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) error
 	crashActualLRPMutex       sync.RWMutex
 	crashActualLRPArgsForCall []struct {
@@ -113,6 +146,58 @@ type FakeClient struct {
 	retireActualLRPReturns struct {
 		result1 error
 	}
+	EvacuateClaimedActualLRPStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error)
+	evacuateClaimedActualLRPMutex       sync.RWMutex
+	evacuateClaimedActualLRPArgsForCall []struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+	}
+	evacuateClaimedActualLRPReturns struct {
+		result1 bool
+		result2 error
+	}
+	EvacuateRunningActualLRPStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, ttl uint64) (bool, error)
+	evacuateRunningActualLRPMutex       sync.RWMutex
+	evacuateRunningActualLRPArgsForCall []struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+		netInfo     *models.ActualLRPNetInfo
+		ttl         uint64
+	}
+	evacuateRunningActualLRPReturns struct {
+		result1 bool
+		result2 error
+	}
+	EvacuateStoppedActualLRPStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error)
+	evacuateStoppedActualLRPMutex       sync.RWMutex
+	evacuateStoppedActualLRPArgsForCall []struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+	}
+	evacuateStoppedActualLRPReturns struct {
+		result1 bool
+		result2 error
+	}
+	EvacuateCrashedActualLRPStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) (bool, error)
+	evacuateCrashedActualLRPMutex       sync.RWMutex
+	evacuateCrashedActualLRPArgsForCall []struct {
+		key          *models.ActualLRPKey
+		instanceKey  *models.ActualLRPInstanceKey
+		errorMessage string
+	}
+	evacuateCrashedActualLRPReturns struct {
+		result1 bool
+		result2 error
+	}
+	RemoveEvacuatingActualLRPStub        func(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) error
+	removeEvacuatingActualLRPMutex       sync.RWMutex
+	removeEvacuatingActualLRPArgsForCall []struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+	}
+	removeEvacuatingActualLRPReturns struct {
+		result1 error
+	}
 	DesiredLRPsStub        func(models.DesiredLRPFilter) ([]*models.DesiredLRP, error)
 	desiredLRPsMutex       sync.RWMutex
 	desiredLRPsArgsForCall []struct {
@@ -122,6 +207,15 @@ type FakeClient struct {
 		result1 []*models.DesiredLRP
 		result2 error
 	}
+	IterateDesiredLRPsStub        func(filter models.DesiredLRPFilter, pageSize int) bbs.DesiredLRPIterator
+	iterateDesiredLRPsMutex       sync.RWMutex
+	iterateDesiredLRPsArgsForCall []struct {
+		filter   models.DesiredLRPFilter
+		pageSize int
+	}
+	iterateDesiredLRPsReturns struct {
+		result1 bbs.DesiredLRPIterator
+	}
 	DesiredLRPByProcessGuidStub        func(processGuid string) (*models.DesiredLRP, error)
 	desiredLRPByProcessGuidMutex       sync.RWMutex
 	desiredLRPByProcessGuidArgsForCall []struct {
@@ -131,6 +225,41 @@ type FakeClient struct {
 		result1 *models.DesiredLRP
 		result2 error
 	}
+	DesireLRPStub        func(*models.DesiredLRP) error
+	desireLRPMutex       sync.RWMutex
+	desireLRPArgsForCall []struct {
+		arg1 *models.DesiredLRP
+	}
+	desireLRPReturns struct {
+		result1 error
+	}
+	UpdateDesiredLRPStub        func(processGuid string, update *models.DesiredLRPUpdate) error
+	updateDesiredLRPMutex       sync.RWMutex
+	updateDesiredLRPArgsForCall []struct {
+		processGuid string
+		update      *models.DesiredLRPUpdate
+	}
+	updateDesiredLRPReturns struct {
+		result1 error
+	}
+	UpdateDesiredLRPIfUnmodifiedStub        func(processGuid string, update *models.DesiredLRPUpdate, expectedTag *models.ModificationTag) error
+	updateDesiredLRPIfUnmodifiedMutex       sync.RWMutex
+	updateDesiredLRPIfUnmodifiedArgsForCall []struct {
+		processGuid string
+		update      *models.DesiredLRPUpdate
+		expectedTag *models.ModificationTag
+	}
+	updateDesiredLRPIfUnmodifiedReturns struct {
+		result1 error
+	}
+	RemoveDesiredLRPStub        func(processGuid string) error
+	removeDesiredLRPMutex       sync.RWMutex
+	removeDesiredLRPArgsForCall []struct {
+		processGuid string
+	}
+	removeDesiredLRPReturns struct {
+		result1 error
+	}
 	TasksStub        func() ([]*models.Task, error)
 	tasksMutex       sync.RWMutex
 	tasksArgsForCall []struct{}
@@ -156,6 +285,15 @@ type FakeClient struct {
 		result1 []*models.Task
 		result2 error
 	}
+	IterateTasksStub        func(filter models.TaskFilter, pageSize int) bbs.TaskIterator
+	iterateTasksMutex       sync.RWMutex
+	iterateTasksArgsForCall []struct {
+		filter   models.TaskFilter
+		pageSize int
+	}
+	iterateTasksReturns struct {
+		result1 bbs.TaskIterator
+	}
 	TaskByGuidStub        func(guid string) (*models.Task, error)
 	taskByGuidMutex       sync.RWMutex
 	taskByGuidArgsForCall []struct {
@@ -165,6 +303,76 @@ type FakeClient struct {
 		result1 *models.Task
 		result2 error
 	}
+	DesireTaskStub        func(*models.Task) error
+	desireTaskMutex       sync.RWMutex
+	desireTaskArgsForCall []struct {
+		arg1 *models.Task
+	}
+	desireTaskReturns struct {
+		result1 error
+	}
+	StartTaskStub        func(taskGuid string, cellId string) (bool, error)
+	startTaskMutex       sync.RWMutex
+	startTaskArgsForCall []struct {
+		taskGuid string
+		cellId   string
+	}
+	startTaskReturns struct {
+		result1 bool
+		result2 error
+	}
+	CancelTaskStub        func(taskGuid string) error
+	cancelTaskMutex       sync.RWMutex
+	cancelTaskArgsForCall []struct {
+		taskGuid string
+	}
+	cancelTaskReturns struct {
+		result1 error
+	}
+	FailTaskStub        func(taskGuid string, failureReason string) error
+	failTaskMutex       sync.RWMutex
+	failTaskArgsForCall []struct {
+		taskGuid      string
+		failureReason string
+	}
+	failTaskReturns struct {
+		result1 error
+	}
+	CompleteTaskStub        func(taskGuid string, cellId string, failed bool, failureReason string, result string) error
+	completeTaskMutex       sync.RWMutex
+	completeTaskArgsForCall []struct {
+		taskGuid      string
+		cellId        string
+		failed        bool
+		failureReason string
+		result        string
+	}
+	completeTaskReturns struct {
+		result1 error
+	}
+	ResolvingTaskStub        func(taskGuid string) error
+	resolvingTaskMutex       sync.RWMutex
+	resolvingTaskArgsForCall []struct {
+		taskGuid string
+	}
+	resolvingTaskReturns struct {
+		result1 error
+	}
+	ResolveTaskStub        func(taskGuid string) error
+	resolveTaskMutex       sync.RWMutex
+	resolveTaskArgsForCall []struct {
+		taskGuid string
+	}
+	resolveTaskReturns struct {
+		result1 error
+	}
+	CellsStub        func() ([]*models.CellPresence, error)
+	cellsMutex       sync.RWMutex
+	cellsArgsForCall []struct{}
+	cellsReturns struct {
+		result1 []*models.CellPresence
+		result2 error
+	}
 	SubscribeToEventsStub        func() (events.EventSource, error)
 	subscribeToEventsMutex       sync.RWMutex
 	subscribeToEventsArgsForCall []struct{}
@@ -172,6 +380,15 @@ type FakeClient struct {
 		result1 events.EventSource
 		result2 error
 	}
+	SubscribeToEventsWithFilterStub        func(models.EventFilter) (events.EventSource, error)
+	subscribeToEventsWithFilterMutex       sync.RWMutex
+	subscribeToEventsWithFilterArgsForCall []struct {
+		arg1 models.EventFilter
+	}
+	subscribeToEventsWithFilterReturns struct {
+		result1 events.EventSource
+		result2 error
+	}
 }
 
 func (fake *FakeClient) Domains() ([]string, error) {
@@ -265,6 +482,39 @@ func (fake *FakeClient) ActualLRPGroupsReturns(result1 []*models.ActualLRPGroup,
 	}{result1, result2}
 }
 
+func (fake *FakeClient) IterateActualLRPGroups(filter models.ActualLRPFilter, pageSize int) bbs.ActualLRPGroupIterator {
+	fake.iterateActualLRPGroupsMutex.Lock()
+	fake.iterateActualLRPGroupsArgsForCall = append(fake.iterateActualLRPGroupsArgsForCall, struct {
+		filter   models.ActualLRPFilter
+		pageSize int
+	}{filter, pageSize})
+	fake.iterateActualLRPGroupsMutex.Unlock()
+	if fake.IterateActualLRPGroupsStub != nil {
+		return fake.IterateActualLRPGroupsStub(filter, pageSize)
+	} else {
+		return fake.iterateActualLRPGroupsReturns.result1
+	}
+}
+
+func (fake *FakeClient) IterateActualLRPGroupsCallCount() int {
+	fake.iterateActualLRPGroupsMutex.RLock()
+	defer fake.iterateActualLRPGroupsMutex.RUnlock()
+	return len(fake.iterateActualLRPGroupsArgsForCall)
+}
+
+func (fake *FakeClient) IterateActualLRPGroupsArgsForCall(i int) (models.ActualLRPFilter, int) {
+	fake.iterateActualLRPGroupsMutex.RLock()
+	defer fake.iterateActualLRPGroupsMutex.RUnlock()
+	return fake.iterateActualLRPGroupsArgsForCall[i].filter, fake.iterateActualLRPGroupsArgsForCall[i].pageSize
+}
+
+func (fake *FakeClient) IterateActualLRPGroupsReturns(result1 bbs.ActualLRPGroupIterator) {
+	fake.IterateActualLRPGroupsStub = nil
+	fake.iterateActualLRPGroupsReturns = struct {
+		result1 bbs.ActualLRPGroupIterator
+	}{result1}
+}
+
 func (fake *FakeClient) ActualLRPGroupsByProcessGuid(processGuid string) ([]*models.ActualLRPGroup, error) {
 	fake.actualLRPGroupsByProcessGuidMutex.Lock()
 	fake.actualLRPGroupsByProcessGuidArgsForCall = append(fake.actualLRPGroupsByProcessGuidArgsForCall, struct {
@@ -367,6 +617,42 @@ func (fake *FakeClient) ClaimActualLRPReturns(result1 *models.ActualLRP, result2
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ClaimActualLRPIfUnmodified(processGuid string, index int, instanceKey *models.ActualLRPInstanceKey, expectedTag *models.ModificationTag) (*models.ActualLRP, error) {
+	fake.claimActualLRPIfUnmodifiedMutex.Lock()
+	fake.claimActualLRPIfUnmodifiedArgsForCall = append(fake.claimActualLRPIfUnmodifiedArgsForCall, struct {
+		processGuid string
+		index       int
+		instanceKey *models.ActualLRPInstanceKey
+		expectedTag *models.ModificationTag
+	}{processGuid, index, instanceKey, expectedTag})
+	fake.claimActualLRPIfUnmodifiedMutex.Unlock()
+	if fake.ClaimActualLRPIfUnmodifiedStub != nil {
+		return fake.ClaimActualLRPIfUnmodifiedStub(processGuid, index, instanceKey, expectedTag)
+	} else {
+		return fake.claimActualLRPIfUnmodifiedReturns.result1, fake.claimActualLRPIfUnmodifiedReturns.result2
+	}
+}
+
+func (fake *FakeClient) ClaimActualLRPIfUnmodifiedCallCount() int {
+	fake.claimActualLRPIfUnmodifiedMutex.RLock()
+	defer fake.claimActualLRPIfUnmodifiedMutex.RUnlock()
+	return len(fake.claimActualLRPIfUnmodifiedArgsForCall)
+}
+
+func (fake *FakeClient) ClaimActualLRPIfUnmodifiedArgsForCall(i int) (string, int, *models.ActualLRPInstanceKey, *models.ModificationTag) {
+	fake.claimActualLRPIfUnmodifiedMutex.RLock()
+	defer fake.claimActualLRPIfUnmodifiedMutex.RUnlock()
+	return fake.claimActualLRPIfUnmodifiedArgsForCall[i].processGuid, fake.claimActualLRPIfUnmodifiedArgsForCall[i].index, fake.claimActualLRPIfUnmodifiedArgsForCall[i].instanceKey, fake.claimActualLRPIfUnmodifiedArgsForCall[i].expectedTag
+}
+
+func (fake *FakeClient) ClaimActualLRPIfUnmodifiedReturns(result1 *models.ActualLRP, result2 error) {
+	fake.ClaimActualLRPIfUnmodifiedStub = nil
+	fake.claimActualLRPIfUnmodifiedReturns = struct {
+		result1 *models.ActualLRP
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) StartActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (*models.ActualLRP, error) {
 	fake.startActualLRPMutex.Lock()
 	fake.startActualLRPArgsForCall = append(fake.startActualLRPArgsForCall, struct {
@@ -402,6 +688,42 @@ func (fake *FakeClient) StartActualLRPReturns(result1 *models.ActualLRP, result2
 	}{result1, result2}
 }
 
+func (fake *FakeClient) StartActualLRPIfUnmodified(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, expectedTag *models.ModificationTag) (*models.ActualLRP, error) {
+	fake.startActualLRPIfUnmodifiedMutex.Lock()
+	fake.startActualLRPIfUnmodifiedArgsForCall = append(fake.startActualLRPIfUnmodifiedArgsForCall, struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+		netInfo     *models.ActualLRPNetInfo
+		expectedTag *models.ModificationTag
+	}{key, instanceKey, netInfo, expectedTag})
+	fake.startActualLRPIfUnmodifiedMutex.Unlock()
+	if fake.StartActualLRPIfUnmodifiedStub != nil {
+		return fake.StartActualLRPIfUnmodifiedStub(key, instanceKey, netInfo, expectedTag)
+	} else {
+		return fake.startActualLRPIfUnmodifiedReturns.result1, fake.startActualLRPIfUnmodifiedReturns.result2
+	}
+}
+
+func (fake *FakeClient) StartActualLRPIfUnmodifiedCallCount() int {
+	fake.startActualLRPIfUnmodifiedMutex.RLock()
+	defer fake.startActualLRPIfUnmodifiedMutex.RUnlock()
+	return len(fake.startActualLRPIfUnmodifiedArgsForCall)
+}
+
+func (fake *FakeClient) StartActualLRPIfUnmodifiedArgsForCall(i int) (*models.ActualLRPKey, *models.ActualLRPInstanceKey, *models.ActualLRPNetInfo, *models.ModificationTag) {
+	fake.startActualLRPIfUnmodifiedMutex.RLock()
+	defer fake.startActualLRPIfUnmodifiedMutex.RUnlock()
+	return fake.startActualLRPIfUnmodifiedArgsForCall[i].key, fake.startActualLRPIfUnmodifiedArgsForCall[i].instanceKey, fake.startActualLRPIfUnmodifiedArgsForCall[i].netInfo, fake.startActualLRPIfUnmodifiedArgsForCall[i].expectedTag
+}
+
+func (fake *FakeClient) StartActualLRPIfUnmodifiedReturns(result1 *models.ActualLRP, result2 error) {
+	fake.StartActualLRPIfUnmodifiedStub = nil
+	fake.startActualLRPIfUnmodifiedReturns = struct {
+		result1 *models.ActualLRP
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) CrashActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) error {
 	fake.crashActualLRPMutex.Lock()
 	fake.crashActualLRPArgsForCall = append(fake.crashActualLRPArgsForCall, struct {
@@ -534,6 +856,178 @@ func (fake *FakeClient) RetireActualLRPReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) EvacuateClaimedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error) {
+	fake.evacuateClaimedActualLRPMutex.Lock()
+	fake.evacuateClaimedActualLRPArgsForCall = append(fake.evacuateClaimedActualLRPArgsForCall, struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+	}{key, instanceKey})
+	fake.evacuateClaimedActualLRPMutex.Unlock()
+	if fake.EvacuateClaimedActualLRPStub != nil {
+		return fake.EvacuateClaimedActualLRPStub(key, instanceKey)
+	} else {
+		return fake.evacuateClaimedActualLRPReturns.result1, fake.evacuateClaimedActualLRPReturns.result2
+	}
+}
+
+func (fake *FakeClient) EvacuateClaimedActualLRPCallCount() int {
+	fake.evacuateClaimedActualLRPMutex.RLock()
+	defer fake.evacuateClaimedActualLRPMutex.RUnlock()
+	return len(fake.evacuateClaimedActualLRPArgsForCall)
+}
+
+func (fake *FakeClient) EvacuateClaimedActualLRPArgsForCall(i int) (*models.ActualLRPKey, *models.ActualLRPInstanceKey) {
+	fake.evacuateClaimedActualLRPMutex.RLock()
+	defer fake.evacuateClaimedActualLRPMutex.RUnlock()
+	return fake.evacuateClaimedActualLRPArgsForCall[i].key, fake.evacuateClaimedActualLRPArgsForCall[i].instanceKey
+}
+
+func (fake *FakeClient) EvacuateClaimedActualLRPReturns(result1 bool, result2 error) {
+	fake.EvacuateClaimedActualLRPStub = nil
+	fake.evacuateClaimedActualLRPReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EvacuateRunningActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo, ttl uint64) (bool, error) {
+	fake.evacuateRunningActualLRPMutex.Lock()
+	fake.evacuateRunningActualLRPArgsForCall = append(fake.evacuateRunningActualLRPArgsForCall, struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+		netInfo     *models.ActualLRPNetInfo
+		ttl         uint64
+	}{key, instanceKey, netInfo, ttl})
+	fake.evacuateRunningActualLRPMutex.Unlock()
+	if fake.EvacuateRunningActualLRPStub != nil {
+		return fake.EvacuateRunningActualLRPStub(key, instanceKey, netInfo, ttl)
+	} else {
+		return fake.evacuateRunningActualLRPReturns.result1, fake.evacuateRunningActualLRPReturns.result2
+	}
+}
+
+func (fake *FakeClient) EvacuateRunningActualLRPCallCount() int {
+	fake.evacuateRunningActualLRPMutex.RLock()
+	defer fake.evacuateRunningActualLRPMutex.RUnlock()
+	return len(fake.evacuateRunningActualLRPArgsForCall)
+}
+
+func (fake *FakeClient) EvacuateRunningActualLRPArgsForCall(i int) (*models.ActualLRPKey, *models.ActualLRPInstanceKey, *models.ActualLRPNetInfo, uint64) {
+	fake.evacuateRunningActualLRPMutex.RLock()
+	defer fake.evacuateRunningActualLRPMutex.RUnlock()
+	return fake.evacuateRunningActualLRPArgsForCall[i].key, fake.evacuateRunningActualLRPArgsForCall[i].instanceKey, fake.evacuateRunningActualLRPArgsForCall[i].netInfo, fake.evacuateRunningActualLRPArgsForCall[i].ttl
+}
+
+func (fake *FakeClient) EvacuateRunningActualLRPReturns(result1 bool, result2 error) {
+	fake.EvacuateRunningActualLRPStub = nil
+	fake.evacuateRunningActualLRPReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EvacuateStoppedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (bool, error) {
+	fake.evacuateStoppedActualLRPMutex.Lock()
+	fake.evacuateStoppedActualLRPArgsForCall = append(fake.evacuateStoppedActualLRPArgsForCall, struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+	}{key, instanceKey})
+	fake.evacuateStoppedActualLRPMutex.Unlock()
+	if fake.EvacuateStoppedActualLRPStub != nil {
+		return fake.EvacuateStoppedActualLRPStub(key, instanceKey)
+	} else {
+		return fake.evacuateStoppedActualLRPReturns.result1, fake.evacuateStoppedActualLRPReturns.result2
+	}
+}
+
+func (fake *FakeClient) EvacuateStoppedActualLRPCallCount() int {
+	fake.evacuateStoppedActualLRPMutex.RLock()
+	defer fake.evacuateStoppedActualLRPMutex.RUnlock()
+	return len(fake.evacuateStoppedActualLRPArgsForCall)
+}
+
+func (fake *FakeClient) EvacuateStoppedActualLRPArgsForCall(i int) (*models.ActualLRPKey, *models.ActualLRPInstanceKey) {
+	fake.evacuateStoppedActualLRPMutex.RLock()
+	defer fake.evacuateStoppedActualLRPMutex.RUnlock()
+	return fake.evacuateStoppedActualLRPArgsForCall[i].key, fake.evacuateStoppedActualLRPArgsForCall[i].instanceKey
+}
+
+func (fake *FakeClient) EvacuateStoppedActualLRPReturns(result1 bool, result2 error) {
+	fake.EvacuateStoppedActualLRPStub = nil
+	fake.evacuateStoppedActualLRPReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EvacuateCrashedActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) (bool, error) {
+	fake.evacuateCrashedActualLRPMutex.Lock()
+	fake.evacuateCrashedActualLRPArgsForCall = append(fake.evacuateCrashedActualLRPArgsForCall, struct {
+		key          *models.ActualLRPKey
+		instanceKey  *models.ActualLRPInstanceKey
+		errorMessage string
+	}{key, instanceKey, errorMessage})
+	fake.evacuateCrashedActualLRPMutex.Unlock()
+	if fake.EvacuateCrashedActualLRPStub != nil {
+		return fake.EvacuateCrashedActualLRPStub(key, instanceKey, errorMessage)
+	} else {
+		return fake.evacuateCrashedActualLRPReturns.result1, fake.evacuateCrashedActualLRPReturns.result2
+	}
+}
+
+func (fake *FakeClient) EvacuateCrashedActualLRPCallCount() int {
+	fake.evacuateCrashedActualLRPMutex.RLock()
+	defer fake.evacuateCrashedActualLRPMutex.RUnlock()
+	return len(fake.evacuateCrashedActualLRPArgsForCall)
+}
+
+func (fake *FakeClient) EvacuateCrashedActualLRPArgsForCall(i int) (*models.ActualLRPKey, *models.ActualLRPInstanceKey, string) {
+	fake.evacuateCrashedActualLRPMutex.RLock()
+	defer fake.evacuateCrashedActualLRPMutex.RUnlock()
+	return fake.evacuateCrashedActualLRPArgsForCall[i].key, fake.evacuateCrashedActualLRPArgsForCall[i].instanceKey, fake.evacuateCrashedActualLRPArgsForCall[i].errorMessage
+}
+
+func (fake *FakeClient) EvacuateCrashedActualLRPReturns(result1 bool, result2 error) {
+	fake.EvacuateCrashedActualLRPStub = nil
+	fake.evacuateCrashedActualLRPReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RemoveEvacuatingActualLRP(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) error {
+	fake.removeEvacuatingActualLRPMutex.Lock()
+	fake.removeEvacuatingActualLRPArgsForCall = append(fake.removeEvacuatingActualLRPArgsForCall, struct {
+		key         *models.ActualLRPKey
+		instanceKey *models.ActualLRPInstanceKey
+	}{key, instanceKey})
+	fake.removeEvacuatingActualLRPMutex.Unlock()
+	if fake.RemoveEvacuatingActualLRPStub != nil {
+		return fake.RemoveEvacuatingActualLRPStub(key, instanceKey)
+	} else {
+		return fake.removeEvacuatingActualLRPReturns.result1
+	}
+}
+
+func (fake *FakeClient) RemoveEvacuatingActualLRPCallCount() int {
+	fake.removeEvacuatingActualLRPMutex.RLock()
+	defer fake.removeEvacuatingActualLRPMutex.RUnlock()
+	return len(fake.removeEvacuatingActualLRPArgsForCall)
+}
+
+func (fake *FakeClient) RemoveEvacuatingActualLRPArgsForCall(i int) (*models.ActualLRPKey, *models.ActualLRPInstanceKey) {
+	fake.removeEvacuatingActualLRPMutex.RLock()
+	defer fake.removeEvacuatingActualLRPMutex.RUnlock()
+	return fake.removeEvacuatingActualLRPArgsForCall[i].key, fake.removeEvacuatingActualLRPArgsForCall[i].instanceKey
+}
+
+func (fake *FakeClient) RemoveEvacuatingActualLRPReturns(result1 error) {
+	fake.RemoveEvacuatingActualLRPStub = nil
+	fake.removeEvacuatingActualLRPReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) DesiredLRPs(arg1 models.DesiredLRPFilter) ([]*models.DesiredLRP, error) {
 	fake.desiredLRPsMutex.Lock()
 	fake.desiredLRPsArgsForCall = append(fake.desiredLRPsArgsForCall, struct {
@@ -567,6 +1061,39 @@ func (fake *FakeClient) DesiredLRPsReturns(result1 []*models.DesiredLRP, result2
 	}{result1, result2}
 }
 
+func (fake *FakeClient) IterateDesiredLRPs(filter models.DesiredLRPFilter, pageSize int) bbs.DesiredLRPIterator {
+	fake.iterateDesiredLRPsMutex.Lock()
+	fake.iterateDesiredLRPsArgsForCall = append(fake.iterateDesiredLRPsArgsForCall, struct {
+		filter   models.DesiredLRPFilter
+		pageSize int
+	}{filter, pageSize})
+	fake.iterateDesiredLRPsMutex.Unlock()
+	if fake.IterateDesiredLRPsStub != nil {
+		return fake.IterateDesiredLRPsStub(filter, pageSize)
+	} else {
+		return fake.iterateDesiredLRPsReturns.result1
+	}
+}
+
+func (fake *FakeClient) IterateDesiredLRPsCallCount() int {
+	fake.iterateDesiredLRPsMutex.RLock()
+	defer fake.iterateDesiredLRPsMutex.RUnlock()
+	return len(fake.iterateDesiredLRPsArgsForCall)
+}
+
+func (fake *FakeClient) IterateDesiredLRPsArgsForCall(i int) (models.DesiredLRPFilter, int) {
+	fake.iterateDesiredLRPsMutex.RLock()
+	defer fake.iterateDesiredLRPsMutex.RUnlock()
+	return fake.iterateDesiredLRPsArgsForCall[i].filter, fake.iterateDesiredLRPsArgsForCall[i].pageSize
+}
+
+func (fake *FakeClient) IterateDesiredLRPsReturns(result1 bbs.DesiredLRPIterator) {
+	fake.IterateDesiredLRPsStub = nil
+	fake.iterateDesiredLRPsReturns = struct {
+		result1 bbs.DesiredLRPIterator
+	}{result1}
+}
+
 func (fake *FakeClient) DesiredLRPByProcessGuid(processGuid string) (*models.DesiredLRP, error) {
 	fake.desiredLRPByProcessGuidMutex.Lock()
 	fake.desiredLRPByProcessGuidArgsForCall = append(fake.desiredLRPByProcessGuidArgsForCall, struct {
@@ -600,6 +1127,137 @@ func (fake *FakeClient) DesiredLRPByProcessGuidReturns(result1 *models.DesiredLR
 	}{result1, result2}
 }
 
+func (fake *FakeClient) DesireLRP(arg1 *models.DesiredLRP) error {
+	fake.desireLRPMutex.Lock()
+	fake.desireLRPArgsForCall = append(fake.desireLRPArgsForCall, struct {
+		arg1 *models.DesiredLRP
+	}{arg1})
+	fake.desireLRPMutex.Unlock()
+	if fake.DesireLRPStub != nil {
+		return fake.DesireLRPStub(arg1)
+	} else {
+		return fake.desireLRPReturns.result1
+	}
+}
+
+func (fake *FakeClient) DesireLRPCallCount() int {
+	fake.desireLRPMutex.RLock()
+	defer fake.desireLRPMutex.RUnlock()
+	return len(fake.desireLRPArgsForCall)
+}
+
+func (fake *FakeClient) DesireLRPArgsForCall(i int) *models.DesiredLRP {
+	fake.desireLRPMutex.RLock()
+	defer fake.desireLRPMutex.RUnlock()
+	return fake.desireLRPArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) DesireLRPReturns(result1 error) {
+	fake.DesireLRPStub = nil
+	fake.desireLRPReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateDesiredLRP(processGuid string, update *models.DesiredLRPUpdate) error {
+	fake.updateDesiredLRPMutex.Lock()
+	fake.updateDesiredLRPArgsForCall = append(fake.updateDesiredLRPArgsForCall, struct {
+		processGuid string
+		update      *models.DesiredLRPUpdate
+	}{processGuid, update})
+	fake.updateDesiredLRPMutex.Unlock()
+	if fake.UpdateDesiredLRPStub != nil {
+		return fake.UpdateDesiredLRPStub(processGuid, update)
+	} else {
+		return fake.updateDesiredLRPReturns.result1
+	}
+}
+
+func (fake *FakeClient) UpdateDesiredLRPCallCount() int {
+	fake.updateDesiredLRPMutex.RLock()
+	defer fake.updateDesiredLRPMutex.RUnlock()
+	return len(fake.updateDesiredLRPArgsForCall)
+}
+
+func (fake *FakeClient) UpdateDesiredLRPArgsForCall(i int) (string, *models.DesiredLRPUpdate) {
+	fake.updateDesiredLRPMutex.RLock()
+	defer fake.updateDesiredLRPMutex.RUnlock()
+	return fake.updateDesiredLRPArgsForCall[i].processGuid, fake.updateDesiredLRPArgsForCall[i].update
+}
+
+func (fake *FakeClient) UpdateDesiredLRPReturns(result1 error) {
+	fake.UpdateDesiredLRPStub = nil
+	fake.updateDesiredLRPReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateDesiredLRPIfUnmodified(processGuid string, update *models.DesiredLRPUpdate, expectedTag *models.ModificationTag) error {
+	fake.updateDesiredLRPIfUnmodifiedMutex.Lock()
+	fake.updateDesiredLRPIfUnmodifiedArgsForCall = append(fake.updateDesiredLRPIfUnmodifiedArgsForCall, struct {
+		processGuid string
+		update      *models.DesiredLRPUpdate
+		expectedTag *models.ModificationTag
+	}{processGuid, update, expectedTag})
+	fake.updateDesiredLRPIfUnmodifiedMutex.Unlock()
+	if fake.UpdateDesiredLRPIfUnmodifiedStub != nil {
+		return fake.UpdateDesiredLRPIfUnmodifiedStub(processGuid, update, expectedTag)
+	} else {
+		return fake.updateDesiredLRPIfUnmodifiedReturns.result1
+	}
+}
+
+func (fake *FakeClient) UpdateDesiredLRPIfUnmodifiedCallCount() int {
+	fake.updateDesiredLRPIfUnmodifiedMutex.RLock()
+	defer fake.updateDesiredLRPIfUnmodifiedMutex.RUnlock()
+	return len(fake.updateDesiredLRPIfUnmodifiedArgsForCall)
+}
+
+func (fake *FakeClient) UpdateDesiredLRPIfUnmodifiedArgsForCall(i int) (string, *models.DesiredLRPUpdate, *models.ModificationTag) {
+	fake.updateDesiredLRPIfUnmodifiedMutex.RLock()
+	defer fake.updateDesiredLRPIfUnmodifiedMutex.RUnlock()
+	return fake.updateDesiredLRPIfUnmodifiedArgsForCall[i].processGuid, fake.updateDesiredLRPIfUnmodifiedArgsForCall[i].update, fake.updateDesiredLRPIfUnmodifiedArgsForCall[i].expectedTag
+}
+
+func (fake *FakeClient) UpdateDesiredLRPIfUnmodifiedReturns(result1 error) {
+	fake.UpdateDesiredLRPIfUnmodifiedStub = nil
+	fake.updateDesiredLRPIfUnmodifiedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RemoveDesiredLRP(processGuid string) error {
+	fake.removeDesiredLRPMutex.Lock()
+	fake.removeDesiredLRPArgsForCall = append(fake.removeDesiredLRPArgsForCall, struct {
+		processGuid string
+	}{processGuid})
+	fake.removeDesiredLRPMutex.Unlock()
+	if fake.RemoveDesiredLRPStub != nil {
+		return fake.RemoveDesiredLRPStub(processGuid)
+	} else {
+		return fake.removeDesiredLRPReturns.result1
+	}
+}
+
+func (fake *FakeClient) RemoveDesiredLRPCallCount() int {
+	fake.removeDesiredLRPMutex.RLock()
+	defer fake.removeDesiredLRPMutex.RUnlock()
+	return len(fake.removeDesiredLRPArgsForCall)
+}
+
+func (fake *FakeClient) RemoveDesiredLRPArgsForCall(i int) string {
+	fake.removeDesiredLRPMutex.RLock()
+	defer fake.removeDesiredLRPMutex.RUnlock()
+	return fake.removeDesiredLRPArgsForCall[i].processGuid
+}
+
+func (fake *FakeClient) RemoveDesiredLRPReturns(result1 error) {
+	fake.RemoveDesiredLRPStub = nil
+	fake.removeDesiredLRPReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) Tasks() ([]*models.Task, error) {
 	fake.tasksMutex.Lock()
 	fake.tasksArgsForCall = append(fake.tasksArgsForCall, struct{}{})
@@ -691,6 +1349,39 @@ func (fake *FakeClient) TasksByCellIDReturns(result1 []*models.Task, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeClient) IterateTasks(filter models.TaskFilter, pageSize int) bbs.TaskIterator {
+	fake.iterateTasksMutex.Lock()
+	fake.iterateTasksArgsForCall = append(fake.iterateTasksArgsForCall, struct {
+		filter   models.TaskFilter
+		pageSize int
+	}{filter, pageSize})
+	fake.iterateTasksMutex.Unlock()
+	if fake.IterateTasksStub != nil {
+		return fake.IterateTasksStub(filter, pageSize)
+	} else {
+		return fake.iterateTasksReturns.result1
+	}
+}
+
+func (fake *FakeClient) IterateTasksCallCount() int {
+	fake.iterateTasksMutex.RLock()
+	defer fake.iterateTasksMutex.RUnlock()
+	return len(fake.iterateTasksArgsForCall)
+}
+
+func (fake *FakeClient) IterateTasksArgsForCall(i int) (models.TaskFilter, int) {
+	fake.iterateTasksMutex.RLock()
+	defer fake.iterateTasksMutex.RUnlock()
+	return fake.iterateTasksArgsForCall[i].filter, fake.iterateTasksArgsForCall[i].pageSize
+}
+
+func (fake *FakeClient) IterateTasksReturns(result1 bbs.TaskIterator) {
+	fake.IterateTasksStub = nil
+	fake.iterateTasksReturns = struct {
+		result1 bbs.TaskIterator
+	}{result1}
+}
+
 func (fake *FakeClient) TaskByGuid(guid string) (*models.Task, error) {
 	fake.taskByGuidMutex.Lock()
 	fake.taskByGuidArgsForCall = append(fake.taskByGuidArgsForCall, struct {
@@ -724,6 +1415,262 @@ func (fake *FakeClient) TaskByGuidReturns(result1 *models.Task, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeClient) DesireTask(arg1 *models.Task) error {
+	fake.desireTaskMutex.Lock()
+	fake.desireTaskArgsForCall = append(fake.desireTaskArgsForCall, struct {
+		arg1 *models.Task
+	}{arg1})
+	fake.desireTaskMutex.Unlock()
+	if fake.DesireTaskStub != nil {
+		return fake.DesireTaskStub(arg1)
+	} else {
+		return fake.desireTaskReturns.result1
+	}
+}
+
+func (fake *FakeClient) DesireTaskCallCount() int {
+	fake.desireTaskMutex.RLock()
+	defer fake.desireTaskMutex.RUnlock()
+	return len(fake.desireTaskArgsForCall)
+}
+
+func (fake *FakeClient) DesireTaskArgsForCall(i int) *models.Task {
+	fake.desireTaskMutex.RLock()
+	defer fake.desireTaskMutex.RUnlock()
+	return fake.desireTaskArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) DesireTaskReturns(result1 error) {
+	fake.DesireTaskStub = nil
+	fake.desireTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) StartTask(taskGuid string, cellId string) (bool, error) {
+	fake.startTaskMutex.Lock()
+	fake.startTaskArgsForCall = append(fake.startTaskArgsForCall, struct {
+		taskGuid string
+		cellId   string
+	}{taskGuid, cellId})
+	fake.startTaskMutex.Unlock()
+	if fake.StartTaskStub != nil {
+		return fake.StartTaskStub(taskGuid, cellId)
+	} else {
+		return fake.startTaskReturns.result1, fake.startTaskReturns.result2
+	}
+}
+
+func (fake *FakeClient) StartTaskCallCount() int {
+	fake.startTaskMutex.RLock()
+	defer fake.startTaskMutex.RUnlock()
+	return len(fake.startTaskArgsForCall)
+}
+
+func (fake *FakeClient) StartTaskArgsForCall(i int) (string, string) {
+	fake.startTaskMutex.RLock()
+	defer fake.startTaskMutex.RUnlock()
+	return fake.startTaskArgsForCall[i].taskGuid, fake.startTaskArgsForCall[i].cellId
+}
+
+func (fake *FakeClient) StartTaskReturns(result1 bool, result2 error) {
+	fake.StartTaskStub = nil
+	fake.startTaskReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CancelTask(taskGuid string) error {
+	fake.cancelTaskMutex.Lock()
+	fake.cancelTaskArgsForCall = append(fake.cancelTaskArgsForCall, struct {
+		taskGuid string
+	}{taskGuid})
+	fake.cancelTaskMutex.Unlock()
+	if fake.CancelTaskStub != nil {
+		return fake.CancelTaskStub(taskGuid)
+	} else {
+		return fake.cancelTaskReturns.result1
+	}
+}
+
+func (fake *FakeClient) CancelTaskCallCount() int {
+	fake.cancelTaskMutex.RLock()
+	defer fake.cancelTaskMutex.RUnlock()
+	return len(fake.cancelTaskArgsForCall)
+}
+
+func (fake *FakeClient) CancelTaskArgsForCall(i int) string {
+	fake.cancelTaskMutex.RLock()
+	defer fake.cancelTaskMutex.RUnlock()
+	return fake.cancelTaskArgsForCall[i].taskGuid
+}
+
+func (fake *FakeClient) CancelTaskReturns(result1 error) {
+	fake.CancelTaskStub = nil
+	fake.cancelTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) FailTask(taskGuid string, failureReason string) error {
+	fake.failTaskMutex.Lock()
+	fake.failTaskArgsForCall = append(fake.failTaskArgsForCall, struct {
+		taskGuid      string
+		failureReason string
+	}{taskGuid, failureReason})
+	fake.failTaskMutex.Unlock()
+	if fake.FailTaskStub != nil {
+		return fake.FailTaskStub(taskGuid, failureReason)
+	} else {
+		return fake.failTaskReturns.result1
+	}
+}
+
+func (fake *FakeClient) FailTaskCallCount() int {
+	fake.failTaskMutex.RLock()
+	defer fake.failTaskMutex.RUnlock()
+	return len(fake.failTaskArgsForCall)
+}
+
+func (fake *FakeClient) FailTaskArgsForCall(i int) (string, string) {
+	fake.failTaskMutex.RLock()
+	defer fake.failTaskMutex.RUnlock()
+	return fake.failTaskArgsForCall[i].taskGuid, fake.failTaskArgsForCall[i].failureReason
+}
+
+func (fake *FakeClient) FailTaskReturns(result1 error) {
+	fake.FailTaskStub = nil
+	fake.failTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CompleteTask(taskGuid string, cellId string, failed bool, failureReason string, result string) error {
+	fake.completeTaskMutex.Lock()
+	fake.completeTaskArgsForCall = append(fake.completeTaskArgsForCall, struct {
+		taskGuid      string
+		cellId        string
+		failed        bool
+		failureReason string
+		result        string
+	}{taskGuid, cellId, failed, failureReason, result})
+	fake.completeTaskMutex.Unlock()
+	if fake.CompleteTaskStub != nil {
+		return fake.CompleteTaskStub(taskGuid, cellId, failed, failureReason, result)
+	} else {
+		return fake.completeTaskReturns.result1
+	}
+}
+
+func (fake *FakeClient) CompleteTaskCallCount() int {
+	fake.completeTaskMutex.RLock()
+	defer fake.completeTaskMutex.RUnlock()
+	return len(fake.completeTaskArgsForCall)
+}
+
+func (fake *FakeClient) CompleteTaskArgsForCall(i int) (string, string, bool, string, string) {
+	fake.completeTaskMutex.RLock()
+	defer fake.completeTaskMutex.RUnlock()
+	return fake.completeTaskArgsForCall[i].taskGuid, fake.completeTaskArgsForCall[i].cellId, fake.completeTaskArgsForCall[i].failed, fake.completeTaskArgsForCall[i].failureReason, fake.completeTaskArgsForCall[i].result
+}
+
+func (fake *FakeClient) CompleteTaskReturns(result1 error) {
+	fake.CompleteTaskStub = nil
+	fake.completeTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ResolvingTask(taskGuid string) error {
+	fake.resolvingTaskMutex.Lock()
+	fake.resolvingTaskArgsForCall = append(fake.resolvingTaskArgsForCall, struct {
+		taskGuid string
+	}{taskGuid})
+	fake.resolvingTaskMutex.Unlock()
+	if fake.ResolvingTaskStub != nil {
+		return fake.ResolvingTaskStub(taskGuid)
+	} else {
+		return fake.resolvingTaskReturns.result1
+	}
+}
+
+func (fake *FakeClient) ResolvingTaskCallCount() int {
+	fake.resolvingTaskMutex.RLock()
+	defer fake.resolvingTaskMutex.RUnlock()
+	return len(fake.resolvingTaskArgsForCall)
+}
+
+func (fake *FakeClient) ResolvingTaskArgsForCall(i int) string {
+	fake.resolvingTaskMutex.RLock()
+	defer fake.resolvingTaskMutex.RUnlock()
+	return fake.resolvingTaskArgsForCall[i].taskGuid
+}
+
+func (fake *FakeClient) ResolvingTaskReturns(result1 error) {
+	fake.ResolvingTaskStub = nil
+	fake.resolvingTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ResolveTask(taskGuid string) error {
+	fake.resolveTaskMutex.Lock()
+	fake.resolveTaskArgsForCall = append(fake.resolveTaskArgsForCall, struct {
+		taskGuid string
+	}{taskGuid})
+	fake.resolveTaskMutex.Unlock()
+	if fake.ResolveTaskStub != nil {
+		return fake.ResolveTaskStub(taskGuid)
+	} else {
+		return fake.resolveTaskReturns.result1
+	}
+}
+
+func (fake *FakeClient) ResolveTaskCallCount() int {
+	fake.resolveTaskMutex.RLock()
+	defer fake.resolveTaskMutex.RUnlock()
+	return len(fake.resolveTaskArgsForCall)
+}
+
+func (fake *FakeClient) ResolveTaskArgsForCall(i int) string {
+	fake.resolveTaskMutex.RLock()
+	defer fake.resolveTaskMutex.RUnlock()
+	return fake.resolveTaskArgsForCall[i].taskGuid
+}
+
+func (fake *FakeClient) ResolveTaskReturns(result1 error) {
+	fake.ResolveTaskStub = nil
+	fake.resolveTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) Cells() ([]*models.CellPresence, error) {
+	fake.cellsMutex.Lock()
+	fake.cellsArgsForCall = append(fake.cellsArgsForCall, struct{}{})
+	fake.cellsMutex.Unlock()
+	if fake.CellsStub != nil {
+		return fake.CellsStub()
+	} else {
+		return fake.cellsReturns.result1, fake.cellsReturns.result2
+	}
+}
+
+func (fake *FakeClient) CellsCallCount() int {
+	fake.cellsMutex.RLock()
+	defer fake.cellsMutex.RUnlock()
+	return len(fake.cellsArgsForCall)
+}
+
+func (fake *FakeClient) CellsReturns(result1 []*models.CellPresence, result2 error) {
+	fake.CellsStub = nil
+	fake.cellsReturns = struct {
+		result1 []*models.CellPresence
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) SubscribeToEvents() (events.EventSource, error) {
 	fake.subscribeToEventsMutex.Lock()
 	fake.subscribeToEventsArgsForCall = append(fake.subscribeToEventsArgsForCall, struct{}{})
@@ -749,4 +1696,37 @@ func (fake *FakeClient) SubscribeToEventsReturns(result1 events.EventSource, res
 	}{result1, result2}
 }
 
+func (fake *FakeClient) SubscribeToEventsWithFilter(arg1 models.EventFilter) (events.EventSource, error) {
+	fake.subscribeToEventsWithFilterMutex.Lock()
+	fake.subscribeToEventsWithFilterArgsForCall = append(fake.subscribeToEventsWithFilterArgsForCall, struct {
+		arg1 models.EventFilter
+	}{arg1})
+	fake.subscribeToEventsWithFilterMutex.Unlock()
+	if fake.SubscribeToEventsWithFilterStub != nil {
+		return fake.SubscribeToEventsWithFilterStub(arg1)
+	} else {
+		return fake.subscribeToEventsWithFilterReturns.result1, fake.subscribeToEventsWithFilterReturns.result2
+	}
+}
+
+func (fake *FakeClient) SubscribeToEventsWithFilterCallCount() int {
+	fake.subscribeToEventsWithFilterMutex.RLock()
+	defer fake.subscribeToEventsWithFilterMutex.RUnlock()
+	return len(fake.subscribeToEventsWithFilterArgsForCall)
+}
+
+func (fake *FakeClient) SubscribeToEventsWithFilterArgsForCall(i int) models.EventFilter {
+	fake.subscribeToEventsWithFilterMutex.RLock()
+	defer fake.subscribeToEventsWithFilterMutex.RUnlock()
+	return fake.subscribeToEventsWithFilterArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) SubscribeToEventsWithFilterReturns(result1 events.EventSource, result2 error) {
+	fake.SubscribeToEventsWithFilterStub = nil
+	fake.subscribeToEventsWithFilterReturns = struct {
+		result1 events.EventSource
+		result2 error
+	}{result1, result2}
+}
+
 var _ bbs.Client = new(FakeClient)
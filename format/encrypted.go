@@ -0,0 +1,57 @@
+package format
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+)
+
+// An encrypted payload is laid out as
+//
+//	label length (1 byte) | key label | nonce length (1 byte) | nonce | ciphertext
+//
+// so that a record names the key it was encrypted with.
+
+var ErrTruncatedEncryptedPayload = errors.New("encrypted payload is truncated")
+
+func encodeEncrypted(encrypted encryption.Encrypted) []byte {
+	label := []byte(encrypted.KeyLabel)
+
+	data := make([]byte, 0, 2+len(label)+len(encrypted.Nonce)+len(encrypted.CipherText))
+	data = append(data, byte(len(label)))
+	data = append(data, label...)
+	data = append(data, byte(len(encrypted.Nonce)))
+	data = append(data, encrypted.Nonce...)
+	return append(data, encrypted.CipherText...)
+}
+
+func decodeEncrypted(data []byte) (encryption.Encrypted, error) {
+	label, data, err := readLengthPrefixed(data)
+	if err != nil {
+		return encryption.Encrypted{}, err
+	}
+
+	nonce, cipherText, err := readLengthPrefixed(data)
+	if err != nil {
+		return encryption.Encrypted{}, err
+	}
+
+	return encryption.Encrypted{
+		KeyLabel:   string(label),
+		Nonce:      nonce,
+		CipherText: cipherText,
+	}, nil
+}
+
+func readLengthPrefixed(data []byte) ([]byte, []byte, error) {
+	if len(data) < 1 {
+		return nil, nil, ErrTruncatedEncryptedPayload
+	}
+
+	length := int(data[0])
+	if len(data) < 1+length {
+		return nil, nil, ErrTruncatedEncryptedPayload
+	}
+
+	return data[1 : 1+length], data[1+length:], nil
+}
@@ -0,0 +1,62 @@
+package format
+
+import "fmt"
+
+// EnvelopeFormat identifies how the payload of a stored record is encoded.
+type EnvelopeFormat byte
+
+const (
+	JSON  EnvelopeFormat = 0
+	PROTO EnvelopeFormat = 1
+
+	// LEGACY_JSON writes unencrypted records as the bare JSON that predates
+	// envelopes, which every BBS can read. Encrypted records need an
+	// envelope, and are written as JSON ones.
+	LEGACY_JSON EnvelopeFormat = legacyJSONPrefix
+)
+
+// Version identifies the layout of the envelope itself.
+type Version byte
+
+const (
+	// V0 envelopes carry the payload as is.
+	V0 Version = 0
+	// V1 envelopes carry the payload encrypted, see encrypted.go.
+	V1 Version = 1
+)
+
+// EnvelopeOffset is the length of the envelope header: a format byte
+// followed by a version byte.
+const EnvelopeOffset int = 2
+
+// legacyJSONPrefix starts every record written before records were
+// enveloped, and never collides with a format byte.
+const legacyJSONPrefix = '{'
+
+func (f EnvelopeFormat) String() string {
+	switch f {
+	case JSON:
+		return "json"
+	case PROTO:
+		return "proto"
+	case LEGACY_JSON:
+		return "legacy"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(f))
+	}
+}
+
+// EnvelopeFormatFromString parses the format names accepted on the command
+// line.
+func EnvelopeFormatFromString(name string) (EnvelopeFormat, error) {
+	switch name {
+	case "json":
+		return JSON, nil
+	case "proto":
+		return PROTO, nil
+	case "legacy":
+		return LEGACY_JSON, nil
+	default:
+		return 0, fmt.Errorf("unknown storage format %q (must be legacy, json or proto)", name)
+	}
+}
@@ -0,0 +1,124 @@
+package format
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Model is a record the BBS stores.
+type Model interface {
+	proto.Message
+	Validate() error
+}
+
+// Serializer encodes records for storage. Marshal writes an envelope in the
+// serializer's format, encrypted when the serializer has a cryptor; Unmarshal
+// reads any envelope format and version, as well as the bare JSON records
+// that predate envelopes. Neither validates the record. Encrypted records are
+// bound to the key they are stored under, and fail to unmarshal under another.
+type Serializer interface {
+	Marshal(key string, model Model) ([]byte, error)
+	Unmarshal(key string, encoded []byte, model Model) error
+}
+
+var ErrEmptyPayload = errors.New("empty payload")
+var ErrNoCryptor = errors.New("record is encrypted but no encryption keys are configured")
+
+type serializer struct {
+	format  EnvelopeFormat
+	cryptor encryption.Cryptor
+}
+
+// NewSerializer writes records unencrypted when cryptor is nil.
+func NewSerializer(format EnvelopeFormat, cryptor encryption.Cryptor) Serializer {
+	return &serializer{format: format, cryptor: cryptor}
+}
+
+func (s *serializer) Marshal(key string, model Model) ([]byte, error) {
+	var payload []byte
+	var err error
+
+	switch s.format {
+	case JSON, LEGACY_JSON:
+		payload, err = json.Marshal(model)
+	case PROTO:
+		payload, err = proto.Marshal(model)
+	default:
+		err = fmt.Errorf("unknown envelope format %s", s.format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeFormat := s.format
+	if envelopeFormat == LEGACY_JSON {
+		if s.cryptor == nil {
+			return payload, nil
+		}
+		envelopeFormat = JSON
+	}
+
+	version := V0
+	if s.cryptor != nil {
+		encrypted, err := s.cryptor.Encrypt(payload, []byte(key))
+		if err != nil {
+			return nil, err
+		}
+		payload = encodeEncrypted(encrypted)
+		version = V1
+	}
+
+	encoded := make([]byte, EnvelopeOffset, EnvelopeOffset+len(payload))
+	encoded[0] = byte(envelopeFormat)
+	encoded[1] = byte(version)
+	return append(encoded, payload...), nil
+}
+
+func (s *serializer) Unmarshal(key string, encoded []byte, model Model) error {
+	if len(encoded) == 0 {
+		return ErrEmptyPayload
+	}
+
+	if encoded[0] == legacyJSONPrefix {
+		return json.Unmarshal(encoded, model)
+	}
+
+	if len(encoded) < EnvelopeOffset {
+		return fmt.Errorf("payload too short for an envelope (%d bytes)", len(encoded))
+	}
+
+	payload := encoded[EnvelopeOffset:]
+
+	switch version := Version(encoded[1]); version {
+	case V0:
+	case V1:
+		if s.cryptor == nil {
+			return ErrNoCryptor
+		}
+
+		encrypted, err := decodeEncrypted(payload)
+		if err != nil {
+			return err
+		}
+
+		payload, err = s.cryptor.Decrypt(encrypted, []byte(key))
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown envelope version %d", version)
+	}
+
+	switch EnvelopeFormat(encoded[0]) {
+	case JSON:
+		return json.Unmarshal(payload, model)
+	case PROTO:
+		return proto.Unmarshal(payload, model)
+	default:
+		return fmt.Errorf("unknown envelope format %s", EnvelopeFormat(encoded[0]))
+	}
+}
@@ -0,0 +1,245 @@
+package format_test
+
+import (
+	"crypto/rand"
+	"encoding/json"
+
+	"github.com/cloudfoundry-incubator/bbs/encryption"
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+	"github.com/gogo/protobuf/proto"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const recordKey = "/v1/desired/some-guid"
+
+var _ = Describe("Serializer", func() {
+	var desiredLRP *models.DesiredLRP
+
+	BeforeEach(func() {
+		desiredLRP = model_helpers.NewValidDesiredLRP("some-guid")
+	})
+
+	Describe("Marshal", func() {
+		It("writes a JSON envelope", func() {
+			encoded, err := format.NewSerializer(format.JSON, nil).Marshal(recordKey, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(encoded[0]).To(Equal(byte(format.JSON)))
+			Expect(encoded[1]).To(Equal(byte(format.V0)))
+
+			expected, err := json.Marshal(desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded[format.EnvelopeOffset:]).To(MatchJSON(expected))
+		})
+
+		It("writes a protobuf envelope", func() {
+			encoded, err := format.NewSerializer(format.PROTO, nil).Marshal(recordKey, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(encoded[0]).To(Equal(byte(format.PROTO)))
+			Expect(encoded[1]).To(Equal(byte(format.V0)))
+
+			expected, err := proto.Marshal(desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded[format.EnvelopeOffset:]).To(Equal(expected))
+		})
+
+		It("writes legacy records as bare JSON", func() {
+			encoded, err := format.NewSerializer(format.LEGACY_JSON, nil).Marshal(recordKey, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected, err := json.Marshal(desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded).To(MatchJSON(expected))
+		})
+
+		It("does not validate the record", func() {
+			invalid := &models.DesiredLRP{}
+			_, err := format.NewSerializer(format.PROTO, nil).Marshal(recordKey, invalid)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Unmarshal", func() {
+		var serializer format.Serializer
+
+		BeforeEach(func() {
+			serializer = format.NewSerializer(format.PROTO, nil)
+		})
+
+		It("reads JSON envelopes", func() {
+			encoded, err := format.NewSerializer(format.JSON, nil).Marshal(recordKey, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded models.DesiredLRP
+			Expect(serializer.Unmarshal(recordKey, encoded, &decoded)).To(Succeed())
+			Expect(&decoded).To(Equal(desiredLRP))
+		})
+
+		It("reads protobuf envelopes", func() {
+			encoded, err := format.NewSerializer(format.PROTO, nil).Marshal(recordKey, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded models.DesiredLRP
+			Expect(serializer.Unmarshal(recordKey, encoded, &decoded)).To(Succeed())
+			Expect(&decoded).To(Equal(desiredLRP))
+		})
+
+		It("reads tasks without going through JSON", func() {
+			task := model_helpers.NewValidTask("some-task-guid")
+			encoded, err := serializer.Marshal(recordKey, task)
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded models.Task
+			Expect(serializer.Unmarshal(recordKey, encoded, &decoded)).To(Succeed())
+			Expect(&decoded).To(Equal(task))
+		})
+
+		It("reads records stored as bare JSON before envelopes", func() {
+			legacy, err := json.Marshal(desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded models.DesiredLRP
+			Expect(serializer.Unmarshal(recordKey, legacy, &decoded)).To(Succeed())
+			Expect(&decoded).To(Equal(desiredLRP))
+		})
+
+		It("fails on an empty payload", func() {
+			var decoded models.DesiredLRP
+			Expect(serializer.Unmarshal(recordKey, []byte{}, &decoded)).To(Equal(format.ErrEmptyPayload))
+		})
+
+		It("fails on an unknown envelope version", func() {
+			var decoded models.DesiredLRP
+			Expect(serializer.Unmarshal(recordKey, []byte{byte(format.PROTO), 42}, &decoded)).NotTo(Succeed())
+		})
+
+		It("fails on an unknown envelope format", func() {
+			var decoded models.DesiredLRP
+			Expect(serializer.Unmarshal(recordKey, []byte{42, byte(format.V0)}, &decoded)).NotTo(Succeed())
+		})
+
+		It("fails on a corrupt payload", func() {
+			var decoded models.DesiredLRP
+			Expect(serializer.Unmarshal(recordKey, []byte("ßßßßßß"), &decoded)).NotTo(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Serializer with encryption", func() {
+	var (
+		desiredLRP *models.DesiredLRP
+		keyManager encryption.KeyManager
+		serializer format.Serializer
+	)
+
+	BeforeEach(func() {
+		desiredLRP = model_helpers.NewValidDesiredLRP("some-guid")
+
+		var err error
+		keyManager, err = encryption.KeyConfig{
+			ActiveKeyLabel: "active",
+			Keys:           map[string]string{"active": "a", "old": "b"},
+		}.KeyManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		serializer = format.NewSerializer(format.PROTO, encryption.NewCryptor(keyManager, rand.Reader))
+	})
+
+	It("writes an encrypted envelope that names the active key", func() {
+		encoded, err := serializer.Marshal(recordKey, desiredLRP)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(encoded[0]).To(Equal(byte(format.PROTO)))
+		Expect(encoded[1]).To(Equal(byte(format.V1)))
+		Expect(encoded[2]).To(Equal(byte(len("active"))))
+		Expect(string(encoded[3:9])).To(Equal("active"))
+		Expect(string(encoded)).NotTo(ContainSubstring(desiredLRP.ProcessGuid))
+	})
+
+	It("writes legacy records in an encrypted JSON envelope", func() {
+		encoded, err := format.NewSerializer(format.LEGACY_JSON, encryption.NewCryptor(keyManager, rand.Reader)).Marshal(recordKey, desiredLRP)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(encoded[0]).To(Equal(byte(format.JSON)))
+		Expect(encoded[1]).To(Equal(byte(format.V1)))
+
+		var decoded models.DesiredLRP
+		Expect(serializer.Unmarshal(recordKey, encoded, &decoded)).To(Succeed())
+		Expect(&decoded).To(Equal(desiredLRP))
+	})
+
+	It("reads what it writes", func() {
+		encoded, err := serializer.Marshal(recordKey, desiredLRP)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded models.DesiredLRP
+		Expect(serializer.Unmarshal(recordKey, encoded, &decoded)).To(Succeed())
+		Expect(&decoded).To(Equal(desiredLRP))
+	})
+
+	It("reads records encrypted with a key that is no longer active", func() {
+		oldKeyManager, err := encryption.KeyConfig{
+			ActiveKeyLabel: "old",
+			Keys:           map[string]string{"old": "b"},
+		}.KeyManager()
+		Expect(err).NotTo(HaveOccurred())
+		oldSerializer := format.NewSerializer(format.JSON, encryption.NewCryptor(oldKeyManager, rand.Reader))
+
+		encoded, err := oldSerializer.Marshal(recordKey, desiredLRP)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded models.DesiredLRP
+		Expect(serializer.Unmarshal(recordKey, encoded, &decoded)).To(Succeed())
+		Expect(&decoded).To(Equal(desiredLRP))
+	})
+
+	It("reads unencrypted records", func() {
+		encoded, err := format.NewSerializer(format.PROTO, nil).Marshal(recordKey, desiredLRP)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded models.DesiredLRP
+		Expect(serializer.Unmarshal(recordKey, encoded, &decoded)).To(Succeed())
+		Expect(&decoded).To(Equal(desiredLRP))
+	})
+
+	It("cannot be read without keys", func() {
+		encoded, err := serializer.Marshal(recordKey, desiredLRP)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded models.DesiredLRP
+		err = format.NewSerializer(format.PROTO, nil).Unmarshal(recordKey, encoded, &decoded)
+		Expect(err).To(Equal(format.ErrNoCryptor))
+	})
+
+	It("cannot be read under another key", func() {
+		encoded, err := serializer.Marshal(recordKey, desiredLRP)
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded models.DesiredLRP
+		Expect(serializer.Unmarshal("/v1/desired/other-guid", encoded, &decoded)).NotTo(Succeed())
+	})
+
+	It("fails on a truncated encrypted payload", func() {
+		var decoded models.DesiredLRP
+		err := serializer.Unmarshal(recordKey, []byte{byte(format.PROTO), byte(format.V1), 6, 'a'}, &decoded)
+		Expect(err).To(Equal(format.ErrTruncatedEncryptedPayload))
+	})
+})
+
+var _ = Describe("EnvelopeFormatFromString", func() {
+	It("parses the known formats", func() {
+		Expect(format.EnvelopeFormatFromString("json")).To(Equal(format.JSON))
+		Expect(format.EnvelopeFormatFromString("proto")).To(Equal(format.PROTO))
+		Expect(format.EnvelopeFormatFromString("legacy")).To(Equal(format.LEGACY_JSON))
+	})
+
+	It("rejects unknown formats", func() {
+		_, err := format.EnvelopeFormatFromString("xml")
+		Expect(err).To(HaveOccurred())
+	})
+})
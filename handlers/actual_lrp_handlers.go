@@ -28,15 +28,26 @@ func (h *ActualLRPHandler) ActualLRPGroups(w http.ResponseWriter, req *http.Requ
 		"domain": domain, "cell_id": cellId,
 	})
 
-	filter := models.ActualLRPFilter{Domain: domain, CellID: cellId}
-	actualLRPGroups, err := h.db.ActualLRPGroups(h.logger, filter)
+	p, err := parsePage(req)
 	if err != nil {
-		logger.Error("failed-to-fetch-actual-lrp-groups", err)
-		writeUnknownErrorResponse(w, err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
 		return
 	}
 
-	writeProtoResponse(w, http.StatusOK, actualLRPGroups)
+	filter, err := p.actualLRPFilter(models.ActualLRPFilter{Domain: domain, CellID: cellId})
+	if err != nil {
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return
+	}
+
+	actualLRPGroups, bbsErr := h.db.ActualLRPGroups(h.logger, filter)
+	if bbsErr != nil {
+		logger.Error("failed-to-fetch-actual-lrp-groups", bbsErr)
+		writeUnknownErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeProtoResponse(w, http.StatusOK, pageActualLRPGroups(actualLRPGroups, p))
 }
 
 func (h *ActualLRPHandler) ActualLRPGroupsByProcessGuid(w http.ResponseWriter, req *http.Request) {
@@ -86,5 +97,9 @@ func (h *ActualLRPHandler) ActualLRPGroupByProcessGuidAndIndex(w http.ResponseWr
 		return
 	}
 
+	if notModified(w, req, actualLRPGroupETag(actualLRPGroup)) {
+		return
+	}
+
 	writeProtoResponse(w, http.StatusOK, actualLRPGroup)
 }
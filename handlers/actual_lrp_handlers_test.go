@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"encoding/base64"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -82,7 +83,7 @@ var _ = Describe("ActualLRP Handlers", func() {
 
 			BeforeEach(func() {
 				actualLRPGroups = &models.ActualLRPGroups{
-					[]*models.ActualLRPGroup{
+					ActualLrpGroups: []*models.ActualLRPGroup{
 						{Instance: &actualLRP1},
 						{Instance: &actualLRP2, Evacuating: &evacuatingLRP2},
 					},
@@ -124,6 +125,70 @@ var _ = Describe("ActualLRP Handlers", func() {
 				})
 			})
 
+			Context("and paginating", func() {
+				var actualLRP10 models.ActualLRP
+
+				BeforeEach(func() {
+					actualLRP10 = actualLRP1
+					actualLRP10.Index = 10
+
+					actualLRPGroups.ActualLrpGroups = []*models.ActualLRPGroup{
+						{Instance: &actualLRP1},
+						{Instance: &actualLRP10},
+						{Instance: &actualLRP2, Evacuating: &evacuatingLRP2},
+					}
+
+					var err error
+					request, err = http.NewRequest("", "http://example.com?limit=2", nil)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("asks the DB for one group more than the page holds", func() {
+					_, filter := fakeActualLRPDB.ActualLRPGroupsArgsForCall(0)
+					Expect(filter).To(Equal(models.ActualLRPFilter{Limit: 3}))
+				})
+
+				It("returns the first page with a continuation token", func() {
+					response := &models.ActualLRPGroups{}
+					err := response.Unmarshal(responseRecorder.Body.Bytes())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(response.ActualLrpGroups).To(Equal([]*models.ActualLRPGroup{
+						{Instance: &actualLRP1},
+						{Instance: &actualLRP10},
+					}))
+					Expect(response.ContinuationToken).NotTo(BeEmpty())
+				})
+
+				Context("with a continuation token", func() {
+					BeforeEach(func() {
+						var err error
+						request, err = http.NewRequest("", "http://example.com?limit=2&continuation_token="+
+							base64.URLEncoding.EncodeToString([]byte("process-guid-0\x0010")), nil)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("asks the DB for the groups after it", func() {
+						_, filter := fakeActualLRPDB.ActualLRPGroupsArgsForCall(0)
+						Expect(filter).To(Equal(models.ActualLRPFilter{Limit: 3, AfterProcessGuid: "process-guid-0", AfterIndex: 10}))
+					})
+				})
+
+				Context("with a continuation token that names no group", func() {
+					BeforeEach(func() {
+						var err error
+						request, err = http.NewRequest("", "http://example.com?limit=2&continuation_token="+
+							base64.URLEncoding.EncodeToString([]byte("process-guid-0")), nil)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("responds with 400 Bad Request", func() {
+						Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+						Expect(fakeActualLRPDB.ActualLRPGroupsCallCount()).To(Equal(0))
+					})
+				})
+			})
+
 			Context("and filtering by cellId", func() {
 				BeforeEach(func() {
 					var err error
@@ -241,7 +306,7 @@ var _ = Describe("ActualLRP Handlers", func() {
 
 			BeforeEach(func() {
 				actualLRPGroups = &models.ActualLRPGroups{
-					[]*models.ActualLRPGroup{
+					ActualLrpGroups: []*models.ActualLRPGroup{
 						{Instance: &actualLRP1},
 						{Instance: &actualLRP2, Evacuating: &evacuatingLRP2},
 					},
@@ -395,6 +460,60 @@ var _ = Describe("ActualLRP Handlers", func() {
 
 					Expect(response).To(Equal(actualLRPGroup))
 				})
+
+				Context("when both LRPs have modification tags", func() {
+					BeforeEach(func() {
+						actualLRP2.ModificationTag = models.ModificationTag{Epoch: "instance-epoch", Index: 2}
+						evacuatingLRP2.ModificationTag = models.ModificationTag{Epoch: "evacuating-epoch", Index: 5}
+					})
+
+					It("includes both modification tags in the ETag", func() {
+						Expect(responseRecorder.Header().Get("ETag")).To(Equal(`"instance-epoch:2+evacuating-epoch:5"`))
+					})
+				})
+			})
+
+			Context("when there is only an evacuating LRP", func() {
+				BeforeEach(func() {
+					evacuatingLRP2.ModificationTag = models.ModificationTag{Epoch: "evacuating-epoch", Index: 5}
+					actualLRPGroup = &models.ActualLRPGroup{Evacuating: &evacuatingLRP2}
+					fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexReturns(actualLRPGroup, nil)
+				})
+
+				It("leaves the instance part of the ETag empty", func() {
+					Expect(responseRecorder.Header().Get("ETag")).To(Equal(`"+evacuating-epoch:5"`))
+				})
+			})
+
+			Context("when the instance has a modification tag", func() {
+				BeforeEach(func() {
+					actualLRP1.ModificationTag = models.ModificationTag{Epoch: "some-epoch", Index: 7}
+				})
+
+				It("sets the ETag header from the modification tag", func() {
+					Expect(responseRecorder.Header().Get("ETag")).To(Equal(`"some-epoch:7"`))
+				})
+
+				Context("when If-None-Match names the current ETag", func() {
+					BeforeEach(func() {
+						request.Header.Set("If-None-Match", `W/"some-epoch:7"`)
+					})
+
+					It("responds with 304 Not Modified and no body", func() {
+						Expect(responseRecorder.Code).To(Equal(http.StatusNotModified))
+						Expect(responseRecorder.Body.Len()).To(BeZero())
+					})
+				})
+
+				Context("when If-None-Match names an older ETag", func() {
+					BeforeEach(func() {
+						request.Header.Set("If-None-Match", `"some-epoch:6"`)
+					})
+
+					It("responds with 200 Status OK", func() {
+						Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+					})
+				})
 			})
 		})
 
@@ -33,7 +33,7 @@ func (h *ActualLRPLifecycleHandler) ClaimActualLRP(w http.ResponseWriter, req *h
 	}
 
 	request := &models.ClaimActualLRPRequest{}
-	err = request.Unmarshal(data)
+	err = unmarshalRequest(req, data, request)
 	if err != nil {
 		logger.Error("failed-to-parse-request-body", err)
 		writeBadRequestResponse(w, models.InvalidRequest, err)
@@ -46,12 +46,24 @@ func (h *ActualLRPLifecycleHandler) ClaimActualLRP(w http.ResponseWriter, req *h
 		return
 	}
 
+	if request.ExpectedModificationTag == nil {
+		request.ExpectedModificationTag, err = parseIfMatch(req)
+		if err != nil {
+			logger.Error("invalid-if-match", err)
+			writeBadRequestResponse(w, models.InvalidRequest, err)
+			return
+		}
+	}
+
 	actualLRP, bbsErr := h.db.ClaimActualLRP(logger, request)
 	if bbsErr != nil {
 		logger.Error("failed-to-claim-actual-lrp", bbsErr)
-		if bbsErr.Equal(models.ErrResourceNotFound) {
+		switch bbsErr.Type {
+		case models.ResourceNotFound:
 			writeNotFoundResponse(w, bbsErr)
-		} else {
+		case models.ResourceConflict:
+			writeConflictResponse(w, bbsErr)
+		default:
 			writeUnknownErrorResponse(w, bbsErr)
 		}
 		return
@@ -71,7 +83,7 @@ func (h *ActualLRPLifecycleHandler) StartActualLRP(w http.ResponseWriter, req *h
 	}
 
 	request := &models.StartActualLRPRequest{}
-	err = request.Unmarshal(data)
+	err = unmarshalRequest(req, data, request)
 	if err != nil {
 		logger.Error("failed-to-parse-request-body", err)
 		writeBadRequestResponse(w, models.InvalidRequest, err)
@@ -84,12 +96,24 @@ func (h *ActualLRPLifecycleHandler) StartActualLRP(w http.ResponseWriter, req *h
 		return
 	}
 
+	if request.ExpectedModificationTag == nil {
+		request.ExpectedModificationTag, err = parseIfMatch(req)
+		if err != nil {
+			logger.Error("invalid-if-match", err)
+			writeBadRequestResponse(w, models.InvalidRequest, err)
+			return
+		}
+	}
+
 	actualLRP, bbsErr := h.db.StartActualLRP(logger, request)
 	if bbsErr != nil {
 		logger.Error("failed-to-start-actual-lrp", bbsErr)
-		if bbsErr.Equal(models.ErrResourceNotFound) {
+		switch bbsErr.Type {
+		case models.ResourceNotFound:
 			writeNotFoundResponse(w, bbsErr)
-		} else {
+		case models.ResourceConflict:
+			writeConflictResponse(w, bbsErr)
+		default:
 			writeUnknownErrorResponse(w, bbsErr)
 		}
 		return
@@ -109,7 +133,7 @@ func (h *ActualLRPLifecycleHandler) CrashActualLRP(w http.ResponseWriter, req *h
 	}
 
 	request := &models.CrashActualLRPRequest{}
-	err = request.Unmarshal(data)
+	err = unmarshalRequest(req, data, request)
 	if err != nil {
 		logger.Error("crashed-to-parse-request-body", err)
 		writeBadRequestResponse(w, models.InvalidRequest, err)
@@ -147,7 +171,7 @@ func (h *ActualLRPLifecycleHandler) FailActualLRP(w http.ResponseWriter, req *ht
 	}
 
 	request := &models.FailActualLRPRequest{}
-	err = request.Unmarshal(data)
+	err = unmarshalRequest(req, data, request)
 	if err != nil {
 		logger.Error("failed-to-parse-request-body", err)
 		writeBadRequestResponse(w, models.InvalidRequest, err)
@@ -214,7 +238,7 @@ func (h *ActualLRPLifecycleHandler) RetireActualLRP(w http.ResponseWriter, req *
 	}
 
 	request := &models.RetireActualLRPRequest{}
-	err = request.Unmarshal(data)
+	err = unmarshalRequest(req, data, request)
 	if err != nil {
 		logger.Error("failed-to-parse-request-body", err)
 		writeBadRequestResponse(w, models.InvalidRequest, err)
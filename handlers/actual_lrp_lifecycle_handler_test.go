@@ -39,6 +39,7 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 			index       int32 = 1
 			instanceKey models.ActualLRPInstanceKey
 			requestBody interface{}
+			ifMatch     string
 		)
 
 		BeforeEach(func() {
@@ -61,10 +62,14 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 				State: models.ActualLRPStateUnclaimed,
 				Since: 1138,
 			}
+			ifMatch = ""
 		})
 
 		JustBeforeEach(func() {
 			request = newTestRequest(requestBody)
+			if ifMatch != "" {
+				request.Header.Set("If-Match", ifMatch)
+			}
 			handler.ClaimActualLRP(responseRecorder, request)
 		})
 
@@ -98,6 +103,28 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 			})
 		})
 
+		Context("when the request has an If-Match header", func() {
+			BeforeEach(func() {
+				ifMatch = `"some-epoch:4+evacuating-epoch:1"`
+			})
+
+			It("makes the claim conditional on the instance's modification tag", func() {
+				Expect(fakeActualLRPDB.ClaimActualLRPCallCount()).To(Equal(1))
+				_, request := fakeActualLRPDB.ClaimActualLRPArgsForCall(0)
+				Expect(request.ExpectedModificationTag).To(Equal(&models.ModificationTag{Epoch: "some-epoch", Index: 4}))
+			})
+		})
+
+		Context("when the modification tag does not match", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.ClaimActualLRPReturns(nil, models.ErrResourceConflict)
+			})
+
+			It("responds with 409 Conflict", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
 		Context("when the request is invalid", func() {
 			BeforeEach(func() {
 				requestBody = &models.ClaimActualLRPRequest{}
@@ -150,6 +177,7 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 			netInfo     models.ActualLRPNetInfo
 
 			requestBody interface{}
+			ifMatch     string
 		)
 
 		BeforeEach(func() {
@@ -174,10 +202,14 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 				State:        models.ActualLRPStateUnclaimed,
 				Since:        1138,
 			}
+			ifMatch = ""
 		})
 
 		JustBeforeEach(func() {
 			request = newTestRequest(requestBody)
+			if ifMatch != "" {
+				request.Header.Set("If-Match", ifMatch)
+			}
 			handler.StartActualLRP(responseRecorder, request)
 		})
 
@@ -207,6 +239,51 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 			})
 		})
 
+		Context("when the request has an If-Match header", func() {
+			BeforeEach(func() {
+				ifMatch = `"some-epoch:4"`
+			})
+
+			It("makes the start conditional on the modification tag", func() {
+				Expect(fakeActualLRPDB.StartActualLRPCallCount()).To(Equal(1))
+				_, actualRequest := fakeActualLRPDB.StartActualLRPArgsForCall(0)
+				Expect(actualRequest.ExpectedModificationTag).To(Equal(&models.ModificationTag{Epoch: "some-epoch", Index: 4}))
+			})
+		})
+
+		Context("when the If-Match header names a group with only an evacuating LRP", func() {
+			BeforeEach(func() {
+				ifMatch = `"+evacuating-epoch:5"`
+			})
+
+			It("makes the start conditional on there being no instance", func() {
+				Expect(fakeActualLRPDB.StartActualLRPCallCount()).To(Equal(1))
+				_, actualRequest := fakeActualLRPDB.StartActualLRPArgsForCall(0)
+				Expect(actualRequest.ExpectedModificationTag).To(Equal(&models.ModificationTag{}))
+			})
+		})
+
+		Context("when the If-Match header is malformed", func() {
+			BeforeEach(func() {
+				ifMatch = `"some-epoch"`
+			})
+
+			It("responds with 400 Bad Request", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeActualLRPDB.StartActualLRPCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the modification tag does not match", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.StartActualLRPReturns(nil, models.ErrResourceConflict)
+			})
+
+			It("responds with 409 Conflict", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
 		Context("when the request is invalid", func() {
 			BeforeEach(func() {
 				requestBody = &models.StartActualLRPRequest{}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+// requestScope is what a request modifies. The domain a request names is only
+// trusted for records that do not exist yet; otherwise the stored record's
+// domain is looked up before the request is authorized.
+type requestScope struct {
+	domain      string
+	cellID      string
+	processGuid string
+	index       int32
+	actualLRP   bool
+	taskGuid    string
+}
+
+type scopeParser func(req *http.Request, data []byte) (requestScope, error)
+
+// scopedRoutes are checked against the domains and cell of the caller as well
+// as its routes. Any other route is only checked against the caller's routes.
+var scopedRoutes = map[string]scopeParser{
+	bbs.UpsertDomainRoute: func(req *http.Request, _ []byte) (requestScope, error) {
+		return requestScope{domain: req.FormValue(":domain")}, nil
+	},
+
+	bbs.ClaimActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.ClaimActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return requestScope{
+			processGuid: request.ProcessGuid,
+			index:       request.Index,
+			actualLRP:   true,
+			cellID:      request.ActualLrpInstanceKey.GetCellId(),
+		}, err
+	},
+	bbs.StartActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.StartActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, request.ActualLrpInstanceKey), err
+	},
+	bbs.CrashActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.CrashActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, request.ActualLrpInstanceKey), err
+	},
+	bbs.FailActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.FailActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, nil), err
+	},
+	bbs.RetireActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.RetireActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, nil), err
+	},
+	bbs.RemoveActualLRPRoute: func(req *http.Request, _ []byte) (requestScope, error) {
+		index, err := strconv.ParseInt(req.FormValue(":index"), 10, 32)
+		return requestScope{processGuid: req.FormValue(":process_guid"), index: int32(index), actualLRP: true}, err
+	},
+
+	bbs.EvacuateClaimedActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.EvacuateClaimedActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, request.ActualLrpInstanceKey), err
+	},
+	bbs.EvacuateRunningActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.EvacuateRunningActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, request.ActualLrpInstanceKey), err
+	},
+	bbs.EvacuateStoppedActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.EvacuateStoppedActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, request.ActualLrpInstanceKey), err
+	},
+	bbs.EvacuateCrashedActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.EvacuateCrashedActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, request.ActualLrpInstanceKey), err
+	},
+	bbs.RemoveEvacuatingActualLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.RemoveEvacuatingActualLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return actualLRPScope(request.ActualLrpKey, request.ActualLrpInstanceKey), err
+	},
+
+	bbs.DesireDesiredLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		desiredLRP := &models.DesiredLRP{}
+		err := unmarshalRequest(req, data, desiredLRP)
+		return requestScope{domain: desiredLRP.Domain, processGuid: desiredLRP.ProcessGuid}, err
+	},
+	bbs.UpdateDesiredLRPRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.UpdateDesiredLRPRequest{}
+		err := unmarshalRequest(req, data, request)
+		return requestScope{processGuid: request.ProcessGuid}, err
+	},
+	bbs.RemoveDesiredLRPRoute: func(req *http.Request, _ []byte) (requestScope, error) {
+		return requestScope{processGuid: req.FormValue(":process_guid")}, nil
+	},
+
+	bbs.DesireTaskRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		task := &models.Task{}
+		err := unmarshalRequest(req, data, task)
+		return requestScope{domain: task.Domain, taskGuid: task.TaskGuid}, err
+	},
+	bbs.StartTaskRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.StartTaskRequest{}
+		err := unmarshalRequest(req, data, request)
+		return requestScope{taskGuid: request.TaskGuid, cellID: request.CellId}, err
+	},
+	bbs.CancelTaskRoute:    taskGuidScope,
+	bbs.ResolvingTaskRoute: taskGuidScope,
+	bbs.ResolveTaskRoute:   taskGuidScope,
+	bbs.FailTaskRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.FailTaskRequest{}
+		err := unmarshalRequest(req, data, request)
+		return requestScope{taskGuid: request.TaskGuid}, err
+	},
+	bbs.CompleteTaskRoute: func(req *http.Request, data []byte) (requestScope, error) {
+		request := &models.CompleteTaskRequest{}
+		err := unmarshalRequest(req, data, request)
+		return requestScope{taskGuid: request.TaskGuid, cellID: request.CellId}, err
+	},
+}
+
+func actualLRPScope(key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) requestScope {
+	return requestScope{
+		domain:      key.GetDomain(),
+		processGuid: key.GetProcessGuid(),
+		index:       key.GetIndex(),
+		actualLRP:   key != nil,
+		cellID:      instanceKey.GetCellId(),
+	}
+}
+
+func taskGuidScope(req *http.Request, data []byte) (requestScope, error) {
+	request := &models.TaskGuidRequest{}
+	err := unmarshalRequest(req, data, request)
+	return requestScope{taskGuid: request.TaskGuid}, err
+}
+
+var errUnknownClient = errors.New("client could not be identified")
+var errRouteNotAllowed = errors.New("client may not call this route")
+var errDomainNotAllowed = errors.New("client may not modify this domain")
+var errDomainMismatch = errors.New("request names a domain other than the stored record's")
+var errCellNotAllowed = errors.New("client may not act on behalf of this cell")
+
+type Authorizer struct {
+	logger       lager.Logger
+	policy       *authorization.Policy
+	actualLRPDB  db.ActualLRPDB
+	desiredLRPDB db.DesiredLRPDB
+	taskDB       db.TaskDB
+}
+
+func NewAuthorizer(logger lager.Logger, policy *authorization.Policy, actualLRPDB db.ActualLRPDB, desiredLRPDB db.DesiredLRPDB, taskDB db.TaskDB) *Authorizer {
+	return &Authorizer{
+		logger:       logger.Session("authorizer"),
+		policy:       policy,
+		actualLRPDB:  actualLRPDB,
+		desiredLRPDB: desiredLRPDB,
+		taskDB:       taskDB,
+	}
+}
+
+// Authorize wraps the handler for the named route so that it only serves
+// requests from clients the policy allows.
+func (a *Authorizer) Authorize(routeName string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w = negotiateResponseWriter(w, req)
+
+		client, found := a.policy.Authenticate(req)
+		if !found {
+			a.logger.Info("unknown-client", lager.Data{"route": routeName})
+			writeUnauthorizedResponse(w, errUnknownClient)
+			return
+		}
+		logger := a.logger.Session("authorize", lager.Data{"route": routeName, "client": client.Name})
+
+		if !client.AllowsRoute(routeName) {
+			logger.Info("route-not-allowed")
+			writeForbiddenResponse(w, errRouteNotAllowed)
+			return
+		}
+
+		parseScope, scoped := scopedRoutes[routeName]
+		if !scoped || (!client.RestrictsDomains() && client.CellID == "") {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		data, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			logger.Error("failed-to-read-body", err)
+			writeUnknownErrorResponse(w, err)
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+		scope, err := parseScope(req, data)
+		if err != nil {
+			// leave rejecting the malformed request to the handler
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		if !client.AllowsCell(scope.cellID) {
+			logger.Info("cell-not-allowed", lager.Data{"cell-id": scope.cellID})
+			writeForbiddenResponse(w, errCellNotAllowed)
+			return
+		}
+
+		if client.RestrictsDomains() {
+			domain, found, bbsErr := a.storedDomain(logger, scope)
+			if bbsErr != nil {
+				writeUnknownErrorResponse(w, bbsErr)
+				return
+			}
+
+			if !found {
+				domain = scope.domain
+			} else if scope.domain != "" && scope.domain != domain {
+				logger.Info("domain-mismatch", lager.Data{"domain": scope.domain, "stored-domain": domain})
+				writeForbiddenResponse(w, errDomainMismatch)
+				return
+			}
+
+			if !client.AllowsDomain(domain) {
+				logger.Info("domain-not-allowed", lager.Data{"domain": domain})
+				writeForbiddenResponse(w, errDomainNotAllowed)
+				return
+			}
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// storedDomain returns the domain of the record the request modifies, and
+// whether there is one. An actual LRP without a record of its own falls back
+// to its desired LRP.
+func (a *Authorizer) storedDomain(logger lager.Logger, scope requestScope) (string, bool, *models.Error) {
+	switch {
+	case scope.actualLRP:
+		group, bbsErr := a.actualLRPDB.ActualLRPGroupByProcessGuidAndIndex(logger, scope.processGuid, scope.index)
+		if bbsErr == nil {
+			actualLRP, _ := group.Resolve()
+			return actualLRP.Domain, true, nil
+		}
+		if !bbsErr.Equal(models.ErrResourceNotFound) {
+			logger.Error("failed-to-fetch-actual-lrp", bbsErr)
+			return "", false, bbsErr
+		}
+		fallthrough
+
+	case scope.processGuid != "":
+		desiredLRP, bbsErr := a.desiredLRPDB.DesiredLRPByProcessGuid(logger, scope.processGuid)
+		if bbsErr != nil {
+			if bbsErr.Equal(models.ErrResourceNotFound) {
+				return "", false, nil
+			}
+			logger.Error("failed-to-fetch-desired-lrp", bbsErr)
+			return "", false, bbsErr
+		}
+		return desiredLRP.Domain, true, nil
+
+	case scope.taskGuid != "":
+		task, bbsErr := a.taskDB.TaskByGuid(logger, scope.taskGuid)
+		if bbsErr != nil {
+			if bbsErr.Equal(models.ErrResourceNotFound) {
+				return "", false, nil
+			}
+			logger.Error("failed-to-fetch-task", bbsErr)
+			return "", false, bbsErr
+		}
+		return task.Domain, true, nil
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,376 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager"
+)
+
+var _ = Describe("Authorizer", func() {
+	var (
+		logger           lager.Logger
+		fakeActualLRPDB  *fakes.FakeActualLRPDB
+		fakeDesiredLRPDB *fakes.FakeDesiredLRPDB
+		fakeTaskDB       *fakes.FakeTaskDB
+		responseRecorder *httptest.ResponseRecorder
+		authorizer       *handlers.Authorizer
+
+		routeName   string
+		token       string
+		requestBody interface{}
+		served      bool
+	)
+
+	BeforeEach(func() {
+		logger = lager.NewLogger("test")
+		logger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		fakeActualLRPDB = new(fakes.FakeActualLRPDB)
+		fakeDesiredLRPDB = new(fakes.FakeDesiredLRPDB)
+		fakeTaskDB = new(fakes.FakeTaskDB)
+		fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexReturns(nil, models.ErrResourceNotFound)
+		fakeDesiredLRPDB.DesiredLRPByProcessGuidReturns(nil, models.ErrResourceNotFound)
+		fakeTaskDB.TaskByGuidReturns(nil, models.ErrResourceNotFound)
+		responseRecorder = httptest.NewRecorder()
+		served = false
+
+		policy, err := authorization.NewPolicy([]authorization.Client{
+			{
+				Name:   "cell",
+				Token:  "cell-token",
+				CellID: "cell-a",
+				Routes: []string{bbs.StartActualLRPRoute, bbs.StartTaskRoute},
+			},
+			{
+				Name:    "scheduler",
+				Token:   "scheduler-token",
+				Domains: []string{"scheduler-domain"},
+				Routes: []string{
+					bbs.DesireTaskRoute,
+					bbs.CancelTaskRoute,
+					bbs.DesireDesiredLRPRoute,
+					bbs.UpdateDesiredLRPRoute,
+					bbs.RetireActualLRPRoute,
+				},
+			},
+			{
+				Name:   "admin",
+				Token:  "admin-token",
+				Routes: []string{authorization.AllRoutes},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		authorizer = handlers.NewAuthorizer(logger, policy, fakeActualLRPDB, fakeDesiredLRPDB, fakeTaskDB)
+	})
+
+	JustBeforeEach(func() {
+		handler := authorizer.Authorize(routeName, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			served = true
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		request := newTestRequest(requestBody)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		handler.ServeHTTP(responseRecorder, request)
+	})
+
+	expectUnauthorized := func(statusCode int) {
+		Expect(served).To(BeFalse())
+		Expect(responseRecorder.Code).To(Equal(statusCode))
+
+		bbsErr := &models.Error{}
+		err := proto.Unmarshal(responseRecorder.Body.Bytes(), bbsErr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bbsErr.Type).To(Equal(models.Unauthorized))
+	}
+
+	Context("when the client cannot be identified", func() {
+		BeforeEach(func() {
+			routeName = bbs.TasksRoute
+			token = "bogus-token"
+			requestBody = ""
+		})
+
+		It("responds with 401 Unauthorized", func() {
+			expectUnauthorized(http.StatusUnauthorized)
+		})
+	})
+
+	Context("when the client may not call the route", func() {
+		BeforeEach(func() {
+			routeName = bbs.RetireActualLRPRoute
+			token = "cell-token"
+			requestBody = &models.RetireActualLRPRequest{
+				ActualLrpKey: &models.ActualLRPKey{ProcessGuid: "process-guid", Domain: "domain"},
+			}
+		})
+
+		It("responds with 403 Forbidden", func() {
+			expectUnauthorized(http.StatusForbidden)
+		})
+	})
+
+	Context("when the client may call every route", func() {
+		BeforeEach(func() {
+			routeName = bbs.RetireActualLRPRoute
+			token = "admin-token"
+			requestBody = &models.RetireActualLRPRequest{
+				ActualLrpKey: &models.ActualLRPKey{ProcessGuid: "process-guid", Domain: "domain"},
+			}
+		})
+
+		It("serves the request", func() {
+			Expect(served).To(BeTrue())
+		})
+	})
+
+	Describe("cell clients", func() {
+		BeforeEach(func() {
+			routeName = bbs.StartActualLRPRoute
+			token = "cell-token"
+		})
+
+		Context("when the request is for the client's cell", func() {
+			BeforeEach(func() {
+				requestBody = &models.StartActualLRPRequest{
+					ActualLrpKey:         &models.ActualLRPKey{ProcessGuid: "process-guid", Domain: "domain"},
+					ActualLrpInstanceKey: &models.ActualLRPInstanceKey{InstanceGuid: "instance-guid", CellId: "cell-a"},
+				}
+			})
+
+			It("serves the request", func() {
+				Expect(served).To(BeTrue())
+			})
+		})
+
+		Context("when the request is for another cell", func() {
+			BeforeEach(func() {
+				requestBody = &models.StartActualLRPRequest{
+					ActualLrpKey:         &models.ActualLRPKey{ProcessGuid: "process-guid", Domain: "domain"},
+					ActualLrpInstanceKey: &models.ActualLRPInstanceKey{InstanceGuid: "instance-guid", CellId: "cell-b"},
+				}
+			})
+
+			It("responds with 403 Forbidden", func() {
+				expectUnauthorized(http.StatusForbidden)
+			})
+		})
+
+		Context("when starting a task on another cell", func() {
+			BeforeEach(func() {
+				routeName = bbs.StartTaskRoute
+				requestBody = &models.StartTaskRequest{TaskGuid: "task-guid", CellId: "cell-b"}
+			})
+
+			It("responds with 403 Forbidden", func() {
+				expectUnauthorized(http.StatusForbidden)
+			})
+		})
+	})
+
+	Describe("domain restricted clients", func() {
+		BeforeEach(func() {
+			token = "scheduler-token"
+		})
+
+		Context("when desiring a task in the client's domain", func() {
+			BeforeEach(func() {
+				routeName = bbs.DesireTaskRoute
+				requestBody = &models.Task{TaskGuid: "task-guid", Domain: "scheduler-domain"}
+			})
+
+			It("serves the request", func() {
+				Expect(served).To(BeTrue())
+			})
+		})
+
+		Context("when desiring a task in another domain", func() {
+			BeforeEach(func() {
+				routeName = bbs.DesireTaskRoute
+				requestBody = &models.Task{TaskGuid: "task-guid", Domain: "other-domain"}
+			})
+
+			It("responds with 403 Forbidden", func() {
+				expectUnauthorized(http.StatusForbidden)
+			})
+		})
+
+		Context("when the request names a task", func() {
+			BeforeEach(func() {
+				routeName = bbs.CancelTaskRoute
+				requestBody = &models.TaskGuidRequest{TaskGuid: "task-guid"}
+			})
+
+			Context("in the client's domain", func() {
+				BeforeEach(func() {
+					fakeTaskDB.TaskByGuidReturns(&models.Task{TaskGuid: "task-guid", Domain: "scheduler-domain"}, nil)
+				})
+
+				It("looks up the task's domain", func() {
+					Expect(fakeTaskDB.TaskByGuidCallCount()).To(Equal(1))
+					_, taskGuid := fakeTaskDB.TaskByGuidArgsForCall(0)
+					Expect(taskGuid).To(Equal("task-guid"))
+				})
+
+				It("serves the request", func() {
+					Expect(served).To(BeTrue())
+				})
+			})
+
+			Context("in another domain", func() {
+				BeforeEach(func() {
+					fakeTaskDB.TaskByGuidReturns(&models.Task{TaskGuid: "task-guid", Domain: "other-domain"}, nil)
+				})
+
+				It("responds with 403 Forbidden", func() {
+					expectUnauthorized(http.StatusForbidden)
+				})
+			})
+
+			Context("that does not exist", func() {
+				BeforeEach(func() {
+					fakeTaskDB.TaskByGuidReturns(nil, models.ErrResourceNotFound)
+				})
+
+				It("responds with 403 Forbidden", func() {
+					expectUnauthorized(http.StatusForbidden)
+				})
+			})
+
+			Context("when looking up the task fails", func() {
+				BeforeEach(func() {
+					fakeTaskDB.TaskByGuidReturns(nil, models.ErrUnknownError)
+				})
+
+				It("responds with 500 Internal Server Error", func() {
+					Expect(served).To(BeFalse())
+					Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the request names a desired LRP", func() {
+			BeforeEach(func() {
+				routeName = bbs.UpdateDesiredLRPRoute
+				requestBody = &models.UpdateDesiredLRPRequest{ProcessGuid: "process-guid", Update: &models.DesiredLRPUpdate{}}
+				fakeDesiredLRPDB.DesiredLRPByProcessGuidReturns(&models.DesiredLRP{ProcessGuid: "process-guid", Domain: "other-domain"}, nil)
+			})
+
+			It("checks the desired LRP's domain", func() {
+				Expect(fakeDesiredLRPDB.DesiredLRPByProcessGuidCallCount()).To(Equal(1))
+				_, processGuid := fakeDesiredLRPDB.DesiredLRPByProcessGuidArgsForCall(0)
+				Expect(processGuid).To(Equal("process-guid"))
+				expectUnauthorized(http.StatusForbidden)
+			})
+		})
+
+		Context("when the request names an actual LRP", func() {
+			BeforeEach(func() {
+				routeName = bbs.RetireActualLRPRoute
+				requestBody = &models.RetireActualLRPRequest{
+					ActualLrpKey: &models.ActualLRPKey{ProcessGuid: "process-guid", Index: 2, Domain: "scheduler-domain"},
+				}
+			})
+
+			Context("whose stored domain is the client's", func() {
+				BeforeEach(func() {
+					fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexReturns(&models.ActualLRPGroup{
+						Instance: &models.ActualLRP{ActualLRPKey: models.NewActualLRPKey("process-guid", 2, "scheduler-domain")},
+					}, nil)
+				})
+
+				It("looks up the actual LRP", func() {
+					Expect(fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexCallCount()).To(Equal(1))
+					_, processGuid, index := fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexArgsForCall(0)
+					Expect(processGuid).To(Equal("process-guid"))
+					Expect(index).To(BeEquivalentTo(2))
+				})
+
+				It("serves the request", func() {
+					Expect(served).To(BeTrue())
+				})
+			})
+
+			Context("whose stored domain differs from the one in the request", func() {
+				BeforeEach(func() {
+					fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexReturns(&models.ActualLRPGroup{
+						Evacuating: &models.ActualLRP{ActualLRPKey: models.NewActualLRPKey("process-guid", 2, "other-domain")},
+					}, nil)
+				})
+
+				It("responds with 403 Forbidden", func() {
+					expectUnauthorized(http.StatusForbidden)
+				})
+			})
+
+			Context("that is only desired, in another domain", func() {
+				BeforeEach(func() {
+					fakeDesiredLRPDB.DesiredLRPByProcessGuidReturns(&models.DesiredLRP{ProcessGuid: "process-guid", Domain: "other-domain"}, nil)
+				})
+
+				It("responds with 403 Forbidden", func() {
+					expectUnauthorized(http.StatusForbidden)
+				})
+			})
+
+			Context("when looking up the actual LRP fails", func() {
+				BeforeEach(func() {
+					fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexReturns(nil, models.ErrUnknownError)
+				})
+
+				It("responds with 500 Internal Server Error", func() {
+					Expect(served).To(BeFalse())
+					Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when desiring a task whose guid is stored in another domain", func() {
+			BeforeEach(func() {
+				routeName = bbs.DesireTaskRoute
+				requestBody = &models.Task{TaskGuid: "task-guid", Domain: "scheduler-domain"}
+				fakeTaskDB.TaskByGuidReturns(&models.Task{TaskGuid: "task-guid", Domain: "other-domain"}, nil)
+			})
+
+			It("responds with 403 Forbidden", func() {
+				expectUnauthorized(http.StatusForbidden)
+			})
+		})
+
+		Context("when desiring an LRP whose process guid is stored in another domain", func() {
+			BeforeEach(func() {
+				routeName = bbs.DesireDesiredLRPRoute
+				requestBody = &models.DesiredLRP{ProcessGuid: "process-guid", Domain: "scheduler-domain"}
+				fakeDesiredLRPDB.DesiredLRPByProcessGuidReturns(&models.DesiredLRP{ProcessGuid: "process-guid", Domain: "other-domain"}, nil)
+			})
+
+			It("responds with 403 Forbidden", func() {
+				expectUnauthorized(http.StatusForbidden)
+			})
+		})
+	})
+
+	Context("when the client has no domain or cell restrictions", func() {
+		BeforeEach(func() {
+			routeName = bbs.CancelTaskRoute
+			token = "admin-token"
+			requestBody = &models.TaskGuidRequest{TaskGuid: "task-guid"}
+		})
+
+		It("does not look up the task", func() {
+			Expect(fakeTaskDB.TaskByGuidCallCount()).To(Equal(0))
+			Expect(served).To(BeTrue())
+		})
+	})
+})
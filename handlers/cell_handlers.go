@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type CellHandler struct {
+	db     db.CellDB
+	logger lager.Logger
+}
+
+func NewCellHandler(logger lager.Logger, db db.CellDB) *CellHandler {
+	return &CellHandler{
+		db:     db,
+		logger: logger.Session("cell-handler"),
+	}
+}
+
+func (h *CellHandler) Cells(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("cells")
+
+	cells, bbsErr := h.db.Cells(logger)
+	if bbsErr != nil {
+		logger.Error("failed-to-fetch-cells", bbsErr)
+		writeUnknownErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeProtoResponse(w, http.StatusOK, &models.CellsResponse{Cells: cells})
+}
@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager"
+)
+
+var _ = Describe("Cell Handlers", func() {
+	var (
+		logger           lager.Logger
+		fakeCellDB       *fakes.FakeCellDB
+		responseRecorder *httptest.ResponseRecorder
+		handler          *handlers.CellHandler
+	)
+
+	BeforeEach(func() {
+		fakeCellDB = new(fakes.FakeCellDB)
+		logger = lager.NewLogger("test")
+		logger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		responseRecorder = httptest.NewRecorder()
+		handler = handlers.NewCellHandler(logger, fakeCellDB)
+	})
+
+	Describe("Cells", func() {
+		var cells []*models.CellPresence
+
+		BeforeEach(func() {
+			cell1 := models.NewCellPresence("cell-1", "1.2.3.4", "z1", models.NewCellCapacity(128, 1024, 6), []string{"docker"}, []string{"cflinuxfs2"})
+			cell2 := models.NewCellPresence("cell-2", "5.6.7.8", "z2", models.NewCellCapacity(256, 2048, 12), []string{}, []string{})
+			cells = []*models.CellPresence{&cell1, &cell2}
+		})
+
+		JustBeforeEach(func() {
+			handler.Cells(responseRecorder, newTestRequest(""))
+		})
+
+		Context("when reading cells succeeds", func() {
+			BeforeEach(func() {
+				fakeCellDB.CellsReturns(cells, nil)
+			})
+
+			It("returns a list of cells", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+
+				response := &models.CellsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Cells).To(Equal(cells))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeCellDB.CellsReturns(nil, models.ErrUnknownError)
+			})
+
+			It("responds with a 500", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"io/ioutil"
 	"net/http"
 
 	"github.com/cloudfoundry-incubator/bbs/db"
@@ -26,14 +27,20 @@ func (h *DesiredLRPHandler) DesiredLRPs(w http.ResponseWriter, req *http.Request
 		"domain": domain,
 	})
 
-	desiredLRPs, err := h.db.DesiredLRPs(h.logger, models.DesiredLRPFilter{Domain: domain})
+	p, err := parsePage(req)
 	if err != nil {
-		logger.Error("failed-to-fetch-desired-lrps", err)
-		writeUnknownErrorResponse(w, err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return
+	}
+
+	desiredLRPs, bbsErr := h.db.DesiredLRPs(h.logger, p.desiredLRPFilter(models.DesiredLRPFilter{Domain: domain}))
+	if bbsErr != nil {
+		logger.Error("failed-to-fetch-desired-lrps", bbsErr)
+		writeUnknownErrorResponse(w, bbsErr)
 		return
 	}
 
-	writeProtoResponse(w, http.StatusOK, desiredLRPs)
+	writeProtoResponse(w, http.StatusOK, pageDesiredLRPs(desiredLRPs, p))
 }
 
 func (h *DesiredLRPHandler) DesiredLRPByProcessGuid(w http.ResponseWriter, req *http.Request) {
@@ -53,5 +60,123 @@ func (h *DesiredLRPHandler) DesiredLRPByProcessGuid(w http.ResponseWriter, req *
 		return
 	}
 
+	if desiredLRP.ModificationTag != nil && notModified(w, req, modificationTagETag(desiredLRP.ModificationTag)) {
+		return
+	}
+
 	writeProtoResponse(w, http.StatusOK, desiredLRP)
 }
+
+func (h *DesiredLRPHandler) DesireDesiredLRP(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("desire-lrp")
+
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		logger.Error("failed-to-read-body", err)
+		writeUnknownErrorResponse(w, err)
+		return
+	}
+
+	desiredLRP := &models.DesiredLRP{}
+	err = unmarshalRequest(req, data, desiredLRP)
+	if err != nil {
+		logger.Error("failed-to-parse-request-body", err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return
+	}
+	logger.Debug("parsed-request-body", lager.Data{"process_guid": desiredLRP.GetProcessGuid()})
+	if err := desiredLRP.Validate(); err != nil {
+		logger.Error("invalid-request", err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return
+	}
+
+	bbsErr := h.db.DesireLRP(logger, desiredLRP)
+	if bbsErr != nil {
+		logger.Error("failed-to-desire-lrp", bbsErr)
+		if bbsErr.Equal(models.ErrResourceExists) {
+			writeConflictResponse(w, bbsErr)
+		} else {
+			writeUnknownErrorResponse(w, bbsErr)
+		}
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusCreated)
+}
+
+func (h *DesiredLRPHandler) UpdateDesiredLRP(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("update-desired-lrp")
+
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		logger.Error("failed-to-read-body", err)
+		writeUnknownErrorResponse(w, err)
+		return
+	}
+
+	request := &models.UpdateDesiredLRPRequest{}
+	err = unmarshalRequest(req, data, request)
+	if modErr, ok := err.(models.ErrInvalidModification); ok {
+		logger.Error("invalid-modification", modErr)
+		writeBadRequestResponse(w, models.InvalidRecord, modErr)
+		return
+	} else if err != nil {
+		logger.Error("failed-to-parse-request-body", err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return
+	}
+	logger.Debug("parsed-request-body", lager.Data{"request": request})
+	if err := request.Validate(); err != nil {
+		logger.Error("invalid-request", err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return
+	}
+
+	if request.ExpectedModificationTag == nil {
+		request.ExpectedModificationTag, err = parseIfMatch(req)
+		if err != nil {
+			logger.Error("invalid-if-match", err)
+			writeBadRequestResponse(w, models.InvalidRequest, err)
+			return
+		}
+	}
+
+	bbsErr := h.db.UpdateDesiredLRP(logger, request)
+	if bbsErr != nil {
+		logger.Error("failed-to-update-desired-lrp", bbsErr)
+		switch bbsErr.Type {
+		case models.ResourceNotFound:
+			writeNotFoundResponse(w, bbsErr)
+		case models.ResourceConflict:
+			writeConflictResponse(w, bbsErr)
+		case models.InvalidRecord:
+			writeBadRequestResponse(w, models.InvalidRecord, bbsErr)
+		default:
+			writeUnknownErrorResponse(w, bbsErr)
+		}
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
+
+func (h *DesiredLRPHandler) RemoveDesiredLRP(w http.ResponseWriter, req *http.Request) {
+	processGuid := req.FormValue(":process_guid")
+	logger := h.logger.Session("remove-desired-lrp", lager.Data{
+		"process_guid": processGuid,
+	})
+
+	bbsErr := h.db.RemoveDesiredLRP(logger, processGuid)
+	if bbsErr != nil {
+		logger.Error("failed-to-remove-desired-lrp", bbsErr)
+		if bbsErr.Equal(models.ErrResourceNotFound) {
+			writeNotFoundResponse(w, bbsErr)
+		} else {
+			writeUnknownErrorResponse(w, bbsErr)
+		}
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
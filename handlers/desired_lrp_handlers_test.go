@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"encoding/base64"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -8,6 +9,7 @@ import (
 	"github.com/cloudfoundry-incubator/bbs/db/fakes"
 	"github.com/cloudfoundry-incubator/bbs/handlers"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-golang/lager"
@@ -51,7 +53,7 @@ var _ = Describe("DesiredLRP Handlers", func() {
 
 			BeforeEach(func() {
 				desiredLRPs = &models.DesiredLRPs{
-					[]*models.DesiredLRP{&desiredLRP1, &desiredLRP2},
+					DesiredLrps: []*models.DesiredLRP{&desiredLRP1, &desiredLRP2},
 				}
 				fakeDesiredLRPDB.DesiredLRPsReturns(desiredLRPs, nil)
 			})
@@ -89,6 +91,45 @@ var _ = Describe("DesiredLRP Handlers", func() {
 					Expect(filter.Domain).To(Equal("domain-1"))
 				})
 			})
+
+			Context("and paginating", func() {
+				BeforeEach(func() {
+					desiredLRP1.ProcessGuid = "process-guid-a"
+					desiredLRP2.ProcessGuid = "process-guid-b"
+
+					var err error
+					request, err = http.NewRequest("", "http://example.com?limit=1", nil)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("asks the DB for one desired lrp more than the page holds", func() {
+					_, filter := fakeDesiredLRPDB.DesiredLRPsArgsForCall(0)
+					Expect(filter).To(Equal(models.DesiredLRPFilter{Limit: 2}))
+				})
+
+				It("returns the first page with a continuation token", func() {
+					response := &models.DesiredLRPs{}
+					err := response.Unmarshal(responseRecorder.Body.Bytes())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(response.DesiredLrps).To(Equal([]*models.DesiredLRP{&desiredLRP1}))
+					Expect(response.ContinuationToken).NotTo(BeEmpty())
+				})
+
+				Context("with a continuation token", func() {
+					BeforeEach(func() {
+						var err error
+						request, err = http.NewRequest("", "http://example.com?limit=1&continuation_token="+
+							base64.URLEncoding.EncodeToString([]byte("process-guid-a")), nil)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("asks the DB for the desired lrps after it", func() {
+						_, filter := fakeDesiredLRPDB.DesiredLRPsArgsForCall(0)
+						Expect(filter).To(Equal(models.DesiredLRPFilter{Limit: 2, AfterProcessGuid: "process-guid-a"}))
+					})
+				})
+			})
 		})
 
 		Context("when the DB returns no desired lrp groups", func() {
@@ -166,6 +207,37 @@ var _ = Describe("DesiredLRP Handlers", func() {
 
 				Expect(response).To(Equal(desiredLRP))
 			})
+
+			Context("when the desired lrp has a modification tag", func() {
+				BeforeEach(func() {
+					desiredLRP.ModificationTag = &models.ModificationTag{Epoch: "some-epoch", Index: 3}
+				})
+
+				It("sets the ETag header from the modification tag", func() {
+					Expect(responseRecorder.Header().Get("ETag")).To(Equal(`"some-epoch:3"`))
+				})
+
+				Context("when If-None-Match names the current ETag", func() {
+					BeforeEach(func() {
+						request.Header.Set("If-None-Match", `"other-epoch:1", "some-epoch:3"`)
+					})
+
+					It("responds with 304 Not Modified and no body", func() {
+						Expect(responseRecorder.Code).To(Equal(http.StatusNotModified))
+						Expect(responseRecorder.Body.Len()).To(BeZero())
+					})
+				})
+
+				Context("when If-None-Match names an older ETag", func() {
+					BeforeEach(func() {
+						request.Header.Set("If-None-Match", `"some-epoch:2"`)
+					})
+
+					It("responds with 200 Status OK", func() {
+						Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+					})
+				})
+			})
 		})
 
 		Context("when the DB returns no desired lrp", func() {
@@ -204,4 +276,319 @@ var _ = Describe("DesiredLRP Handlers", func() {
 			})
 		})
 	})
+
+	Describe("DesireDesiredLRP", func() {
+		var (
+			desiredLRP  *models.DesiredLRP
+			requestBody interface{}
+		)
+
+		BeforeEach(func() {
+			desiredLRP = model_helpers.NewValidDesiredLRP("some-guid")
+			desiredLRP.Instances = 5
+			requestBody = desiredLRP
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.DesireDesiredLRP(responseRecorder, request)
+		})
+
+		Context("when creating desired lrp in DB succeeds", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesireLRPReturns(nil)
+			})
+
+			It("responds with 201 CREATED", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusCreated))
+			})
+
+			It("desires the lrp in the DB", func() {
+				Expect(fakeDesiredLRPDB.DesireLRPCallCount()).To(Equal(1))
+				_, actualDesiredLRP := fakeDesiredLRPDB.DesireLRPArgsForCall(0)
+				Expect(actualDesiredLRP).To(Equal(desiredLRP))
+			})
+		})
+
+		Context("when the desired lrp is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.DesiredLRP{}
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("does not try to desire the lrp", func() {
+				Expect(fakeDesiredLRPDB.DesireLRPCallCount()).To(Equal(0))
+			})
+
+			It("responds with a relevant error message", func() {
+				var bbsError models.Error
+				err := bbsError.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bbsError.Type).To(Equal(models.InvalidRequest))
+			})
+		})
+
+		Context("when the desired lrp already exists", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesireLRPReturns(models.ErrResourceExists)
+			})
+
+			It("responds with 409 CONFLICT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+
+			It("provides relevant error information", func() {
+				var bbsError models.Error
+				err := bbsError.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bbsError.Equal(models.ErrResourceExists)).To(BeTrue())
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesireLRPReturns(models.ErrUnknownError)
+			})
+
+			It("responds with a 500", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+
+			It("provides relevant error information", func() {
+				var bbsError models.Error
+				err := bbsError.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bbsError.Equal(models.ErrUnknownError)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UpdateDesiredLRP", func() {
+		var (
+			processGuid string
+			update      *models.DesiredLRPUpdate
+			requestBody interface{}
+			contentType string
+			ifMatch     string
+		)
+
+		BeforeEach(func() {
+			processGuid = "some-guid"
+			someText := "some-text"
+			instances := int32(3)
+			update = &models.DesiredLRPUpdate{
+				Instances:  &instances,
+				Annotation: &someText,
+			}
+			requestBody = &models.UpdateDesiredLRPRequest{
+				ProcessGuid: processGuid,
+				Update:      update,
+			}
+			contentType = ""
+			ifMatch = ""
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			if contentType != "" {
+				request.Header.Set("Content-Type", contentType)
+			}
+			if ifMatch != "" {
+				request.Header.Set("If-Match", ifMatch)
+			}
+			handler.UpdateDesiredLRP(responseRecorder, request)
+		})
+
+		Context("when updating desired lrp in DB succeeds", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPReturns(nil)
+			})
+
+			It("responds with 204 Status NO CONTENT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+			})
+
+			It("updates the desired lrp in the DB", func() {
+				Expect(fakeDesiredLRPDB.UpdateDesiredLRPCallCount()).To(Equal(1))
+				_, actualRequest := fakeDesiredLRPDB.UpdateDesiredLRPArgsForCall(0)
+				Expect(actualRequest.ProcessGuid).To(Equal(processGuid))
+				Expect(actualRequest.Update).To(Equal(update))
+				Expect(actualRequest.ExpectedModificationTag).To(BeNil())
+			})
+		})
+
+		Context("when the request has an If-Match header", func() {
+			BeforeEach(func() {
+				ifMatch = `"some-epoch:3"`
+			})
+
+			It("makes the update conditional on the modification tag", func() {
+				Expect(fakeDesiredLRPDB.UpdateDesiredLRPCallCount()).To(Equal(1))
+				_, actualRequest := fakeDesiredLRPDB.UpdateDesiredLRPArgsForCall(0)
+				Expect(actualRequest.ExpectedModificationTag).To(Equal(&models.ModificationTag{Epoch: "some-epoch", Index: 3}))
+			})
+		})
+
+		Context("when the If-Match header is not an ETag", func() {
+			BeforeEach(func() {
+				ifMatch = "some-epoch:3"
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("does not try to update the lrp", func() {
+				Expect(fakeDesiredLRPDB.UpdateDesiredLRPCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.UpdateDesiredLRPRequest{}
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("does not try to update the lrp", func() {
+				Expect(fakeDesiredLRPDB.UpdateDesiredLRPCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the desired lrp does not exist", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPReturns(models.ErrResourceNotFound)
+			})
+
+			It("responds with 404", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the desired lrp was modified concurrently", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPReturns(models.ErrResourceConflict)
+			})
+
+			It("responds with 409 CONFLICT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the update makes an invalid modification", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPReturns(&models.Error{
+					Type:    models.InvalidRecord,
+					Message: models.ErrInvalidModification{"rootfs"}.Error(),
+				})
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("provides relevant error information", func() {
+				var bbsError models.Error
+				err := bbsError.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bbsError.Type).To(Equal(models.InvalidRecord))
+				Expect(bbsError.Message).To(ContainSubstring("rootfs"))
+			})
+		})
+
+		Context("when a JSON update names an immutable field", func() {
+			BeforeEach(func() {
+				contentType = "application/json"
+				requestBody = `{"process_guid":"some-guid","update":{"instances":2,"rootfs":"docker:///other"}}`
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("provides relevant error information", func() {
+				var bbsError models.Error
+				err := bbsError.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bbsError.Type).To(Equal(models.InvalidRecord))
+				Expect(bbsError.Message).To(ContainSubstring("rootfs"))
+			})
+
+			It("does not try to update the lrp", func() {
+				Expect(fakeDesiredLRPDB.UpdateDesiredLRPCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPReturns(models.ErrUnknownError)
+			})
+
+			It("responds with a 500", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("RemoveDesiredLRP", func() {
+		var (
+			request     *http.Request
+			processGuid = "process-guid"
+		)
+
+		BeforeEach(func() {
+			request = newTestRequest("")
+			request.URL.RawQuery = url.Values{":process_guid": []string{processGuid}}.Encode()
+		})
+
+		JustBeforeEach(func() {
+			handler.RemoveDesiredLRP(responseRecorder, request)
+		})
+
+		Context("when removing the desired lrp from the DB succeeds", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.RemoveDesiredLRPReturns(nil)
+			})
+
+			It("responds with 204 Status NO CONTENT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+			})
+
+			It("removes the desired lrp by process guid", func() {
+				Expect(fakeDesiredLRPDB.RemoveDesiredLRPCallCount()).To(Equal(1))
+				_, actualProcessGuid := fakeDesiredLRPDB.RemoveDesiredLRPArgsForCall(0)
+				Expect(actualProcessGuid).To(Equal(processGuid))
+			})
+		})
+
+		Context("when the desired lrp does not exist", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.RemoveDesiredLRPReturns(models.ErrResourceNotFound)
+			})
+
+			It("responds with 404", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.RemoveDesiredLRPReturns(models.ErrUnknownError)
+			})
+
+			It("responds with a 500", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+)
+
+var errInvalidETag = errors.New("If-Match must be * or a single entity tag issued by this server")
+
+// modificationTagETag renders a modification tag as a strong entity tag.
+func modificationTagETag(tag *models.ModificationTag) string {
+	return `"` + modificationTagValue(tag) + `"`
+}
+
+func modificationTagValue(tag *models.ModificationTag) string {
+	return fmt.Sprintf("%s:%d", tag.Epoch, tag.Index)
+}
+
+// actualLRPGroupETag covers both records of the group, so that evacuation
+// changes it too. The instance's tag comes first and is what If-Match is
+// checked against when the instance is claimed or started; a group with only
+// an evacuating record has an empty instance part.
+func actualLRPGroupETag(group *models.ActualLRPGroup) string {
+	var value string
+	if group.Instance != nil {
+		value = modificationTagValue(&group.Instance.ModificationTag)
+	}
+	if group.Evacuating != nil {
+		value += "+" + modificationTagValue(&group.Evacuating.ModificationTag)
+	}
+	return `"` + value + `"`
+}
+
+// notModified sets the ETag header and reports whether the client's
+// If-None-Match header already names it, in which case a 304 has been written.
+func notModified(w http.ResponseWriter, req *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+
+	for _, candidate := range strings.Split(req.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseIfMatch returns the modification tag a write is conditional on, or nil
+// if the request has no If-Match header or it is *. An ETag whose instance part
+// is empty yields the zero tag, which only matches a missing instance record.
+func parseIfMatch(req *http.Request) (*models.ModificationTag, error) {
+	ifMatch := strings.TrimSpace(req.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	if len(ifMatch) < 2 || ifMatch[0] != '"' || ifMatch[len(ifMatch)-1] != '"' {
+		return nil, errInvalidETag
+	}
+	value := ifMatch[1 : len(ifMatch)-1]
+
+	// tags of actual LRP groups carry the evacuating record's tag after a +
+	if plus := strings.Index(value, "+"); plus >= 0 {
+		if plus == 0 {
+			return &models.ModificationTag{}, nil
+		}
+		value = value[:plus]
+	}
+
+	colon := strings.LastIndex(value, ":")
+	if colon <= 0 {
+		return nil, errInvalidETag
+	}
+
+	index, err := strconv.ParseUint(value[colon+1:], 10, 32)
+	if err != nil {
+		return nil, errInvalidETag
+	}
+
+	return &models.ModificationTag{Epoch: value[:colon], Index: uint32(index)}, nil
+}
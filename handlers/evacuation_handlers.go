@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type EvacuationHandler struct {
+	db     db.EvacuationDB
+	logger lager.Logger
+}
+
+func NewEvacuationHandler(logger lager.Logger, db db.EvacuationDB) *EvacuationHandler {
+	return &EvacuationHandler{
+		db:     db,
+		logger: logger.Session("evacuation-handler"),
+	}
+}
+
+func (h *EvacuationHandler) EvacuateClaimedActualLRP(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("evacuate-claimed-actual-lrp")
+
+	request := &models.EvacuateClaimedActualLRPRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	keepContainer, bbsErr := h.db.EvacuateClaimedActualLRP(logger, request)
+	writeEvacuationResponse(logger, w, keepContainer, bbsErr)
+}
+
+func (h *EvacuationHandler) EvacuateRunningActualLRP(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("evacuate-running-actual-lrp")
+
+	request := &models.EvacuateRunningActualLRPRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	keepContainer, bbsErr := h.db.EvacuateRunningActualLRP(logger, request)
+	writeEvacuationResponse(logger, w, keepContainer, bbsErr)
+}
+
+func (h *EvacuationHandler) EvacuateStoppedActualLRP(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("evacuate-stopped-actual-lrp")
+
+	request := &models.EvacuateStoppedActualLRPRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	keepContainer, bbsErr := h.db.EvacuateStoppedActualLRP(logger, request)
+	writeEvacuationResponse(logger, w, keepContainer, bbsErr)
+}
+
+func (h *EvacuationHandler) EvacuateCrashedActualLRP(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("evacuate-crashed-actual-lrp")
+
+	request := &models.EvacuateCrashedActualLRPRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	keepContainer, bbsErr := h.db.EvacuateCrashedActualLRP(logger, request)
+	writeEvacuationResponse(logger, w, keepContainer, bbsErr)
+}
+
+func (h *EvacuationHandler) RemoveEvacuatingActualLRP(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("remove-evacuating-actual-lrp")
+
+	request := &models.RemoveEvacuatingActualLRPRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	bbsErr := h.db.RemoveEvacuatingActualLRP(logger, request)
+	if bbsErr != nil {
+		logger.Error("failed-to-remove-evacuating-actual-lrp", bbsErr)
+		switch bbsErr.Type {
+		case models.ResourceNotFound:
+			writeNotFoundResponse(w, bbsErr)
+		case models.ActualLRPCannotBeRemoved:
+			writeProtoResponse(w, http.StatusConflict, bbsErr)
+		default:
+			writeUnknownErrorResponse(w, bbsErr)
+		}
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
+
+// writeEvacuationResponse always responds with 200 so that the keep container
+// verdict reaches the cell even when the evacuation itself failed.
+func writeEvacuationResponse(logger lager.Logger, w http.ResponseWriter, keepContainer bool, bbsErr *models.Error) {
+	if bbsErr != nil {
+		logger.Error("failed-to-evacuate-actual-lrp", bbsErr)
+	}
+
+	writeProtoResponse(w, http.StatusOK, &models.EvacuationResponse{
+		Error:         bbsErr,
+		KeepContainer: keepContainer,
+	})
+}
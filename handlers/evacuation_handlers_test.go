@@ -0,0 +1,236 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager"
+)
+
+var _ = Describe("Evacuation Handlers", func() {
+	var (
+		logger           lager.Logger
+		fakeEvacuationDB *fakes.FakeEvacuationDB
+		responseRecorder *httptest.ResponseRecorder
+		handler          *handlers.EvacuationHandler
+
+		key         models.ActualLRPKey
+		instanceKey models.ActualLRPInstanceKey
+	)
+
+	parseEvacuationResponse := func() *models.EvacuationResponse {
+		response := &models.EvacuationResponse{}
+		err := response.Unmarshal(responseRecorder.Body.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		return response
+	}
+
+	BeforeEach(func() {
+		fakeEvacuationDB = new(fakes.FakeEvacuationDB)
+		logger = lager.NewLogger("test")
+		logger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		responseRecorder = httptest.NewRecorder()
+		handler = handlers.NewEvacuationHandler(logger, fakeEvacuationDB)
+
+		key = models.NewActualLRPKey("process-guid", 1, "domain")
+		instanceKey = models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+	})
+
+	Describe("EvacuateClaimedActualLRP", func() {
+		var requestBody interface{}
+
+		BeforeEach(func() {
+			requestBody = &models.EvacuateClaimedActualLRPRequest{
+				ActualLrpKey:         &key,
+				ActualLrpInstanceKey: &instanceKey,
+			}
+		})
+
+		JustBeforeEach(func() {
+			handler.EvacuateClaimedActualLRP(responseRecorder, newTestRequest(requestBody))
+		})
+
+		Context("when the evacuation succeeds", func() {
+			BeforeEach(func() {
+				fakeEvacuationDB.EvacuateClaimedActualLRPReturns(false, nil)
+			})
+
+			It("evacuates the actual lrp", func() {
+				Expect(fakeEvacuationDB.EvacuateClaimedActualLRPCallCount()).To(Equal(1))
+				_, request := fakeEvacuationDB.EvacuateClaimedActualLRPArgsForCall(0)
+				Expect(*request.ActualLrpKey).To(Equal(key))
+				Expect(*request.ActualLrpInstanceKey).To(Equal(instanceKey))
+			})
+
+			It("responds with the keep container verdict", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := parseEvacuationResponse()
+				Expect(response.KeepContainer).To(BeFalse())
+				Expect(response.Error).To(BeNil())
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.EvacuateClaimedActualLRPRequest{}
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeEvacuationDB.EvacuateClaimedActualLRPCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeEvacuationDB.EvacuateClaimedActualLRPReturns(false, models.ErrActualLRPCannotBeUnclaimed)
+			})
+
+			It("responds with the verdict and the error", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := parseEvacuationResponse()
+				Expect(response.KeepContainer).To(BeFalse())
+				Expect(response.Error.Equal(models.ErrActualLRPCannotBeUnclaimed)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("EvacuateRunningActualLRP", func() {
+		var (
+			requestBody interface{}
+			netInfo     models.ActualLRPNetInfo
+		)
+
+		BeforeEach(func() {
+			netInfo = models.NewActualLRPNetInfo("1.2.3.4", models.NewPortMapping(8080, 80))
+			requestBody = &models.EvacuateRunningActualLRPRequest{
+				ActualLrpKey:         &key,
+				ActualLrpInstanceKey: &instanceKey,
+				ActualLrpNetInfo:     &netInfo,
+				Ttl:                  60,
+			}
+		})
+
+		JustBeforeEach(func() {
+			handler.EvacuateRunningActualLRP(responseRecorder, newTestRequest(requestBody))
+		})
+
+		Context("when the evacuation succeeds", func() {
+			BeforeEach(func() {
+				fakeEvacuationDB.EvacuateRunningActualLRPReturns(true, nil)
+			})
+
+			It("evacuates the actual lrp", func() {
+				Expect(fakeEvacuationDB.EvacuateRunningActualLRPCallCount()).To(Equal(1))
+				_, request := fakeEvacuationDB.EvacuateRunningActualLRPArgsForCall(0)
+				Expect(*request.ActualLrpNetInfo).To(Equal(netInfo))
+				Expect(request.Ttl).To(Equal(uint64(60)))
+			})
+
+			It("responds with the keep container verdict", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				Expect(parseEvacuationResponse().KeepContainer).To(BeTrue())
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.EvacuateRunningActualLRPRequest{}
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeEvacuationDB.EvacuateRunningActualLRPCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeEvacuationDB.EvacuateRunningActualLRPReturns(true, models.ErrUnknownError)
+			})
+
+			It("still responds with the verdict", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := parseEvacuationResponse()
+				Expect(response.KeepContainer).To(BeTrue())
+				Expect(response.Error.Equal(models.ErrUnknownError)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("EvacuateStoppedActualLRP", func() {
+		JustBeforeEach(func() {
+			request := &models.EvacuateStoppedActualLRPRequest{
+				ActualLrpKey:         &key,
+				ActualLrpInstanceKey: &instanceKey,
+			}
+			handler.EvacuateStoppedActualLRP(responseRecorder, newTestRequest(request))
+		})
+
+		It("evacuates the actual lrp and responds with the verdict", func() {
+			Expect(fakeEvacuationDB.EvacuateStoppedActualLRPCallCount()).To(Equal(1))
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+			Expect(parseEvacuationResponse().KeepContainer).To(BeFalse())
+		})
+	})
+
+	Describe("EvacuateCrashedActualLRP", func() {
+		JustBeforeEach(func() {
+			request := &models.EvacuateCrashedActualLRPRequest{
+				ActualLrpKey:         &key,
+				ActualLrpInstanceKey: &instanceKey,
+				ErrorMessage:         "oh no",
+			}
+			handler.EvacuateCrashedActualLRP(responseRecorder, newTestRequest(request))
+		})
+
+		It("evacuates the actual lrp and responds with the verdict", func() {
+			Expect(fakeEvacuationDB.EvacuateCrashedActualLRPCallCount()).To(Equal(1))
+			_, request := fakeEvacuationDB.EvacuateCrashedActualLRPArgsForCall(0)
+			Expect(request.ErrorMessage).To(Equal("oh no"))
+
+			Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+			Expect(parseEvacuationResponse().KeepContainer).To(BeFalse())
+		})
+	})
+
+	Describe("RemoveEvacuatingActualLRP", func() {
+		JustBeforeEach(func() {
+			request := &models.RemoveEvacuatingActualLRPRequest{
+				ActualLrpKey:         &key,
+				ActualLrpInstanceKey: &instanceKey,
+			}
+			handler.RemoveEvacuatingActualLRP(responseRecorder, newTestRequest(request))
+		})
+
+		It("removes the evacuating actual lrp and responds with 204", func() {
+			Expect(fakeEvacuationDB.RemoveEvacuatingActualLRPCallCount()).To(Equal(1))
+			Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+		})
+
+		Context("when the evacuating actual lrp belongs to another instance", func() {
+			BeforeEach(func() {
+				fakeEvacuationDB.RemoveEvacuatingActualLRPReturns(models.ErrActualLRPCannotBeRemoved)
+			})
+
+			It("responds with 409 CONFLICT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeEvacuationDB.RemoveEvacuatingActualLRPReturns(models.ErrUnknownError)
+			})
+
+			It("responds with a 500", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
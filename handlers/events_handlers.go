@@ -3,28 +3,36 @@ package handlers
 import (
 	"encoding/base64"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
+	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/gogo/protobuf/proto"
 	"github.com/pivotal-golang/lager"
 	"github.com/vito/go-sse/sse"
 )
 
 type EventHandler struct {
-	hub    events.Hub
-	logger lager.Logger
+	hub               events.Hub
+	heartbeatInterval time.Duration
+	logger            lager.Logger
 }
 
-var ()
-
-func NewEventHandler(logger lager.Logger, hub events.Hub) *EventHandler {
+// NewEventHandler returns a handler that streams hub events. A zero
+// heartbeatInterval disables heartbeats.
+func NewEventHandler(logger lager.Logger, hub events.Hub, heartbeatInterval time.Duration) *EventHandler {
 	return &EventHandler{
-		hub:    hub,
-		logger: logger.Session("domain-handler"),
+		hub:               hub,
+		heartbeatInterval: heartbeatInterval,
+		logger:            logger.Session("domain-handler"),
 	}
 }
 
+type sequencedEventOrError struct {
+	event events.SequencedEvent
+	err   error
+}
+
 func (h *EventHandler) Subscribe(w http.ResponseWriter, req *http.Request) {
 	logger := h.logger.Session("event-handler")
 
@@ -32,7 +40,30 @@ func (h *EventHandler) Subscribe(w http.ResponseWriter, req *http.Request) {
 
 	flusher := w.(http.Flusher)
 
-	source, err := h.hub.Subscribe()
+	filter := eventFilter(req)
+
+	// go-sse clients always send the header, possibly empty, so clients that
+	// need to resume on a fresh connection use the query parameter instead
+	lastEventID := req.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = req.FormValue("last_event_id")
+	}
+
+	requestedEventID := lastEventID
+
+	var source events.SequencedEventSource
+	var err error
+	if lastEventID != "" {
+		epoch, id, parseErr := events.ParseEventID(lastEventID)
+		if parseErr != nil {
+			logger.Error("failed-to-parse-last-event-id", parseErr, lager.Data{"last-event-id": lastEventID})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		source, err = h.hub.Resume(epoch, id, filter)
+	} else {
+		source, err = h.hub.SubscribeSequenced(filter)
+	}
 	if err != nil {
 		logger.Error("failed-to-subscribe-to-event-hub", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -54,32 +85,97 @@ func (h *EventHandler) Subscribe(w http.ResponseWriter, req *http.Request) {
 
 	flusher.Flush()
 
-	eventID := 0
-	for {
-		event, err := source.Next()
-		if err != nil {
-			logger.Error("failed-to-get-next-event", err)
-			return
+	done := make(chan struct{})
+	defer close(done)
+
+	eventsOrErrors := make(chan sequencedEventOrError)
+	go func() {
+		for {
+			sequenced, err := source.NextSequenced()
+			select {
+			case eventsOrErrors <- sequencedEventOrError{sequenced, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
 		}
+	}()
 
-		payload, err := proto.Marshal(event)
+	var heartbeats <-chan time.Time
+	if h.heartbeatInterval > 0 {
+		ticker := time.NewTicker(h.heartbeatInterval)
+		defer ticker.Stop()
+		heartbeats = ticker.C
+
+		// announce the interval up front so the client can time out a stream
+		// that goes quiet before the first tick
+		err = events.NewHeartbeatEvent(lastEventID, h.heartbeatInterval).Write(w)
 		if err != nil {
-			logger.Error("failed-to-marshal-event", err)
 			return
 		}
+		flusher.Flush()
+	}
+
+	for {
+		var sseEvent sse.Event
+
+		select {
+		case <-heartbeats:
+			// heartbeats repeat the last ID sent, as SSE readers reset their
+			// last event ID to whatever an event carries
+			sseEvent = events.NewHeartbeatEvent(lastEventID, h.heartbeatInterval)
+
+		case next := <-eventsOrErrors:
+			if next.err != nil {
+				logger.Error("failed-to-get-next-event", next.err)
+				return
+			}
+
+			sequenced := next.event
+			lastEventID = events.FormatEventID(h.hub.Epoch(), sequenced.ID)
+
+			if sequenced.ResyncRequired {
+				logger.Info("resync-required", lager.Data{"last-event-id": requestedEventID})
+				// SSE readers drop events without data, so carry the ID the client
+				// failed to resume from
+				sseEvent = sse.Event{
+					ID:   lastEventID,
+					Name: events.ResyncRequiredEventName,
+					Data: []byte(requestedEventID),
+				}
+			} else {
+				event := sequenced.Event
+				payload, err := proto.Marshal(event)
+				if err != nil {
+					logger.Error("failed-to-marshal-event", err)
+					return
+				}
+
+				encodedPayload := base64.StdEncoding.EncodeToString(payload)
+				sseEvent = sse.Event{
+					ID:   lastEventID,
+					Name: string(event.EventType()),
+					Data: []byte(encodedPayload),
+				}
+			}
+		}
 
-		encodedPayload := base64.StdEncoding.EncodeToString(payload)
-		err = sse.Event{
-			ID:   strconv.Itoa(eventID),
-			Name: string(event.EventType()),
-			Data: []byte(encodedPayload),
-		}.Write(w)
+		err = sseEvent.Write(w)
 		if err != nil {
-			break
+			return
 		}
 
 		flusher.Flush()
+	}
+}
 
-		eventID++
+func eventFilter(req *http.Request) models.EventFilter {
+	return models.EventFilter{
+		Domain:      req.FormValue("domain"),
+		CellID:      req.FormValue("cell_id"),
+		ProcessGuid: req.FormValue("process_guid"),
+		EventTypes:  req.Form["event_type"],
 	}
 }
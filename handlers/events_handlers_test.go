@@ -5,10 +5,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/cloudfoundry-incubator/bbs/events/eventfakes"
 	"github.com/cloudfoundry-incubator/bbs/handlers"
+	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/pivotal-golang/lager"
 	"github.com/vito/go-sse/sse"
 
@@ -21,18 +23,24 @@ var _ = Describe("Event Handlers", func() {
 		logger lager.Logger
 		hub    events.Hub
 
-		handler *handlers.EventHandler
+		handler           *handlers.EventHandler
+		heartbeatInterval time.Duration
 
 		server *httptest.Server
 	)
 
 	BeforeEach(func() {
-		hub = events.NewHub()
+		hubConfig := events.DefaultHubConfig()
+		hubConfig.Epoch = "some-epoch"
+		hub = events.NewHubWithConfig(hubConfig)
+		heartbeatInterval = 0
 
 		logger = lager.NewLogger("test")
 		logger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+	})
 
-		handler = handlers.NewEventHandler(logger, hub)
+	JustBeforeEach(func() {
+		handler = handlers.NewEventHandler(logger, hub, heartbeatInterval)
 	})
 
 	AfterEach(func() {
@@ -47,9 +55,13 @@ var _ = Describe("Event Handlers", func() {
 		var (
 			response        *http.Response
 			eventStreamDone chan struct{}
+			lastEventID     string
+			query           string
 		)
 
 		BeforeEach(func() {
+			lastEventID = ""
+			query = ""
 			eventStreamDone = make(chan struct{})
 			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				handler.Subscribe(w, r)
@@ -58,8 +70,13 @@ var _ = Describe("Event Handlers", func() {
 		})
 
 		JustBeforeEach(func() {
-			var err error
-			response, err = http.Get(server.URL)
+			request, err := http.NewRequest("GET", server.URL+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+			if lastEventID != "" {
+				request.Header.Set("Last-Event-ID", lastEventID)
+			}
+
+			response, err = http.DefaultClient.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -81,7 +98,7 @@ var _ = Describe("Event Handlers", func() {
 				encodedPayload := base64.StdEncoding.EncodeToString([]byte("A"))
 
 				Expect(reader.Next()).To(Equal(sse.Event{
-					ID:   "0",
+					ID:   "some-epoch-1",
 					Name: "fake",
 					Data: []byte(encodedPayload),
 				}))
@@ -90,13 +107,148 @@ var _ = Describe("Event Handlers", func() {
 
 				encodedPayload = base64.StdEncoding.EncodeToString([]byte("B"))
 				Expect(reader.Next()).To(Equal(sse.Event{
-					ID:   "1",
+					ID:   "some-epoch-2",
 					Name: "fake",
 					Data: []byte(encodedPayload),
 				}))
 
 			})
 
+			Context("when heartbeats are enabled", func() {
+				BeforeEach(func() {
+					heartbeatInterval = 10 * time.Millisecond
+				})
+
+				It("announces the heartbeat interval as soon as the stream opens", func() {
+					reader := sse.NewReadCloser(response.Body)
+
+					event, err := reader.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(event).To(Equal(sse.Event{
+						Name: events.HeartbeatEventName,
+						Data: []byte("10"),
+					}))
+				})
+
+				It("sends heartbeats carrying the last event ID", func() {
+					reader := sse.NewReadCloser(response.Body)
+
+					event, err := reader.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(event.Name).To(Equal(events.HeartbeatEventName))
+					Expect(event.ID).To(BeEmpty())
+
+					hub.Emit(&eventfakes.FakeEvent{"A"})
+					Eventually(func() string {
+						event, err := reader.Next()
+						Expect(err).NotTo(HaveOccurred())
+						return event.Name
+					}).Should(Equal("fake"))
+
+					event, err = reader.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(event).To(Equal(events.NewHeartbeatEvent("some-epoch-1", heartbeatInterval)))
+				})
+			})
+
+			Context("when resuming through the last_event_id query parameter", func() {
+				BeforeEach(func() {
+					hub.Emit(&eventfakes.FakeEvent{"A"})
+					hub.Emit(&eventfakes.FakeEvent{"B"})
+					query = "?last_event_id=some-epoch-1"
+				})
+
+				It("replays the events emitted after it", func() {
+					reader := sse.NewReadCloser(response.Body)
+
+					event, err := reader.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(event.ID).To(Equal("some-epoch-2"))
+				})
+			})
+
+			Context("when filtering events", func() {
+				BeforeEach(func() {
+					query = "?domain=some-domain&event_type=" + models.EventTypeTaskCreated
+				})
+
+				It("only emits the matching events", func() {
+					reader := sse.NewReadCloser(response.Body)
+
+					hub.Emit(&eventfakes.FakeEvent{"A"})
+					hub.Emit(models.NewTaskCreatedEvent(&models.Task{TaskGuid: "other-task", Domain: "other-domain"}))
+					hub.Emit(models.NewTaskCreatedEvent(&models.Task{TaskGuid: "some-task", Domain: "some-domain"}))
+
+					event, err := reader.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(event.ID).To(Equal("some-epoch-3"))
+					Expect(event.Name).To(Equal(models.EventTypeTaskCreated))
+				})
+			})
+
+			Context("when resuming from a Last-Event-ID", func() {
+				BeforeEach(func() {
+					hub.Emit(&eventfakes.FakeEvent{"A"})
+					hub.Emit(&eventfakes.FakeEvent{"B"})
+					lastEventID = "some-epoch-1"
+				})
+
+				It("replays the events emitted after it", func() {
+					reader := sse.NewReadCloser(response.Body)
+
+					encodedPayload := base64.StdEncoding.EncodeToString([]byte("B"))
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "some-epoch-2",
+						Name: "fake",
+						Data: []byte(encodedPayload),
+					}))
+				})
+
+				Context("when the Last-Event-ID is no longer buffered", func() {
+					BeforeEach(func() {
+						lastEventID = "some-epoch-42"
+					})
+
+					It("tells the client to resync", func() {
+						reader := sse.NewReadCloser(response.Body)
+
+						event, err := reader.Next()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(event.ID).To(Equal("some-epoch-2"))
+						Expect(event.Name).To(Equal(events.ResyncRequiredEventName))
+						Expect(event.Data).To(Equal([]byte("some-epoch-42")))
+					})
+				})
+
+				Context("when the Last-Event-ID was issued by a hub with another epoch", func() {
+					BeforeEach(func() {
+						// the other hub has emitted fewer events, so ID 1 is
+						// one this hub has also emitted
+						lastEventID = "other-epoch-1"
+					})
+
+					It("tells the client to resync rather than replaying after it", func() {
+						reader := sse.NewReadCloser(response.Body)
+
+						event, err := reader.Next()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(event.ID).To(Equal("some-epoch-2"))
+						Expect(event.Name).To(Equal(events.ResyncRequiredEventName))
+						Expect(event.Data).To(Equal([]byte("other-epoch-1")))
+					})
+				})
+
+				Context("when the Last-Event-ID is invalid", func() {
+					BeforeEach(func() {
+						lastEventID = "not-an-id"
+					})
+
+					It("responds with 400 BAD REQUEST", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			It("returns Content-Type as text/event-stream", func() {
 				Expect(response.Header.Get("Content-Type")).To(Equal("text/event-stream; charset=utf-8"))
 				Expect(response.Header.Get("Cache-Control")).To(Equal("no-cache, no-store, must-revalidate"))
@@ -2,21 +2,27 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/cloudfoundry-incubator/bbs"
+	"github.com/cloudfoundry-incubator/bbs/authorization"
 	"github.com/cloudfoundry-incubator/bbs/db"
 	"github.com/cloudfoundry-incubator/bbs/events"
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/rata"
 )
 
-func New(logger lager.Logger, db db.DB, hub events.Hub) http.Handler {
+// New returns the BBS API handler. If policy is nil every caller may call
+// every route.
+func New(logger lager.Logger, db db.DB, cellDB db.CellDB, hub events.Hub, eventHeartbeatInterval time.Duration, policy *authorization.Policy) http.Handler {
 	domainHandler := NewDomainHandler(logger, db)
 	actualLRPHandler := NewActualLRPHandler(logger, db)
 	actualLRPLifecycleHandler := NewActualLRPLifecycleHandler(logger, db)
+	evacuationHandler := NewEvacuationHandler(logger, db)
 	desiredLRPHandler := NewDesiredLRPHandler(logger, db)
 	taskHandler := NewTaskHandler(logger, db)
-	eventsHandler := NewEventHandler(logger, hub)
+	cellHandler := NewCellHandler(logger, cellDB)
+	eventsHandler := NewEventHandler(logger, hub, eventHeartbeatInterval)
 
 	actions := rata.Handlers{
 		// Domains
@@ -34,18 +40,47 @@ func New(logger lager.Logger, db db.DB, hub events.Hub) http.Handler {
 		bbs.FailActualLRPRoute:                       route(actualLRPLifecycleHandler.FailActualLRP),
 		bbs.RemoveActualLRPRoute:                     route(actualLRPLifecycleHandler.RemoveActualLRP),
 
+		// Evacuation
+		bbs.EvacuateClaimedActualLRPRoute:  route(evacuationHandler.EvacuateClaimedActualLRP),
+		bbs.EvacuateRunningActualLRPRoute:  route(evacuationHandler.EvacuateRunningActualLRP),
+		bbs.EvacuateStoppedActualLRPRoute:  route(evacuationHandler.EvacuateStoppedActualLRP),
+		bbs.EvacuateCrashedActualLRPRoute:  route(evacuationHandler.EvacuateCrashedActualLRP),
+		bbs.RemoveEvacuatingActualLRPRoute: route(evacuationHandler.RemoveEvacuatingActualLRP),
+
 		// Desired LRPs
 		bbs.DesiredLRPsRoute:             route(desiredLRPHandler.DesiredLRPs),
 		bbs.DesiredLRPByProcessGuidRoute: route(desiredLRPHandler.DesiredLRPByProcessGuid),
+		bbs.DesireDesiredLRPRoute:        route(desiredLRPHandler.DesireDesiredLRP),
+		bbs.UpdateDesiredLRPRoute:        route(desiredLRPHandler.UpdateDesiredLRP),
+		bbs.RemoveDesiredLRPRoute:        route(desiredLRPHandler.RemoveDesiredLRP),
 
 		// Tasks
 		bbs.TasksRoute:      route(taskHandler.Tasks),
 		bbs.TaskByGuidRoute: route(taskHandler.TaskByGuid),
 
+		// Task Lifecycle
+		bbs.DesireTaskRoute:    route(taskHandler.DesireTask),
+		bbs.StartTaskRoute:     route(taskHandler.StartTask),
+		bbs.CancelTaskRoute:    route(taskHandler.CancelTask),
+		bbs.FailTaskRoute:      route(taskHandler.FailTask),
+		bbs.CompleteTaskRoute:  route(taskHandler.CompleteTask),
+		bbs.ResolvingTaskRoute: route(taskHandler.ResolvingTask),
+		bbs.ResolveTaskRoute:   route(taskHandler.ResolveTask),
+
+		// Cells
+		bbs.CellsRoute: route(cellHandler.Cells),
+
 		// Events
 		bbs.EventStreamRoute: route(eventsHandler.Subscribe),
 	}
 
+	if policy != nil {
+		authorizer := NewAuthorizer(logger, policy, db, db, db)
+		for name, action := range actions {
+			actions[name] = authorizer.Authorize(name, action)
+		}
+	}
+
 	handler, err := rata.NewRouter(bbs.Routes, actions)
 	if err != nil {
 		panic("unable to create router: " + err.Error())
@@ -55,5 +90,7 @@ func New(logger lager.Logger, db db.DB, hub events.Hub) http.Handler {
 }
 
 func route(f func(w http.ResponseWriter, r *http.Request)) http.Handler {
-	return http.HandlerFunc(f)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f(negotiateResponseWriter(w, r), r)
+	})
 }
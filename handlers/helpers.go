@@ -1,13 +1,112 @@
 package handlers
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/cloudfoundry-incubator/bbs"
 	"github.com/cloudfoundry-incubator/bbs/models"
 	"github.com/gogo/protobuf/proto"
+	"github.com/pivotal-golang/lager"
 )
 
+type validatingMessage interface {
+	proto.Message
+	Unmarshal(data []byte) error
+	Validate() error
+}
+
+type unmarshaler interface {
+	Unmarshal(data []byte) error
+}
+
+// unmarshalRequest decodes a request body as JSON if the client sent JSON, and
+// as protobuf otherwise.
+func unmarshalRequest(req *http.Request, data []byte, message unmarshaler) error {
+	if isJSONContentType(req.Header.Get(bbs.ContentTypeHeader)) {
+		return json.Unmarshal(data, message)
+	}
+	return message.Unmarshal(data)
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == bbs.JSONContentType
+}
+
+// acceptsJSON reports whether the client prefers JSON responses, either by
+// listing JSON before protobuf in its Accept header or, without a preference,
+// by sending a JSON request body.
+func acceptsJSON(req *http.Request) bool {
+	for _, accepted := range strings.Split(req.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case bbs.JSONContentType:
+			return true
+		case bbs.ProtoContentType:
+			return false
+		}
+	}
+
+	return isJSONContentType(req.Header.Get(bbs.ContentTypeHeader))
+}
+
+// jsonResponseWriter marks a response that should be written as JSON.
+type jsonResponseWriter struct {
+	http.ResponseWriter
+}
+
+// negotiateResponseWriter wraps w so that responses are rendered as JSON if
+// the client asked for it.
+func negotiateResponseWriter(w http.ResponseWriter, req *http.Request) http.ResponseWriter {
+	if _, ok := w.(jsonResponseWriter); ok || !acceptsJSON(req) {
+		return w
+	}
+	return jsonResponseWriter{w}
+}
+
+func (w jsonResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w jsonResponseWriter) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
+
+func parseRequest(logger lager.Logger, w http.ResponseWriter, req *http.Request, request validatingMessage) bool {
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		logger.Error("failed-to-read-body", err)
+		writeUnknownErrorResponse(w, err)
+		return false
+	}
+
+	err = unmarshalRequest(req, data, request)
+	if err != nil {
+		logger.Error("failed-to-parse-request-body", err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return false
+	}
+
+	if err := request.Validate(); err != nil {
+		logger.Error("invalid-request", err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
+		return false
+	}
+
+	return true
+}
+
 func writeUnknownErrorResponse(w http.ResponseWriter, err error) {
 	writeProtoResponse(w, http.StatusInternalServerError, &models.Error{
 		Type:    models.UnknownError,
@@ -22,6 +121,27 @@ func writeNotFoundResponse(w http.ResponseWriter, err error) {
 	})
 }
 
+func writeConflictResponse(w http.ResponseWriter, err error) {
+	writeProtoResponse(w, http.StatusConflict, &models.Error{
+		Type:    models.ResourceConflict,
+		Message: err.Error(),
+	})
+}
+
+func writeUnauthorizedResponse(w http.ResponseWriter, err error) {
+	writeProtoResponse(w, http.StatusUnauthorized, &models.Error{
+		Type:    models.Unauthorized,
+		Message: err.Error(),
+	})
+}
+
+func writeForbiddenResponse(w http.ResponseWriter, err error) {
+	writeProtoResponse(w, http.StatusForbidden, &models.Error{
+		Type:    models.Unauthorized,
+		Message: err.Error(),
+	})
+}
+
 func writeBadRequestResponse(w http.ResponseWriter, errorType string, err error) {
 	writeProtoResponse(w, http.StatusBadRequest, &models.Error{
 		Type:    errorType,
@@ -29,14 +149,34 @@ func writeBadRequestResponse(w http.ResponseWriter, errorType string, err error)
 	})
 }
 
+// writeProtoResponse writes message as protobuf, or as JSON if the client
+// asked for it.
 func writeProtoResponse(w http.ResponseWriter, statusCode int, message proto.Message) {
+	if _, ok := w.(jsonResponseWriter); ok {
+		writeJSONResponse(w, statusCode, message)
+		return
+	}
+
 	responseBytes, err := proto.Marshal(message)
 	if err != nil {
 		panic("Unable to encode Proto: " + err.Error())
 	}
 
 	w.Header().Set("Content-Length", strconv.Itoa(len(responseBytes)))
-	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Type", bbs.ProtoContentType)
+	w.WriteHeader(statusCode)
+
+	w.Write(responseBytes)
+}
+
+func writeJSONResponse(w http.ResponseWriter, statusCode int, message proto.Message) {
+	responseBytes, err := json.Marshal(message)
+	if err != nil {
+		panic("Unable to encode JSON: " + err.Error())
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(responseBytes)))
+	w.Header().Set("Content-Type", bbs.JSONContentType)
 	w.WriteHeader(statusCode)
 
 	w.Write(responseBytes)
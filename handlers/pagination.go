@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+)
+
+var errInvalidLimit = errors.New("limit must be a non-negative integer")
+var errInvalidContinuationToken = errors.New("invalid continuation token")
+
+// A page selects up to limit records whose keys sort after the key the
+// continuation token was issued for; the db does the selecting. Records
+// created behind the token since it was issued, including a task created
+// again under a guid already paged past, are not seen by the rest of the walk.
+type page struct {
+	limit int
+	after string
+}
+
+func parsePage(req *http.Request) (page, error) {
+	var p page
+
+	if limit := req.FormValue("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return page{}, errInvalidLimit
+		}
+		p.limit = n
+	}
+
+	if token := req.FormValue("continuation_token"); token != "" {
+		after, err := base64.URLEncoding.DecodeString(token)
+		if err != nil || len(after) == 0 {
+			return page{}, errInvalidContinuationToken
+		}
+		p.after = string(after)
+	}
+
+	return p, nil
+}
+
+// dbLimit asks the db for one record more than the page holds, so that the
+// page knows whether another follows it.
+func (p page) dbLimit() int {
+	if p.limit == 0 {
+		return 0
+	}
+	return p.limit + 1
+}
+
+// cut returns how many of count records the page holds, and whether more
+// follow it.
+func (p page) cut(count int) (int, bool) {
+	if p.limit > 0 && count > p.limit {
+		return p.limit, true
+	}
+	return count, false
+}
+
+func continuationToken(key string) string {
+	return base64.URLEncoding.EncodeToString([]byte(key))
+}
+
+func (p page) actualLRPFilter(filter models.ActualLRPFilter) (models.ActualLRPFilter, error) {
+	filter.Limit = p.dbLimit()
+	if p.after == "" {
+		return filter, nil
+	}
+
+	sep := strings.LastIndex(p.after, "\x00")
+	if sep <= 0 {
+		return filter, errInvalidContinuationToken
+	}
+	index, err := strconv.ParseInt(p.after[sep+1:], 10, 32)
+	if err != nil {
+		return filter, errInvalidContinuationToken
+	}
+
+	filter.AfterProcessGuid = p.after[:sep]
+	filter.AfterIndex = int32(index)
+	return filter, nil
+}
+
+func (p page) desiredLRPFilter(filter models.DesiredLRPFilter) models.DesiredLRPFilter {
+	filter.Limit = p.dbLimit()
+	filter.AfterProcessGuid = p.after
+	return filter
+}
+
+func (p page) taskFilter(filter models.TaskFilter) models.TaskFilter {
+	filter.Limit = p.dbLimit()
+	filter.AfterTaskGuid = p.after
+	return filter
+}
+
+func pageActualLRPGroups(groups *models.ActualLRPGroups, p page) *models.ActualLRPGroups {
+	end, more := p.cut(len(groups.GetActualLrpGroups()))
+	if !more {
+		return groups
+	}
+
+	records := groups.ActualLrpGroups[:end]
+	lrp, _ := records[end-1].Resolve()
+	return &models.ActualLRPGroups{
+		ActualLrpGroups:   records,
+		ContinuationToken: continuationToken(lrp.ProcessGuid + "\x00" + strconv.Itoa(int(lrp.Index))),
+	}
+}
+
+func pageDesiredLRPs(desiredLRPs *models.DesiredLRPs, p page) *models.DesiredLRPs {
+	end, more := p.cut(len(desiredLRPs.GetDesiredLrps()))
+	if !more {
+		return desiredLRPs
+	}
+
+	records := desiredLRPs.DesiredLrps[:end]
+	return &models.DesiredLRPs{
+		DesiredLrps:       records,
+		ContinuationToken: continuationToken(records[end-1].ProcessGuid),
+	}
+}
+
+func pageTasks(tasks *models.Tasks, p page) *models.Tasks {
+	end, more := p.cut(len(tasks.GetTasks()))
+	if !more {
+		return tasks
+	}
+
+	records := tasks.Tasks[:end]
+	return &models.Tasks{
+		Tasks:             records,
+		ContinuationToken: continuationToken(records[end-1].TaskGuid),
+	}
+}
@@ -34,29 +34,20 @@ func (h *TaskHandler) Tasks(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	tasks, err := h.db.Tasks(h.logger, taskFilter(domain, cellID))
+	p, err := parsePage(req)
 	if err != nil {
-		logger.Error("failed-to-fetch-tasks", err)
-		writeUnknownErrorResponse(w, err)
+		writeBadRequestResponse(w, models.InvalidRequest, err)
 		return
 	}
 
-	writeProtoResponse(w, http.StatusOK, tasks)
-}
-
-func taskFilter(domain string, cellID string) db.TaskFilter {
-	if domain != "" {
-		return func(t *models.Task) bool {
-			return domain == t.Domain
-		}
-	}
-	if cellID != "" {
-		return func(t *models.Task) bool {
-			return cellID == t.CellId
-		}
+	tasks, bbsErr := h.db.Tasks(h.logger, p.taskFilter(models.TaskFilter{Domain: domain, CellID: cellID}))
+	if bbsErr != nil {
+		logger.Error("failed-to-fetch-tasks", bbsErr)
+		writeUnknownErrorResponse(w, bbsErr)
+		return
 	}
 
-	return nil
+	writeProtoResponse(w, http.StatusOK, pageTasks(tasks, p))
 }
 
 func (h *TaskHandler) TaskByGuid(w http.ResponseWriter, req *http.Request) {
@@ -78,3 +69,148 @@ func (h *TaskHandler) TaskByGuid(w http.ResponseWriter, req *http.Request) {
 
 	writeProtoResponse(w, http.StatusOK, task)
 }
+
+func (h *TaskHandler) DesireTask(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("desire-task")
+
+	task := &models.Task{}
+	if !parseRequest(logger, w, req, task) {
+		return
+	}
+
+	bbsErr := h.db.DesireTask(logger, task)
+	if bbsErr != nil {
+		logger.Error("failed-to-desire-task", bbsErr)
+		writeTaskErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusCreated)
+}
+
+func (h *TaskHandler) StartTask(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("start-task")
+
+	request := &models.StartTaskRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	shouldStart, bbsErr := h.db.StartTask(logger, request.TaskGuid, request.CellId)
+	if bbsErr != nil {
+		logger.Error("failed-to-start-task", bbsErr)
+		writeTaskErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeProtoResponse(w, http.StatusOK, &models.StartTaskResponse{ShouldStart: shouldStart})
+}
+
+func (h *TaskHandler) CancelTask(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("cancel-task")
+
+	request := &models.TaskGuidRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	bbsErr := h.db.CancelTask(logger, request.TaskGuid)
+	if bbsErr != nil {
+		logger.Error("failed-to-cancel-task", bbsErr)
+		writeTaskErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
+
+func (h *TaskHandler) FailTask(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("fail-task")
+
+	request := &models.FailTaskRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	bbsErr := h.db.FailTask(logger, request.TaskGuid, request.FailureReason)
+	if bbsErr != nil {
+		logger.Error("failed-to-fail-task", bbsErr)
+		writeTaskErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
+
+func (h *TaskHandler) CompleteTask(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("complete-task")
+
+	request := &models.CompleteTaskRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	bbsErr := h.db.CompleteTask(logger, request.TaskGuid, request.CellId, request.Failed, request.FailureReason, request.Result)
+	if bbsErr != nil {
+		logger.Error("failed-to-complete-task", bbsErr)
+		writeTaskErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
+
+func (h *TaskHandler) ResolvingTask(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("resolving-task")
+
+	request := &models.TaskGuidRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	bbsErr := h.db.ResolvingTask(logger, request.TaskGuid)
+	if bbsErr != nil {
+		logger.Error("failed-to-mark-task-as-resolving", bbsErr)
+		writeTaskErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
+
+func (h *TaskHandler) ResolveTask(w http.ResponseWriter, req *http.Request) {
+	logger := h.logger.Session("resolve-task")
+
+	request := &models.TaskGuidRequest{}
+	if !parseRequest(logger, w, req, request) {
+		return
+	}
+
+	bbsErr := h.db.ResolveTask(logger, request.TaskGuid)
+	if bbsErr != nil {
+		logger.Error("failed-to-resolve-task", bbsErr)
+		writeTaskErrorResponse(w, bbsErr)
+		return
+	}
+
+	writeEmptyResponse(w, http.StatusNoContent)
+}
+
+func writeTaskErrorResponse(w http.ResponseWriter, bbsErr *models.Error) {
+	switch bbsErr.Type {
+	case models.ResourceNotFound:
+		writeNotFoundResponse(w, bbsErr)
+	case models.ResourceConflict:
+		writeConflictResponse(w, bbsErr)
+	case models.TaskCannotBeStarted,
+		models.TaskCannotBeCancelled,
+		models.TaskCannotBeFailed,
+		models.TaskCannotBeCompleted,
+		models.TaskCannotBeMarkedAsResolving,
+		models.TaskCannotBeResolved,
+		models.TaskRunningOnDifferentCell:
+		writeProtoResponse(w, http.StatusConflict, bbsErr)
+	default:
+		writeUnknownErrorResponse(w, bbsErr)
+	}
+}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -8,6 +9,7 @@ import (
 	"github.com/cloudfoundry-incubator/bbs/db/fakes"
 	"github.com/cloudfoundry-incubator/bbs/handlers"
 	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-golang/lager"
@@ -51,7 +53,7 @@ var _ = Describe("Task Handlers", func() {
 
 			BeforeEach(func() {
 				tasks = &models.Tasks{
-					[]*models.Task{&task1, &task2},
+					Tasks: []*models.Task{&task1, &task2},
 				}
 				fakeTaskDB.TasksReturns(tasks, nil)
 			})
@@ -71,7 +73,7 @@ var _ = Describe("Task Handlers", func() {
 			It("calls the DB with no filter", func() {
 				Expect(fakeTaskDB.TasksCallCount()).To(Equal(1))
 				_, filter := fakeTaskDB.TasksArgsForCall(0)
-				Expect(filter).To(BeNil())
+				Expect(filter).To(Equal(models.TaskFilter{}))
 			})
 
 			Context("and filtering by domain", func() {
@@ -84,8 +86,7 @@ var _ = Describe("Task Handlers", func() {
 				It("calls the DB with a domain filter", func() {
 					Expect(fakeTaskDB.TasksCallCount()).To(Equal(1))
 					_, filter := fakeTaskDB.TasksArgsForCall(0)
-					Expect(filter(&task1)).To(BeTrue())
-					Expect(filter(&task2)).To(BeFalse())
+					Expect(filter).To(Equal(models.TaskFilter{Domain: "domain-1"}))
 				})
 			})
 
@@ -99,8 +100,79 @@ var _ = Describe("Task Handlers", func() {
 				It("calls the DB with a cell filter", func() {
 					Expect(fakeTaskDB.TasksCallCount()).To(Equal(1))
 					_, filter := fakeTaskDB.TasksArgsForCall(0)
-					Expect(filter(&task1)).To(BeFalse())
-					Expect(filter(&task2)).To(BeTrue())
+					Expect(filter).To(Equal(models.TaskFilter{CellID: "cell-id"}))
+				})
+			})
+
+			Context("and paginating", func() {
+				var taskA, taskB, taskC models.Task
+
+				pageRequest := func(query string) *http.Request {
+					request, err := http.NewRequest("", "http://example.com?"+query, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return request
+				}
+
+				readPage := func() *models.Tasks {
+					response := &models.Tasks{}
+					err := response.Unmarshal(responseRecorder.Body.Bytes())
+					Expect(err).NotTo(HaveOccurred())
+					return response
+				}
+
+				BeforeEach(func() {
+					taskA = models.Task{TaskGuid: "task-a"}
+					taskB = models.Task{TaskGuid: "task-b"}
+					taskC = models.Task{TaskGuid: "task-c"}
+					tasks.Tasks = []*models.Task{&taskA, &taskB, &taskC}
+
+					request = pageRequest("limit=2")
+				})
+
+				It("asks the DB for one task more than the page holds", func() {
+					_, filter := fakeTaskDB.TasksArgsForCall(0)
+					Expect(filter).To(Equal(models.TaskFilter{Limit: 3}))
+				})
+
+				It("returns the first page with a continuation token", func() {
+					page := readPage()
+					Expect(page.Tasks).To(Equal([]*models.Task{&taskA, &taskB}))
+					Expect(page.ContinuationToken).NotTo(BeEmpty())
+				})
+
+				It("returns the rest after the continuation token", func() {
+					token := readPage().ContinuationToken
+
+					fakeTaskDB.TasksReturns(&models.Tasks{Tasks: []*models.Task{&taskC}}, nil)
+					responseRecorder = httptest.NewRecorder()
+					handler.Tasks(responseRecorder, pageRequest("limit=2&continuation_token="+token))
+
+					_, filter := fakeTaskDB.TasksArgsForCall(1)
+					Expect(filter).To(Equal(models.TaskFilter{Limit: 3, AfterTaskGuid: "task-b"}))
+
+					page := readPage()
+					Expect(page.Tasks).To(Equal([]*models.Task{&taskC}))
+					Expect(page.ContinuationToken).To(BeEmpty())
+				})
+
+				Context("when the limit is invalid", func() {
+					BeforeEach(func() {
+						request = pageRequest("limit=-1")
+					})
+
+					It("responds with 400 Bad Request", func() {
+						Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the continuation token is invalid", func() {
+					BeforeEach(func() {
+						request = pageRequest("continuation_token=%25%25")
+					})
+
+					It("responds with 400 Bad Request", func() {
+						Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+					})
 				})
 			})
 
@@ -212,4 +284,277 @@ var _ = Describe("Task Handlers", func() {
 			})
 		})
 	})
+
+	Describe("DesireTask", func() {
+		var (
+			task        *models.Task
+			requestBody interface{}
+			contentType string
+		)
+
+		BeforeEach(func() {
+			task = model_helpers.NewValidTask("task-guid")
+			requestBody = task
+			contentType = ""
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			if contentType != "" {
+				request.Header.Set("Content-Type", contentType)
+			}
+			handler.DesireTask(responseRecorder, request)
+		})
+
+		Context("when desiring the task succeeds", func() {
+			It("responds with 201 CREATED", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusCreated))
+			})
+
+			It("desires the task in the DB", func() {
+				Expect(fakeTaskDB.DesireTaskCallCount()).To(Equal(1))
+				_, actualTask := fakeTaskDB.DesireTaskArgsForCall(0)
+				Expect(actualTask).To(Equal(task))
+			})
+		})
+
+		Context("when the task is sent as JSON", func() {
+			BeforeEach(func() {
+				payload, err := json.Marshal(task)
+				Expect(err).NotTo(HaveOccurred())
+				requestBody = payload
+				contentType = "application/json"
+			})
+
+			It("desires the decoded task", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusCreated))
+				Expect(fakeTaskDB.DesireTaskCallCount()).To(Equal(1))
+				_, actualTask := fakeTaskDB.DesireTaskArgsForCall(0)
+				Expect(actualTask.TaskGuid).To(Equal(task.TaskGuid))
+				Expect(actualTask.Action).To(Equal(task.Action))
+			})
+		})
+
+		Context("when the task is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.Task{}
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeTaskDB.DesireTaskCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the task already exists", func() {
+			BeforeEach(func() {
+				fakeTaskDB.DesireTaskReturns(models.ErrResourceExists)
+			})
+
+			It("responds with 409 CONFLICT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("StartTask", func() {
+		var requestBody interface{}
+
+		BeforeEach(func() {
+			requestBody = &models.StartTaskRequest{
+				TaskGuid: "task-guid",
+				CellId:   "cell-id",
+			}
+		})
+
+		JustBeforeEach(func() {
+			handler.StartTask(responseRecorder, newTestRequest(requestBody))
+		})
+
+		Context("when starting the task succeeds", func() {
+			BeforeEach(func() {
+				fakeTaskDB.StartTaskReturns(true, nil)
+			})
+
+			It("starts the task on the cell", func() {
+				Expect(fakeTaskDB.StartTaskCallCount()).To(Equal(1))
+				_, taskGuid, cellId := fakeTaskDB.StartTaskArgsForCall(0)
+				Expect(taskGuid).To(Equal("task-guid"))
+				Expect(cellId).To(Equal("cell-id"))
+			})
+
+			It("responds with whether the cell should start the task", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+
+				response := &models.StartTaskResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.ShouldStart).To(BeTrue())
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.StartTaskRequest{}
+			})
+
+			It("responds with 400 BAD REQUEST", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeTaskDB.StartTaskCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the task cannot be started", func() {
+			BeforeEach(func() {
+				fakeTaskDB.StartTaskReturns(false, models.ErrTaskCannotBeStarted)
+			})
+
+			It("responds with 409 CONFLICT and the typed error", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+
+				var bbsError models.Error
+				err := bbsError.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bbsError.Equal(models.ErrTaskCannotBeStarted)).To(BeTrue())
+			})
+		})
+
+		Context("when the task does not exist", func() {
+			BeforeEach(func() {
+				fakeTaskDB.StartTaskReturns(false, models.ErrResourceNotFound)
+			})
+
+			It("responds with 404", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("CancelTask", func() {
+		JustBeforeEach(func() {
+			handler.CancelTask(responseRecorder, newTestRequest(&models.TaskGuidRequest{TaskGuid: "task-guid"}))
+		})
+
+		It("cancels the task and responds with 204", func() {
+			Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+			Expect(fakeTaskDB.CancelTaskCallCount()).To(Equal(1))
+			_, taskGuid := fakeTaskDB.CancelTaskArgsForCall(0)
+			Expect(taskGuid).To(Equal("task-guid"))
+		})
+
+		Context("when the task cannot be cancelled", func() {
+			BeforeEach(func() {
+				fakeTaskDB.CancelTaskReturns(models.ErrTaskCannotBeCancelled)
+			})
+
+			It("responds with 409 CONFLICT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("FailTask", func() {
+		JustBeforeEach(func() {
+			request := &models.FailTaskRequest{TaskGuid: "task-guid", FailureReason: "some-reason"}
+			handler.FailTask(responseRecorder, newTestRequest(request))
+		})
+
+		It("fails the task and responds with 204", func() {
+			Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+			Expect(fakeTaskDB.FailTaskCallCount()).To(Equal(1))
+			_, taskGuid, failureReason := fakeTaskDB.FailTaskArgsForCall(0)
+			Expect(taskGuid).To(Equal("task-guid"))
+			Expect(failureReason).To(Equal("some-reason"))
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeTaskDB.FailTaskReturns(models.ErrUnknownError)
+			})
+
+			It("responds with a 500", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("CompleteTask", func() {
+		JustBeforeEach(func() {
+			request := &models.CompleteTaskRequest{
+				TaskGuid:      "task-guid",
+				CellId:        "cell-id",
+				Failed:        true,
+				FailureReason: "some-reason",
+				Result:        "some-result",
+			}
+			handler.CompleteTask(responseRecorder, newTestRequest(request))
+		})
+
+		It("completes the task and responds with 204", func() {
+			Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+			Expect(fakeTaskDB.CompleteTaskCallCount()).To(Equal(1))
+			_, taskGuid, cellId, failed, failureReason, result := fakeTaskDB.CompleteTaskArgsForCall(0)
+			Expect(taskGuid).To(Equal("task-guid"))
+			Expect(cellId).To(Equal("cell-id"))
+			Expect(failed).To(BeTrue())
+			Expect(failureReason).To(Equal("some-reason"))
+			Expect(result).To(Equal("some-result"))
+		})
+
+		Context("when the task is running on a different cell", func() {
+			BeforeEach(func() {
+				fakeTaskDB.CompleteTaskReturns(models.ErrTaskRunningOnDifferentCell)
+			})
+
+			It("responds with 409 CONFLICT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("ResolvingTask", func() {
+		JustBeforeEach(func() {
+			handler.ResolvingTask(responseRecorder, newTestRequest(&models.TaskGuidRequest{TaskGuid: "task-guid"}))
+		})
+
+		It("marks the task as resolving and responds with 204", func() {
+			Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+			Expect(fakeTaskDB.ResolvingTaskCallCount()).To(Equal(1))
+			_, taskGuid := fakeTaskDB.ResolvingTaskArgsForCall(0)
+			Expect(taskGuid).To(Equal("task-guid"))
+		})
+
+		Context("when the task cannot be marked as resolving", func() {
+			BeforeEach(func() {
+				fakeTaskDB.ResolvingTaskReturns(models.ErrTaskCannotBeMarkedAsResolving)
+			})
+
+			It("responds with 409 CONFLICT", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("ResolveTask", func() {
+		JustBeforeEach(func() {
+			handler.ResolveTask(responseRecorder, newTestRequest(&models.TaskGuidRequest{TaskGuid: "task-guid"}))
+		})
+
+		It("resolves the task and responds with 204", func() {
+			Expect(responseRecorder.Code).To(Equal(http.StatusNoContent))
+			Expect(fakeTaskDB.ResolveTaskCallCount()).To(Equal(1))
+			_, taskGuid := fakeTaskDB.ResolveTaskArgsForCall(0)
+			Expect(taskGuid).To(Equal("task-guid"))
+		})
+
+		Context("when the task does not exist", func() {
+			BeforeEach(func() {
+				fakeTaskDB.ResolveTaskReturns(models.ErrResourceNotFound)
+			})
+
+			It("responds with 404", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
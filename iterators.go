@@ -0,0 +1,178 @@
+package bbs
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+)
+
+// An ActualLRPGroupIterator fetches actual LRP groups a page at a time, in
+// order of process guid and index. Next returns io.EOF once every group has
+// been returned.
+type ActualLRPGroupIterator interface {
+	Next() (*models.ActualLRPGroup, error)
+}
+
+// A DesiredLRPIterator fetches desired LRPs a page at a time, in order of
+// process guid. Next returns io.EOF once every desired LRP has been returned.
+type DesiredLRPIterator interface {
+	Next() (*models.DesiredLRP, error)
+}
+
+// A TaskIterator fetches tasks a page at a time, in order of task guid. Next
+// returns io.EOF once every task has been returned.
+type TaskIterator interface {
+	Next() (*models.Task, error)
+}
+
+func (c *client) IterateActualLRPGroups(filter models.ActualLRPFilter, pageSize int) ActualLRPGroupIterator {
+	return &actualLRPGroupIterator{
+		client: c,
+		query:  actualLRPFilterQuery(filter),
+		pager:  pager{pageSize: pageSize},
+	}
+}
+
+func (c *client) IterateDesiredLRPs(filter models.DesiredLRPFilter, pageSize int) DesiredLRPIterator {
+	return &desiredLRPIterator{
+		client: c,
+		query:  desiredLRPFilterQuery(filter),
+		pager:  pager{pageSize: pageSize},
+	}
+}
+
+func (c *client) IterateTasks(filter models.TaskFilter, pageSize int) TaskIterator {
+	query := url.Values{}
+	if filter.Domain != "" {
+		query.Set("domain", filter.Domain)
+	}
+	if filter.CellID != "" {
+		query.Set("cell_id", filter.CellID)
+	}
+
+	return &taskIterator{
+		client: c,
+		query:  query,
+		pager:  pager{pageSize: pageSize},
+	}
+}
+
+// pager tracks the continuation token between the pages of a list request.
+type pager struct {
+	pageSize int
+	token    string
+	done     bool
+}
+
+func (p *pager) pageQuery(filter url.Values) (url.Values, bool) {
+	if p.done {
+		return nil, false
+	}
+
+	query := url.Values{}
+	for key, values := range filter {
+		query[key] = values
+	}
+	if p.pageSize > 0 {
+		query.Set("limit", strconv.Itoa(p.pageSize))
+	}
+	if p.token != "" {
+		query.Set("continuation_token", p.token)
+	}
+
+	return query, true
+}
+
+func (p *pager) advance(continuationToken string) {
+	p.token = continuationToken
+	p.done = continuationToken == ""
+}
+
+type actualLRPGroupIterator struct {
+	pager
+	client *client
+	query  url.Values
+	groups []*models.ActualLRPGroup
+}
+
+func (it *actualLRPGroupIterator) Next() (*models.ActualLRPGroup, error) {
+	for len(it.groups) == 0 {
+		query, more := it.pageQuery(it.query)
+		if !more {
+			return nil, io.EOF
+		}
+
+		var page models.ActualLRPGroups
+		err := it.client.doRequest(ActualLRPGroupsRoute, nil, query, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		it.groups = page.GetActualLrpGroups()
+		it.advance(page.GetContinuationToken())
+	}
+
+	group := it.groups[0]
+	it.groups = it.groups[1:]
+	return group, nil
+}
+
+type desiredLRPIterator struct {
+	pager
+	client      *client
+	query       url.Values
+	desiredLRPs []*models.DesiredLRP
+}
+
+func (it *desiredLRPIterator) Next() (*models.DesiredLRP, error) {
+	for len(it.desiredLRPs) == 0 {
+		query, more := it.pageQuery(it.query)
+		if !more {
+			return nil, io.EOF
+		}
+
+		var page models.DesiredLRPs
+		err := it.client.doRequest(DesiredLRPsRoute, nil, query, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		it.desiredLRPs = page.GetDesiredLrps()
+		it.advance(page.GetContinuationToken())
+	}
+
+	desiredLRP := it.desiredLRPs[0]
+	it.desiredLRPs = it.desiredLRPs[1:]
+	return desiredLRP, nil
+}
+
+type taskIterator struct {
+	pager
+	client *client
+	query  url.Values
+	tasks  []*models.Task
+}
+
+func (it *taskIterator) Next() (*models.Task, error) {
+	for len(it.tasks) == 0 {
+		query, more := it.pageQuery(it.query)
+		if !more {
+			return nil, io.EOF
+		}
+
+		var page models.Tasks
+		err := it.client.doRequest(TasksRoute, nil, query, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		it.tasks = page.GetTasks()
+		it.advance(page.GetContinuationToken())
+	}
+
+	task := it.tasks[0]
+	it.tasks = it.tasks[1:]
+	return task, nil
+}
@@ -0,0 +1,225 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/coreos/go-etcd/etcd"
+	"github.com/nu7hatch/gouuid"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/ifrit"
+)
+
+const (
+	migrationLockTTL           = 30 * time.Second
+	migrationLockRetryInterval = 5 * time.Second
+)
+
+// manager runs pending migrations before reporting ready, and refuses to run
+// at all against data newer than the latest migration it knows about. It
+// holds the migration lock from reading the version until the last migration
+// is recorded, so that only one BBS migrates at a time; the others wait and
+// then find the data already migrated.
+type manager struct {
+	logger      lager.Logger
+	versionDB   db.VersionDB
+	storeClient *etcd.Client
+	serializer  format.Serializer
+	migrations  []Migration
+	clock       clock.Clock
+}
+
+func NewManager(
+	logger lager.Logger,
+	versionDB db.VersionDB,
+	storeClient *etcd.Client,
+	serializer format.Serializer,
+	migrations []Migration,
+	clock clock.Clock,
+) ifrit.Runner {
+	return &manager{
+		logger:      logger,
+		versionDB:   versionDB,
+		storeClient: storeClient,
+		serializer:  serializer,
+		migrations:  migrations,
+		clock:       clock,
+	}
+}
+
+func (m *manager) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := m.logger.Session("migration-manager")
+	logger.Info("starting")
+
+	migrations, err := sortMigrations(m.migrations)
+	if err != nil {
+		logger.Error("invalid-migrations", err)
+		return err
+	}
+
+	guid, err := uuid.NewV4()
+	if err != nil {
+		logger.Error("failed-to-generate-lock-owner", err)
+		return err
+	}
+	owner := guid.String()
+
+	locked, err := m.lock(logger, owner, signals)
+	if err != nil || !locked {
+		return err
+	}
+
+	currentVersion, err := m.migrate(logger, owner, migrations)
+	if err != nil {
+		return err
+	}
+
+	close(ready)
+	logger.Info("started", lager.Data{"version": currentVersion})
+	defer logger.Info("finished")
+
+	<-signals
+	return nil
+}
+
+// lock waits for the migration lock, giving up if signalled.
+func (m *manager) lock(logger lager.Logger, owner string, signals <-chan os.Signal) (bool, error) {
+	for {
+		bbsErr := m.versionDB.LockMigrations(logger, owner, migrationLockTTL)
+		if bbsErr == nil {
+			return true, nil
+		}
+		if !bbsErr.Equal(models.ErrResourceExists) {
+			logger.Error("failed-to-lock-migrations", bbsErr)
+			return false, bbsErr
+		}
+
+		logger.Info("waiting-for-migration-lock")
+		timer := m.clock.NewTimer(migrationLockRetryInterval)
+		select {
+		case <-signals:
+			timer.Stop()
+			return false, nil
+		case <-timer.C():
+		}
+	}
+}
+
+// migrate runs the pending migrations, renewing the migration lock while
+// they run and releasing it once they are done.
+func (m *manager) migrate(logger lager.Logger, owner string, migrations []Migration) (int64, error) {
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := m.clock.NewTicker(migrationLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C():
+				bbsErr := m.versionDB.LockMigrations(logger, owner, migrationLockTTL)
+				if bbsErr != nil {
+					logger.Error("failed-to-renew-migration-lock", bbsErr)
+				}
+			}
+		}
+	}()
+
+	defer func() {
+		close(done)
+		<-renewed
+		bbsErr := m.versionDB.UnlockMigrations(logger, owner)
+		if bbsErr != nil {
+			logger.Error("failed-to-unlock-migrations", bbsErr)
+		}
+	}()
+
+	var targetVersion int64
+	if len(migrations) > 0 {
+		targetVersion = migrations[len(migrations)-1].Version()
+	}
+
+	currentVersion, bbsErr := m.versionDB.Version(logger)
+	versionRecorded := true
+	if bbsErr != nil {
+		if !bbsErr.Equal(models.ErrResourceNotFound) {
+			logger.Error("failed-to-fetch-version", bbsErr)
+			return 0, bbsErr
+		}
+		currentVersion = 0
+		versionRecorded = false
+	}
+
+	logger.Info("fetched-version", lager.Data{"current-version": currentVersion, "target-version": targetVersion})
+
+	if currentVersion > targetVersion {
+		err := fmt.Errorf("stored data is at version %d, but this BBS only understands up to version %d", currentVersion, targetVersion)
+		logger.Error("data-newer-than-bbs", err)
+		return 0, err
+	}
+
+	for _, migration := range migrations {
+		if migration.Version() <= currentVersion {
+			continue
+		}
+
+		migrationLogger := logger.Session("migration", lager.Data{"version": migration.Version()})
+		migrationLogger.Info("starting")
+		err := migration.Up(migrationLogger, m.storeClient, m.serializer)
+		if err != nil {
+			migrationLogger.Error("failed", err)
+			return 0, err
+		}
+
+		bbsErr := m.versionDB.SetVersion(migrationLogger, currentVersion, migration.Version())
+		if bbsErr != nil {
+			migrationLogger.Error("failed-to-set-version", bbsErr)
+			return 0, bbsErr
+		}
+		migrationLogger.Info("succeeded")
+
+		currentVersion = migration.Version()
+		versionRecorded = true
+	}
+
+	if !versionRecorded {
+		bbsErr := m.versionDB.SetVersion(logger, currentVersion, currentVersion)
+		if bbsErr != nil {
+			logger.Error("failed-to-set-version", bbsErr)
+			return 0, bbsErr
+		}
+	}
+
+	return currentVersion, nil
+}
+
+func sortMigrations(migrations []Migration) ([]Migration, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Sort(byVersion(sorted))
+
+	for i, migration := range sorted {
+		if migration.Version() < 1 {
+			return nil, fmt.Errorf("migration versions must be positive, found %d", migration.Version())
+		}
+		if i > 0 && sorted[i-1].Version() == migration.Version() {
+			return nil, fmt.Errorf("multiple migrations to version %d", migration.Version())
+		}
+	}
+
+	return sorted, nil
+}
+
+type byVersion []Migration
+
+func (m byVersion) Len() int           { return len(m) }
+func (m byVersion) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m byVersion) Less(i, j int) bool { return m[i].Version() < m[j].Version() }
@@ -0,0 +1,259 @@
+package migration_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/cloudfoundry-incubator/bbs/migration"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/coreos/go-etcd/etcd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		logger     *lagertest.TestLogger
+		versionDB  *fakes.FakeVersionDB
+		clock      *fakeclock.FakeClock
+		ran        *runLog
+		migrations []migration.Migration
+		process    ifrit.Process
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		versionDB = new(fakes.FakeVersionDB)
+		clock = fakeclock.NewFakeClock(time.Now())
+		ran = &runLog{}
+		migrations = []migration.Migration{
+			&fakeMigration{version: 2, ran: ran},
+			&fakeMigration{version: 1, ran: ran},
+		}
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Background(migration.NewManager(logger, versionDB, nil, nil, migrations, clock))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	Context("when the data is at an older version", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(1, nil)
+		})
+
+		It("runs the pending migrations before becoming ready", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+			Expect(ran.versions()).To(Equal([]int64{2}))
+
+			Expect(versionDB.SetVersionCallCount()).To(Equal(1))
+			_, prevVersion, version := versionDB.SetVersionArgsForCall(0)
+			Expect(prevVersion).To(BeEquivalentTo(1))
+			Expect(version).To(BeEquivalentTo(2))
+		})
+
+		It("holds the migration lock while migrating", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+
+			Expect(versionDB.LockMigrationsCallCount()).To(Equal(1))
+			_, owner, _ := versionDB.LockMigrationsArgsForCall(0)
+			Expect(versionDB.UnlockMigrationsCallCount()).To(Equal(1))
+			_, unlockOwner := versionDB.UnlockMigrationsArgsForCall(0)
+			Expect(unlockOwner).To(Equal(owner))
+		})
+	})
+
+	Context("when no version has been recorded", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(0, models.ErrResourceNotFound)
+		})
+
+		It("runs every migration in order", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+			Expect(ran.versions()).To(Equal([]int64{1, 2}))
+
+			Expect(versionDB.SetVersionCallCount()).To(Equal(2))
+			_, prevVersion, version := versionDB.SetVersionArgsForCall(0)
+			Expect(prevVersion).To(BeEquivalentTo(0))
+			Expect(version).To(BeEquivalentTo(1))
+			_, prevVersion, version = versionDB.SetVersionArgsForCall(1)
+			Expect(prevVersion).To(BeEquivalentTo(1))
+			Expect(version).To(BeEquivalentTo(2))
+		})
+
+		Context("and there are no migrations", func() {
+			BeforeEach(func() {
+				migrations = nil
+			})
+
+			It("records version 0", func() {
+				Eventually(process.Ready()).Should(BeClosed())
+
+				Expect(versionDB.SetVersionCallCount()).To(Equal(1))
+				_, prevVersion, version := versionDB.SetVersionArgsForCall(0)
+				Expect(prevVersion).To(BeEquivalentTo(0))
+				Expect(version).To(BeEquivalentTo(0))
+			})
+		})
+	})
+
+	Context("when the data is at the latest version", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(2, nil)
+		})
+
+		It("becomes ready without migrating", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+			Expect(ran.versions()).To(BeEmpty())
+			Expect(versionDB.SetVersionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the data is newer than the latest migration", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(3, nil)
+		})
+
+		It("exits with an error without migrating", func() {
+			Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+			Expect(process.Ready()).NotTo(BeClosed())
+			Expect(ran.versions()).To(BeEmpty())
+		})
+	})
+
+	Context("when the version cannot be fetched", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(0, models.ErrUnknownError)
+		})
+
+		It("exits with an error", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(models.ErrUnknownError)))
+			Expect(ran.versions()).To(BeEmpty())
+		})
+	})
+
+	Context("when a migration fails", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(0, nil)
+			migrations = []migration.Migration{
+				&fakeMigration{version: 1, ran: ran, err: errors.New("boom")},
+				&fakeMigration{version: 2, ran: ran},
+			}
+		})
+
+		It("stops without recording the failed version", func() {
+			Eventually(process.Wait()).Should(Receive(MatchError("boom")))
+			Expect(ran.versions()).To(Equal([]int64{1}))
+			Expect(versionDB.SetVersionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the version cannot be recorded", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(0, nil)
+			versionDB.SetVersionReturns(models.ErrResourceConflict)
+		})
+
+		It("stops after the first migration and logs the failure", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(models.ErrResourceConflict)))
+			Expect(ran.versions()).To(Equal([]int64{1}))
+			Expect(process.Ready()).NotTo(BeClosed())
+			Expect(logger).To(gbytes.Say("failed-to-set-version"))
+		})
+	})
+
+	Context("when another BBS holds the migration lock", func() {
+		BeforeEach(func() {
+			versionDB.LockMigrationsReturns(models.ErrResourceExists)
+			versionDB.VersionReturns(1, nil)
+		})
+
+		It("waits for the lock before reading the version", func() {
+			Eventually(clock.WatcherCount).Should(Equal(1))
+			Consistently(process.Ready()).ShouldNot(BeClosed())
+			Expect(versionDB.VersionCallCount()).To(Equal(0))
+
+			versionDB.LockMigrationsReturns(nil)
+			clock.Increment(5 * time.Second)
+
+			Eventually(process.Ready()).Should(BeClosed())
+			Expect(versionDB.LockMigrationsCallCount()).To(Equal(2))
+			Expect(ran.versions()).To(Equal([]int64{2}))
+		})
+
+		It("gives up waiting when signalled", func() {
+			Eventually(versionDB.LockMigrationsCallCount).Should(Equal(1))
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(versionDB.VersionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the migration lock cannot be taken", func() {
+		BeforeEach(func() {
+			versionDB.LockMigrationsReturns(models.ErrUnknownError)
+		})
+
+		It("exits with an error without migrating", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(models.ErrUnknownError)))
+			Expect(versionDB.VersionCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when two migrations have the same version", func() {
+		BeforeEach(func() {
+			versionDB.VersionReturns(0, nil)
+			migrations = append(migrations, &fakeMigration{version: 2, ran: ran})
+		})
+
+		It("exits with an error without migrating", func() {
+			Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+			Expect(ran.versions()).To(BeEmpty())
+		})
+	})
+})
+
+type runLog struct {
+	lock    sync.Mutex
+	entries []int64
+}
+
+func (r *runLog) record(version int64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, version)
+}
+
+func (r *runLog) versions() []int64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]int64{}, r.entries...)
+}
+
+type fakeMigration struct {
+	version int64
+	ran     *runLog
+	err     error
+}
+
+func (m *fakeMigration) Version() int64 {
+	return m.version
+}
+
+func (m *fakeMigration) Up(logger lager.Logger, storeClient *etcd.Client, serializer format.Serializer) error {
+	m.ran.record(m.version)
+	return m.err
+}
@@ -0,0 +1,20 @@
+package migration
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/format"
+	"github.com/coreos/go-etcd/etcd"
+	"github.com/pivotal-golang/lager"
+)
+
+// Migration brings stored data from the schema version before it up to
+// Version. Versions start at 1; data written before versions were recorded
+// is at version 0. Up works on the raw records, as the db.DB of this release
+// may not be able to read data at older versions.
+type Migration interface {
+	Version() int64
+	Up(logger lager.Logger, storeClient *etcd.Client, serializer format.Serializer) error
+}
+
+// Migrations holds every migration this release of the BBS knows about.
+// Released migrations must never be edited or removed, only added to.
+var Migrations = []Migration{}
@@ -31,6 +31,13 @@ type ActualLRPChange struct {
 type ActualLRPFilter struct {
 	Domain string
 	CellID string
+
+	// Limit, if set, caps the groups the db lists, in order of process guid
+	// and index, starting after the group AfterProcessGuid and AfterIndex
+	// name. The client iterators page by themselves and ignore these.
+	Limit            int
+	AfterProcessGuid string
+	AfterIndex       int32
 }
 
 func NewActualLRPKey(processGuid string, index int32, domain string) ActualLRPKey {
@@ -127,6 +134,17 @@ func (group ActualLRPGroup) Resolve() (*ActualLRP, bool) {
 	}
 }
 
+// InstanceModificationTag returns the tag that conditional claims and starts
+// are checked against, the zero tag when the group has no instance record.
+// It is the tag carried by the instance part of the group's ETag.
+func (group ActualLRPGroup) InstanceModificationTag() *ModificationTag {
+	if group.Instance == nil {
+		return &ModificationTag{}
+	}
+	tag := group.Instance.ModificationTag
+	return &tag
+}
+
 func NewUnclaimedActualLRP(lrpKey ActualLRPKey, since int64) *ActualLRP {
 	return &ActualLRP{
 		ActualLRPKey: lrpKey,
@@ -199,7 +199,8 @@ func (m *ActualLRP) GetModificationTag() ModificationTag {
 }
 
 type ActualLRPGroups struct {
-	ActualLrpGroups []*ActualLRPGroup `protobuf:"bytes,1,rep,name=actual_lrp_groups" json:"actual_lrp_groups,omitempty"`
+	ActualLrpGroups   []*ActualLRPGroup `protobuf:"bytes,1,rep,name=actual_lrp_groups" json:"actual_lrp_groups,omitempty"`
+	ContinuationToken string            `protobuf:"bytes,2,opt,name=continuation_token" json:"continuation_token,omitempty"`
 }
 
 func (m *ActualLRPGroups) Reset()      { *m = ActualLRPGroups{} }
@@ -212,6 +213,13 @@ func (m *ActualLRPGroups) GetActualLrpGroups() []*ActualLRPGroup {
 	return nil
 }
 
+func (m *ActualLRPGroups) GetContinuationToken() string {
+	if m != nil {
+		return m.ContinuationToken
+	}
+	return ""
+}
+
 func (m *ActualLRPGroup) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
@@ -939,6 +947,28 @@ func (m *ActualLRPGroups) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinuationToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContinuationToken = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -1126,6 +1156,7 @@ func (this *ActualLRPGroups) String() string {
 	}
 	s := strings.Join([]string{`&ActualLRPGroups{`,
 		`ActualLrpGroups:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrpGroups), "ActualLRPGroup", "ActualLRPGroup", 1) + `,`,
+		`ContinuationToken:` + fmt.Sprintf("%v", this.ContinuationToken) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1226,6 +1257,8 @@ func (m *ActualLRPGroups) Size() (n int) {
 			n += 1 + l + sovActualLrp(uint64(l))
 		}
 	}
+	l = len(m.ContinuationToken)
+	n += 1 + l + sovActualLrp(uint64(l))
 	return n
 }
 
@@ -1488,6 +1521,10 @@ func (m *ActualLRPGroups) MarshalTo(data []byte) (n int, err error) {
 			i += n
 		}
 	}
+	data[i] = 0x12
+	i++
+	i = encodeVarintActualLrp(data, i, uint64(len(m.ContinuationToken)))
+	i += copy(data[i:], m.ContinuationToken)
 	return i, nil
 }
 
@@ -1585,7 +1622,8 @@ func (this *ActualLRPGroups) GoString() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&models.ActualLRPGroups{` +
-		`ActualLrpGroups:` + fmt.Sprintf("%#v", this.ActualLrpGroups) + `}`}, ", ")
+		`ActualLrpGroups:` + fmt.Sprintf("%#v", this.ActualLrpGroups),
+		`ContinuationToken:` + fmt.Sprintf("%#v", this.ContinuationToken) + `}`}, ", ")
 	return s
 }
 func valueToGoStringActualLrp(v interface{}, typ string) string {
@@ -1838,5 +1876,8 @@ func (this *ActualLRPGroups) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.ContinuationToken != that1.ContinuationToken {
+		return false
+	}
 	return true
 }
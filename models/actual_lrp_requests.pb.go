@@ -24,9 +24,10 @@ var _ = proto.Marshal
 var _ = math.Inf
 
 type ClaimActualLRPRequest struct {
-	ProcessGuid          string                `protobuf:"bytes,1,opt,name=process_guid" json:"process_guid"`
-	Index                int32                 `protobuf:"varint,2,opt,name=index" json:"index"`
-	ActualLrpInstanceKey *ActualLRPInstanceKey `protobuf:"bytes,3,opt,name=actual_lrp_instance_key" json:"actual_lrp_instance_key,omitempty"`
+	ProcessGuid             string                `protobuf:"bytes,1,opt,name=process_guid" json:"process_guid"`
+	Index                   int32                 `protobuf:"varint,2,opt,name=index" json:"index"`
+	ActualLrpInstanceKey    *ActualLRPInstanceKey `protobuf:"bytes,3,opt,name=actual_lrp_instance_key" json:"actual_lrp_instance_key,omitempty"`
+	ExpectedModificationTag *ModificationTag      `protobuf:"bytes,4,opt,name=expected_modification_tag" json:"expected_modification_tag,omitempty"`
 }
 
 func (m *ClaimActualLRPRequest) Reset()      { *m = ClaimActualLRPRequest{} }
@@ -53,10 +54,18 @@ func (m *ClaimActualLRPRequest) GetActualLrpInstanceKey() *ActualLRPInstanceKey
 	return nil
 }
 
+func (m *ClaimActualLRPRequest) GetExpectedModificationTag() *ModificationTag {
+	if m != nil {
+		return m.ExpectedModificationTag
+	}
+	return nil
+}
+
 type StartActualLRPRequest struct {
-	ActualLrpKey         *ActualLRPKey         `protobuf:"bytes,1,opt,name=actual_lrp_key" json:"actual_lrp_key,omitempty"`
-	ActualLrpInstanceKey *ActualLRPInstanceKey `protobuf:"bytes,2,opt,name=actual_lrp_instance_key" json:"actual_lrp_instance_key,omitempty"`
-	ActualLrpNetInfo     *ActualLRPNetInfo     `protobuf:"bytes,3,opt,name=actual_lrp_net_info" json:"actual_lrp_net_info,omitempty"`
+	ActualLrpKey            *ActualLRPKey         `protobuf:"bytes,1,opt,name=actual_lrp_key" json:"actual_lrp_key,omitempty"`
+	ActualLrpInstanceKey    *ActualLRPInstanceKey `protobuf:"bytes,2,opt,name=actual_lrp_instance_key" json:"actual_lrp_instance_key,omitempty"`
+	ActualLrpNetInfo        *ActualLRPNetInfo     `protobuf:"bytes,3,opt,name=actual_lrp_net_info" json:"actual_lrp_net_info,omitempty"`
+	ExpectedModificationTag *ModificationTag      `protobuf:"bytes,4,opt,name=expected_modification_tag" json:"expected_modification_tag,omitempty"`
 }
 
 func (m *StartActualLRPRequest) Reset()      { *m = StartActualLRPRequest{} }
@@ -83,6 +92,13 @@ func (m *StartActualLRPRequest) GetActualLrpNetInfo() *ActualLRPNetInfo {
 	return nil
 }
 
+func (m *StartActualLRPRequest) GetExpectedModificationTag() *ModificationTag {
+	if m != nil {
+		return m.ExpectedModificationTag
+	}
+	return nil
+}
+
 type CrashActualLRPRequest struct {
 	ActualLrpKey         *ActualLRPKey         `protobuf:"bytes,1,opt,name=actual_lrp_key" json:"actual_lrp_key,omitempty"`
 	ActualLrpInstanceKey *ActualLRPInstanceKey `protobuf:"bytes,2,opt,name=actual_lrp_instance_key" json:"actual_lrp_instance_key,omitempty"`
@@ -233,6 +249,33 @@ func (m *ClaimActualLRPRequest) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedModificationTag", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpectedModificationTag == nil {
+				m.ExpectedModificationTag = &ModificationTag{}
+			}
+			if err := m.ExpectedModificationTag.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -356,6 +399,33 @@ func (m *StartActualLRPRequest) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedModificationTag", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpectedModificationTag == nil {
+				m.ExpectedModificationTag = &ModificationTag{}
+			}
+			if err := m.ExpectedModificationTag.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -749,6 +819,7 @@ func (this *ClaimActualLRPRequest) String() string {
 		`ProcessGuid:` + fmt.Sprintf("%v", this.ProcessGuid) + `,`,
 		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
 		`ActualLrpInstanceKey:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrpInstanceKey), "ActualLRPInstanceKey", "ActualLRPInstanceKey", 1) + `,`,
+		`ExpectedModificationTag:` + strings.Replace(fmt.Sprintf("%v", this.ExpectedModificationTag), "ModificationTag", "ModificationTag", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -761,6 +832,7 @@ func (this *StartActualLRPRequest) String() string {
 		`ActualLrpKey:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrpKey), "ActualLRPKey", "ActualLRPKey", 1) + `,`,
 		`ActualLrpInstanceKey:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrpInstanceKey), "ActualLRPInstanceKey", "ActualLRPInstanceKey", 1) + `,`,
 		`ActualLrpNetInfo:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrpNetInfo), "ActualLRPNetInfo", "ActualLRPNetInfo", 1) + `,`,
+		`ExpectedModificationTag:` + strings.Replace(fmt.Sprintf("%v", this.ExpectedModificationTag), "ModificationTag", "ModificationTag", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -816,6 +888,10 @@ func (m *ClaimActualLRPRequest) Size() (n int) {
 		l = m.ActualLrpInstanceKey.Size()
 		n += 1 + l + sovActualLrpRequests(uint64(l))
 	}
+	if m.ExpectedModificationTag != nil {
+		l = m.ExpectedModificationTag.Size()
+		n += 1 + l + sovActualLrpRequests(uint64(l))
+	}
 	return n
 }
 
@@ -834,6 +910,10 @@ func (m *StartActualLRPRequest) Size() (n int) {
 		l = m.ActualLrpNetInfo.Size()
 		n += 1 + l + sovActualLrpRequests(uint64(l))
 	}
+	if m.ExpectedModificationTag != nil {
+		l = m.ExpectedModificationTag.Size()
+		n += 1 + l + sovActualLrpRequests(uint64(l))
+	}
 	return n
 }
 
@@ -920,6 +1000,16 @@ func (m *ClaimActualLRPRequest) MarshalTo(data []byte) (n int, err error) {
 		}
 		i += n1
 	}
+	if m.ExpectedModificationTag != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintActualLrpRequests(data, i, uint64(m.ExpectedModificationTag.Size()))
+		n2, err := m.ExpectedModificationTag.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
 	return i, nil
 }
 
@@ -942,31 +1032,41 @@ func (m *StartActualLRPRequest) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintActualLrpRequests(data, i, uint64(m.ActualLrpKey.Size()))
-		n2, err := m.ActualLrpKey.MarshalTo(data[i:])
+		n3, err := m.ActualLrpKey.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n2
+		i += n3
 	}
 	if m.ActualLrpInstanceKey != nil {
 		data[i] = 0x12
 		i++
 		i = encodeVarintActualLrpRequests(data, i, uint64(m.ActualLrpInstanceKey.Size()))
-		n3, err := m.ActualLrpInstanceKey.MarshalTo(data[i:])
+		n4, err := m.ActualLrpInstanceKey.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	if m.ActualLrpNetInfo != nil {
 		data[i] = 0x1a
 		i++
 		i = encodeVarintActualLrpRequests(data, i, uint64(m.ActualLrpNetInfo.Size()))
-		n4, err := m.ActualLrpNetInfo.MarshalTo(data[i:])
+		n5, err := m.ActualLrpNetInfo.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n5
+	}
+	if m.ExpectedModificationTag != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintActualLrpRequests(data, i, uint64(m.ExpectedModificationTag.Size()))
+		n6, err := m.ExpectedModificationTag.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
 	}
 	return i, nil
 }
@@ -990,21 +1090,21 @@ func (m *CrashActualLRPRequest) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintActualLrpRequests(data, i, uint64(m.ActualLrpKey.Size()))
-		n5, err := m.ActualLrpKey.MarshalTo(data[i:])
+		n7, err := m.ActualLrpKey.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n7
 	}
 	if m.ActualLrpInstanceKey != nil {
 		data[i] = 0x12
 		i++
 		i = encodeVarintActualLrpRequests(data, i, uint64(m.ActualLrpInstanceKey.Size()))
-		n6, err := m.ActualLrpInstanceKey.MarshalTo(data[i:])
+		n8, err := m.ActualLrpInstanceKey.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n6
+		i += n8
 	}
 	data[i] = 0x1a
 	i++
@@ -1032,11 +1132,11 @@ func (m *FailActualLRPRequest) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintActualLrpRequests(data, i, uint64(m.ActualLrpKey.Size()))
-		n7, err := m.ActualLrpKey.MarshalTo(data[i:])
+		n9, err := m.ActualLrpKey.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n9
 	}
 	data[i] = 0x12
 	i++
@@ -1064,11 +1164,11 @@ func (m *RetireActualLRPRequest) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintActualLrpRequests(data, i, uint64(m.ActualLrpKey.Size()))
-		n8, err := m.ActualLrpKey.MarshalTo(data[i:])
+		n10, err := m.ActualLrpKey.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n10
 	}
 	return i, nil
 }
@@ -1107,7 +1207,8 @@ func (this *ClaimActualLRPRequest) GoString() string {
 	s := strings.Join([]string{`&models.ClaimActualLRPRequest{` +
 		`ProcessGuid:` + fmt.Sprintf("%#v", this.ProcessGuid),
 		`Index:` + fmt.Sprintf("%#v", this.Index),
-		`ActualLrpInstanceKey:` + fmt.Sprintf("%#v", this.ActualLrpInstanceKey) + `}`}, ", ")
+		`ActualLrpInstanceKey:` + fmt.Sprintf("%#v", this.ActualLrpInstanceKey),
+		`ExpectedModificationTag:` + fmt.Sprintf("%#v", this.ExpectedModificationTag) + `}`}, ", ")
 	return s
 }
 func (this *StartActualLRPRequest) GoString() string {
@@ -1117,7 +1218,8 @@ func (this *StartActualLRPRequest) GoString() string {
 	s := strings.Join([]string{`&models.StartActualLRPRequest{` +
 		`ActualLrpKey:` + fmt.Sprintf("%#v", this.ActualLrpKey),
 		`ActualLrpInstanceKey:` + fmt.Sprintf("%#v", this.ActualLrpInstanceKey),
-		`ActualLrpNetInfo:` + fmt.Sprintf("%#v", this.ActualLrpNetInfo) + `}`}, ", ")
+		`ActualLrpNetInfo:` + fmt.Sprintf("%#v", this.ActualLrpNetInfo),
+		`ExpectedModificationTag:` + fmt.Sprintf("%#v", this.ExpectedModificationTag) + `}`}, ", ")
 	return s
 }
 func (this *CrashActualLRPRequest) GoString() string {
@@ -1201,6 +1303,9 @@ func (this *ClaimActualLRPRequest) Equal(that interface{}) bool {
 	if !this.ActualLrpInstanceKey.Equal(that1.ActualLrpInstanceKey) {
 		return false
 	}
+	if !this.ExpectedModificationTag.Equal(that1.ExpectedModificationTag) {
+		return false
+	}
 	return true
 }
 func (this *StartActualLRPRequest) Equal(that interface{}) bool {
@@ -1232,6 +1337,9 @@ func (this *StartActualLRPRequest) Equal(that interface{}) bool {
 	if !this.ActualLrpNetInfo.Equal(that1.ActualLrpNetInfo) {
 		return false
 	}
+	if !this.ExpectedModificationTag.Equal(that1.ExpectedModificationTag) {
+		return false
+	}
 	return true
 }
 func (this *CrashActualLRPRequest) Equal(that interface{}) bool {
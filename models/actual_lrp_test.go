@@ -290,6 +290,23 @@ var _ = Describe("ActualLRP", func() {
 	})
 
 	Describe("ActualLRPGroup", func() {
+		Describe("InstanceModificationTag", func() {
+			It("returns the instance's modification tag", func() {
+				group := models.ActualLRPGroup{
+					Instance:   &models.ActualLRP{ModificationTag: models.ModificationTag{Epoch: "instance-epoch", Index: 2}},
+					Evacuating: &models.ActualLRP{ModificationTag: models.ModificationTag{Epoch: "evacuating-epoch", Index: 5}},
+				}
+				Expect(group.InstanceModificationTag()).To(Equal(&models.ModificationTag{Epoch: "instance-epoch", Index: 2}))
+			})
+
+			It("returns the zero tag when there is no instance", func() {
+				group := models.ActualLRPGroup{
+					Evacuating: &models.ActualLRP{ModificationTag: models.ModificationTag{Epoch: "evacuating-epoch", Index: 5}},
+				}
+				Expect(group.InstanceModificationTag()).To(Equal(&models.ModificationTag{}))
+			})
+		})
+
 		Describe("Resolve", func() {
 			var (
 				instanceLRP   *models.ActualLRP
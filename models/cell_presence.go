@@ -1,9 +1,14 @@
 package models
 
+import (
+	"encoding/json"
+	"reflect"
+)
+
 type CellSet map[string]CellPresence
 
 func (set CellSet) Add(cell CellPresence) {
-	set[cell.CellID] = cell
+	set[cell.CellId] = cell
 }
 
 func (set CellSet) Each(predicate func(cell CellPresence)) {
@@ -17,16 +22,10 @@ func (set CellSet) HasCellID(cellID string) bool {
 	return ok
 }
 
-type CellCapacity struct {
-	MemoryMB   int `json:"memory_mb"`
-	DiskMB     int `json:"disk_mb"`
-	Containers int `json:"containers"`
-}
-
-func NewCellCapacity(memoryMB, diskMB, containers int) CellCapacity {
+func NewCellCapacity(memoryMB, diskMB, containers int32) CellCapacity {
 	return CellCapacity{
-		MemoryMB:   memoryMB,
-		DiskMB:     diskMB,
+		MemoryMb:   memoryMB,
+		DiskMb:     diskMB,
 		Containers: containers,
 	}
 }
@@ -34,11 +33,11 @@ func NewCellCapacity(memoryMB, diskMB, containers int) CellCapacity {
 func (cap CellCapacity) Validate() error {
 	var validationError ValidationError
 
-	if cap.MemoryMB <= 0 {
+	if cap.MemoryMb <= 0 {
 		validationError = validationError.Append(ErrInvalidField{"memory_mb"})
 	}
 
-	if cap.DiskMB < 0 {
+	if cap.DiskMb < 0 {
 		validationError = validationError.Append(ErrInvalidField{"disk_mb"})
 	}
 
@@ -53,16 +52,8 @@ func (cap CellCapacity) Validate() error {
 	return nil
 }
 
-type CellPresence struct {
-	CellID          string              `json:"cell_id"`
-	RepAddress      string              `json:"rep_address"`
-	Zone            string              `json:"zone"`
-	Capacity        CellCapacity        `json:"capacity"`
-	RootFSProviders map[string][]string `json:"rootfs_providers"`
-}
-
 func NewCellPresence(cellID, repAddress, zone string, capacity CellCapacity, rootFSProviders, preloadedRootFSes []string) CellPresence {
-	rootFSProviderMap := make(map[string][]string)
+	rootFSProviderMap := RootFSProviders{}
 
 	for _, provider := range rootFSProviders {
 		rootFSProviderMap[provider] = []string{}
@@ -71,18 +62,18 @@ func NewCellPresence(cellID, repAddress, zone string, capacity CellCapacity, roo
 	rootFSProviderMap["preloaded"] = preloadedRootFSes
 
 	return CellPresence{
-		CellID:          cellID,
+		CellId:          cellID,
 		RepAddress:      repAddress,
 		Zone:            zone,
 		Capacity:        capacity,
-		RootFSProviders: rootFSProviderMap,
+		RootfsProviders: &rootFSProviderMap,
 	}
 }
 
 func (c CellPresence) Validate() error {
 	var validationError ValidationError
 
-	if c.CellID == "" {
+	if c.CellId == "" {
 		validationError = validationError.Append(ErrInvalidField{"cell_id"})
 	}
 
@@ -100,3 +91,40 @@ func (c CellPresence) Validate() error {
 
 	return nil
 }
+
+// RootFSProviders maps each rootfs provider scheme supported by a cell to its
+// properties. It is carried as JSON inside the protobuf message so that the
+// presence stored in consul keeps its existing shape.
+type RootFSProviders map[string][]string
+
+func (p RootFSProviders) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p RootFSProviders) MarshalTo(data []byte) (n int, err error) {
+	bytes, err := p.Marshal()
+	if err != nil {
+		return 0, err
+	}
+	return copy(data, bytes), nil
+}
+
+func (p *RootFSProviders) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, p)
+}
+
+func (p *RootFSProviders) Size() int {
+	if p == nil {
+		return 0
+	}
+
+	bytes, err := p.Marshal()
+	if err != nil {
+		return 0
+	}
+	return len(bytes)
+}
+
+func (p RootFSProviders) Equal(other RootFSProviders) bool {
+	return reflect.DeepEqual(p, other)
+}
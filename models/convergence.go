@@ -0,0 +1,17 @@
+package models
+
+type LRPConvergenceSummary struct {
+	MissingLRPs          int
+	ExtraLRPs            int
+	RestartedCrashedLRPs int
+	LRPsOnMissingCells   int
+	OrphanedLRPs         int
+}
+
+type TaskConvergenceSummary struct {
+	PendingTasksKicked   int
+	PendingTasksExpired  int
+	RunningTasksFailed   int
+	CompletedTasksKicked int
+	TasksPruned          int
+}
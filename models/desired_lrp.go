@@ -2,6 +2,7 @@ package models
 
 import (
 	"net/url"
+	"reflect"
 	"regexp"
 )
 
@@ -16,6 +17,12 @@ type DesiredLRPChange struct {
 
 type DesiredLRPFilter struct {
 	Domain string
+
+	// Limit, if set, caps the desired LRPs the db lists, in order of process
+	// guid, starting after AfterProcessGuid. The client iterators page by
+	// themselves and ignore these.
+	Limit            int
+	AfterProcessGuid string
 }
 
 func PreloadedRootFS(stack string) string {
@@ -25,6 +32,51 @@ func PreloadedRootFS(stack string) string {
 	}).String()
 }
 
+func (desired DesiredLRP) ApplyUpdate(update *DesiredLRPUpdate) DesiredLRP {
+	if update.Instances != nil {
+		desired.Instances = *update.Instances
+	}
+	if update.Routes != nil {
+		desired.Routes = update.Routes
+	}
+	if update.Annotation != nil {
+		desired.Annotation = *update.Annotation
+	}
+	return desired
+}
+
+func (desired DesiredLRP) ValidateModifications(updatedModel DesiredLRP) error {
+	if desired.ProcessGuid != updatedModel.ProcessGuid {
+		return ErrInvalidModification{"process_guid"}
+	}
+
+	if desired.Domain != updatedModel.Domain {
+		return ErrInvalidModification{"domain"}
+	}
+
+	if desired.RootFs != updatedModel.RootFs {
+		return ErrInvalidModification{"rootfs"}
+	}
+
+	if !reflect.DeepEqual(desired.Setup, updatedModel.Setup) {
+		return ErrInvalidModification{"setup"}
+	}
+
+	if !reflect.DeepEqual(desired.Action, updatedModel.Action) {
+		return ErrInvalidModification{"action"}
+	}
+
+	if !reflect.DeepEqual(desired.Monitor, updatedModel.Monitor) {
+		return ErrInvalidModification{"monitor"}
+	}
+
+	if !reflect.DeepEqual(desired.Ports, updatedModel.Ports) {
+		return ErrInvalidModification{"ports"}
+	}
+
+	return nil
+}
+
 func (desired DesiredLRP) Validate() error {
 	var validationError ValidationError
 
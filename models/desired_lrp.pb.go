@@ -27,7 +27,8 @@ var _ = proto.Marshal
 var _ = math.Inf
 
 type DesiredLRPs struct {
-	DesiredLrps []*DesiredLRP `protobuf:"bytes,1,rep,name=desired_lrps" json:"desired_lrps,omitempty"`
+	DesiredLrps       []*DesiredLRP `protobuf:"bytes,1,rep,name=desired_lrps" json:"desired_lrps,omitempty"`
+	ContinuationToken string        `protobuf:"bytes,2,opt,name=continuation_token" json:"continuation_token,omitempty"`
 }
 
 func (m *DesiredLRPs) Reset()      { *m = DesiredLRPs{} }
@@ -40,6 +41,13 @@ func (m *DesiredLRPs) GetDesiredLrps() []*DesiredLRP {
 	return nil
 }
 
+func (m *DesiredLRPs) GetContinuationToken() string {
+	if m != nil {
+		return m.ContinuationToken
+	}
+	return ""
+}
+
 type DesiredLRP struct {
 	ProcessGuid          string                 `protobuf:"bytes,1,opt,name=process_guid" json:"process_guid"`
 	Domain               string                 `protobuf:"bytes,2,opt,name=domain" json:"domain"`
@@ -266,6 +274,28 @@ func (m *DesiredLRPs) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinuationToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContinuationToken = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -1001,6 +1031,7 @@ func (this *DesiredLRPs) String() string {
 	}
 	s := strings.Join([]string{`&DesiredLRPs{`,
 		`DesiredLrps:` + strings.Replace(fmt.Sprintf("%v", this.DesiredLrps), "DesiredLRP", "DesiredLRP", 1) + `,`,
+		`ContinuationToken:` + fmt.Sprintf("%v", this.ContinuationToken) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1072,6 +1103,8 @@ func (m *DesiredLRPs) Size() (n int) {
 			n += 1 + l + sovDesiredLrp(uint64(l))
 		}
 	}
+	l = len(m.ContinuationToken)
+	n += 1 + l + sovDesiredLrp(uint64(l))
 	return n
 }
 
@@ -1192,6 +1225,10 @@ func (m *DesiredLRPs) MarshalTo(data []byte) (n int, err error) {
 			i += n
 		}
 	}
+	data[i] = 0x12
+	i++
+	i = encodeVarintDesiredLrp(data, i, uint64(len(m.ContinuationToken)))
+	i += copy(data[i:], m.ContinuationToken)
 	return i, nil
 }
 
@@ -1429,7 +1466,8 @@ func (this *DesiredLRPs) GoString() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&models.DesiredLRPs{` +
-		`DesiredLrps:` + fmt.Sprintf("%#v", this.DesiredLrps) + `}`}, ", ")
+		`DesiredLrps:` + fmt.Sprintf("%#v", this.DesiredLrps),
+		`ContinuationToken:` + fmt.Sprintf("%#v", this.ContinuationToken) + `}`}, ", ")
 	return s
 }
 func (this *DesiredLRP) GoString() string {
@@ -1531,6 +1569,9 @@ func (this *DesiredLRPs) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.ContinuationToken != that1.ContinuationToken {
+		return false
+	}
 	return true
 }
 func (this *DesiredLRP) Equal(that interface{}) bool {
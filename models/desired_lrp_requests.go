@@ -0,0 +1,75 @@
+package models
+
+import "encoding/json"
+
+// immutableDesiredLRPFields are the JSON names of the desired LRP fields an
+// update cannot change.
+var immutableDesiredLRPFields = []string{"process_guid", "domain", "rootfs", "setup", "action", "monitor", "ports"}
+
+func (request UpdateDesiredLRPRequest) Validate() error {
+	var validationError ValidationError
+
+	if request.ProcessGuid == "" {
+		validationError = validationError.Append(ErrInvalidField{"process_guid"})
+	}
+
+	if request.Update == nil {
+		validationError = validationError.Append(ErrInvalidField{"update"})
+	} else if err := request.Update.Validate(); err != nil {
+		validationError = validationError.Append(err)
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (update DesiredLRPUpdate) Validate() error {
+	var validationError ValidationError
+
+	if update.Instances != nil && *update.Instances < 0 {
+		validationError = validationError.Append(ErrInvalidField{"instances"})
+	}
+
+	if len(update.GetAnnotation()) > maximumAnnotationLength {
+		validationError = validationError.Append(ErrInvalidField{"annotation"})
+	}
+
+	totalRoutesLength := 0
+	if update.Routes != nil {
+		for _, value := range *update.Routes {
+			totalRoutesLength += len(*value)
+			if totalRoutesLength > maximumRouteLength {
+				validationError = validationError.Append(ErrInvalidField{"routes"})
+				break
+			}
+		}
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+// UnmarshalJSON rejects updates naming an immutable field with
+// ErrInvalidModification, rather than silently dropping the field.
+func (update *DesiredLRPUpdate) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range immutableDesiredLRPFields {
+		if _, ok := fields[field]; ok {
+			return ErrInvalidModification{field}
+		}
+	}
+
+	type desiredLRPUpdate DesiredLRPUpdate
+	return json.Unmarshal(data, (*desiredLRPUpdate)(update))
+}
@@ -0,0 +1,125 @@
+package models_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DesiredLRP Requests", func() {
+	Describe("UpdateDesiredLRPRequest", func() {
+		Describe("Validate", func() {
+			var request models.UpdateDesiredLRPRequest
+
+			BeforeEach(func() {
+				instances := int32(3)
+				request = models.UpdateDesiredLRPRequest{
+					ProcessGuid: "p-guid",
+					Update:      &models.DesiredLRPUpdate{Instances: &instances},
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the ProcessGuid is blank", func() {
+				BeforeEach(func() {
+					request.ProcessGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"process_guid"}))
+				})
+			})
+
+			Context("when the Update is blank", func() {
+				BeforeEach(func() {
+					request.Update = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"update"}))
+				})
+			})
+
+			Context("when the Update is invalid", func() {
+				BeforeEach(func() {
+					instances := int32(-1)
+					request.Update.Instances = &instances
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"instances"}))
+				})
+			})
+		})
+	})
+
+	Describe("DesiredLRPUpdate", func() {
+		Describe("Validate", func() {
+			var update models.DesiredLRPUpdate
+
+			BeforeEach(func() {
+				update = models.DesiredLRPUpdate{}
+			})
+
+			Context("when empty", func() {
+				It("returns nil", func() {
+					Expect(update.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the annotation is too long", func() {
+				BeforeEach(func() {
+					annotation := strings.Repeat("a", 10*1024+1)
+					update.Annotation = &annotation
+				})
+
+				It("returns a validation error", func() {
+					Expect(update.Validate()).To(ConsistOf(models.ErrInvalidField{"annotation"}))
+				})
+			})
+
+			Context("when the routes are too long", func() {
+				BeforeEach(func() {
+					largeRoute := json.RawMessage(`"` + strings.Repeat("r", 4*1024) + `"`)
+					update.Routes = &models.Routes{"my-router": &largeRoute}
+				})
+
+				It("returns a validation error", func() {
+					Expect(update.Validate()).To(ConsistOf(models.ErrInvalidField{"routes"}))
+				})
+			})
+		})
+
+		Describe("UnmarshalJSON", func() {
+			var update models.DesiredLRPUpdate
+
+			BeforeEach(func() {
+				update = models.DesiredLRPUpdate{}
+			})
+
+			It("decodes the mutable fields", func() {
+				err := json.Unmarshal([]byte(`{"instances":2,"annotation":"some-text"}`), &update)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(*update.Instances).To(BeEquivalentTo(2))
+				Expect(*update.Annotation).To(Equal("some-text"))
+			})
+
+			Context("when the update names an immutable field", func() {
+				It("returns an invalid modification error", func() {
+					err := json.Unmarshal([]byte(`{"instances":2,"rootfs":"docker:///other"}`), &update)
+					Expect(err).To(Equal(models.ErrInvalidModification{"rootfs"}))
+				})
+			})
+		})
+	})
+})
@@ -270,6 +270,59 @@ var _ = Describe("DesiredLRP", func() {
 		})
 	})
 
+	Describe("ApplyUpdate", func() {
+		It("updates the instances, routes and annotation", func() {
+			instances := int32(7)
+			annotation := "new-annotation"
+			routeMessage := json.RawMessage(`{"hostnames":["new-route"]}`)
+			routes := models.Routes{"cf-router": &routeMessage}
+
+			updated := desiredLRP.ApplyUpdate(&models.DesiredLRPUpdate{
+				Instances:  &instances,
+				Routes:     &routes,
+				Annotation: &annotation,
+			})
+
+			Expect(updated.Instances).To(BeEquivalentTo(7))
+			Expect(updated.Annotation).To(Equal("new-annotation"))
+			Expect(*updated.Routes).To(Equal(routes))
+		})
+
+		It("leaves unset fields unchanged", func() {
+			updated := desiredLRP.ApplyUpdate(&models.DesiredLRPUpdate{})
+			Expect(updated).To(Equal(desiredLRP))
+		})
+	})
+
+	Describe("ValidateModifications", func() {
+		var updated models.DesiredLRP
+
+		BeforeEach(func() {
+			updated = desiredLRP
+		})
+
+		It("allows changes to the mutable fields", func() {
+			updated.Instances = desiredLRP.Instances + 1
+			updated.Annotation = "something-else"
+			Expect(desiredLRP.ValidateModifications(updated)).To(Succeed())
+		})
+
+		It("rejects changes to the rootfs", func() {
+			updated.RootFs = "docker:///other"
+			Expect(desiredLRP.ValidateModifications(updated)).To(Equal(models.ErrInvalidModification{"rootfs"}))
+		})
+
+		It("rejects changes to the action", func() {
+			updated.Action = models.WrapAction(&models.RunAction{Path: "other", User: "me"})
+			Expect(desiredLRP.ValidateModifications(updated)).To(Equal(models.ErrInvalidModification{"action"}))
+		})
+
+		It("rejects changes to the ports", func() {
+			updated.Ports = []uint32{1, 2, 3}
+			Expect(desiredLRP.ValidateModifications(updated)).To(Equal(models.ErrInvalidModification{"ports"}))
+		})
+	})
+
 	Describe("Validate", func() {
 		var assertDesiredLRPValidationFailsWithMessage = func(lrp models.DesiredLRP, substring string) {
 			validationErr := lrp.Validate()
@@ -22,12 +22,22 @@ const (
 	ResourceNotFound = "ResourceNotFound"
 	RouterError      = "RouterError"
 
-	ActualLRPCannotBeClaimed = "ActualLRPCannotBeClaimed"
-	ActualLRPCannotBeStarted = "ActualLRPCannotBeStarted"
-	ActualLRPCannotBeCrashed = "ActualLRPCannotBeCrashed"
-	ActualLRPCannotBeFailed  = "ActualLRPCannotBeFailed"
-	ActualLRPCannotBeRemoved = "ActualLRPCannotBeRemoved"
-	ActualLRPCannotBeStopped = "ActualLRPCannotBeStopped"
+	ActualLRPCannotBeClaimed   = "ActualLRPCannotBeClaimed"
+	ActualLRPCannotBeStarted   = "ActualLRPCannotBeStarted"
+	ActualLRPCannotBeCrashed   = "ActualLRPCannotBeCrashed"
+	ActualLRPCannotBeFailed    = "ActualLRPCannotBeFailed"
+	ActualLRPCannotBeRemoved   = "ActualLRPCannotBeRemoved"
+	ActualLRPCannotBeStopped   = "ActualLRPCannotBeStopped"
+	ActualLRPCannotBeUnclaimed = "ActualLRPCannotBeUnclaimed"
+	ActualLRPCannotBeEvacuated = "ActualLRPCannotBeEvacuated"
+
+	TaskCannotBeStarted           = "TaskCannotBeStarted"
+	TaskCannotBeCancelled         = "TaskCannotBeCancelled"
+	TaskCannotBeFailed            = "TaskCannotBeFailed"
+	TaskCannotBeCompleted         = "TaskCannotBeCompleted"
+	TaskCannotBeMarkedAsResolving = "TaskCannotBeMarkedAsResolving"
+	TaskCannotBeResolved          = "TaskCannotBeResolved"
+	TaskRunningOnDifferentCell    = "TaskRunningOnDifferentCell"
 )
 
 var (
@@ -36,6 +46,16 @@ var (
 		Message: "the requested resource could not be found",
 	}
 
+	ErrResourceExists = &Error{
+		Type:    ResourceConflict,
+		Message: "the requested resource already exists",
+	}
+
+	ErrResourceConflict = &Error{
+		Type:    ResourceConflict,
+		Message: "the requested resource is in a conflicting state",
+	}
+
 	ErrBadRequest = &Error{
 		Type:    InvalidRequest,
 		Message: "the request received is invalid",
@@ -85,6 +105,51 @@ var (
 		Type:    ActualLRPCannotBeStopped,
 		Message: "cannot stop actual LRP",
 	}
+
+	ErrActualLRPCannotBeUnclaimed = &Error{
+		Type:    ActualLRPCannotBeUnclaimed,
+		Message: "cannot unclaim actual LRP",
+	}
+
+	ErrActualLRPCannotBeEvacuated = &Error{
+		Type:    ActualLRPCannotBeEvacuated,
+		Message: "cannot evacuate actual LRP",
+	}
+
+	ErrTaskCannotBeStarted = &Error{
+		Type:    TaskCannotBeStarted,
+		Message: "cannot start task",
+	}
+
+	ErrTaskCannotBeCancelled = &Error{
+		Type:    TaskCannotBeCancelled,
+		Message: "cannot cancel task",
+	}
+
+	ErrTaskCannotBeFailed = &Error{
+		Type:    TaskCannotBeFailed,
+		Message: "cannot fail task",
+	}
+
+	ErrTaskCannotBeCompleted = &Error{
+		Type:    TaskCannotBeCompleted,
+		Message: "cannot complete task",
+	}
+
+	ErrTaskCannotBeMarkedAsResolving = &Error{
+		Type:    TaskCannotBeMarkedAsResolving,
+		Message: "cannot mark task as resolving",
+	}
+
+	ErrTaskCannotBeResolved = &Error{
+		Type:    TaskCannotBeResolved,
+		Message: "cannot resolve task",
+	}
+
+	ErrTaskRunningOnDifferentCell = &Error{
+		Type:    TaskRunningOnDifferentCell,
+		Message: "task running on different cell",
+	}
 )
 
 func (err *Error) Equal(other error) bool {
@@ -0,0 +1,79 @@
+package models
+
+func (request EvacuateClaimedActualLRPRequest) Validate() error {
+	validationError := validateEvacuationKeys(request.ActualLrpKey, request.ActualLrpInstanceKey)
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request EvacuateRunningActualLRPRequest) Validate() error {
+	validationError := validateEvacuationKeys(request.ActualLrpKey, request.ActualLrpInstanceKey)
+
+	if request.ActualLrpNetInfo == nil {
+		validationError = validationError.Append(ErrInvalidField{"actual_lrp_net_info"})
+	} else if err := request.ActualLrpNetInfo.Validate(); err != nil {
+		validationError = validationError.Append(err)
+	}
+
+	if request.Ttl == 0 {
+		validationError = validationError.Append(ErrInvalidField{"ttl"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request EvacuateStoppedActualLRPRequest) Validate() error {
+	validationError := validateEvacuationKeys(request.ActualLrpKey, request.ActualLrpInstanceKey)
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request EvacuateCrashedActualLRPRequest) Validate() error {
+	validationError := validateEvacuationKeys(request.ActualLrpKey, request.ActualLrpInstanceKey)
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request RemoveEvacuatingActualLRPRequest) Validate() error {
+	validationError := validateEvacuationKeys(request.ActualLrpKey, request.ActualLrpInstanceKey)
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func validateEvacuationKeys(key *ActualLRPKey, instanceKey *ActualLRPInstanceKey) ValidationError {
+	var validationError ValidationError
+
+	if key == nil {
+		validationError = validationError.Append(ErrInvalidField{"actual_lrp_key"})
+	} else if err := key.Validate(); err != nil {
+		validationError = validationError.Append(err)
+	}
+
+	if instanceKey == nil {
+		validationError = validationError.Append(ErrInvalidField{"actual_lrp_instance_key"})
+	} else if err := instanceKey.Validate(); err != nil {
+		validationError = validationError.Append(err)
+	}
+
+	return validationError
+}
@@ -0,0 +1,254 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evacuation Requests", func() {
+	var (
+		actualLRPKey         models.ActualLRPKey
+		actualLRPInstanceKey models.ActualLRPInstanceKey
+	)
+
+	BeforeEach(func() {
+		actualLRPKey = models.NewActualLRPKey("p-guid", 2, "domain")
+		actualLRPInstanceKey = models.NewActualLRPInstanceKey("i-guid", "c-id")
+	})
+
+	Describe("EvacuateClaimedActualLRPRequest", func() {
+		Describe("Validate", func() {
+			var request models.EvacuateClaimedActualLRPRequest
+
+			BeforeEach(func() {
+				request = models.EvacuateClaimedActualLRPRequest{
+					ActualLrpKey:         &actualLRPKey,
+					ActualLrpInstanceKey: &actualLRPInstanceKey,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the ActualLrpKey is blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpKey = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"actual_lrp_key"}))
+				})
+			})
+
+			Context("when the ActualLrpKey is invalid", func() {
+				BeforeEach(func() {
+					request.ActualLrpKey.ProcessGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"process_guid"}))
+				})
+			})
+
+			Context("when the ActualLrpInstanceKey is blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpInstanceKey = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"actual_lrp_instance_key"}))
+				})
+			})
+
+			Context("when the ActualLrpInstanceKey is invalid", func() {
+				BeforeEach(func() {
+					request.ActualLrpInstanceKey.InstanceGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"instance_guid"}))
+				})
+			})
+		})
+	})
+
+	Describe("EvacuateRunningActualLRPRequest", func() {
+		Describe("Validate", func() {
+			var (
+				request models.EvacuateRunningActualLRPRequest
+				netInfo models.ActualLRPNetInfo
+			)
+
+			BeforeEach(func() {
+				netInfo = models.NewActualLRPNetInfo("1.2.3.4", models.NewPortMapping(8080, 80))
+				request = models.EvacuateRunningActualLRPRequest{
+					ActualLrpKey:         &actualLRPKey,
+					ActualLrpInstanceKey: &actualLRPInstanceKey,
+					ActualLrpNetInfo:     &netInfo,
+					Ttl:                  60,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the ActualLrpKey is blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpKey = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"actual_lrp_key"}))
+				})
+			})
+
+			Context("when the ActualLrpInstanceKey is blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpInstanceKey = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"actual_lrp_instance_key"}))
+				})
+			})
+
+			Context("when the ActualLrpNetInfo is blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpNetInfo = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"actual_lrp_net_info"}))
+				})
+			})
+
+			Context("when the ActualLrpNetInfo is invalid", func() {
+				BeforeEach(func() {
+					request.ActualLrpNetInfo.Address = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"address"}))
+				})
+			})
+
+			Context("when the Ttl is zero", func() {
+				BeforeEach(func() {
+					request.Ttl = 0
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"ttl"}))
+				})
+			})
+		})
+	})
+
+	Describe("EvacuateStoppedActualLRPRequest", func() {
+		Describe("Validate", func() {
+			var request models.EvacuateStoppedActualLRPRequest
+
+			BeforeEach(func() {
+				request = models.EvacuateStoppedActualLRPRequest{
+					ActualLrpKey:         &actualLRPKey,
+					ActualLrpInstanceKey: &actualLRPInstanceKey,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the keys are blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpKey = nil
+					request.ActualLrpInstanceKey = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(
+						models.ErrInvalidField{"actual_lrp_key"},
+						models.ErrInvalidField{"actual_lrp_instance_key"},
+					))
+				})
+			})
+		})
+	})
+
+	Describe("EvacuateCrashedActualLRPRequest", func() {
+		Describe("Validate", func() {
+			var request models.EvacuateCrashedActualLRPRequest
+
+			BeforeEach(func() {
+				request = models.EvacuateCrashedActualLRPRequest{
+					ActualLrpKey:         &actualLRPKey,
+					ActualLrpInstanceKey: &actualLRPInstanceKey,
+					ErrorMessage:         "oh no",
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the keys are blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpKey = nil
+					request.ActualLrpInstanceKey = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(
+						models.ErrInvalidField{"actual_lrp_key"},
+						models.ErrInvalidField{"actual_lrp_instance_key"},
+					))
+				})
+			})
+		})
+	})
+
+	Describe("RemoveEvacuatingActualLRPRequest", func() {
+		Describe("Validate", func() {
+			var request models.RemoveEvacuatingActualLRPRequest
+
+			BeforeEach(func() {
+				request = models.RemoveEvacuatingActualLRPRequest{
+					ActualLrpKey:         &actualLRPKey,
+					ActualLrpInstanceKey: &actualLRPInstanceKey,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the keys are blank", func() {
+				BeforeEach(func() {
+					request.ActualLrpKey = nil
+					request.ActualLrpInstanceKey = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(
+						models.ErrInvalidField{"actual_lrp_key"},
+						models.ErrInvalidField{"actual_lrp_instance_key"},
+					))
+				})
+			})
+		})
+	})
+})
@@ -20,8 +20,146 @@ const (
 	EventTypeActualLRPCreated = "actual_lrp_created"
 	EventTypeActualLRPChanged = "actual_lrp_changed"
 	EventTypeActualLRPRemoved = "actual_lrp_removed"
+	EventTypeActualLRPCrashed = "actual_lrp_crashed"
+
+	EventTypeTaskCreated = "task_created"
+	EventTypeTaskChanged = "task_changed"
+	EventTypeTaskRemoved = "task_removed"
 )
 
+// EventFilter selects the events a subscriber receives. Empty fields match
+// everything. Changed events match if either side of the change matches.
+type EventFilter struct {
+	Domain      string
+	CellID      string
+	ProcessGuid string
+	EventTypes  []string
+}
+
+func (filter EventFilter) Matches(event Event) bool {
+	if len(filter.EventTypes) > 0 && !containsString(filter.EventTypes, event.EventType()) {
+		return false
+	}
+
+	if filter.Domain == "" && filter.CellID == "" && filter.ProcessGuid == "" {
+		return true
+	}
+
+	for _, subject := range eventSubjects(event) {
+		if filter.matchesSubject(subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (filter EventFilter) matchesSubject(subject eventSubject) bool {
+	if filter.Domain != "" && filter.Domain != subject.domain {
+		return false
+	}
+	if filter.CellID != "" && filter.CellID != subject.cellID {
+		return false
+	}
+	if filter.ProcessGuid != "" && filter.ProcessGuid != subject.processGuid {
+		return false
+	}
+	return true
+}
+
+// eventSubject holds the attributes of a resource an event is about that an
+// EventFilter can select on.
+type eventSubject struct {
+	domain      string
+	cellID      string
+	processGuid string
+}
+
+func eventSubjects(event Event) []eventSubject {
+	switch event := event.(type) {
+	case *DesiredLRPCreatedEvent:
+		return desiredLRPSubjects(event.DesiredLrp)
+	case *DesiredLRPChangedEvent:
+		return desiredLRPSubjects(event.Before, event.After)
+	case *DesiredLRPRemovedEvent:
+		return desiredLRPSubjects(event.DesiredLrp)
+	case *ActualLRPCreatedEvent:
+		return actualLRPGroupSubjects(event.ActualLrpGroup)
+	case *ActualLRPChangedEvent:
+		return actualLRPGroupSubjects(event.Before, event.After)
+	case *ActualLRPRemovedEvent:
+		return actualLRPGroupSubjects(event.ActualLrpGroup)
+	case *ActualLRPCrashedEvent:
+		return []eventSubject{{
+			domain:      event.ActualLrpKey.GetDomain(),
+			cellID:      event.ActualLrpInstanceKey.GetCellId(),
+			processGuid: event.ActualLrpKey.GetProcessGuid(),
+		}}
+	case *TaskCreatedEvent:
+		return taskSubjects(event.Task)
+	case *TaskChangedEvent:
+		return taskSubjects(event.Before, event.After)
+	case *TaskRemovedEvent:
+		return taskSubjects(event.Task)
+	default:
+		return nil
+	}
+}
+
+func desiredLRPSubjects(desiredLRPs ...*DesiredLRP) []eventSubject {
+	subjects := []eventSubject{}
+	for _, desiredLRP := range desiredLRPs {
+		if desiredLRP != nil {
+			subjects = append(subjects, eventSubject{
+				domain:      desiredLRP.Domain,
+				processGuid: desiredLRP.ProcessGuid,
+			})
+		}
+	}
+	return subjects
+}
+
+func actualLRPGroupSubjects(groups ...*ActualLRPGroup) []eventSubject {
+	subjects := []eventSubject{}
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, actualLRP := range []*ActualLRP{group.Instance, group.Evacuating} {
+			if actualLRP != nil {
+				subjects = append(subjects, eventSubject{
+					domain:      actualLRP.Domain,
+					cellID:      actualLRP.CellId,
+					processGuid: actualLRP.ProcessGuid,
+				})
+			}
+		}
+	}
+	return subjects
+}
+
+func taskSubjects(tasks ...*Task) []eventSubject {
+	subjects := []eventSubject{}
+	for _, task := range tasks {
+		if task != nil {
+			subjects = append(subjects, eventSubject{
+				domain: task.Domain,
+				cellID: task.CellId,
+			})
+		}
+	}
+	return subjects
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func NewDesiredLRPCreatedEvent(desiredLRP *DesiredLRP) *DesiredLRPCreatedEvent {
 	return &DesiredLRPCreatedEvent{
 		DesiredLrp: desiredLRP,
@@ -110,3 +248,67 @@ func (event *ActualLRPCreatedEvent) Key() string {
 	actualLRP, _ := event.ActualLrpGroup.Resolve()
 	return actualLRP.GetInstanceGuid()
 }
+
+// NewActualLRPCrashedEvent describes the crash of the instance identified by
+// instanceKey, given the actual LRP as recorded after the crash.
+func NewActualLRPCrashedEvent(instanceKey ActualLRPInstanceKey, crashed *ActualLRP) *ActualLRPCrashedEvent {
+	key := crashed.ActualLRPKey
+	return &ActualLRPCrashedEvent{
+		ActualLrpKey:         &key,
+		ActualLrpInstanceKey: &instanceKey,
+		CrashCount:           crashed.CrashCount,
+		CrashReason:          crashed.CrashReason,
+		Since:                crashed.Since,
+	}
+}
+
+func (event *ActualLRPCrashedEvent) EventType() string {
+	return EventTypeActualLRPCrashed
+}
+
+func (event *ActualLRPCrashedEvent) Key() string {
+	return event.ActualLrpInstanceKey.GetInstanceGuid()
+}
+
+func NewTaskCreatedEvent(task *Task) *TaskCreatedEvent {
+	return &TaskCreatedEvent{
+		Task: task,
+	}
+}
+
+func (event *TaskCreatedEvent) EventType() string {
+	return EventTypeTaskCreated
+}
+
+func (event *TaskCreatedEvent) Key() string {
+	return event.Task.GetTaskGuid()
+}
+
+func NewTaskChangedEvent(before, after *Task) *TaskChangedEvent {
+	return &TaskChangedEvent{
+		Before: before,
+		After:  after,
+	}
+}
+
+func (event *TaskChangedEvent) EventType() string {
+	return EventTypeTaskChanged
+}
+
+func (event *TaskChangedEvent) Key() string {
+	return event.Before.GetTaskGuid()
+}
+
+func NewTaskRemovedEvent(task *Task) *TaskRemovedEvent {
+	return &TaskRemovedEvent{
+		Task: task,
+	}
+}
+
+func (event *TaskRemovedEvent) EventType() string {
+	return EventTypeTaskRemoved
+}
+
+func (event *TaskRemovedEvent) Key() string {
+	return event.Task.GetTaskGuid()
+}
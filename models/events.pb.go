@@ -73,6 +73,52 @@ func (m *ActualLRPRemovedEvent) GetActualLrpGroup() *ActualLRPGroup {
 	return nil
 }
 
+type ActualLRPCrashedEvent struct {
+	ActualLrpKey         *ActualLRPKey         `protobuf:"bytes,1,opt,name=actual_lrp_key" json:"actual_lrp_key,omitempty"`
+	ActualLrpInstanceKey *ActualLRPInstanceKey `protobuf:"bytes,2,opt,name=actual_lrp_instance_key" json:"actual_lrp_instance_key,omitempty"`
+	CrashCount           int32                 `protobuf:"varint,3,opt,name=crash_count" json:"crash_count"`
+	CrashReason          string                `protobuf:"bytes,4,opt,name=crash_reason" json:"crash_reason"`
+	Since                int64                 `protobuf:"varint,5,opt,name=since" json:"since"`
+}
+
+func (m *ActualLRPCrashedEvent) Reset()      { *m = ActualLRPCrashedEvent{} }
+func (*ActualLRPCrashedEvent) ProtoMessage() {}
+
+func (m *ActualLRPCrashedEvent) GetActualLrpKey() *ActualLRPKey {
+	if m != nil {
+		return m.ActualLrpKey
+	}
+	return nil
+}
+
+func (m *ActualLRPCrashedEvent) GetActualLrpInstanceKey() *ActualLRPInstanceKey {
+	if m != nil {
+		return m.ActualLrpInstanceKey
+	}
+	return nil
+}
+
+func (m *ActualLRPCrashedEvent) GetCrashCount() int32 {
+	if m != nil {
+		return m.CrashCount
+	}
+	return 0
+}
+
+func (m *ActualLRPCrashedEvent) GetCrashReason() string {
+	if m != nil {
+		return m.CrashReason
+	}
+	return ""
+}
+
+func (m *ActualLRPCrashedEvent) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
 type DesiredLRPCreatedEvent struct {
 	DesiredLrp *DesiredLRP `protobuf:"bytes,1,opt,name=desired_lrp" json:"desired_lrp,omitempty"`
 }
@@ -123,6 +169,56 @@ func (m *DesiredLRPRemovedEvent) GetDesiredLrp() *DesiredLRP {
 	return nil
 }
 
+type TaskCreatedEvent struct {
+	Task *Task `protobuf:"bytes,1,opt,name=task" json:"task,omitempty"`
+}
+
+func (m *TaskCreatedEvent) Reset()      { *m = TaskCreatedEvent{} }
+func (*TaskCreatedEvent) ProtoMessage() {}
+
+func (m *TaskCreatedEvent) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+type TaskChangedEvent struct {
+	Before *Task `protobuf:"bytes,1,opt,name=before" json:"before,omitempty"`
+	After  *Task `protobuf:"bytes,2,opt,name=after" json:"after,omitempty"`
+}
+
+func (m *TaskChangedEvent) Reset()      { *m = TaskChangedEvent{} }
+func (*TaskChangedEvent) ProtoMessage() {}
+
+func (m *TaskChangedEvent) GetBefore() *Task {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *TaskChangedEvent) GetAfter() *Task {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+type TaskRemovedEvent struct {
+	Task *Task `protobuf:"bytes,1,opt,name=task" json:"task,omitempty"`
+}
+
+func (m *TaskRemovedEvent) Reset()      { *m = TaskRemovedEvent{} }
+func (*TaskRemovedEvent) ProtoMessage() {}
+
+func (m *TaskRemovedEvent) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
 func (m *ActualLRPCreatedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
@@ -357,6 +453,156 @@ func (m *ActualLRPRemovedEvent) Unmarshal(data []byte) error {
 
 	return nil
 }
+func (m *ActualLRPCrashedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActualLrpKey == nil {
+				m.ActualLrpKey = &ActualLRPKey{}
+			}
+			if err := m.ActualLrpKey.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpInstanceKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActualLrpInstanceKey == nil {
+				m.ActualLrpInstanceKey = &ActualLRPInstanceKey{}
+			}
+			if err := m.ActualLrpInstanceKey.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CrashCount", wireType)
+			}
+			m.CrashCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.CrashCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CrashReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CrashReason = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Since |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
 func (m *DesiredLRPCreatedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
@@ -591,14 +837,14 @@ func (m *DesiredLRPRemovedEvent) Unmarshal(data []byte) error {
 
 	return nil
 }
-func skipEvents(data []byte) (n int, err error) {
+func (m *TaskCreatedEvent) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if iNdEx >= l {
-				return 0, io.ErrUnexpectedEOF
+				return io.ErrUnexpectedEOF
 			}
 			b := data[iNdEx]
 			iNdEx++
@@ -607,70 +853,304 @@ func skipEvents(data []byte) (n int, err error) {
 				break
 			}
 		}
+		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
-		switch wireType {
-		case 0:
-			for {
-				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
-				}
-				iNdEx++
-				if data[iNdEx-1] < 0x80 {
-					break
-				}
-			}
-			return iNdEx, nil
+		switch fieldNum {
 		case 1:
-			iNdEx += 8
-			return iNdEx, nil
-		case 2:
-			var length int
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Task", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
+					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				length |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			iNdEx += length
-			return iNdEx, nil
-		case 3:
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Task == nil {
+				m.Task = &Task{}
+			}
+			if err := m.Task.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
 			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := data[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
-				next, err := skipEvents(data[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
 			}
-			return iNdEx, nil
-		case 4:
-			return iNdEx, nil
-		case 5:
-			iNdEx += 4
-			return iNdEx, nil
-		default:
-			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskChangedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Before == nil {
+				m.Before = &Task{}
+			}
+			if err := m.Before.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.After == nil {
+				m.After = &Task{}
+			}
+			if err := m.After.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *TaskRemovedEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Task", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Task == nil {
+				m.Task = &Task{}
+			}
+			if err := m.Task.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipEvents(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func skipEvents(data []byte) (n int, err error) {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if data[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := data[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipEvents(data[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
 	}
 	panic("unreachable")
@@ -706,6 +1186,20 @@ func (this *ActualLRPRemovedEvent) String() string {
 	}, "")
 	return s
 }
+func (this *ActualLRPCrashedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPCrashedEvent{`,
+		`ActualLrpKey:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrpKey), "ActualLRPKey", "ActualLRPKey", 1) + `,`,
+		`ActualLrpInstanceKey:` + strings.Replace(fmt.Sprintf("%v", this.ActualLrpInstanceKey), "ActualLRPInstanceKey", "ActualLRPInstanceKey", 1) + `,`,
+		`CrashCount:` + fmt.Sprintf("%v", this.CrashCount) + `,`,
+		`CrashReason:` + fmt.Sprintf("%v", this.CrashReason) + `,`,
+		`Since:` + fmt.Sprintf("%v", this.Since) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *DesiredLRPCreatedEvent) String() string {
 	if this == nil {
 		return "nil"
@@ -737,6 +1231,37 @@ func (this *DesiredLRPRemovedEvent) String() string {
 	}, "")
 	return s
 }
+func (this *TaskCreatedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskCreatedEvent{`,
+		`Task:` + strings.Replace(fmt.Sprintf("%v", this.Task), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskChangedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskChangedEvent{`,
+		`Before:` + strings.Replace(fmt.Sprintf("%v", this.Before), "Task", "Task", 1) + `,`,
+		`After:` + strings.Replace(fmt.Sprintf("%v", this.After), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TaskRemovedEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TaskRemovedEvent{`,
+		`Task:` + strings.Replace(fmt.Sprintf("%v", this.Task), "Task", "Task", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringEvents(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -745,17 +1270,69 @@ func valueToStringEvents(v interface{}) string {
 	pv := reflect.Indirect(rv).Interface()
 	return fmt.Sprintf("*%v", pv)
 }
-func (m *ActualLRPCreatedEvent) Size() (n int) {
+func (m *ActualLRPCreatedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.ActualLrpGroup != nil {
+		l = m.ActualLrpGroup.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *ActualLRPChangedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.Before != nil {
+		l = m.Before.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.After != nil {
+		l = m.After.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *ActualLRPRemovedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.ActualLrpGroup != nil {
+		l = m.ActualLrpGroup.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	return n
+}
+
+func (m *ActualLRPCrashedEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.ActualLrpKey != nil {
+		l = m.ActualLrpKey.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.ActualLrpInstanceKey != nil {
+		l = m.ActualLrpInstanceKey.Size()
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	n += 1 + sovEvents(uint64(m.CrashCount))
+	l = len(m.CrashReason)
+	n += 1 + l + sovEvents(uint64(l))
+	n += 1 + sovEvents(uint64(m.Since))
+	return n
+}
+
+func (m *DesiredLRPCreatedEvent) Size() (n int) {
 	var l int
 	_ = l
-	if m.ActualLrpGroup != nil {
-		l = m.ActualLrpGroup.Size()
+	if m.DesiredLrp != nil {
+		l = m.DesiredLrp.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func (m *ActualLRPChangedEvent) Size() (n int) {
+func (m *DesiredLRPChangedEvent) Size() (n int) {
 	var l int
 	_ = l
 	if m.Before != nil {
@@ -769,27 +1346,27 @@ func (m *ActualLRPChangedEvent) Size() (n int) {
 	return n
 }
 
-func (m *ActualLRPRemovedEvent) Size() (n int) {
+func (m *DesiredLRPRemovedEvent) Size() (n int) {
 	var l int
 	_ = l
-	if m.ActualLrpGroup != nil {
-		l = m.ActualLrpGroup.Size()
+	if m.DesiredLrp != nil {
+		l = m.DesiredLrp.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func (m *DesiredLRPCreatedEvent) Size() (n int) {
+func (m *TaskCreatedEvent) Size() (n int) {
 	var l int
 	_ = l
-	if m.DesiredLrp != nil {
-		l = m.DesiredLrp.Size()
+	if m.Task != nil {
+		l = m.Task.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
 }
 
-func (m *DesiredLRPChangedEvent) Size() (n int) {
+func (m *TaskChangedEvent) Size() (n int) {
 	var l int
 	_ = l
 	if m.Before != nil {
@@ -803,11 +1380,11 @@ func (m *DesiredLRPChangedEvent) Size() (n int) {
 	return n
 }
 
-func (m *DesiredLRPRemovedEvent) Size() (n int) {
+func (m *TaskRemovedEvent) Size() (n int) {
 	var l int
 	_ = l
-	if m.DesiredLrp != nil {
-		l = m.DesiredLrp.Size()
+	if m.Task != nil {
+		l = m.Task.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
 	return n
@@ -920,6 +1497,54 @@ func (m *ActualLRPRemovedEvent) MarshalTo(data []byte) (n int, err error) {
 	return i, nil
 }
 
+func (m *ActualLRPCrashedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ActualLRPCrashedEvent) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ActualLrpKey != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.ActualLrpKey.Size()))
+		n5, err := m.ActualLrpKey.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.ActualLrpInstanceKey != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.ActualLrpInstanceKey.Size()))
+		n6, err := m.ActualLrpInstanceKey.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	data[i] = 0x18
+	i++
+	i = encodeVarintEvents(data, i, uint64(m.CrashCount))
+	data[i] = 0x22
+	i++
+	i = encodeVarintEvents(data, i, uint64(len(m.CrashReason)))
+	i += copy(data[i:], m.CrashReason)
+	data[i] = 0x28
+	i++
+	i = encodeVarintEvents(data, i, uint64(m.Since))
+	return i, nil
+}
+
 func (m *DesiredLRPCreatedEvent) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -939,11 +1564,11 @@ func (m *DesiredLRPCreatedEvent) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintEvents(data, i, uint64(m.DesiredLrp.Size()))
-		n5, err := m.DesiredLrp.MarshalTo(data[i:])
+		n7, err := m.DesiredLrp.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n7
 	}
 	return i, nil
 }
@@ -967,21 +1592,21 @@ func (m *DesiredLRPChangedEvent) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintEvents(data, i, uint64(m.Before.Size()))
-		n6, err := m.Before.MarshalTo(data[i:])
+		n8, err := m.Before.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n6
+		i += n8
 	}
 	if m.After != nil {
 		data[i] = 0x12
 		i++
 		i = encodeVarintEvents(data, i, uint64(m.After.Size()))
-		n7, err := m.After.MarshalTo(data[i:])
+		n9, err := m.After.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n9
 	}
 	return i, nil
 }
@@ -1005,11 +1630,105 @@ func (m *DesiredLRPRemovedEvent) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintEvents(data, i, uint64(m.DesiredLrp.Size()))
-		n8, err := m.DesiredLrp.MarshalTo(data[i:])
+		n10, err := m.DesiredLrp.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n10
+	}
+	return i, nil
+}
+
+func (m *TaskCreatedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskCreatedEvent) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Task != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.Task.Size()))
+		n11, err := m.Task.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	return i, nil
+}
+
+func (m *TaskChangedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskChangedEvent) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Before != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.Before.Size()))
+		n12, err := m.Before.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.After != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.After.Size()))
+		n13, err := m.After.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+
+func (m *TaskRemovedEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *TaskRemovedEvent) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Task != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintEvents(data, i, uint64(m.Task.Size()))
+		n14, err := m.Task.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
 	}
 	return i, nil
 }
@@ -1066,6 +1785,18 @@ func (this *ActualLRPRemovedEvent) GoString() string {
 		`ActualLrpGroup:` + fmt.Sprintf("%#v", this.ActualLrpGroup) + `}`}, ", ")
 	return s
 }
+func (this *ActualLRPCrashedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.ActualLRPCrashedEvent{` +
+		`ActualLrpKey:` + fmt.Sprintf("%#v", this.ActualLrpKey),
+		`ActualLrpInstanceKey:` + fmt.Sprintf("%#v", this.ActualLrpInstanceKey),
+		`CrashCount:` + fmt.Sprintf("%#v", this.CrashCount),
+		`CrashReason:` + fmt.Sprintf("%#v", this.CrashReason),
+		`Since:` + fmt.Sprintf("%#v", this.Since) + `}`}, ", ")
+	return s
+}
 func (this *DesiredLRPCreatedEvent) GoString() string {
 	if this == nil {
 		return "nil"
@@ -1091,6 +1822,31 @@ func (this *DesiredLRPRemovedEvent) GoString() string {
 		`DesiredLrp:` + fmt.Sprintf("%#v", this.DesiredLrp) + `}`}, ", ")
 	return s
 }
+func (this *TaskCreatedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskCreatedEvent{` +
+		`Task:` + fmt.Sprintf("%#v", this.Task) + `}`}, ", ")
+	return s
+}
+func (this *TaskChangedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskChangedEvent{` +
+		`Before:` + fmt.Sprintf("%#v", this.Before),
+		`After:` + fmt.Sprintf("%#v", this.After) + `}`}, ", ")
+	return s
+}
+func (this *TaskRemovedEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&models.TaskRemovedEvent{` +
+		`Task:` + fmt.Sprintf("%#v", this.Task) + `}`}, ", ")
+	return s
+}
 func valueToGoStringEvents(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -1194,6 +1950,43 @@ func (this *ActualLRPRemovedEvent) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ActualLRPCrashedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ActualLRPCrashedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.ActualLrpKey.Equal(that1.ActualLrpKey) {
+		return false
+	}
+	if !this.ActualLrpInstanceKey.Equal(that1.ActualLrpInstanceKey) {
+		return false
+	}
+	if this.CrashCount != that1.CrashCount {
+		return false
+	}
+	if this.CrashReason != that1.CrashReason {
+		return false
+	}
+	if this.Since != that1.Since {
+		return false
+	}
+	return true
+}
 func (this *DesiredLRPCreatedEvent) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -1272,3 +2065,81 @@ func (this *DesiredLRPRemovedEvent) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *TaskCreatedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskCreatedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Task.Equal(that1.Task) {
+		return false
+	}
+	return true
+}
+func (this *TaskChangedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskChangedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Before.Equal(that1.Before) {
+		return false
+	}
+	if !this.After.Equal(that1.After) {
+		return false
+	}
+	return true
+}
+func (this *TaskRemovedEvent) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*TaskRemovedEvent)
+	if !ok {
+		return false
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Task.Equal(that1.Task) {
+		return false
+	}
+	return true
+}
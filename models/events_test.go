@@ -0,0 +1,85 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventFilter", func() {
+	var (
+		desiredLRPEvent models.Event
+		actualLRPEvent  models.Event
+		taskEvent       models.Event
+	)
+
+	BeforeEach(func() {
+		desiredLRPEvent = models.NewDesiredLRPCreatedEvent(&models.DesiredLRP{
+			ProcessGuid: "process-guid",
+			Domain:      "lrp-domain",
+		})
+
+		before := &models.ActualLRPGroup{Instance: &models.ActualLRP{
+			ActualLRPKey:         models.NewActualLRPKey("process-guid", 0, "lrp-domain"),
+			ActualLRPInstanceKey: models.NewActualLRPInstanceKey("instance-guid", "cell-a"),
+		}}
+		after := &models.ActualLRPGroup{Instance: &models.ActualLRP{
+			ActualLRPKey:         models.NewActualLRPKey("process-guid", 0, "lrp-domain"),
+			ActualLRPInstanceKey: models.NewActualLRPInstanceKey("instance-guid", "cell-b"),
+		}}
+		actualLRPEvent = models.NewActualLRPChangedEvent(before, after)
+
+		taskEvent = models.NewTaskCreatedEvent(&models.Task{
+			TaskGuid: "task-guid",
+			Domain:   "task-domain",
+			CellId:   "cell-a",
+		})
+	})
+
+	It("matches every event when empty", func() {
+		filter := models.EventFilter{}
+		Expect(filter.Matches(desiredLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(actualLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(taskEvent)).To(BeTrue())
+	})
+
+	It("filters by domain", func() {
+		filter := models.EventFilter{Domain: "lrp-domain"}
+		Expect(filter.Matches(desiredLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(actualLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(taskEvent)).To(BeFalse())
+	})
+
+	It("filters by cell id, matching either side of a change", func() {
+		filter := models.EventFilter{CellID: "cell-b"}
+		Expect(filter.Matches(desiredLRPEvent)).To(BeFalse())
+		Expect(filter.Matches(actualLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(taskEvent)).To(BeFalse())
+
+		filter = models.EventFilter{CellID: "cell-a"}
+		Expect(filter.Matches(actualLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(taskEvent)).To(BeTrue())
+	})
+
+	It("filters by process guid", func() {
+		filter := models.EventFilter{ProcessGuid: "process-guid"}
+		Expect(filter.Matches(desiredLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(actualLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(taskEvent)).To(BeFalse())
+	})
+
+	It("filters by event type", func() {
+		filter := models.EventFilter{EventTypes: []string{models.EventTypeTaskCreated, models.EventTypeDesiredLRPCreated}}
+		Expect(filter.Matches(desiredLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(actualLRPEvent)).To(BeFalse())
+		Expect(filter.Matches(taskEvent)).To(BeTrue())
+	})
+
+	It("requires every field to match", func() {
+		filter := models.EventFilter{Domain: "lrp-domain", CellID: "cell-a"}
+		Expect(filter.Matches(desiredLRPEvent)).To(BeFalse())
+		Expect(filter.Matches(actualLRPEvent)).To(BeTrue())
+		Expect(filter.Matches(taskEvent)).To(BeFalse())
+	})
+})
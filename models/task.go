@@ -10,6 +10,22 @@ import (
 
 var taskGuidPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
+type TaskChange struct {
+	Before *Task
+	After  *Task
+}
+
+type TaskFilter struct {
+	Domain string
+	CellID string
+
+	// Limit, if set, caps the tasks the db lists, in order of task guid,
+	// starting after AfterTaskGuid. The client iterators page by themselves
+	// and ignore these.
+	Limit         int
+	AfterTaskGuid string
+}
+
 func (task Task) Validate() error {
 	var validationError ValidationError
 
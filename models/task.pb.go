@@ -66,7 +66,8 @@ func (x *Task_State) UnmarshalJSON(data []byte) error {
 }
 
 type Tasks struct {
-	Tasks []*Task `protobuf:"bytes,1,rep,name=tasks" json:"tasks,omitempty"`
+	Tasks             []*Task `protobuf:"bytes,1,rep,name=tasks" json:"tasks,omitempty"`
+	ContinuationToken string  `protobuf:"bytes,2,opt,name=continuation_token" json:"continuation_token,omitempty"`
 }
 
 func (m *Tasks) Reset()      { *m = Tasks{} }
@@ -79,6 +80,13 @@ func (m *Tasks) GetTasks() []*Task {
 	return nil
 }
 
+func (m *Tasks) GetContinuationToken() string {
+	if m != nil {
+		return m.ContinuationToken
+	}
+	return ""
+}
+
 type Task struct {
 	TaskGuid              string                 `protobuf:"bytes,1,opt,name=task_guid" json:"task_guid"`
 	Domain                string                 `protobuf:"bytes,2,opt,name=domain" json:"domain"`
@@ -324,6 +332,28 @@ func (m *Tasks) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinuationToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContinuationToken = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -966,6 +996,7 @@ func (this *Tasks) String() string {
 	}
 	s := strings.Join([]string{`&Tasks{`,
 		`Tasks:` + strings.Replace(fmt.Sprintf("%v", this.Tasks), "Task", "Task", 1) + `,`,
+		`ContinuationToken:` + fmt.Sprintf("%v", this.ContinuationToken) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1020,6 +1051,8 @@ func (m *Tasks) Size() (n int) {
 			n += 1 + l + sovTask(uint64(l))
 		}
 	}
+	l = len(m.ContinuationToken)
+	n += 1 + l + sovTask(uint64(l))
 	return n
 }
 
@@ -1118,6 +1151,10 @@ func (m *Tasks) MarshalTo(data []byte) (n int, err error) {
 			i += n
 		}
 	}
+	data[i] = 0x12
+	i++
+	i = encodeVarintTask(data, i, uint64(len(m.ContinuationToken)))
+	i += copy(data[i:], m.ContinuationToken)
 	return i, nil
 }
 
@@ -1308,7 +1345,8 @@ func (this *Tasks) GoString() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&models.Tasks{` +
-		`Tasks:` + fmt.Sprintf("%#v", this.Tasks) + `}`}, ", ")
+		`Tasks:` + fmt.Sprintf("%#v", this.Tasks),
+		`ContinuationToken:` + fmt.Sprintf("%#v", this.ContinuationToken) + `}`}, ", ")
 	return s
 }
 func (this *Task) GoString() string {
@@ -1395,6 +1433,9 @@ func (this *Tasks) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.ContinuationToken != that1.ContinuationToken {
+		return false
+	}
 	return true
 }
 func (this *Task) Equal(that interface{}) bool {
@@ -0,0 +1,65 @@
+package models
+
+func (request TaskGuidRequest) Validate() error {
+	var validationError ValidationError
+
+	if !taskGuidPattern.MatchString(request.TaskGuid) {
+		validationError = validationError.Append(ErrInvalidField{"task_guid"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request StartTaskRequest) Validate() error {
+	var validationError ValidationError
+
+	if !taskGuidPattern.MatchString(request.TaskGuid) {
+		validationError = validationError.Append(ErrInvalidField{"task_guid"})
+	}
+
+	if request.CellId == "" {
+		validationError = validationError.Append(ErrInvalidField{"cell_id"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request FailTaskRequest) Validate() error {
+	var validationError ValidationError
+
+	if !taskGuidPattern.MatchString(request.TaskGuid) {
+		validationError = validationError.Append(ErrInvalidField{"task_guid"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request CompleteTaskRequest) Validate() error {
+	var validationError ValidationError
+
+	if !taskGuidPattern.MatchString(request.TaskGuid) {
+		validationError = validationError.Append(ErrInvalidField{"task_guid"})
+	}
+
+	if request.CellId == "" {
+		validationError = validationError.Append(ErrInvalidField{"cell_id"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
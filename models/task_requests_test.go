@@ -0,0 +1,146 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/bbs/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Task Requests", func() {
+	Describe("TaskGuidRequest", func() {
+		Describe("Validate", func() {
+			var request models.TaskGuidRequest
+
+			BeforeEach(func() {
+				request = models.TaskGuidRequest{
+					TaskGuid: "t-guid",
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the TaskGuid is blank", func() {
+				BeforeEach(func() {
+					request.TaskGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"task_guid"}))
+				})
+			})
+		})
+	})
+
+	Describe("StartTaskRequest", func() {
+		Describe("Validate", func() {
+			var request models.StartTaskRequest
+
+			BeforeEach(func() {
+				request = models.StartTaskRequest{
+					TaskGuid: "t-guid",
+					CellId:   "c-id",
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the TaskGuid is blank", func() {
+				BeforeEach(func() {
+					request.TaskGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"task_guid"}))
+				})
+			})
+
+			Context("when the CellId is blank", func() {
+				BeforeEach(func() {
+					request.CellId = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"cell_id"}))
+				})
+			})
+		})
+	})
+
+	Describe("FailTaskRequest", func() {
+		Describe("Validate", func() {
+			var request models.FailTaskRequest
+
+			BeforeEach(func() {
+				request = models.FailTaskRequest{
+					TaskGuid:      "t-guid",
+					FailureReason: "some-reason",
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the TaskGuid is blank", func() {
+				BeforeEach(func() {
+					request.TaskGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"task_guid"}))
+				})
+			})
+		})
+	})
+
+	Describe("CompleteTaskRequest", func() {
+		Describe("Validate", func() {
+			var request models.CompleteTaskRequest
+
+			BeforeEach(func() {
+				request = models.CompleteTaskRequest{
+					TaskGuid: "t-guid",
+					CellId:   "c-id",
+					Result:   "some-result",
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the TaskGuid is blank", func() {
+				BeforeEach(func() {
+					request.TaskGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"task_guid"}))
+				})
+			})
+
+			Context("when the CellId is blank", func() {
+				BeforeEach(func() {
+					request.CellId = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"cell_id"}))
+				})
+			})
+		})
+	})
+})
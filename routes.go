@@ -20,14 +20,36 @@ const (
 	RemoveActualLRPRoute = "RemoveActualLRP"
 	RetireActualLRPRoute = "RetireActualLRP"
 
+	// Evacuation
+	EvacuateClaimedActualLRPRoute  = "EvacuateClaimedActualLRP"
+	EvacuateRunningActualLRPRoute  = "EvacuateRunningActualLRP"
+	EvacuateStoppedActualLRPRoute  = "EvacuateStoppedActualLRP"
+	EvacuateCrashedActualLRPRoute  = "EvacuateCrashedActualLRP"
+	RemoveEvacuatingActualLRPRoute = "RemoveEvacuatingActualLRP"
+
 	// Desired LRPs
 	DesiredLRPsRoute             = "DesiredLRPs"
 	DesiredLRPByProcessGuidRoute = "DesiredLRPByProcessGuid"
+	DesireDesiredLRPRoute        = "DesireDesiredLRP"
+	UpdateDesiredLRPRoute        = "UpdateDesiredLRP"
+	RemoveDesiredLRPRoute        = "RemoveDesiredLRP"
 
-	// Desired LRPs
+	// Tasks
 	TasksRoute      = "Tasks"
 	TaskByGuidRoute = "TaskByGuid"
 
+	// Task Lifecycle
+	DesireTaskRoute    = "DesireTask"
+	StartTaskRoute     = "StartTask"
+	CancelTaskRoute    = "CancelTask"
+	FailTaskRoute      = "FailTask"
+	CompleteTaskRoute  = "CompleteTask"
+	ResolvingTaskRoute = "ResolvingTask"
+	ResolveTaskRoute   = "ResolveTask"
+
+	// Cells
+	CellsRoute = "Cells"
+
 	// Event Streaming
 	EventStreamRoute = "EventStream"
 )
@@ -50,14 +72,36 @@ var Routes = rata.Routes{
 	{Path: "/v1/actual_lrps/:process_guid/index/:index", Method: "DELETE", Name: RemoveActualLRPRoute},
 	{Path: "/v1/actual_lrps/retire", Method: "POST", Name: RetireActualLRPRoute},
 
+	// Evacuation
+	{Path: "/v1/actual_lrps/evacuate_claimed", Method: "POST", Name: EvacuateClaimedActualLRPRoute},
+	{Path: "/v1/actual_lrps/evacuate_running", Method: "POST", Name: EvacuateRunningActualLRPRoute},
+	{Path: "/v1/actual_lrps/evacuate_stopped", Method: "POST", Name: EvacuateStoppedActualLRPRoute},
+	{Path: "/v1/actual_lrps/evacuate_crashed", Method: "POST", Name: EvacuateCrashedActualLRPRoute},
+	{Path: "/v1/actual_lrps/remove_evacuating", Method: "POST", Name: RemoveEvacuatingActualLRPRoute},
+
 	// Desired LRPs
 	{Path: "/v1/desired_lrps", Method: "GET", Name: DesiredLRPsRoute},
 	{Path: "/v1/desired_lrps/:process_guid", Method: "GET", Name: DesiredLRPByProcessGuidRoute},
+	{Path: "/v1/desired_lrp/desire", Method: "POST", Name: DesireDesiredLRPRoute},
+	{Path: "/v1/desired_lrp/update", Method: "POST", Name: UpdateDesiredLRPRoute},
+	{Path: "/v1/desired_lrps/:process_guid", Method: "DELETE", Name: RemoveDesiredLRPRoute},
 
 	// Tasks
 	{Path: "/v1/tasks", Method: "GET", Name: TasksRoute},
 	{Path: "/v1/tasks/:task_guid", Method: "GET", Name: TaskByGuidRoute},
 
+	// Task Lifecycle
+	{Path: "/v1/tasks/desire", Method: "POST", Name: DesireTaskRoute},
+	{Path: "/v1/tasks/start", Method: "POST", Name: StartTaskRoute},
+	{Path: "/v1/tasks/cancel", Method: "POST", Name: CancelTaskRoute},
+	{Path: "/v1/tasks/fail", Method: "POST", Name: FailTaskRoute},
+	{Path: "/v1/tasks/complete", Method: "POST", Name: CompleteTaskRoute},
+	{Path: "/v1/tasks/resolving", Method: "POST", Name: ResolvingTaskRoute},
+	{Path: "/v1/tasks/resolve", Method: "POST", Name: ResolveTaskRoute},
+
+	// Cells
+	{Path: "/v1/cells", Method: "GET", Name: CellsRoute},
+
 	// Event Streaming
 	{Path: "/v1/events", Method: "GET", Name: EventStreamRoute},
 }
@@ -0,0 +1,45 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+)
+
+type FakeTaskCompletionClient struct {
+	SubmitStub        func(taskDB db.TaskDB, task *models.Task)
+	submitMutex       sync.RWMutex
+	submitArgsForCall []struct {
+		taskDB db.TaskDB
+		task   *models.Task
+	}
+}
+
+func (fake *FakeTaskCompletionClient) Submit(taskDB db.TaskDB, task *models.Task) {
+	fake.submitMutex.Lock()
+	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
+		taskDB db.TaskDB
+		task   *models.Task
+	}{taskDB, task})
+	fake.submitMutex.Unlock()
+	if fake.SubmitStub != nil {
+		fake.SubmitStub(taskDB, task)
+	}
+}
+
+func (fake *FakeTaskCompletionClient) SubmitCallCount() int {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	return len(fake.submitArgsForCall)
+}
+
+func (fake *FakeTaskCompletionClient) SubmitArgsForCall(i int) (db.TaskDB, *models.Task) {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	return fake.submitArgsForCall[i].taskDB, fake.submitArgsForCall[i].task
+}
+
+var _ taskworkpool.TaskCompletionClient = new(FakeTaskCompletionClient)
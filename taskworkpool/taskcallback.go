@@ -0,0 +1,90 @@
+package taskworkpool
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+// NewCompletedTaskHandler POSTs completed tasks to their callback URL, retrying with backoff.
+func NewCompletedTaskHandler(httpClient *http.Client, clock clock.Clock, maxRetries int, retryInterval time.Duration) CompletedTaskHandler {
+	return func(logger lager.Logger, taskDB db.TaskDB, task *models.Task) {
+		logger = logger.Session("handle-completed-task", lager.Data{"task-guid": task.TaskGuid})
+
+		if task.CompletionCallbackUrl == "" {
+			return
+		}
+
+		bbsErr := taskDB.ResolvingTask(logger, task.TaskGuid)
+		if bbsErr != nil {
+			logger.Error("marking-task-as-resolving-failed", bbsErr)
+			return
+		}
+
+		payload, err := json.Marshal(task)
+		if err != nil {
+			logger.Error("failed-to-serialize-task", err)
+			return
+		}
+
+		interval := retryInterval
+		for attempt := 0; attempt <= maxRetries; attempt++ {
+			if attempt > 0 {
+				clock.Sleep(interval)
+				interval *= 2
+			}
+
+			resolve, retry := postCallback(logger, httpClient, task.CompletionCallbackUrl, payload)
+			if resolve {
+				bbsErr = taskDB.ResolveTask(logger, task.TaskGuid)
+				if bbsErr != nil {
+					logger.Error("resolving-task-failed", bbsErr)
+				}
+				return
+			}
+
+			if !retry {
+				return
+			}
+		}
+
+		logger.Info("giving-up-on-callback", lager.Data{"attempts": maxRetries + 1})
+	}
+}
+
+func postCallback(logger lager.Logger, httpClient *http.Client, url string, payload []byte) (resolve bool, retry bool) {
+	request, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		logger.Error("building-request-failed", err)
+		return false, false
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		logger.Error("doing-request-failed", err)
+		return false, true
+	}
+	defer response.Body.Close()
+
+	logger.Info("succeeded-doing-request", lager.Data{"status-code": response.StatusCode})
+
+	switch {
+	case response.StatusCode >= 200 && response.StatusCode < 300:
+		return true, false
+	case response.StatusCode == http.StatusRequestTimeout || response.StatusCode == 429:
+		return false, true
+	case response.StatusCode >= 400 && response.StatusCode < 500:
+		return true, false
+	case response.StatusCode >= 500:
+		return false, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,175 @@
+package taskworkpool_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/models/internal/model_helpers"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager/lagertest"
+)
+
+var _ = Describe("TaskCallback", func() {
+	const maxRetries = 2
+
+	var (
+		logger  *lagertest.TestLogger
+		taskDB  *fakes.FakeTaskDB
+		task    *models.Task
+		handler taskworkpool.CompletedTaskHandler
+
+		server         *httptest.Server
+		statusCodes    []int
+		receivedBodies [][]byte
+		receivedLock   sync.Mutex
+	)
+
+	receivedCount := func() int {
+		receivedLock.Lock()
+		defer receivedLock.Unlock()
+		return len(receivedBodies)
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		taskDB = new(fakes.FakeTaskDB)
+		statusCodes = []int{http.StatusOK}
+		receivedBodies = nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			receivedLock.Lock()
+			attempt := len(receivedBodies)
+			receivedBodies = append(receivedBodies, body)
+			receivedLock.Unlock()
+
+			if attempt >= len(statusCodes) {
+				attempt = len(statusCodes) - 1
+			}
+			w.WriteHeader(statusCodes[attempt])
+		}))
+
+		task = model_helpers.NewValidTask("task-guid")
+		task.State = models.Task_Completed
+		task.CompletionCallbackUrl = server.URL + "/the-callback"
+
+		handler = taskworkpool.NewCompletedTaskHandler(http.DefaultClient, clock.NewClock(), maxRetries, time.Millisecond)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		handler(logger, taskDB, task)
+	})
+
+	Context("when the task has no completion callback url", func() {
+		BeforeEach(func() {
+			task.CompletionCallbackUrl = ""
+		})
+
+		It("leaves the task alone", func() {
+			Expect(taskDB.ResolvingTaskCallCount()).To(Equal(0))
+			Expect(receivedCount()).To(Equal(0))
+			Expect(taskDB.ResolveTaskCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when marking the task as resolving fails", func() {
+		BeforeEach(func() {
+			taskDB.ResolvingTaskReturns(models.ErrTaskCannotBeMarkedAsResolving)
+		})
+
+		It("does not call the callback", func() {
+			Expect(taskDB.ResolvingTaskCallCount()).To(Equal(1))
+			Expect(receivedCount()).To(Equal(0))
+			Expect(taskDB.ResolveTaskCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the callback succeeds", func() {
+		It("marks the task as resolving before posting it", func() {
+			Expect(taskDB.ResolvingTaskCallCount()).To(Equal(1))
+			_, guid := taskDB.ResolvingTaskArgsForCall(0)
+			Expect(guid).To(Equal("task-guid"))
+		})
+
+		It("posts the task as json", func() {
+			Expect(receivedCount()).To(Equal(1))
+
+			var received models.Task
+			err := json.Unmarshal(receivedBodies[0], &received)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received.TaskGuid).To(Equal(task.TaskGuid))
+			Expect(received.Result).To(Equal(task.Result))
+		})
+
+		It("resolves the task", func() {
+			Expect(taskDB.ResolveTaskCallCount()).To(Equal(1))
+			_, guid := taskDB.ResolveTaskArgsForCall(0)
+			Expect(guid).To(Equal("task-guid"))
+		})
+	})
+
+	Context("when the callback permanently rejects the task", func() {
+		BeforeEach(func() {
+			statusCodes = []int{http.StatusNotFound}
+		})
+
+		It("does not retry and resolves the task", func() {
+			Expect(receivedCount()).To(Equal(1))
+			Expect(taskDB.ResolveTaskCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the callback fails with a server error", func() {
+		Context("and then succeeds", func() {
+			BeforeEach(func() {
+				statusCodes = []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK}
+			})
+
+			It("retries and resolves the task", func() {
+				Expect(receivedCount()).To(Equal(3))
+				Expect(taskDB.ResolveTaskCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("on every attempt", func() {
+			BeforeEach(func() {
+				statusCodes = []int{http.StatusBadGateway}
+			})
+
+			It("gives up after the maximum number of retries without resolving the task", func() {
+				Expect(receivedCount()).To(Equal(maxRetries + 1))
+				Expect(taskDB.ResolveTaskCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the callback receiver cannot be reached", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+
+		It("does not resolve the task", func() {
+			Expect(taskDB.ResolveTaskCallCount()).To(Equal(0))
+		})
+	})
+})
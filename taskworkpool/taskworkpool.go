@@ -0,0 +1,67 @@
+package taskworkpool
+
+import (
+	"os"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry/gunk/workpool"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . TaskCompletionClient
+
+type TaskCompletionClient interface {
+	Submit(taskDB db.TaskDB, task *models.Task)
+}
+
+type CompletedTaskHandler func(logger lager.Logger, taskDB db.TaskDB, task *models.Task)
+
+type TaskCompletionWorkPool struct {
+	logger           lager.Logger
+	maxWorkers       int
+	callbackHandler  CompletedTaskHandler
+	callbackWorkPool *workpool.WorkPool
+}
+
+func New(logger lager.Logger, maxWorkers int, cbHandler CompletedTaskHandler) *TaskCompletionWorkPool {
+	if cbHandler == nil {
+		panic("callbackHandler cannot be nil")
+	}
+	return &TaskCompletionWorkPool{
+		logger:          logger.Session("task-completion-workpool"),
+		maxWorkers:      maxWorkers,
+		callbackHandler: cbHandler,
+	}
+}
+
+func (twp *TaskCompletionWorkPool) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	twp.logger.Info("starting")
+
+	cbWorkPool, err := workpool.NewWorkPool(twp.maxWorkers)
+	if err != nil {
+		twp.logger.Error("failed-to-create-workpool", err)
+		return err
+	}
+	twp.callbackWorkPool = cbWorkPool
+
+	close(ready)
+	twp.logger.Info("started")
+
+	<-signals
+	twp.logger.Info("stopping")
+
+	twp.callbackWorkPool.Stop()
+
+	twp.logger.Info("finished")
+	return nil
+}
+
+func (twp *TaskCompletionWorkPool) Submit(taskDB db.TaskDB, task *models.Task) {
+	if twp.callbackWorkPool == nil {
+		panic("called submit before workpool was started")
+	}
+	twp.callbackWorkPool.Submit(func() {
+		twp.callbackHandler(twp.logger, taskDB, task)
+	})
+}
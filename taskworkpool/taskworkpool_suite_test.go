@@ -0,0 +1,13 @@
+package taskworkpool_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTaskWorkPool(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TaskWorkPool Suite")
+}
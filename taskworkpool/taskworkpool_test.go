@@ -0,0 +1,49 @@
+package taskworkpool_test
+
+import (
+	"os"
+
+	"github.com/cloudfoundry-incubator/bbs/db"
+	"github.com/cloudfoundry-incubator/bbs/db/fakes"
+	"github.com/cloudfoundry-incubator/bbs/models"
+	"github.com/cloudfoundry-incubator/bbs/taskworkpool"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("TaskCompletionWorkPool", func() {
+	var (
+		taskDB         *fakes.FakeTaskDB
+		handledTasks   chan *models.Task
+		workPool       *taskworkpool.TaskCompletionWorkPool
+		workPoolRunner ifrit.Process
+	)
+
+	BeforeEach(func() {
+		taskDB = new(fakes.FakeTaskDB)
+		handledTasks = make(chan *models.Task, 1)
+
+		handler := func(logger lager.Logger, submittedDB db.TaskDB, task *models.Task) {
+			defer GinkgoRecover()
+			Expect(submittedDB).To(Equal(taskDB))
+			handledTasks <- task
+		}
+
+		workPool = taskworkpool.New(lagertest.NewTestLogger("test"), 2, handler)
+		workPoolRunner = ifrit.Invoke(workPool)
+	})
+
+	AfterEach(func() {
+		workPoolRunner.Signal(os.Interrupt)
+		Eventually(workPoolRunner.Wait()).Should(Receive())
+	})
+
+	It("hands submitted tasks to the callback handler", func() {
+		task := &models.Task{TaskGuid: "task-guid"}
+		workPool.Submit(taskDB, task)
+		Eventually(handledTasks).Should(Receive(Equal(task)))
+	})
+})
@@ -56,8 +56,8 @@ func (w *watcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	logger.Info("started")
 	defer logger.Info("finished")
 
-	var desiredStop, actualStop chan<- bool
-	var desiredErrors, actualErrors <-chan error
+	var desiredStop, actualStop, taskStop chan<- bool
+	var desiredErrors, actualErrors, taskErrors <-chan error
 
 	reWatchTimerDesired := w.clock.NewTimer(w.retryWaitDuration)
 	defer reWatchTimerDesired.Stop()
@@ -67,8 +67,13 @@ func (w *watcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	defer reWatchTimerActual.Stop()
 	reWatchTimerActual.Stop()
 
+	reWatchTimerTask := w.clock.NewTimer(w.retryWaitDuration)
+	defer reWatchTimerTask.Stop()
+	reWatchTimerTask.Stop()
+
 	var reWatchActual <-chan time.Time
 	var reWatchDesired <-chan time.Time
+	var reWatchTask <-chan time.Time
 
 	for {
 		select {
@@ -86,6 +91,12 @@ func (w *watcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 					actualStop = nil
 					actualErrors = nil
 				}
+				if taskStop != nil {
+					logger.Info("stopping-task-watch-from-hub-notification")
+					taskStop <- true
+					taskStop = nil
+					taskErrors = nil
+				}
 			} else {
 				wg := sync.WaitGroup{}
 
@@ -111,6 +122,17 @@ func (w *watcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 					}()
 				}
 
+				if taskStop == nil {
+					logger.Info("rewatching-task-from-hub-notification")
+
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						taskStop, taskErrors = w.watchTask(logger)
+						logger.Debug("finished-rewatching-task-from-hub-notification")
+					}()
+				}
+
 				wg.Wait()
 			}
 
@@ -136,6 +158,17 @@ func (w *watcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 			actualErrors = nil
 			actualStop = nil
 
+		case err, ok := <-taskErrors:
+			if ok {
+				reWatchTimerTask.Reset(w.retryWaitDuration)
+				reWatchTask = reWatchTimerTask.C()
+			}
+			if err != nil {
+				logger.Error("task-watch-failed", err)
+			}
+			taskErrors = nil
+			taskStop = nil
+
 		case <-reWatchDesired:
 			reWatchDesired = nil
 
@@ -151,6 +184,13 @@ func (w *watcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 				actualStop, actualErrors = w.watchActual(logger)
 			}
 
+		case <-reWatchTask:
+			reWatchTask = nil
+			if taskStop == nil && hubSize > 0 {
+				logger.Info("rewatching-task")
+				taskStop, taskErrors = w.watchTask(logger)
+			}
+
 		case <-signals:
 			logger.Info("stopping")
 			if desiredStop != nil {
@@ -161,6 +201,10 @@ func (w *watcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 				actualStop <- true
 				actualStop = nil
 			}
+			if taskStop != nil {
+				taskStop <- true
+				taskStop = nil
+			}
 			return nil
 		}
 	}
@@ -199,9 +243,64 @@ func (w *watcher) watchActual(logger lager.Logger) (chan<- bool, <-chan error) {
 				changed.Before,
 				changed.After,
 			))
+			if crashed, ok := crashedInstance(changed); ok {
+				logger.Debug("handling-actual-crash")
+				w.hub.Emit(crashed)
+			}
 		},
 		func(deleted *models.ActualLRPGroup) {
 			logger.Debug("handling-actual-delete")
 			w.hub.Emit(models.NewActualLRPRemovedEvent(deleted))
 		})
 }
+
+func (w *watcher) watchTask(logger lager.Logger) (chan<- bool, <-chan error) {
+	return w.db.WatchForTaskChanges(logger,
+		func(created *models.Task) {
+			logger.Debug("handling-task-create")
+			w.hub.Emit(models.NewTaskCreatedEvent(created))
+		},
+		func(changed *models.TaskChange) {
+			logger.Debug("handling-task-change")
+			w.hub.Emit(models.NewTaskChangedEvent(
+				changed.Before,
+				changed.After,
+			))
+		},
+		func(deleted *models.Task) {
+			logger.Debug("handling-task-delete")
+			w.hub.Emit(models.NewTaskRemovedEvent(deleted))
+		})
+}
+
+// crashedInstance reports the crash behind an actual LRP change. A crash that
+// is restarted immediately leaves the instance unclaimed rather than crashed,
+// so it is recognised by the placed instance going down with new crash
+// details. The crash count alone is not enough: a crash after a long run
+// resets it to 1, which may be what it already was.
+func crashedInstance(changed *models.ActualLRPChange) (*models.ActualLRPCrashedEvent, bool) {
+	before, after := changed.Before.GetInstance(), changed.After.GetInstance()
+	if before == nil || after == nil {
+		return nil, false
+	}
+
+	wasPlaced := before.State == models.ActualLRPStateClaimed || before.State == models.ActualLRPStateRunning
+	isDown := after.State == models.ActualLRPStateCrashed || after.State == models.ActualLRPStateUnclaimed
+	if !wasPlaced || !isDown || after.Since == before.Since {
+		return nil, false
+	}
+
+	// evacuating a running instance unclaims it but keeps it running elsewhere
+	if evacuating := changed.After.GetEvacuating(); evacuating != nil && evacuating.ActualLRPInstanceKey.Equal(&before.ActualLRPInstanceKey) {
+		return nil, false
+	}
+
+	resetAfterLongRun := before.State == models.ActualLRPStateRunning &&
+		after.CrashCount == 1 &&
+		time.Duration(after.Since-before.Since) > models.CrashResetTimeout
+	if after.CrashCount == before.CrashCount && after.CrashReason == before.CrashReason && !resetAfterLongRun {
+		return nil, false
+	}
+
+	return models.NewActualLRPCrashedEvent(before.ActualLRPInstanceKey, after), true
+}
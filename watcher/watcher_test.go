@@ -35,6 +35,9 @@ var _ = Describe("Watcher", func() {
 
 		actualLRPStop   chan bool
 		actualLRPErrors chan error
+
+		taskStop   chan bool
+		taskErrors chan error
 	)
 
 	BeforeEach(func() {
@@ -49,8 +52,12 @@ var _ = Describe("Watcher", func() {
 		actualLRPStop = make(chan bool, 1)
 		actualLRPErrors = make(chan error)
 
+		taskStop = make(chan bool, 1)
+		taskErrors = make(chan error)
+
 		db.WatchForDesiredLRPChangesReturns(desiredLRPStop, desiredLRPErrors)
 		db.WatchForActualLRPChangesReturns(actualLRPStop, actualLRPErrors)
+		db.WatchForTaskChangesReturns(taskStop, taskErrors)
 
 		bbsWatcher = watcher.NewWatcher(logger, db, hub, clock, retryWaitDuration)
 	})
@@ -72,6 +79,7 @@ var _ = Describe("Watcher", func() {
 			It("does not request a watch", func() {
 				Consistently(db.WatchForDesiredLRPChangesCallCount).Should(BeZero())
 				Consistently(db.WatchForActualLRPChangesCallCount).Should(BeZero())
+				Consistently(db.WatchForTaskChangesCallCount).Should(BeZero())
 			})
 
 			Context("and then the hub reports a subscriber", func() {
@@ -86,6 +94,7 @@ var _ = Describe("Watcher", func() {
 				It("requests watches", func() {
 					Eventually(db.WatchForDesiredLRPChangesCallCount).Should(Equal(1))
 					Eventually(db.WatchForActualLRPChangesCallCount).Should(Equal(1))
+					Eventually(db.WatchForTaskChangesCallCount).Should(Equal(1))
 				})
 
 				Context("and then the hub reports two subscribers", func() {
@@ -99,6 +108,9 @@ var _ = Describe("Watcher", func() {
 
 						Eventually(db.WatchForActualLRPChangesCallCount).Should(Equal(1))
 						Consistently(db.WatchForActualLRPChangesCallCount).Should(Equal(1))
+
+						Eventually(db.WatchForTaskChangesCallCount).Should(Equal(1))
+						Consistently(db.WatchForTaskChangesCallCount).Should(Equal(1))
 					})
 				})
 
@@ -110,6 +122,7 @@ var _ = Describe("Watcher", func() {
 					It("stops the watches", func() {
 						Eventually(desiredLRPStop).Should(Receive())
 						Eventually(actualLRPStop).Should(Receive())
+						Eventually(taskStop).Should(Receive())
 					})
 				})
 
@@ -166,6 +179,33 @@ var _ = Describe("Watcher", func() {
 						})
 					})
 				})
+
+				Context("when the task watch reports an error", func() {
+					BeforeEach(func() {
+						taskErrors <- errors.New("oh no!")
+					})
+
+					It("requests a new task watch after the retry interval", func() {
+						clock.Increment(retryWaitDuration / 2)
+						Consistently(db.WatchForTaskChangesCallCount).Should(Equal(1))
+						clock.Increment(retryWaitDuration * 2)
+						Eventually(db.WatchForTaskChangesCallCount).Should(Equal(2))
+					})
+
+					Context("and the hub reports no subscribers before the retry interval elapses", func() {
+						BeforeEach(func() {
+							clock.Increment(retryWaitDuration / 2)
+							callback(0)
+							// give watcher time to clear out event loop
+							time.Sleep(10 * time.Millisecond)
+						})
+
+						It("does not request new watches", func() {
+							clock.Increment(retryWaitDuration * 2)
+							Consistently(db.WatchForTaskChangesCallCount).Should(Equal(1))
+						})
+					})
+				})
 			})
 		})
 
@@ -180,6 +220,7 @@ var _ = Describe("Watcher", func() {
 			It("requests watches", func() {
 				Eventually(db.WatchForDesiredLRPChangesCallCount).Should(Equal(1))
 				Eventually(db.WatchForActualLRPChangesCallCount).Should(Equal(1))
+				Eventually(db.WatchForTaskChangesCallCount).Should(Equal(1))
 			})
 
 			Context("and then the watcher is signaled to stop", func() {
@@ -187,6 +228,7 @@ var _ = Describe("Watcher", func() {
 					process.Signal(os.Interrupt)
 					Eventually(desiredLRPStop).Should(Receive())
 					Eventually(actualLRPStop).Should(Receive())
+					Eventually(taskStop).Should(Receive())
 					Eventually(process.Wait()).Should(Receive())
 				})
 			})
@@ -226,6 +268,24 @@ var _ = Describe("Watcher", func() {
 					Consistently(clock.WatcherCount).Should(Equal(1))
 				})
 			})
+
+			Context("when the watcher receives several task watch errors in a retry interval", func() {
+				It("uses only one active timer", func() {
+					Expect(hub.RegisterCallbackCallCount()).To(Equal(1))
+					callback := hub.RegisterCallbackArgsForCall(0)
+
+					Eventually(db.WatchForTaskChangesCallCount).Should(Equal(1))
+
+					taskErrors <- errors.New("first error")
+
+					callback(1)
+
+					Eventually(db.WatchForTaskChangesCallCount).Should(Equal(2))
+					taskErrors <- errors.New("second error")
+
+					Consistently(clock.WatcherCount).Should(Equal(1))
+				})
+			})
 		})
 	})
 
@@ -237,6 +297,9 @@ var _ = Describe("Watcher", func() {
 			actualCreateCB  func(*models.ActualLRPGroup)
 			actualChangeCB  func(*models.ActualLRPChange)
 			actualDeleteCB  func(*models.ActualLRPGroup)
+			taskCreateCB    func(*models.Task)
+			taskChangeCB    func(*models.TaskChange)
+			taskDeleteCB    func(*models.Task)
 		)
 
 		BeforeEach(func() {
@@ -246,9 +309,11 @@ var _ = Describe("Watcher", func() {
 			process = ifrit.Invoke(bbsWatcher)
 			Eventually(db.WatchForDesiredLRPChangesCallCount).Should(Equal(1))
 			Eventually(db.WatchForActualLRPChangesCallCount).Should(Equal(1))
+			Eventually(db.WatchForTaskChangesCallCount).Should(Equal(1))
 
 			_, desiredCreateCB, desiredChangeCB, desiredDeleteCB = db.WatchForDesiredLRPChangesArgsForCall(0)
 			_, actualCreateCB, actualChangeCB, actualDeleteCB = db.WatchForActualLRPChangesArgsForCall(0)
+			_, taskCreateCB, taskChangeCB, taskDeleteCB = db.WatchForTaskChangesArgsForCall(0)
 		})
 
 		Describe("Desired LRP changes", func() {
@@ -355,6 +420,82 @@ var _ = Describe("Watcher", func() {
 				})
 			})
 
+			Context("when a change records a crash", func() {
+				var crashedGroup *models.ActualLRPGroup
+
+				BeforeEach(func() {
+					actualLRPGroup.Instance.State = models.ActualLRPStateRunning
+					actualLRPGroup.Instance.CrashCount = 1
+
+					crashedGroup = &models.ActualLRPGroup{
+						Instance: &models.ActualLRP{
+							ActualLRPKey: models.NewActualLRPKey(expectedProcessGuid, 1, "domain"),
+							State:        models.ActualLRPStateUnclaimed,
+							CrashCount:   2,
+							CrashReason:  "out of memory",
+							Since:        2000,
+						},
+					}
+				})
+
+				JustBeforeEach(func() {
+					actualChangeCB(&models.ActualLRPChange{Before: actualLRPGroup, After: crashedGroup})
+				})
+
+				It("emits an ActualLRPCrashedEvent for the instance after the change", func() {
+					Expect(hub.EmitCallCount()).To(Equal(2))
+					Expect(hub.EmitArgsForCall(0)).To(BeAssignableToTypeOf(&models.ActualLRPChangedEvent{}))
+
+					key := models.NewActualLRPKey(expectedProcessGuid, 1, "domain")
+					instanceKey := models.NewActualLRPInstanceKey(expectedInstanceGuid, "cell-id")
+					Expect(hub.EmitArgsForCall(1)).To(Equal(&models.ActualLRPCrashedEvent{
+						ActualLrpKey:         &key,
+						ActualLrpInstanceKey: &instanceKey,
+						CrashCount:           2,
+						CrashReason:          "out of memory",
+						Since:                2000,
+					}))
+				})
+
+				Context("when a crash after a long run resets the crash count to what it was", func() {
+					BeforeEach(func() {
+						actualLRPGroup.Instance.CrashReason = "out of memory"
+						actualLRPGroup.Instance.Since = 1000
+						crashedGroup.Instance.CrashCount = 1
+						crashedGroup.Instance.Since = 1000 + models.CrashResetTimeout.Nanoseconds() + 1
+					})
+
+					It("emits an ActualLRPCrashedEvent", func() {
+						Expect(hub.EmitCallCount()).To(Equal(2))
+						crashed := hub.EmitArgsForCall(1).(*models.ActualLRPCrashedEvent)
+						Expect(crashed.CrashCount).To(BeEquivalentTo(1))
+					})
+				})
+
+				Context("when the instance is unclaimed without crashing", func() {
+					BeforeEach(func() {
+						actualLRPGroup.Instance.CrashCount = 2
+						actualLRPGroup.Instance.CrashReason = "out of memory"
+						actualLRPGroup.Instance.Since = 1000
+					})
+
+					It("only emits the change", func() {
+						Expect(hub.EmitCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the instance is evacuated", func() {
+					BeforeEach(func() {
+						evacuating := *actualLRPGroup.Instance
+						crashedGroup.Evacuating = &evacuating
+					})
+
+					It("only emits the change", func() {
+						Expect(hub.EmitCallCount()).To(Equal(1))
+					})
+				})
+			})
+
 			Context("when a delete arrives", func() {
 				BeforeEach(func() {
 					actualDeleteCB(actualLRPGroup)
@@ -370,5 +511,63 @@ var _ = Describe("Watcher", func() {
 				})
 			})
 		})
+
+		Describe("Task changes", func() {
+			var task *models.Task
+
+			BeforeEach(func() {
+				task = &models.Task{
+					TaskGuid: "some-task-guid",
+					Domain:   "tests",
+					State:    models.Task_Pending,
+				}
+			})
+
+			Context("when a create arrives", func() {
+				BeforeEach(func() {
+					taskCreateCB(task)
+				})
+
+				It("emits a TaskCreatedEvent to the hub", func() {
+					Expect(hub.EmitCallCount()).To(Equal(1))
+					event := hub.EmitArgsForCall(0)
+					Expect(event).To(BeAssignableToTypeOf(&models.TaskCreatedEvent{}))
+
+					taskCreatedEvent := event.(*models.TaskCreatedEvent)
+					Expect(taskCreatedEvent.Task).To(Equal(task))
+				})
+			})
+
+			Context("when a change arrives", func() {
+				BeforeEach(func() {
+					taskChangeCB(&models.TaskChange{Before: task, After: task})
+				})
+
+				It("emits a TaskChangedEvent to the hub", func() {
+					Expect(hub.EmitCallCount()).To(Equal(1))
+					event := hub.EmitArgsForCall(0)
+					Expect(event).To(BeAssignableToTypeOf(&models.TaskChangedEvent{}))
+
+					taskChangedEvent := event.(*models.TaskChangedEvent)
+					Expect(taskChangedEvent.Before).To(Equal(task))
+					Expect(taskChangedEvent.After).To(Equal(task))
+				})
+			})
+
+			Context("when a delete arrives", func() {
+				BeforeEach(func() {
+					taskDeleteCB(task)
+				})
+
+				It("emits a TaskRemovedEvent to the hub", func() {
+					Expect(hub.EmitCallCount()).To(Equal(1))
+					event := hub.EmitArgsForCall(0)
+					Expect(event).To(BeAssignableToTypeOf(&models.TaskRemovedEvent{}))
+
+					taskRemovedEvent := event.(*models.TaskRemovedEvent)
+					Expect(taskRemovedEvent.Task).To(Equal(task))
+				})
+			})
+		})
 	})
 })